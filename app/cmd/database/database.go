@@ -4,13 +4,21 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package database
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/Anthony-Bible/password-exchange/app/cmd"
+	storagePostgres "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/postgres"
+	storageSQLite "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/sqlite"
+	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -24,6 +32,18 @@ var (
 		logging.Info().Msg("Starting database server...")
 		cfg.startServer()
 	}
+	// runKeyRotation is a variable to allow mocking in tests.
+	runKeyRotation = func(ctx context.Context) (storageDomain.RotationResult, error) {
+		return rotateKeys(ctx, cfg.PassConfig)
+	}
+	// runCleanup is a variable to allow mocking in tests.
+	runCleanup = func(ctx context.Context, batchSize int, pushgatewayURL string) (storageDomain.CleanupResult, error) {
+		return cleanupExpired(ctx, cfg.PassConfig, batchSize, pushgatewayURL)
+	}
+	// runAuditVerification is a variable to allow mocking in tests.
+	runAuditVerification = func(ctx context.Context, anchor storageDomain.AuditAnchor) (storageDomain.AuditVerification, error) {
+		return verifyAuditLog(ctx, cfg.PassConfig, anchor)
+	}
 )
 
 // databaseCmd represents the database command.
@@ -140,26 +160,122 @@ var migrateForceCmd = &cobra.Command{
 	},
 }
 
+// rotateKeysCmd re-wraps stored messages under the current master key.
+var rotateKeysCmd = &cobra.Command{
+	Use:   "rotate-keys",
+	Short: "Re-wrap stored messages under the current master key",
+	Long: `Re-wrap every stored message's data key, and that of every notification still waiting in
+the outbox, under the current at-rest master key.
+
+Add the new key to the keyring file and make it current (or point atresttransitkey at a new
+transit key) while keeping the old key available, then run this command. Only the wrapped
+data keys change, so the service can keep running. Messages stored before at-rest encryption
+was enabled are sealed as well. Once it finishes, the old key can be retired.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		result, err := runKeyRotation(ctx)
+		if err != nil {
+			return fmt.Errorf("error rotating keys: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Scanned %d messages: %d re-wrapped, %d sealed, %d skipped; %d queued notifications re-wrapped\n",
+			result.Scanned, result.Rewrapped, result.Sealed, result.Skipped, result.Notifications)
+		return nil
+	},
+}
+
+// cleanupCmd purges expired messages once, for running from a scheduler.
+var cleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Delete expired messages and secret requests",
+	Long: `Delete every message past its expires_at and every expired secret request, then exit.
+
+The database server already runs this on cleanupinterval; use this command when the server's
+sweeper is not enough, e.g. from a CronJob. Messages are deleted in batches so large backlogs
+do not hold long locks. With --pushgateway the purge counters are pushed to a Prometheus
+Pushgateway before exiting.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		batchSize, _ := cmd.Flags().GetInt("batch-size")
+		pushgatewayURL, _ := cmd.Flags().GetString("pushgateway")
+
+		result, err := runCleanup(ctx, batchSize, pushgatewayURL)
+		if err != nil {
+			return fmt.Errorf("error cleaning up expired messages: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Purged %d expired messages and %d expired secret requests\n",
+			result.MessagesPurged, result.SecretRequestsPurged)
+		return nil
+	},
+}
+
+// verifyAuditCmd checks the audit log for tampering.
+var verifyAuditCmd = &cobra.Command{
+	Use:   "verify-audit",
+	Short: "Check the audit log for tampering",
+	Long: `Recompute the hash of every audit log entry and check that each links to the one before it.
+Any entry that was altered, removed or reordered makes the check fail.
+
+The chain cannot show entries cut off its end, so keep the head printed by each run somewhere
+outside the database and pass it as --anchor next time: the log must still contain that entry.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		anchorFlag, _ := cmd.Flags().GetString("anchor")
+		anchor, err := parseAuditAnchor(anchorFlag)
+		if err != nil {
+			return err
+		}
+
+		verification, err := runAuditVerification(ctx, anchor)
+		if err != nil {
+			return fmt.Errorf("audit log verification failed: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Verified %d audit events, head is %d:%s\n",
+			verification.Events, verification.Head.Sequence, verification.Head.Hash)
+		return nil
+	},
+}
+
+// parseAuditAnchor parses a "<sequence>:<hash>" anchor as printed by verify-audit; empty means none
+func parseAuditAnchor(value string) (storageDomain.AuditAnchor, error) {
+	if value == "" {
+		return storageDomain.AuditAnchor{}, nil
+	}
+	sequence, hash, ok := strings.Cut(value, ":")
+	number, err := strconv.ParseInt(sequence, 10, 64)
+	if !ok || err != nil || number < 1 || hash == "" {
+		return storageDomain.AuditAnchor{}, fmt.Errorf("invalid anchor %q, expected <sequence>:<hash>", value)
+	}
+	return storageDomain.AuditAnchor{Sequence: number, Hash: hash}, nil
+}
+
 func initConfigAndMigrator() error {
 	bindenvs(cfg)
 	if err := viper.Unmarshal(&cfg.PassConfig); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	// Redis is schemaless, so there is nothing to migrate
+	if cfg.PassConfig.DbDriver == storageDomain.DriverRedis {
+		migrator = schemalessMigrator{}
+		return nil
+	}
+
 	// Initialize migrator
-	connString := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true",
-		cfg.PassConfig.DbUser,
-		cfg.PassConfig.DbPass,
-		cfg.PassConfig.DbHost,
-		cfg.PassConfig.DbPort,
-		cfg.PassConfig.DbName,
-	)
-	db, err := sql.Open("mysql", connString)
+	db, migrationsDir, err := openMigrationDB(cfg.PassConfig)
 	if err != nil {
-		return fmt.Errorf("error opening database: %w", err)
+		return err
 	}
 
-	m, err := NewMigrator(db, "migrations")
+	m, err := NewMigrator(db, migrationsDir)
 	if err != nil {
 		return fmt.Errorf("error initializing migrator: %w", err)
 	}
@@ -167,6 +283,44 @@ func initConfigAndMigrator() error {
 	return nil
 }
 
+// openMigrationDB opens a connection for the configured driver and returns the
+// directory holding that driver's migrations.
+func openMigrationDB(passConfig config.PassConfig) (*sql.DB, string, error) {
+	switch passConfig.DbDriver {
+	case "", storageDomain.DriverMySQL:
+		connString := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&multiStatements=true",
+			passConfig.DbUser,
+			passConfig.DbPass,
+			passConfig.DbHost,
+			passConfig.DbPort,
+			passConfig.DbName,
+		)
+		db, err := sql.Open("mysql", connString)
+		if err != nil {
+			return nil, "", fmt.Errorf("error opening database: %w", err)
+		}
+		return db, "migrations", nil
+	case storageDomain.DriverPostgres:
+		dbConfig := databaseConfig(passConfig)
+		if passConfig.DbPort != 0 {
+			dbConfig.Host = net.JoinHostPort(passConfig.DbHost, strconv.Itoa(passConfig.DbPort))
+		}
+		db, err := sql.Open("pgx", storagePostgres.ConnectionString(dbConfig))
+		if err != nil {
+			return nil, "", fmt.Errorf("error opening database: %w", err)
+		}
+		return db, filepath.Join("migrations", "postgres"), nil
+	case storageDomain.DriverSQLite:
+		db, err := sql.Open("sqlite3", storageSQLite.ConnectionString(passConfig.DbPath))
+		if err != nil {
+			return nil, "", fmt.Errorf("error opening database: %w", err)
+		}
+		return db, filepath.Join("migrations", "sqlite"), nil
+	default:
+		return nil, "", fmt.Errorf("%w: %q", storageDomain.ErrUnsupportedDriver, passConfig.DbDriver)
+	}
+}
+
 // this is required due to viper not automatically mapping env to marshal https://github.com/spf13/viper/issues/584
 func bindenvs(iface interface{}, parts ...string) {
 	ifv := reflect.ValueOf(iface)
@@ -201,6 +355,13 @@ func init() {
 	migrateCmd.AddCommand(migrateStatusCmd)
 	migrateCmd.AddCommand(migrateCreateCmd)
 	migrateCmd.AddCommand(migrateForceCmd)
+	databaseCmd.AddCommand(rotateKeysCmd)
+	databaseCmd.AddCommand(cleanupCmd)
+	databaseCmd.AddCommand(verifyAuditCmd)
+
+	cleanupCmd.Flags().Int("batch-size", 0, "Messages to delete per query (default cleanupbatchsize)")
+	cleanupCmd.Flags().String("pushgateway", "", "Prometheus Pushgateway URL to push purge counters to")
+	verifyAuditCmd.Flags().String("anchor", "", "Head printed by an earlier run, as <sequence>:<hash>")
 
 	// Register with root command
 	cmd.RootCmd.AddCommand(databaseCmd)
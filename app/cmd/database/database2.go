@@ -1,8 +1,25 @@
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	notificationContracts "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	storageCleanup "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/primary/cleanup"
 	storageGRPC "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/primary/grpc"
-	storageMySQL "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/mysql"
+	storageOutbox "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/primary/outbox"
+	storageNATS "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/nats"
+	storageQueue "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/queue"
+	storageRabbitMQ "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/rabbitmq"
+	storageRepository "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/repository"
 	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
@@ -20,22 +37,33 @@ func (conf Config) startServer() {
 func (conf Config) startHexagonalServer() {
 	address := "0.0.0.0:50051"
 
-	// Create database configuration from PassConfig
-	dbConfig := storageDomain.DatabaseConfig{
-		Host:     conf.PassConfig.DbHost,
-		User:     conf.PassConfig.DbUser,
-		Password: conf.PassConfig.DbPass,
-		Name:     conf.PassConfig.DbName,
+	storageService, closeService, err := newStorageService(conf.PassConfig)
+	if err != nil {
+		logging.Fatal().Err(err).Str("driver", conf.PassConfig.DbDriver).Msg("Failed to create storage service")
+	}
+	defer closeService()
+
+	// Count every cleanup, whoever starts it
+	registry := prometheus.NewRegistry()
+	service := storageCleanup.NewInstrumentedService(storageService, storageCleanup.NewMetrics(registry))
+	if conf.PassConfig.MetricsAddress != "" {
+		go serveMetrics(conf.PassConfig.MetricsAddress, registry)
 	}
 
-	// Create MySQL adapter (secondary adapter)
-	mysqlAdapter := storageMySQL.NewMySQLAdapter(dbConfig)
+	// Delete messages as they pass their own expires_at
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go storageCleanup.NewSweeper(service, conf.PassConfig.CleanupInterval, conf.PassConfig.CleanupBatchSize).Run(ctx)
 
-	// Create storage service (domain)
-	storageService := storageDomain.NewStorageService(mysqlAdapter)
+	// Publish the notifications stored with messages, retrying while the queue is unreachable
+	if outboxConfigured(conf.PassConfig) {
+		go storageOutbox.NewRelay(service, conf.PassConfig.OutboxInterval, conf.PassConfig.OutboxBatchSize).Run(ctx)
+	} else {
+		logging.Warn().Str("queueBackend", conf.PassConfig.QueueBackend).Msg("No notification queue is configured, notifications queued in the outbox will not be sent")
+	}
 
 	// Create gRPC server (primary adapter)
-	grpcServer := storageGRPC.NewGRPCServer(storageService, address)
+	grpcServer := storageGRPC.NewGRPCServer(service, address)
 
 	// Start the server
 	logging.Info().Str("address", address).Msg("Starting storage service with hexagonal architecture")
@@ -43,3 +71,201 @@ func (conf Config) startHexagonalServer() {
 		logging.Fatal().Err(err).Msg("Failed to start hexagonal storage server")
 	}
 }
+
+// newStorageService creates the storage service (domain) on the configured repository, with the
+// audit log in the same database. When a queue is configured the service can also report expired
+// messages and relay the notification outbox. The returned function releases the repository and
+// the queue connections.
+func newStorageService(passConfig config.PassConfig) (*storageDomain.StorageService, func(), error) {
+	switch passConfig.QueueBackend {
+	case notificationContracts.QueueBackendRabbitMQ, notificationContracts.QueueBackendNATS, "":
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", notificationDomain.ErrUnsupportedQueueBackend, passConfig.QueueBackend)
+	}
+
+	// Create the storage adapter (secondary adapter) for the configured driver
+	repo, err := storageRepository.NewMessageRepository(databaseConfig(passConfig))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The audit log is kept next to the messages, in the undecorated repository
+	auditLog, err := newAuditLog(repo, passConfig)
+	if err != nil {
+		repo.Close()
+		return nil, nil, err
+	}
+
+	// Seal message content under the master key when one is configured
+	if atRest := atRestConfig(passConfig); atRest.Enabled() {
+		keyWrapper, err := storageRepository.NewKeyWrapper(atRest)
+		if err != nil {
+			repo.Close()
+			return nil, nil, fmt.Errorf("failed to load at-rest master key: %w", err)
+		}
+		repo = storageDomain.NewEncryptedRepository(repo, storageDomain.NewAtRestCipher(keyWrapper))
+		logging.Info().Str("keyID", keyWrapper.CurrentKeyID()).Msg("At-rest encryption enabled")
+	}
+
+	if !outboxConfigured(passConfig) {
+		return storageDomain.NewStorageService(repo).WithAuditLog(auditLog), func() { repo.Close() }, nil
+	}
+
+	// The outbox publisher connects on first use, so it is created even if the queue is down
+	outboxPublisher := newOutboxPublisher(passConfig)
+
+	// Expired messages are reported to webhooks over the same queue as the outbox
+	expiryNotifier := storageQueue.NewExpiryNotifier(outboxPublisher, passConfig.WebhookURL)
+
+	closeService := func() {
+		outboxPublisher.Close()
+		repo.Close()
+	}
+	service := storageDomain.NewStorageServiceWithExpiryNotifier(repo, expiryNotifier).WithAuditLog(auditLog).WithOutboxPublisher(outboxPublisher)
+	return service, closeService, nil
+}
+
+// closingOutboxPublisher is an outbox publisher holding a connection that must be closed
+type closingOutboxPublisher interface {
+	storageDomain.OutboxPublisher
+	Close() error
+}
+
+// outboxConfigured reports whether a queue the outbox can be relayed to is configured
+func outboxConfigured(passConfig config.PassConfig) bool {
+	switch passConfig.QueueBackend {
+	case notificationContracts.QueueBackendRabbitMQ, "":
+		return passConfig.RabHost != ""
+	case notificationContracts.QueueBackendNATS:
+		return true
+	default:
+		return false
+	}
+}
+
+// newOutboxPublisher creates the outbox publisher for the configured queue backend
+func newOutboxPublisher(passConfig config.PassConfig) closingOutboxPublisher {
+	if passConfig.QueueBackend == notificationContracts.QueueBackendNATS {
+		return storageNATS.NewOutboxPublisher(storageNATS.OutboxPublisherConfig{
+			URL:       passConfig.NatsURL,
+			Stream:    passConfig.NatsStream,
+			QueueName: passConfig.RabQName,
+		})
+	}
+	return storageRabbitMQ.NewOutboxPublisher(storageRabbitMQ.OutboxPublisherConfig{
+		Host:      passConfig.RabHost,
+		Port:      passConfig.RabPort,
+		User:      passConfig.RabUser,
+		Password:  passConfig.RabPass,
+		QueueName: passConfig.RabQName,
+	})
+}
+
+// newAuditLog creates the audit log in the repository's database. Client values are hashed
+// under audithashkey; without one they can be recovered by hashing every candidate.
+func newAuditLog(repo storageDomain.MessageRepository, passConfig config.PassConfig) (*storageDomain.AuditLog, error) {
+	auditRepo, ok := repo.(storageDomain.AuditRepository)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not store an audit log", storageDomain.ErrUnsupportedDriver, repo)
+	}
+	if passConfig.AuditHashKey == "" {
+		logging.Warn().Msg("audithashkey is not set, client IPs in the audit log are hashed without a key")
+	}
+	return storageDomain.NewAuditLog(auditRepo, []byte(passConfig.AuditHashKey)), nil
+}
+
+// verifyAuditLog checks the audit log's hash chain, and that it still reaches anchor if one is given
+func verifyAuditLog(ctx context.Context, passConfig config.PassConfig, anchor storageDomain.AuditAnchor) (storageDomain.AuditVerification, error) {
+	repo, err := storageRepository.NewMessageRepository(databaseConfig(passConfig))
+	if err != nil {
+		return storageDomain.AuditVerification{}, err
+	}
+	defer repo.Close()
+
+	auditLog, err := newAuditLog(repo, passConfig)
+	if err != nil {
+		return storageDomain.AuditVerification{}, err
+	}
+	return auditLog.VerifyAuditLog(ctx, anchor)
+}
+
+// serveMetrics exposes the registry's metrics at /metrics on address
+func serveMetrics(address string, registry *prometheus.Registry) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	logging.Info().Str("address", address).Msg("Serving Prometheus metrics")
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		logging.Error().Err(err).Str("address", address).Msg("Metrics server stopped")
+	}
+}
+
+// cleanupExpired purges expired messages once. With a Pushgateway URL the purge counters are
+// pushed there, since the process exits before Prometheus could scrape it.
+func cleanupExpired(ctx context.Context, passConfig config.PassConfig, batchSize int, pushgatewayURL string) (storageDomain.CleanupResult, error) {
+	storageService, closeService, err := newStorageService(passConfig)
+	if err != nil {
+		return storageDomain.CleanupResult{}, err
+	}
+	defer closeService()
+
+	if batchSize <= 0 {
+		batchSize = passConfig.CleanupBatchSize
+	}
+	registry := prometheus.NewRegistry()
+	service := storageCleanup.NewInstrumentedService(storageService, storageCleanup.NewMetrics(registry))
+	result, err := service.CleanupExpiredMessages(ctx, batchSize)
+
+	if pushgatewayURL != "" {
+		if pushErr := push.New(pushgatewayURL, "passwordexchange_cleanup").Gatherer(registry).Push(); pushErr != nil {
+			logging.Error().Err(pushErr).Str("url", pushgatewayURL).Msg("Failed to push cleanup metrics")
+		}
+	}
+	return result, err
+}
+
+// databaseConfig maps the flat PassConfig database settings onto the storage domain's configuration
+func databaseConfig(passConfig config.PassConfig) storageDomain.DatabaseConfig {
+	return storageDomain.DatabaseConfig{
+		Driver:   passConfig.DbDriver,
+		Host:     passConfig.DbHost,
+		User:     passConfig.DbUser,
+		Password: passConfig.DbPass,
+		Name:     passConfig.DbName,
+		Path:     passConfig.DbPath,
+	}
+}
+
+// atRestConfig maps the flat PassConfig at-rest encryption settings onto the storage domain's configuration
+func atRestConfig(passConfig config.PassConfig) storageDomain.AtRestConfig {
+	return storageDomain.AtRestConfig{
+		KeyringPath:    passConfig.AtRestKeyring,
+		TransitAddress: passConfig.AtRestTransitAddr,
+		TransitToken:   passConfig.AtRestTransitToken,
+		TransitMount:   passConfig.AtRestTransitMount,
+		TransitKey:     passConfig.AtRestTransitKey,
+	}
+}
+
+// rotateKeys re-wraps every stored message under the configured master key
+func rotateKeys(ctx context.Context, passConfig config.PassConfig) (storageDomain.RotationResult, error) {
+	atRest := atRestConfig(passConfig)
+	if !atRest.Enabled() {
+		return storageDomain.RotationResult{}, errors.New("no master key configured: set atrestkeyring or atresttransitaddr")
+	}
+
+	keyWrapper, err := storageRepository.NewKeyWrapper(atRest)
+	if err != nil {
+		return storageDomain.RotationResult{}, err
+	}
+	repo, err := storageRepository.NewMessageRepository(databaseConfig(passConfig))
+	if err != nil {
+		return storageDomain.RotationResult{}, err
+	}
+	defer repo.Close()
+
+	// The rotator needs the content as stored, so it gets the undecorated repository
+	rotator := storageDomain.NewKeyRotator(repo, storageDomain.NewAtRestCipher(keyWrapper))
+	return rotator.Rotate(ctx)
+}
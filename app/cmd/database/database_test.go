@@ -2,9 +2,22 @@ package database
 
 import (
 	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
-
+	"time"
+
+	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/keyring"
+	storageRepository "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/repository"
+	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -244,3 +257,237 @@ func TestDatabaseCmdRun_NoServerOnMigrationFailure(t *testing.T) {
 	// Verify server NOT started on failure
 	assert.False(t, serverStarted, "Server should NOT have been started if migration failed")
 }
+
+func TestOpenMigrationDB_SelectsDriverMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		driver  string
+		wantDir string
+	}{
+		{"default is mysql", "", "migrations"},
+		{"mysql", storageDomain.DriverMySQL, "migrations"},
+		{"postgres", storageDomain.DriverPostgres, filepath.Join("migrations", "postgres")},
+		{"sqlite", storageDomain.DriverSQLite, filepath.Join("migrations", "sqlite")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dir, err := openMigrationDB(config.PassConfig{
+				DbDriver: tt.driver,
+				DbHost:   "localhost",
+				DbPort:   5432,
+				DbUser:   "user",
+				DbPass:   "pass",
+				DbName:   "passwordexchange",
+				DbPath:   filepath.Join(t.TempDir(), "passwordexchange.db"),
+			})
+			require.NoError(t, err)
+			defer db.Close()
+			assert.Equal(t, tt.wantDir, dir)
+		})
+	}
+}
+
+func TestOpenMigrationDB_UnsupportedDriver(t *testing.T) {
+	_, _, err := openMigrationDB(config.PassConfig{DbDriver: "oracle"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, storageDomain.ErrUnsupportedDriver)
+}
+
+func TestRotateKeysCommand(t *testing.T) {
+	oldRunKeyRotation := runKeyRotation
+	runKeyRotation = func(ctx context.Context) (storageDomain.RotationResult, error) {
+		return storageDomain.RotationResult{Scanned: 5, Rewrapped: 3, Sealed: 1, Skipped: 1, Notifications: 2}, nil
+	}
+	defer func() { runKeyRotation = oldRunKeyRotation }()
+
+	b := bytes.NewBufferString("")
+	rotateKeysCmd.SetOut(b)
+	defer rotateKeysCmd.SetOut(nil)
+
+	err := rotateKeysCmd.RunE(rotateKeysCmd, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, "Scanned 5 messages: 3 re-wrapped, 1 sealed, 1 skipped; 2 queued notifications re-wrapped\n", b.String())
+}
+
+func TestRotateKeysCommandError(t *testing.T) {
+	oldRunKeyRotation := runKeyRotation
+	runKeyRotation = func(ctx context.Context) (storageDomain.RotationResult, error) {
+		return storageDomain.RotationResult{}, storageDomain.ErrUnknownMasterKey
+	}
+	defer func() { runKeyRotation = oldRunKeyRotation }()
+
+	err := rotateKeysCmd.RunE(rotateKeysCmd, []string{})
+	assert.ErrorIs(t, err, storageDomain.ErrUnknownMasterKey)
+}
+
+func TestCleanupCommand(t *testing.T) {
+	oldRunCleanup := runCleanup
+	var gotBatchSize int
+	var gotPushgateway string
+	runCleanup = func(ctx context.Context, batchSize int, pushgatewayURL string) (storageDomain.CleanupResult, error) {
+		gotBatchSize, gotPushgateway = batchSize, pushgatewayURL
+		return storageDomain.CleanupResult{MessagesPurged: 12, SecretRequestsPurged: 3}, nil
+	}
+	defer func() { runCleanup = oldRunCleanup }()
+
+	b := bytes.NewBufferString("")
+	cleanupCmd.SetOut(b)
+	defer cleanupCmd.SetOut(nil)
+	require.NoError(t, cleanupCmd.Flags().Set("batch-size", "200"))
+	require.NoError(t, cleanupCmd.Flags().Set("pushgateway", "http://pushgateway:9091"))
+	defer func() {
+		_ = cleanupCmd.Flags().Set("batch-size", "0")
+		_ = cleanupCmd.Flags().Set("pushgateway", "")
+	}()
+
+	err := cleanupCmd.RunE(cleanupCmd, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, "Purged 12 expired messages and 3 expired secret requests\n", b.String())
+	assert.Equal(t, 200, gotBatchSize)
+	assert.Equal(t, "http://pushgateway:9091", gotPushgateway)
+}
+
+func TestCleanupExpired_DeletesExpiredMessages(t *testing.T) {
+	server := miniredis.RunT(t)
+	passConfig := config.PassConfig{DbDriver: storageDomain.DriverRedis, DbHost: server.Addr()}
+
+	repo, err := storageRepository.NewMessageRepository(databaseConfig(passConfig))
+	require.NoError(t, err)
+	defer repo.Close()
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, repo.InsertMessage(&storageDomain.Message{UniqueID: "expired", Content: "c", MaxViewCount: 5, ExpiresAt: &past}))
+
+	result, err := cleanupExpired(context.Background(), passConfig, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.MessagesPurged)
+	assert.False(t, server.Exists("passwordexchange:message:expired"))
+
+	events, err := server.List("passwordexchange:audit_events")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], `"event_type":"message.expired"`)
+}
+
+func TestVerifyAuditCommand(t *testing.T) {
+	oldRunAuditVerification := runAuditVerification
+	var gotAnchor storageDomain.AuditAnchor
+	runAuditVerification = func(ctx context.Context, anchor storageDomain.AuditAnchor) (storageDomain.AuditVerification, error) {
+		gotAnchor = anchor
+		return storageDomain.AuditVerification{Events: 42, Head: storageDomain.AuditAnchor{Sequence: 42, Hash: "abc123"}}, nil
+	}
+	defer func() { runAuditVerification = oldRunAuditVerification }()
+
+	b := bytes.NewBufferString("")
+	verifyAuditCmd.SetOut(b)
+	defer verifyAuditCmd.SetOut(nil)
+	require.NoError(t, verifyAuditCmd.Flags().Set("anchor", "40:def456"))
+	defer func() { _ = verifyAuditCmd.Flags().Set("anchor", "") }()
+
+	err := verifyAuditCmd.RunE(verifyAuditCmd, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, "Verified 42 audit events, head is 42:abc123\n", b.String())
+	assert.Equal(t, storageDomain.AuditAnchor{Sequence: 40, Hash: "def456"}, gotAnchor)
+}
+
+func TestVerifyAuditCommandError(t *testing.T) {
+	oldRunAuditVerification := runAuditVerification
+	runAuditVerification = func(ctx context.Context, anchor storageDomain.AuditAnchor) (storageDomain.AuditVerification, error) {
+		return storageDomain.AuditVerification{}, storageDomain.ErrAuditChainBroken
+	}
+	defer func() { runAuditVerification = oldRunAuditVerification }()
+
+	err := verifyAuditCmd.RunE(verifyAuditCmd, []string{})
+	assert.ErrorIs(t, err, storageDomain.ErrAuditChainBroken)
+}
+
+func TestParseAuditAnchor(t *testing.T) {
+	anchor, err := parseAuditAnchor("")
+	require.NoError(t, err)
+	assert.Zero(t, anchor)
+
+	for _, value := range []string{"42", "x:abc", "0:abc", "42:"} {
+		_, err := parseAuditAnchor(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestVerifyAuditLog_RecordedByStorageService(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	passConfig := config.PassConfig{DbDriver: storageDomain.DriverRedis, DbHost: server.Addr(), AuditHashKey: "audit-key"}
+
+	service, closeService, err := newStorageService(passConfig)
+	require.NoError(t, err)
+	defer closeService()
+	require.NoError(t, service.StoreMessage(ctx, &storageDomain.Message{UniqueID: "message-1", Content: "c", MaxViewCount: 5}))
+	_, err = service.RetrieveMessage(ctx, "message-1")
+	require.NoError(t, err)
+
+	verification, err := verifyAuditLog(ctx, passConfig, storageDomain.AuditAnchor{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), verification.Events)
+}
+
+func TestRotateKeys_RequiresMasterKey(t *testing.T) {
+	_, err := rotateKeys(context.Background(), config.PassConfig{DbDriver: storageDomain.DriverRedis})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no master key configured")
+}
+
+func TestRotateKeys_RewrapsStoredMessages(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	passConfig := config.PassConfig{DbDriver: storageDomain.DriverRedis, DbHost: server.Addr()}
+	writeKeyring := func(file keyring.File) string {
+		data, err := json.Marshal(file)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "keyring.json")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		return path
+	}
+	oldKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	newKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	// One message stored before at-rest encryption, one sealed under the old master key
+	repo, err := storageRepository.NewMessageRepository(databaseConfig(passConfig))
+	require.NoError(t, err)
+	defer repo.Close()
+	require.NoError(t, repo.InsertMessage(&storageDomain.Message{UniqueID: "legacy", Content: "legacy-content", MaxViewCount: 5}))
+	oldWrapper, err := keyring.NewKeyWrapper("2026-01", map[string][]byte{"2026-01": bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	sealedRepo := storageDomain.NewEncryptedRepository(repo, storageDomain.NewAtRestCipher(oldWrapper))
+	require.NoError(t, sealedRepo.InsertMessage(&storageDomain.Message{UniqueID: "sealed", Content: "sealed-content", MaxViewCount: 5}))
+
+	passConfig.AtRestKeyring = writeKeyring(keyring.File{Current: "2026-10", Keys: map[string]string{"2026-01": oldKey, "2026-10": newKey}})
+	result, err := rotateKeys(ctx, passConfig)
+	require.NoError(t, err)
+	assert.Equal(t, storageDomain.RotationResult{Scanned: 2, Rewrapped: 1, Sealed: 1}, result)
+
+	// Both open with only the new key left in the keyring
+	newWrapper, err := keyring.NewKeyWrapper("2026-10", map[string][]byte{"2026-10": bytes.Repeat([]byte{2}, 32)})
+	require.NoError(t, err)
+	rotatedRepo := storageDomain.NewEncryptedRepository(repo, storageDomain.NewAtRestCipher(newWrapper))
+	for uniqueID, want := range map[string]string{"legacy": "legacy-content", "sealed": "sealed-content"} {
+		message, err := rotatedRepo.GetMessage(uniqueID)
+		require.NoError(t, err, uniqueID)
+		assert.Equal(t, want, message.Content)
+	}
+}
+
+func TestNewOutboxPublisher_FollowsQueueBackend(t *testing.T) {
+	assert.False(t, outboxConfigured(config.PassConfig{}), "rabbitmq without rabhost")
+	assert.True(t, outboxConfigured(config.PassConfig{RabHost: "rabbitmq"}))
+	assert.True(t, outboxConfigured(config.PassConfig{QueueBackend: "nats"}))
+	assert.False(t, outboxConfigured(config.PassConfig{QueueBackend: "memory", RabHost: "rabbitmq"}), "unsupported backend")
+
+	assert.Equal(t, "*rabbitmq.OutboxPublisher", fmt.Sprintf("%T", newOutboxPublisher(config.PassConfig{RabHost: "rabbitmq"})))
+	assert.Equal(t, "*nats.OutboxPublisher", fmt.Sprintf("%T", newOutboxPublisher(config.PassConfig{QueueBackend: "nats"})))
+}
+
+func TestNewStorageService_UnsupportedQueueBackend(t *testing.T) {
+	for _, backend := range []string{"memory", "kafka"} {
+		_, _, err := newStorageService(config.PassConfig{DbDriver: storageDomain.DriverRedis, QueueBackend: backend})
+		assert.ErrorIs(t, err, notificationDomain.ErrUnsupportedQueueBackend, backend)
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -74,3 +75,18 @@ func (m *defaultMigrator) Create(name string) error {
 	fmt.Printf("Created migration files:\n  %s\n  %s\n", upFileName, downFileName)
 	return nil
 }
+
+// schemalessMigrator is used for stores without a schema, where migrations are no-ops.
+type schemalessMigrator struct{}
+
+func (schemalessMigrator) Up() error { return nil }
+
+func (schemalessMigrator) Down() error { return nil }
+
+func (schemalessMigrator) Version() (uint, bool, error) { return 0, false, nil }
+
+func (schemalessMigrator) Force(version int) error { return nil }
+
+func (schemalessMigrator) Create(name string) error {
+	return errors.New("the configured database driver does not use migrations")
+}
@@ -0,0 +1,173 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	rabbitMQConsumer "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/rabbitmq"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	// runDLQList is a variable to allow mocking in tests.
+	runDLQList = func(ctx context.Context, limit int) ([]rabbitMQConsumer.DeadLetter, error) {
+		admin, err := newDeadLetterAdmin()
+		if err != nil {
+			return nil, err
+		}
+		defer admin.Close()
+		return admin.List(ctx, limit)
+	}
+	// runDLQReplay is a variable to allow mocking in tests.
+	runDLQReplay = func(ctx context.Context, limit int) (int, error) {
+		admin, err := newDeadLetterAdmin()
+		if err != nil {
+			return 0, err
+		}
+		defer admin.Close()
+		return admin.Replay(ctx, limit)
+	}
+	// runDLQPurge is a variable to allow mocking in tests.
+	runDLQPurge = func(ctx context.Context) (int, error) {
+		admin, err := newDeadLetterAdmin()
+		if err != nil {
+			return 0, err
+		}
+		defer admin.Close()
+		return admin.Purge(ctx)
+	}
+)
+
+// newDeadLetterAdmin connects to the dead-letter queue of the configured notification queue
+func newDeadLetterAdmin() (*rabbitMQConsumer.DeadLetterAdmin, error) {
+	var cfg Config
+	bindenvs(cfg)
+	if err := viper.Unmarshal(&cfg.PassConfig); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	queueConn := cfg.queueConnection()
+	if queueConn.Backend != "" && queueConn.Backend != contracts.QueueBackendRabbitMQ {
+		return nil, fmt.Errorf("the dlq commands only manage RabbitMQ dead-letter queues, not the %q backend", queueConn.Backend)
+	}
+	return rabbitMQConsumer.NewDeadLetterAdmin(queueConn)
+}
+
+// dlqCmd groups the commands that manage notifications the email consumer gave up on.
+var dlqCmd = &cobra.Command{
+	Use:   "dlq",
+	Short: "Inspect and re-drive failed notifications",
+	Long: `Notifications the email consumer could not send are retried every emailretrydelay, up to
+emailmaxattempts times. After that, or straight away when a notification can never be sent
+(e.g. an unreadable message or an invalid address), they are moved to the <rabqname>.dlq
+queue with the reason in their headers. Use these commands to look at them, send them again
+once the cause is fixed, or drop them.`,
+}
+
+// dlqListCmd shows the dead letters at the head of the queue.
+var dlqListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List failed notifications without removing them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		deadLetters, err := runDLQList(commandContext(cmd), limit)
+		if err != nil {
+			return fmt.Errorf("error listing dead-lettered notifications: %w", err)
+		}
+		printDeadLetters(cmd.OutOrStdout(), deadLetters)
+		return nil
+	},
+}
+
+// dlqReplayCmd moves dead letters back onto the notification queue.
+var dlqReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Send failed notifications again",
+	Long: `Move failed notifications back onto the notification queue with their attempt count reset.
+By default every notification in the dead-letter queue is replayed; --limit replays only the
+oldest ones.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		replayed, err := runDLQReplay(commandContext(cmd), limit)
+		if err != nil {
+			return fmt.Errorf("error replaying dead-lettered notifications after %d: %w", replayed, err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Replayed %d notifications\n", replayed)
+		return nil
+	},
+}
+
+// dlqPurgeCmd drops every dead letter.
+var dlqPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Delete every failed notification",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			return errors.New("purging cannot be undone; pass --yes to confirm")
+		}
+		purged, err := runDLQPurge(commandContext(cmd))
+		if err != nil {
+			return fmt.Errorf("error purging dead-lettered notifications: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Purged %d notifications\n", purged)
+		return nil
+	},
+}
+
+// commandContext returns the command's context, or a background context outside Execute
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// printDeadLetters writes dead letters as a table
+func printDeadLetters(out io.Writer, deadLetters []rabbitMQConsumer.DeadLetter) {
+	if len(deadLetters) == 0 {
+		fmt.Fprintln(out, "No failed notifications")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MESSAGE ID\tTYPE\tRECIPIENT\tATTEMPTS\tFAILED AT\tREASON")
+	for _, deadLetter := range deadLetters {
+		failedAt := "-"
+		if !deadLetter.FailedAt.IsZero() {
+			failedAt = deadLetter.FailedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			orDash(deadLetter.UniqueID),
+			orDash(deadLetter.NotificationType),
+			orDash(deadLetter.Recipient),
+			deadLetter.Attempts,
+			failedAt,
+			orDash(deadLetter.FailureReason),
+		)
+	}
+	w.Flush()
+}
+
+// orDash stands in for empty table cells
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	emailCmd.AddCommand(dlqCmd)
+	dlqCmd.AddCommand(dlqListCmd)
+	dlqCmd.AddCommand(dlqReplayCmd)
+	dlqCmd.AddCommand(dlqPurgeCmd)
+
+	dlqListCmd.Flags().Int("limit", 20, "Maximum number of notifications to show")
+	dlqReplayCmd.Flags().Int("limit", 0, "Maximum number of notifications to replay (default all)")
+	dlqPurgeCmd.Flags().Bool("yes", false, "Confirm that the failed notifications should be deleted")
+}
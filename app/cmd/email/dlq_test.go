@@ -0,0 +1,88 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	rabbitMQConsumer "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/rabbitmq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDLQListCommand(t *testing.T) {
+	oldRunDLQList := runDLQList
+	var gotLimit int
+	runDLQList = func(ctx context.Context, limit int) ([]rabbitMQConsumer.DeadLetter, error) {
+		gotLimit = limit
+		return []rabbitMQConsumer.DeadLetter{
+			{UniqueID: "abc123", NotificationType: "initial", Recipient: "r***@example.com", Attempts: 5, FailureReason: "failed to send email", FailedAt: time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)},
+			{Attempts: 1, FailureReason: "failed to unmarshal queue message"},
+		}, nil
+	}
+	defer func() { runDLQList = oldRunDLQList }()
+
+	b := bytes.NewBufferString("")
+	dlqListCmd.SetOut(b)
+	defer dlqListCmd.SetOut(nil)
+	require.NoError(t, dlqListCmd.Flags().Set("limit", "50"))
+
+	err := dlqListCmd.RunE(dlqListCmd, []string{})
+	require.NoError(t, err)
+	assert.Equal(t, 50, gotLimit)
+	assert.Contains(t, b.String(), "abc123")
+	assert.Contains(t, b.String(), "2026-10-17T08:00:00Z")
+	assert.Contains(t, b.String(), "failed to unmarshal queue message")
+}
+
+func TestDLQListCommand_Empty(t *testing.T) {
+	oldRunDLQList := runDLQList
+	runDLQList = func(ctx context.Context, limit int) ([]rabbitMQConsumer.DeadLetter, error) {
+		return nil, nil
+	}
+	defer func() { runDLQList = oldRunDLQList }()
+
+	b := bytes.NewBufferString("")
+	dlqListCmd.SetOut(b)
+	defer dlqListCmd.SetOut(nil)
+
+	require.NoError(t, dlqListCmd.RunE(dlqListCmd, []string{}))
+	assert.Equal(t, "No failed notifications\n", b.String())
+}
+
+func TestDLQReplayCommand(t *testing.T) {
+	oldRunDLQReplay := runDLQReplay
+	runDLQReplay = func(ctx context.Context, limit int) (int, error) {
+		return 3, errors.New("channel closed")
+	}
+	defer func() { runDLQReplay = oldRunDLQReplay }()
+
+	err := dlqReplayCmd.RunE(dlqReplayCmd, []string{})
+	assert.ErrorContains(t, err, "after 3")
+}
+
+func TestDLQPurgeCommand_RequiresConfirmation(t *testing.T) {
+	oldRunDLQPurge := runDLQPurge
+	purged := false
+	runDLQPurge = func(ctx context.Context) (int, error) {
+		purged = true
+		return 4, nil
+	}
+	defer func() { runDLQPurge = oldRunDLQPurge }()
+
+	err := dlqPurgeCmd.RunE(dlqPurgeCmd, []string{})
+	assert.Error(t, err)
+	assert.False(t, purged)
+
+	b := bytes.NewBufferString("")
+	dlqPurgeCmd.SetOut(b)
+	defer dlqPurgeCmd.SetOut(nil)
+	require.NoError(t, dlqPurgeCmd.Flags().Set("yes", "true"))
+	defer dlqPurgeCmd.Flags().Set("yes", "false")
+
+	require.NoError(t, dlqPurgeCmd.RunE(dlqPurgeCmd, []string{}))
+	assert.True(t, purged)
+	assert.Equal(t, "Purged 4 notifications\n", b.String())
+}
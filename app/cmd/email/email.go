@@ -72,10 +72,10 @@ func init() {
 	emailCmd.Flags().String("emailpass", "", "pass to log in with for SMTP authentication")
 	emailCmd.Flags().String("emailhost", "", "host to log in with for SMTP authentication")
 	emailCmd.Flags().String("emailport", "", "port to log in with for SMTP authentication")
-	emailCmd.Flags().String("rabuser", "", "User to log in with for rabbitmq authentication")
-	emailCmd.Flags().String("rabhost", "", "host to log in with for rabbitmq authentication")
-	emailCmd.Flags().String("rabpass", "", "password to log in with for rabbitmq authentication")
-	emailCmd.Flags().String("rabport", "", "port to log in with for rabbitmq authentication")
+	emailCmd.PersistentFlags().String("rabuser", "", "User to log in with for rabbitmq authentication")
+	emailCmd.PersistentFlags().String("rabhost", "", "host to log in with for rabbitmq authentication")
+	emailCmd.PersistentFlags().String("rabpass", "", "password to log in with for rabbitmq authentication")
+	emailCmd.PersistentFlags().String("rabport", "", "port to log in with for rabbitmq authentication")
 	// Cobra supports Persistent Flags which will work for this command
 	// and all subcommands, e.g.:
 	// emailCmd.PersistentFlags().String("foo", "", "A help for foo")
@@ -84,10 +84,10 @@ func init() {
 	viper.BindPFlag("emailpass", emailCmd.Flags().Lookup("emailpass"))
 	viper.BindPFlag("emailport", emailCmd.Flags().Lookup("emailport"))
 	viper.BindPFlag("emailhost", emailCmd.Flags().Lookup("emailhost"))
-	viper.BindPFlag("rabuser", emailCmd.Flags().Lookup("rabuser"))
-	viper.BindPFlag("rabpass", emailCmd.Flags().Lookup("rabpass"))
-	viper.BindPFlag("rabport", emailCmd.Flags().Lookup("rabport"))
-	viper.BindPFlag("rabhost", emailCmd.Flags().Lookup("rabhost"))
+	viper.BindPFlag("rabuser", emailCmd.PersistentFlags().Lookup("rabuser"))
+	viper.BindPFlag("rabpass", emailCmd.PersistentFlags().Lookup("rabpass"))
+	viper.BindPFlag("rabport", emailCmd.PersistentFlags().Lookup("rabport"))
+	viper.BindPFlag("rabhost", emailCmd.PersistentFlags().Lookup("rabhost"))
 	// Cobra supports local flags which will only run when this command
 	// is called directly, e.g.:
 	// emailCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
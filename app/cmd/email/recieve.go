@@ -2,12 +2,14 @@ package email
 
 import (
 	"context"
+	"strings"
 
 	notificationConsumer "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/primary/consumer"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/logger"
-	rabbitMQConsumer "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/rabbitmq"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queue"
 	sharedConfig "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/shared"
 	smtpSender "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/smtp"
+	webhookSender "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/webhook"
 	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
 	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
@@ -38,22 +40,10 @@ func (conf Config) startHexagonalProcessing() {
 	ctx := context.Background()
 
 	// Create email connection configuration
-	emailConn := notificationDomain.EmailConnection{
-		Host:     conf.EmailHost,
-		Port:     conf.EmailPort,
-		User:     conf.EmailUser,
-		Password: conf.EmailPass,
-		From:     conf.EmailFrom,
-	}
+	emailConn := conf.emailConnection()
 
 	// Create queue connection configuration
-	queueConn := notificationDomain.QueueConnection{
-		Host:      conf.RabHost,
-		Port:      conf.RabPort,
-		User:      conf.RabUser,
-		Password:  conf.RabPass,
-		QueueName: conf.RabQName,
-	}
+	queueConn := conf.queueConnection()
 
 	// Create port adapters using existing functionality
 	configPort := sharedConfig.NewSharedConfigAdapter(conf.PassConfig)
@@ -61,11 +51,27 @@ func (conf Config) startHexagonalProcessing() {
 	validationPort := &validationAdapter{}
 
 	// Create secondary adapters
-	emailSender := smtpSender.NewSMTPSender(emailConn, configPort, loggerPort, validationPort)
-	queueConsumer := rabbitMQConsumer.NewRabbitMQConsumer()
+	emailSender, err := smtpSender.NewSMTPSender(emailConn, configPort, loggerPort, validationPort)
+	if err != nil {
+		logging.Fatal().Err(err).Str("tlsMode", emailConn.TLSMode).Msg("Failed to create SMTP sender")
+	}
+	defer emailSender.Close()
+	queueConsumer, err := queue.NewConsumer(queueConn, conf.EmailMaxAttempts, conf.EmailRetryDelay)
+	if err != nil {
+		logging.Fatal().Err(err).Str("backend", queueConn.Backend).Msg("Failed to create queue consumer")
+	}
+	webhookService := notificationDomain.NewWebhookService(
+		webhookSender.NewHTTPSender(loggerPort),
+		notificationDomain.WebhookConfig{
+			URL:          conf.WebhookURL,
+			Secret:       conf.WebhookSecret,
+			TrustedHosts: splitHosts(conf.WebhookTrustedHosts),
+		},
+		loggerPort,
+	)
 
 	// Create notification service (domain) - using WithReminder constructor with nil reminder service since email command doesn't need reminders
-	notificationService := notificationDomain.NewNotificationServiceWithReminder(emailSender, queueConsumer, nil, nil, loggerPort, validationPort, configPort)
+	notificationService := notificationDomain.NewNotificationServiceWithReminder(emailSender, queueConsumer, nil, nil, loggerPort, validationPort, configPort, webhookService)
 
 	// Create primary adapter (consumer)
 	consumer := notificationConsumer.NewNotificationConsumer(notificationService, queueConn, 100)
@@ -76,3 +82,45 @@ func (conf Config) startHexagonalProcessing() {
 		logging.Fatal().Err(err).Msg("Failed to start hexagonal notification consumer")
 	}
 }
+
+// emailConnection is the SMTP server notifications are sent through
+func (conf Config) emailConnection() notificationDomain.EmailConnection {
+	return notificationDomain.EmailConnection{
+		Host:          conf.EmailHost,
+		Port:          conf.EmailPort,
+		User:          conf.EmailUser,
+		Password:      conf.EmailPass,
+		From:          conf.EmailFrom,
+		TLSMode:       conf.EmailTLSMode,
+		TLSSkipVerify: conf.EmailTLSSkipVerify,
+		TLSCAFile:     conf.EmailTLSCAFile,
+		DKIMKey:       conf.EmailDKIMKey,
+		DKIMSelector:  conf.EmailDKIMSelector,
+		DKIMDomain:    conf.EmailDKIMDomain,
+	}
+}
+
+// queueConnection is the queue the email consumer reads notifications from
+func (conf Config) queueConnection() notificationDomain.QueueConnection {
+	return notificationDomain.QueueConnection{
+		Backend:   conf.QueueBackend,
+		Host:      conf.RabHost,
+		Port:      conf.RabPort,
+		User:      conf.RabUser,
+		Password:  conf.RabPass,
+		QueueName: conf.RabQName,
+		URL:       conf.NatsURL,
+		Stream:    conf.NatsStream,
+	}
+}
+
+// splitHosts parses a comma-separated host list, ignoring blanks
+func splitHosts(hosts string) []string {
+	var result []string
+	for _, host := range strings.Split(hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			result = append(result, host)
+		}
+	}
+	return result
+}
@@ -25,8 +25,14 @@ func (conf Config) startHexagonalServer() {
 	// Create key generator (secondary adapter)
 	keyGenerator := memoryKeygen.NewKeyGenerator()
 
+	algorithm, err := encryptionDomain.ParseCipherAlgorithm(conf.CipherAlgorithm)
+	if err != nil {
+		logging.Fatal().Err(err).Msg("Invalid cipher algorithm")
+	}
+
 	// Create encryption service (domain)
-	encryptionService := encryptionDomain.NewEncryptionService(keyGenerator)
+	encryptionService := encryptionDomain.NewEncryptionServiceWithAlgorithm(keyGenerator, algorithm)
+	logging.Info().Str("algorithm", algorithm.String()).Msg("Sealing new messages")
 
 	// Create gRPC server (primary adapter)
 	grpcServer := encryptionGRPC.NewGRPCServer(encryptionService, address)
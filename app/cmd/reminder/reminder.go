@@ -14,12 +14,13 @@ import (
 
 	"github.com/Anthony-Bible/password-exchange/app/cmd"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/logger"
-	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/rabbitmq"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queue"
 	sharedConfig "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/shared"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/storage"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/validator"
 	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
-	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/mysql"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/repository"
 	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
@@ -110,19 +111,28 @@ PASSWORDEXCHANGE_REMINDER_INTERVAL: Hours between reminders (1-720, default: 24)
 		}
 
 		// Initialize storage adapter with database connection
-		// Uses MySQL adapter as the concrete implementation of the storage port
+		// The configured driver selects the concrete implementation of the storage port
 		dbConfig := storageDomain.DatabaseConfig{
+			Driver:   cfg.DbDriver,
 			Host:     cfg.DbHost,
 			User:     cfg.DbUser,
 			Password: cfg.DbPass,
 			Name:     cfg.DbName,
+			Path:     cfg.DbPath,
 		}
-		storageAdapter := mysql.NewMySQLAdapter(dbConfig)
-		if mysqlAdapter, ok := storageAdapter.(*mysql.MySQLAdapter); ok {
-			// Set up defer before attempting connection
-			defer mysqlAdapter.Close()
-
-			if err := mysqlAdapter.Connect(); err != nil {
+		storageAdapter, err := repository.NewMessageRepository(dbConfig)
+		if err != nil {
+			logging.Error().
+				Err(err).
+				Str("operation", "database_connect").
+				Str("driver", cfg.DbDriver).
+				Msg("Failed to create storage adapter")
+			return
+		}
+		// Set up defer before attempting connection
+		defer storageAdapter.Close()
+		if connector, ok := storageAdapter.(repository.Connector); ok {
+			if err := connector.Connect(); err != nil {
 				logging.Error().
 					Err(err).
 					Str("operation", "database_connect").
@@ -139,23 +149,17 @@ PASSWORDEXCHANGE_REMINDER_INTERVAL: Hours between reminders (1-720, default: 24)
 		// Create notification storage adapter
 		notificationStorageAdapter := storage.NewGRPCStorageAdapter(storageService)
 
-		// Create RabbitMQ notification publisher
-		rabbitConfig := rabbitmq.NotificationConfig{
-			Host:      cfg.RabHost,
-			Port:      cfg.RabPort,
-			User:      cfg.RabUser,
-			Password:  cfg.RabPass,
-			QueueName: cfg.RabQName,
-		}
-
-		notificationPublisher, err := rabbitmq.NewNotificationPublisher(rabbitConfig)
+		// Create the notification publisher for the configured queue backend
+		queueConn := cfg.queueConnection()
+		notificationPublisher, err := queue.NewPublisher(context.Background(), queueConn)
 		if err != nil {
 			logging.Error().
 				Err(err).
-				Str("operation", "rabbitmq_connect").
+				Str("operation", "queue_connect").
+				Str("backend", queueConn.Backend).
 				Str("host", cfg.RabHost).
 				Int("port", cfg.RabPort).
-				Msg("Failed to connect to RabbitMQ")
+				Msg("Failed to connect to the notification queue")
 			return
 		}
 		defer notificationPublisher.Close()
@@ -166,7 +170,7 @@ PASSWORDEXCHANGE_REMINDER_INTERVAL: Hours between reminders (1-720, default: 24)
 		validationPort := validator.NewValidationAdapter()
 
 		// Create reminder service with storage adapter and notification publisher
-		// Uses RabbitMQ to publish reminder notifications instead of sending emails directly
+		// Publishes reminder notifications to the queue instead of sending emails directly
 		reminderService := notificationDomain.NewReminderService(notificationStorageAdapter, notificationPublisher, loggerPort, configPort, validationPort)
 
 		// Process reminders
@@ -188,6 +192,20 @@ PASSWORDEXCHANGE_REMINDER_INTERVAL: Hours between reminders (1-720, default: 24)
 	},
 }
 
+// queueConnection is the queue reminder notifications are published to
+func (cfg Config) queueConnection() contracts.QueueConnection {
+	return contracts.QueueConnection{
+		Backend:   cfg.QueueBackend,
+		Host:      cfg.RabHost,
+		Port:      cfg.RabPort,
+		User:      cfg.RabUser,
+		Password:  cfg.RabPass,
+		QueueName: cfg.RabQName,
+		URL:       cfg.NatsURL,
+		Stream:    cfg.NatsStream,
+	}
+}
+
 // applyFlagOverrides applies command-line flag overrides with validation
 func applyFlagOverrides(cfg *Config) error {
 	// Override with command-line flags if provided
@@ -1,15 +1,29 @@
 package web
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"path/filepath"
 
 	webAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/primary/web"
+	argon2Adapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/argon2"
 	bcryptAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/bcrypt"
 	grpcClients "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/grpc_clients"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/hasherchain"
 	httpAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/http"
-	rabbitMQAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/rabbitmq"
+	queueAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/queue"
+	storageAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/storage"
 	urlAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/url"
 	messageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	notificationContracts "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	storageOutbox "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/primary/outbox"
+	storageNATS "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/nats"
+	storageRabbitMQ "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/rabbitmq"
+	storageRepository "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/repository"
+	storageSQLite "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/sqlite"
+	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/validation"
@@ -35,29 +49,46 @@ func (conf Config) startHexagonalServer() {
 	}
 	defer encryptionClient.Close()
 
-	storageClient, err := grpcClients.NewStorageClient(dbServiceName)
+	// Notifications are published to the configured queue backend. The publisher connects on
+	// first use, so the web service starts while the queue is down.
+	queuePublisher, err := conf.newQueuePublisher()
 	if err != nil {
-		logging.Fatal().Err(err).Msg("Failed to create storage client")
+		logging.Fatal().Err(err).Str("backend", conf.QueueBackend).Msg("Failed to create notification publisher")
 	}
-	defer storageClient.Close()
-
-	// Create notification publisher
-	notificationConfig := rabbitMQAdapter.NotificationConfig{
-		Host:      conf.RabHost,
-		Port:      conf.RabPort,
-		User:      conf.RabUser,
-		Password:  conf.RabPass,
-		QueueName: conf.RabQName,
+	defer queuePublisher.Close()
+
+	// SQLite runs in-process so a single web container needs no database service
+	var storageClient messageDomain.StorageService
+	if conf.DbDriver == storageDomain.DriverSQLite {
+		repo := conf.newEmbeddedRepository()
+		defer repo.Close()
+		storageService := storageDomain.NewStorageService(conf.withAtRestEncryption(repo)).
+			WithAuditLog(storageDomain.NewAuditLog(repo, []byte(conf.AuditHashKey))).
+			WithOutboxPublisher(queuePublisher)
+		storageClient = storageAdapter.NewStorageAdapter(storageService)
+
+		// Without a database service, this process publishes the notifications stored with messages
+		relayCtx, stopRelay := context.WithCancel(context.Background())
+		defer stopRelay()
+		go storageOutbox.NewRelay(storageService, conf.OutboxInterval, conf.OutboxBatchSize).Run(relayCtx)
+	} else {
+		grpcStorageClient, err := grpcClients.NewStorageClient(dbServiceName)
+		if err != nil {
+			logging.Fatal().Err(err).Msg("Failed to create storage client")
+		}
+		defer grpcStorageClient.Close()
+		storageClient = grpcStorageClient
 	}
 
-	notificationPublisher, err := rabbitMQAdapter.NewNotificationPublisher(notificationConfig)
-	if err != nil {
-		logging.Fatal().Err(err).Msg("Failed to create notification publisher")
-	}
-	defer notificationPublisher.Close()
+	// Create notification publisher
+	notificationPublisher := queueAdapter.NewNotificationPublisher(queuePublisher, conf.WebhookURL)
 
 	// Create other secondary adapters
-	passwordHasher := bcryptAdapter.NewPasswordHasher(11)
+	// New passphrases are hashed with Argon2id; bcrypt hashes from before the switch still verify
+	passwordHasher := hasherchain.NewPasswordHasher(
+		argon2Adapter.NewPasswordHasher(conf.passwordHashParams()),
+		bcryptAdapter.NewPasswordHasher(11),
+	)
 
 	environment := conf.RunningEnvironment
 	siteHost, err := validation.GetViperVariable(environment + "Host")
@@ -89,6 +120,75 @@ func (conf Config) startHexagonalServer() {
 	}
 }
 
+// withAtRestEncryption seals message content under the configured master key, if any
+func (conf Config) withAtRestEncryption(repo storageDomain.MessageRepository) storageDomain.MessageRepository {
+	atRest := storageDomain.AtRestConfig{
+		KeyringPath:    conf.AtRestKeyring,
+		TransitAddress: conf.AtRestTransitAddr,
+		TransitToken:   conf.AtRestTransitToken,
+		TransitMount:   conf.AtRestTransitMount,
+		TransitKey:     conf.AtRestTransitKey,
+	}
+	if !atRest.Enabled() {
+		return repo
+	}
+
+	keyWrapper, err := storageRepository.NewKeyWrapper(atRest)
+	if err != nil {
+		logging.Fatal().Err(err).Msg("Failed to load at-rest master key")
+	}
+	logging.Info().Str("keyID", keyWrapper.CurrentKeyID()).Msg("At-rest encryption enabled")
+	return storageDomain.NewEncryptedRepository(repo, storageDomain.NewAtRestCipher(keyWrapper))
+}
+
+// queuePublisher publishes encoded notifications and holds a connection that must be closed
+type queuePublisher interface {
+	storageDomain.OutboxPublisher
+	Close() error
+}
+
+// newQueuePublisher creates the publisher for the configured queue backend. The web service
+// publishes read receipts, webhook events and request-fulfilled notifications with it, and the
+// embedded storage relays its outbox with it. It connects on first use.
+func (conf Config) newQueuePublisher() (queuePublisher, error) {
+	switch conf.QueueBackend {
+	case notificationContracts.QueueBackendRabbitMQ, "":
+		return storageRabbitMQ.NewOutboxPublisher(storageRabbitMQ.OutboxPublisherConfig{
+			Host:      conf.RabHost,
+			Port:      conf.RabPort,
+			User:      conf.RabUser,
+			Password:  conf.RabPass,
+			QueueName: conf.RabQName,
+		}), nil
+	case notificationContracts.QueueBackendNATS:
+		return storageNATS.NewOutboxPublisher(storageNATS.OutboxPublisherConfig{
+			URL:       conf.NatsURL,
+			Stream:    conf.NatsStream,
+			QueueName: conf.RabQName,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", notificationDomain.ErrUnsupportedQueueBackend, conf.QueueBackend)
+	}
+}
+
+// newEmbeddedRepository migrates and opens the SQLite database at DbPath
+func (conf Config) newEmbeddedRepository() *storageSQLite.SQLiteAdapter {
+	repo := storageSQLite.NewSQLiteAdapter(storageDomain.DatabaseConfig{
+		Driver: storageDomain.DriverSQLite,
+		Path:   conf.DbPath,
+	}).(*storageSQLite.SQLiteAdapter)
+
+	if err := repo.Migrate(filepath.Join("migrations", "sqlite")); err != nil {
+		logging.Fatal().Err(err).Str("path", conf.DbPath).Msg("Failed to migrate SQLite database")
+	}
+	if err := repo.Connect(); err != nil {
+		logging.Fatal().Err(err).Str("path", conf.DbPath).Msg("Failed to open SQLite database")
+	}
+
+	logging.Info().Str("path", conf.DbPath).Msg("Using embedded SQLite storage")
+	return repo
+}
+
 func (conf Config) getServiceNames() (string, string) {
 	encryptionServiceName, err := validation.GetViperVariable(fmt.Sprintf("Encryption%sService", conf.RunningEnvironment))
 	dbServiceName, err := validation.GetViperVariable(fmt.Sprintf("Database%sService", conf.RunningEnvironment))
@@ -102,3 +202,19 @@ func (conf Config) getServiceNames() (string, string) {
 	}
 	return encryptionServiceName, dbServiceName
 }
+
+// passwordHashParams converts the configured Argon2id cost, leaving out-of-range values at zero
+// so the hasher falls back to its defaults
+func (conf Config) passwordHashParams() argon2Adapter.Params {
+	var params argon2Adapter.Params
+	if conf.PassphraseHashMemoryKiB > 0 {
+		params.MemoryKiB = uint32(conf.PassphraseHashMemoryKiB)
+	}
+	if conf.PassphraseHashIterations > 0 {
+		params.Iterations = uint32(conf.PassphraseHashIterations)
+	}
+	if conf.PassphraseHashParallelism > 0 && conf.PassphraseHashParallelism <= math.MaxUint8 {
+		params.Parallelism = uint8(conf.PassphraseHashParallelism)
+	}
+	return params
+}
@@ -0,0 +1,29 @@
+package web
+
+import (
+	"fmt"
+	"testing"
+
+	notificationDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueuePublisher_FollowsQueueBackend(t *testing.T) {
+	for backend, want := range map[string]string{
+		"":         "*rabbitmq.OutboxPublisher",
+		"rabbitmq": "*rabbitmq.OutboxPublisher",
+		"nats":     "*nats.OutboxPublisher",
+	} {
+		publisher, err := Config{config.PassConfig{QueueBackend: backend}}.newQueuePublisher()
+		require.NoError(t, err, backend)
+		assert.Equal(t, want, fmt.Sprintf("%T", publisher), backend)
+		publisher.Close()
+	}
+
+	for _, backend := range []string{"memory", "kafka"} {
+		_, err := Config{config.PassConfig{QueueBackend: backend}}.newQueuePublisher()
+		assert.ErrorIs(t, err, notificationDomain.ErrUnsupportedQueueBackend, backend)
+	}
+}
@@ -70,9 +70,10 @@ const docTemplate = `{
         },
         "/messages": {
             "post": {
-                "description": "Creates a new encrypted message that can be accessed via a unique URL. Optionally sends email notifications to the recipient.",
+                "description": "Creates a new encrypted message that can be accessed via a unique URL. Optionally sends email notifications to the recipient.\nWhen clientEncrypted is true, content must already be AES-GCM ciphertext; the server stores it as-is and returns a URL the client completes with \"#\u003ckey\u003e\".\nTo attach a file, send multipart/form-data with the JSON request in a \"request\" field and the file (up to 10 MiB) in a \"file\" field. Content may be empty when a file is attached.\nTo share with several people, list them in \"recipients\"; each gets an independent message returned in the response's \"recipients\".\nTo seal the message to the recipient's age or OpenPGP public key, set \"recipientPublicKey\"; no key is returned and the recipient opens it with their private key.\nTo require k of n custodians, set \"split\"; each share becomes its own message, returned in the response's \"shares\", and POST /shares/combine reconstructs the secret.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -171,11 +172,154 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Permanently deletes a message before it is viewed or expires. Requires the revocationToken returned when the message was submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Revoke a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Revocation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageRevokeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Message revoked"
+                    },
+                    "400": {
+                        "description": "Missing revocation token",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid revocation token",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}/attachment": {
+            "post": {
+                "description": "Decrypts and returns the file attached to a message. Each download counts toward the message's max view count; a request with the wrong key or passphrase does not.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Download a message attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decryption request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageDecryptRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Decrypted attachment",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "X-Max-View-Count": {
+                                "type": "integer",
+                                "description": "Views allowed before the message is deleted"
+                            },
+                            "X-View-Count": {
+                                "type": "integer",
+                                "description": "Views used, including this download"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid decryption key format",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid passphrase; details.remainingAttempts counts the attempts left",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message or attachment not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Message destroyed after too many wrong passphrases",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Message locked after too many wrong passphrases",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
             }
         },
         "/messages/{id}/decrypt": {
             "post": {
-                "description": "Decrypts and retrieves the message content. This is a one-time operation that will delete the message after successful decryption.",
+                "description": "Decrypts and retrieves the message content. This is a one-time operation that will delete the message after successful decryption.\nClient-encrypted messages are returned as ciphertext with clientEncrypted set; no decryptionKey is needed.\nMessages sealed to a public key need no decryptionKey either: supply privateKey to receive the plaintext, or omit it to receive the armored ciphertext. A wrong private key does not use a view.",
                 "consumes": [
                     "application/json"
                 ],
@@ -213,19 +357,301 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid passphrase",
+                        "description": "Invalid passphrase or private key; details.remainingAttempts counts the passphrase attempts left",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Message already consumed or destroyed after too many wrong passphrases",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "423": {
+                        "description": "Message locked after too many wrong passphrases",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/messages/{id}/status": {
+            "get": {
+                "description": "Returns view counts, expiry and reminder history to the message's sender. Authenticate with the revocationToken returned when the message was submitted. The message content is never returned and no view is counted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Messages"
+                ],
+                "summary": "Get message status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bearer revocation token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message status",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageStatusResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing revocation token",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid revocation token",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests": {
+            "post": {
+                "description": "Creates a link the requester shares with whoever holds a secret. When that person submits the secret through the link, it is encrypted and the link to read it is emailed only to the requester.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Secret Requests"
+                ],
+                "summary": "Request a secret",
+                "parameters": [
+                    {
+                        "description": "Secret request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRequestCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Secret request created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRequestCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests/{id}": {
+            "get": {
+                "description": "Returns the requester's name and description so the person holding the secret knows what is being asked for. The requester's email address is never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Secret Requests"
+                ],
+                "summary": "Get an open secret request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Open secret request",
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRequestInfoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Secret request not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Secret request already fulfilled or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/requests/{id}/fulfill": {
+            "post": {
+                "description": "Encrypts the secret and emails the link to read it to the requester. A request can only be fulfilled once, and the link is never returned to the caller.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Secret Requests"
+                ],
+                "summary": "Fulfill a secret request",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Secret to send",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SecretRequestFulfillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Secret sent to the requester"
+                    },
+                    "400": {
+                        "description": "Validation error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Secret request not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Secret request already fulfilled or expired",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.StandardErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shares/combine": {
+            "post": {
+                "description": "Reconstructs a secret that was split across custodians with \"split\" once at least its threshold of shares are supplied. Shares are combined in memory and nothing is stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares"
+                ],
+                "summary": "Combine secret shares",
+                "parameters": [
+                    {
+                        "description": "Shares to combine",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ShareCombineRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Secret reconstructed",
                         "schema": {
-                            "$ref": "#/definitions/models.StandardErrorResponse"
+                            "$ref": "#/definitions/models.ShareCombineResponse"
                         }
                     },
-                    "404": {
-                        "description": "Message not found or expired",
+                    "400": {
+                        "description": "Validation error or invalid share",
                         "schema": {
                             "$ref": "#/definitions/models.StandardErrorResponse"
                         }
                     },
-                    "410": {
-                        "description": "Message already consumed",
+                    "422": {
+                        "description": "Fewer shares than the threshold",
                         "schema": {
                             "$ref": "#/definitions/models.StandardErrorResponse"
                         }
@@ -264,6 +690,20 @@ const docTemplate = `{
                 }
             }
         },
+        "models.AttachmentInfo": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.HealthCheckResponse": {
             "type": "object",
             "properties": {
@@ -287,6 +727,13 @@ const docTemplate = `{
         "models.MessageAccessInfoResponse": {
             "type": "object",
             "properties": {
+                "attachmentSize": {
+                    "description": "AttachmentSize is the attachment's size in bytes. Omitted when there is no attachment.",
+                    "type": "integer"
+                },
+                "clientEncrypted": {
+                    "type": "boolean"
+                },
                 "exists": {
                     "type": "boolean"
                 },
@@ -294,12 +741,23 @@ const docTemplate = `{
                     "description": "ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.",
                     "type": "string"
                 },
+                "hasAttachment": {
+                    "type": "boolean"
+                },
                 "hasBeenAccessed": {
                     "type": "boolean"
                 },
                 "messageId": {
                     "type": "string"
                 },
+                "publicKeyEncrypted": {
+                    "description": "PublicKeyEncrypted indicates the message is sealed to the recipient's public key and\nis opened with privateKey instead of a decryption key.",
+                    "type": "boolean"
+                },
+                "remainingPassphraseAttempts": {
+                    "description": "RemainingPassphraseAttempts is how many wrong passphrases are left before the message is\nlocked or destroyed. Zero when the message needs no passphrase.",
+                    "type": "integer"
+                },
                 "requiresPassphrase": {
                     "type": "boolean"
                 }
@@ -307,21 +765,39 @@ const docTemplate = `{
         },
         "models.MessageDecryptRequest": {
             "type": "object",
-            "required": [
-                "decryptionKey"
-            ],
             "properties": {
                 "decryptionKey": {
+                    "description": "DecryptionKey is omitted for client-encrypted messages, whose key never leaves the client.",
                     "type": "string"
                 },
                 "passphrase": {
                     "type": "string"
+                },
+                "privateKey": {
+                    "description": "PrivateKey opens a message sealed to the recipient's public key: an age identity\n(\"AGE-SECRET-KEY-1...\") or an ASCII-armored OpenPGP private key. When omitted, such a\nmessage is returned as armored ciphertext for the recipient to decrypt locally.",
+                    "type": "string"
+                },
+                "privateKeyPassphrase": {
+                    "description": "PrivateKeyPassphrase unlocks a passphrase-protected OpenPGP private key.",
+                    "type": "string"
                 }
             }
         },
         "models.MessageDecryptResponse": {
             "type": "object",
             "properties": {
+                "attachment": {
+                    "description": "Attachment describes a file that can be downloaded from POST /messages/{id}/attachment.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AttachmentInfo"
+                        }
+                    ]
+                },
+                "clientEncrypted": {
+                    "description": "ClientEncrypted indicates Content is still ciphertext and must be decrypted by the client.",
+                    "type": "boolean"
+                },
                 "content": {
                     "type": "string"
                 },
@@ -338,6 +814,69 @@ const docTemplate = `{
                 "messageId": {
                     "type": "string"
                 },
+                "publicKeyEncrypted": {
+                    "description": "PublicKeyEncrypted indicates the message is sealed to the recipient's public key. Content is\nthe armored age or OpenPGP ciphertext unless privateKey was supplied.",
+                    "type": "boolean"
+                },
+                "viewCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MessageRevokeRequest": {
+            "type": "object",
+            "required": [
+                "revocationToken"
+            ],
+            "properties": {
+                "revocationToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MessageStatusResponse": {
+            "type": "object",
+            "properties": {
+                "clientEncrypted": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "description": "ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.",
+                    "type": "string"
+                },
+                "failedPassphraseAttempts": {
+                    "description": "FailedPassphraseAttempts counts the wrong passphrases submitted for the message.",
+                    "type": "integer"
+                },
+                "hasAttachment": {
+                    "type": "boolean"
+                },
+                "maxViewCount": {
+                    "type": "integer"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "publicKeyEncrypted": {
+                    "type": "boolean"
+                },
+                "readReceipts": {
+                    "description": "ReadReceipts reports whether the sender is emailed each time the message is viewed.",
+                    "type": "boolean"
+                },
+                "remainingViews": {
+                    "type": "integer"
+                },
+                "reminders": {
+                    "description": "Reminders lists reminder emails sent to the recipient. Empty when none have been sent.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReminderHistoryEntry"
+                    }
+                },
+                "requiresPassphrase": {
+                    "type": "boolean"
+                },
                 "viewCount": {
                     "type": "integer"
                 }
@@ -355,6 +894,10 @@ const docTemplate = `{
                 "antiSpamAnswer": {
                     "type": "string"
                 },
+                "clientEncrypted": {
+                    "description": "ClientEncrypted marks content as base64url(nonce || AES-GCM ciphertext) encrypted by the client.\nThe key must never be sent to the server; email notifications are unavailable in this mode.",
+                    "type": "boolean"
+                },
                 "content": {
                     "type": "string",
                     "maxLength": 10000,
@@ -371,25 +914,61 @@ const docTemplate = `{
                     "maximum": 100,
                     "minimum": 0
                 },
+                "notifyOnView": {
+                    "description": "NotifyOnView emails sender.email a read receipt each time the message is viewed.\nRequires sender.email and a turnstileToken.",
+                    "type": "boolean"
+                },
                 "passphrase": {
                     "type": "string",
                     "maxLength": 500
                 },
+                "passphraseKey": {
+                    "description": "PassphraseKey mixes the passphrase into the content key with Argon2id, so the message\ncannot be decrypted without it even with database access. Requires passphrase and cannot\nbe combined with clientEncrypted or recipientPublicKey.",
+                    "type": "boolean"
+                },
                 "questionId": {
                     "type": "integer"
                 },
                 "recipient": {
                     "$ref": "#/definitions/models.Recipient"
                 },
+                "recipientPublicKey": {
+                    "description": "RecipientPublicKey seals content to an age X25519 recipient (\"age1...\") or an ASCII-armored\nOpenPGP public key. No key is returned and only the recipient's private key can open the\nmessage. Cannot be combined with clientEncrypted, recipients or an attachment.",
+                    "type": "string",
+                    "maxLength": 16384
+                },
+                "recipients": {
+                    "description": "Recipients shares the message with up to 10 people, each receiving their own link, key\nand view counter. Cannot be combined with recipient or clientEncrypted.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Recipient"
+                    }
+                },
                 "sendNotification": {
                     "type": "boolean"
                 },
                 "sender": {
                     "$ref": "#/definitions/models.Sender"
                 },
+                "split": {
+                    "description": "Split divides content with Shamir's Secret Sharing into shares, each stored as its own\nmessage. With recipients, share i is sent to recipients[i].",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SplitConfig"
+                        }
+                    ]
+                },
                 "turnstileToken": {
                     "type": "string",
                     "maxLength": 2048
+                },
+                "webhook": {
+                    "description": "Webhook receives signed message.created, message.viewed, message.exhausted,\nmessage.expired and message.revoked events for this message.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WebhookConfig"
+                        }
+                    ]
                 }
             }
         },
@@ -412,6 +991,32 @@ const docTemplate = `{
                 "notificationSent": {
                     "type": "boolean"
                 },
+                "recipients": {
+                    "description": "Recipients lists the per-recipient messages of a multi-recipient submission. The fields\nabove describe the first of them. Omitted for single-recipient submissions.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecipientMessageResponse"
+                    }
+                },
+                "revocationToken": {
+                    "description": "RevocationToken deletes the message early via DELETE /api/v1/messages/{id} and unlocks\nGET /api/v1/messages/{id}/status. It is shown only once.",
+                    "type": "string"
+                },
+                "revokeUrl": {
+                    "description": "RevokeURL opens the web revocation page with the token in the URL fragment.",
+                    "type": "string"
+                },
+                "shares": {
+                    "description": "Shares lists the message holding each share of a split submission, ordered by share\nindex. The fields above describe share 1. Omitted unless split was requested.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ShareMessageResponse"
+                    }
+                },
+                "statusUrl": {
+                    "description": "StatusURL opens the web status page with the token in the URL fragment.",
+                    "type": "string"
+                },
                 "webUrl": {
                     "type": "string"
                 }
@@ -433,6 +1038,127 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RecipientMessageResponse": {
+            "type": "object",
+            "properties": {
+                "decryptUrl": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revocationToken": {
+                    "type": "string"
+                },
+                "revokeUrl": {
+                    "type": "string"
+                },
+                "statusUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReminderHistoryEntry": {
+            "type": "object",
+            "properties": {
+                "lastReminderSent": {
+                    "type": "string"
+                },
+                "reminderCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SecretRequestCreateRequest": {
+            "type": "object",
+            "required": [
+                "turnstileToken"
+            ],
+            "properties": {
+                "description": {
+                    "description": "Description tells whoever fulfills the request what is being asked for",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "expirationHours": {
+                    "description": "ExpirationHours is how long the request stays open. When 0 or omitted, the server default (7 days / 168 hours) applies.",
+                    "type": "integer",
+                    "maximum": 2160,
+                    "minimum": 0
+                },
+                "requester": {
+                    "description": "Requester is emailed the link to the secret once the request is fulfilled",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Sender"
+                        }
+                    ]
+                },
+                "turnstileToken": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "models.SecretRequestCreateResponse": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "requestUrl": {
+                    "description": "RequestURL is the link to share with whoever holds the secret",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SecretRequestFulfillRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "minLength": 1
+                },
+                "senderName": {
+                    "description": "SenderName is shown to the requester in the notification email",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "models.SecretRequestInfoResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "requesterName": {
+                    "type": "string"
+                }
+            }
+        },
         "models.Sender": {
             "type": "object",
             "required": [
@@ -450,6 +1176,76 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ShareCombineRequest": {
+            "type": "object",
+            "required": [
+                "shares"
+            ],
+            "properties": {
+                "shares": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.ShareCombineResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ShareMessageResponse": {
+            "type": "object",
+            "properties": {
+                "decryptUrl": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "messageId": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name and Email identify the custodian the share was sent to. Empty without recipients.",
+                    "type": "string"
+                },
+                "revocationToken": {
+                    "type": "string"
+                },
+                "revokeUrl": {
+                    "type": "string"
+                },
+                "shareIndex": {
+                    "type": "integer"
+                },
+                "statusUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SplitConfig": {
+            "type": "object",
+            "properties": {
+                "shares": {
+                    "description": "Shares is how many shares, and so messages, to create; 2–10",
+                    "type": "integer"
+                },
+                "threshold": {
+                    "description": "Threshold is how many shares are needed to reconstruct the content; 2–shares",
+                    "type": "integer"
+                }
+            }
+        },
         "models.StandardErrorResponse": {
             "type": "object",
             "properties": {
@@ -470,6 +1266,19 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.WebhookConfig": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "description": "Secret signs each payload with HMAC-SHA256; 16–256 characters",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL must be an absolute https URL",
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/encryption/domain"
@@ -9,9 +10,15 @@ import (
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/encryption"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// maxMessageBytes raises gRPC's 4 MiB default so a whole attachment can be
+// encrypted or decrypted in a single call.
+const maxMessageBytes = 16 << 20
+
 // GRPCServer implements the gRPC encryption service
 type GRPCServer struct {
 	pb.UnimplementedMessageServiceServer
@@ -35,7 +42,10 @@ func (s *GRPCServer) Start() error {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxMessageBytes),
+		grpc.MaxSendMsgSize(maxMessageBytes),
+	)
 	pb.RegisterMessageServiceServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
@@ -53,8 +63,9 @@ func (s *GRPCServer) EncryptMessage(ctx context.Context, request *pb.EncryptedMe
 	logging.Debug().Int("plaintextCount", len(request.GetPlainText())).Msg("Received encryption request")
 
 	domainRequest := domain.EncryptionRequest{
-		Plaintext: request.GetPlainText(),
-		Key:       request.GetKey(),
+		Plaintext:      request.GetPlainText(),
+		Key:            request.GetKey(),
+		AssociatedData: request.GetAssociatedData(),
 	}
 
 	response, err := s.encryptionService.Encrypt(ctx, domainRequest)
@@ -76,8 +87,9 @@ func (s *GRPCServer) DecryptMessage(ctx context.Context, request *pb.DecryptedMe
 	logging.Debug().Int("ciphertextCount", len(request.GetCiphertext())).Msg("Received decryption request")
 
 	domainRequest := domain.DecryptionRequest{
-		Ciphertext: request.GetCiphertext(),
-		Key:        request.GetKey(),
+		Ciphertext:     request.GetCiphertext(),
+		Key:            request.GetKey(),
+		AssociatedData: request.GetAssociatedData(),
 	}
 
 	response, err := s.encryptionService.Decrypt(ctx, domainRequest)
@@ -94,6 +106,173 @@ func (s *GRPCServer) DecryptMessage(ctx context.Context, request *pb.DecryptedMe
 	return pbResponse, nil
 }
 
+// EncryptFile handles chunked file encryption requests
+func (s *GRPCServer) EncryptFile(ctx context.Context, request *pb.EncryptFileRequest) (*pb.EncryptFileResponse, error) {
+	logging.Debug().Int("plaintextBytes", len(request.GetPlaintext())).Msg("Received file encryption request")
+
+	domainRequest := domain.FileEncryptionRequest{
+		Plaintext:      request.GetPlaintext(),
+		Key:            request.GetKey(),
+		AssociatedData: request.GetAssociatedData(),
+	}
+
+	response, err := s.encryptionService.EncryptFile(ctx, domainRequest)
+	if err != nil {
+		logging.Error().Err(err).Msg("File encryption failed")
+		return nil, err
+	}
+
+	logging.Debug().Int("chunkCount", len(response.Chunks)).Msg("Successfully encrypted file")
+	return &pb.EncryptFileResponse{Chunks: response.Chunks}, nil
+}
+
+// DecryptFile handles chunked file decryption requests
+func (s *GRPCServer) DecryptFile(ctx context.Context, request *pb.DecryptFileRequest) (*pb.DecryptFileResponse, error) {
+	logging.Debug().Int("chunkCount", len(request.GetChunks())).Msg("Received file decryption request")
+
+	domainRequest := domain.FileDecryptionRequest{
+		Chunks:         request.GetChunks(),
+		Key:            request.GetKey(),
+		AssociatedData: request.GetAssociatedData(),
+	}
+
+	response, err := s.encryptionService.DecryptFile(ctx, domainRequest)
+	if err != nil {
+		logging.Error().Err(err).Msg("File decryption failed")
+		return nil, err
+	}
+
+	logging.Debug().Int("plaintextBytes", len(response.Plaintext)).Msg("Successfully decrypted file")
+	return &pb.DecryptFileResponse{Plaintext: response.Plaintext}, nil
+}
+
+// EncryptToPublicKey handles requests to seal content to a recipient's public key
+func (s *GRPCServer) EncryptToPublicKey(ctx context.Context, request *pb.PublicKeyEncryptRequest) (*pb.PublicKeyEncryptResponse, error) {
+	logging.Debug().Int("plaintextBytes", len(request.GetPlaintext())).Msg("Received public key encryption request")
+
+	domainRequest := domain.PublicKeyEncryptionRequest{
+		Plaintext: request.GetPlaintext(),
+		PublicKey: request.GetPublicKey(),
+	}
+
+	response, err := s.encryptionService.EncryptToPublicKey(ctx, domainRequest)
+	if err != nil {
+		logging.Error().Err(err).Msg("Public key encryption failed")
+		return nil, publicKeyStatusError(err)
+	}
+
+	logging.Debug().Str("keyType", string(response.KeyType)).Msg("Successfully encrypted to public key")
+	return &pb.PublicKeyEncryptResponse{
+		Ciphertext: response.Ciphertext,
+		KeyType:    string(response.KeyType),
+	}, nil
+}
+
+// DecryptWithPrivateKey handles requests to open content sealed to a public key
+func (s *GRPCServer) DecryptWithPrivateKey(ctx context.Context, request *pb.PublicKeyDecryptRequest) (*pb.PublicKeyDecryptResponse, error) {
+	logging.Debug().Int("ciphertextBytes", len(request.GetCiphertext())).Msg("Received private key decryption request")
+
+	domainRequest := domain.PublicKeyDecryptionRequest{
+		Ciphertext: request.GetCiphertext(),
+		PrivateKey: request.GetPrivateKey(),
+		Passphrase: request.GetPassphrase(),
+	}
+
+	response, err := s.encryptionService.DecryptWithPrivateKey(ctx, domainRequest)
+	if err != nil {
+		logging.Error().Err(err).Msg("Private key decryption failed")
+		return nil, publicKeyStatusError(err)
+	}
+
+	logging.Debug().Int("plaintextBytes", len(response.Plaintext)).Msg("Successfully decrypted with private key")
+	return &pb.PublicKeyDecryptResponse{Plaintext: response.Plaintext}, nil
+}
+
+// publicKeyStatusError tells callers which caller-supplied input was rejected, so they can
+// report a bad key as a client error instead of a server failure.
+func publicKeyStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPublicKey):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, domain.ErrInvalidPrivateKey):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, domain.ErrInvalidCiphertext):
+		return status.Errorf(codes.DataLoss, "%v", err)
+	default:
+		return err
+	}
+}
+
+// SplitSecret handles requests to split a secret into Shamir shares
+func (s *GRPCServer) SplitSecret(ctx context.Context, request *pb.SplitSecretRequest) (*pb.SplitSecretResponse, error) {
+	logging.Debug().
+		Int32("shares", request.GetShares()).
+		Int32("threshold", request.GetThreshold()).
+		Msg("Received secret split request")
+
+	domainRequest := domain.SplitSecretRequest{
+		Secret:    request.GetSecret(),
+		Shares:    int(request.GetShares()),
+		Threshold: int(request.GetThreshold()),
+	}
+
+	response, err := s.encryptionService.SplitSecret(ctx, domainRequest)
+	if err != nil {
+		logging.Error().Err(err).Msg("Secret split failed")
+		return nil, shareStatusError(err)
+	}
+
+	logging.Debug().Int("shares", len(response.Shares)).Msg("Successfully split secret")
+	return &pb.SplitSecretResponse{Shares: response.Shares}, nil
+}
+
+// CombineShares handles requests to reconstruct a secret from Shamir shares
+func (s *GRPCServer) CombineShares(ctx context.Context, request *pb.CombineSharesRequest) (*pb.CombineSharesResponse, error) {
+	logging.Debug().Int("shares", len(request.GetShares())).Msg("Received share combine request")
+
+	response, err := s.encryptionService.CombineShares(ctx, domain.CombineSharesRequest{Shares: request.GetShares()})
+	if err != nil {
+		logging.Error().Err(err).Msg("Share combine failed")
+		return nil, shareStatusError(err)
+	}
+
+	logging.Debug().Msg("Successfully combined secret shares")
+	return &pb.CombineSharesResponse{Secret: response.Secret}, nil
+}
+
+// shareStatusError distinguishes bad shares from too few shares so callers can tell the user
+// whether to fix a share or collect more of them.
+func shareStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSplitParameters), errors.Is(err, domain.ErrInvalidShare):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, domain.ErrInsufficientShares):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	default:
+		return err
+	}
+}
+
+// DerivePassphraseKey handles passphrase key derivation requests
+func (s *GRPCServer) DerivePassphraseKey(ctx context.Context, request *pb.PassphraseKeyRequest) (*pb.PassphraseKeyResponse, error) {
+	logging.Debug().Bool("hasSalt", len(request.GetSalt()) > 0).Msg("Received passphrase key derivation request")
+
+	response, err := s.encryptionService.DerivePassphraseKey(ctx, domain.PassphraseKeyRequest{
+		Key:        request.GetKey(),
+		Passphrase: request.GetPassphrase(),
+		Salt:       request.GetSalt(),
+	})
+	if err != nil {
+		logging.Error().Err(err).Msg("Passphrase key derivation failed")
+		if errors.Is(err, domain.ErrInvalidKeyLength) || errors.Is(err, domain.ErrInvalidKeyDerivationInput) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, err
+	}
+
+	return &pb.PassphraseKeyResponse{Key: response.Key, Salt: response.Salt}, nil
+}
+
 // GenerateRandomString handles random key generation requests
 func (s *GRPCServer) GenerateRandomString(ctx context.Context, request *pb.Randomrequest) (*pb.Randomresponse, error) {
 	logging.Debug().Int32("length", request.GetRandomLength()).Msg("Received random key generation request")
@@ -22,6 +22,10 @@ func (k EncryptionKey) Bytes() []byte {
 type EncryptionRequest struct {
 	Plaintext []string
 	Key       []byte
+	// AssociatedData is authenticated but not encrypted, binding the ciphertext to e.g. its message ID
+	AssociatedData []byte
+	// KeyID names the key in the envelope header so it can be found again; empty for per-message keys
+	KeyID string
 }
 
 // EncryptionResponse represents the result of encryption
@@ -33,6 +37,8 @@ type EncryptionResponse struct {
 type DecryptionRequest struct {
 	Ciphertext []string
 	Key        []byte
+	// AssociatedData must match what the ciphertext was sealed with; legacy ciphertexts ignore it
+	AssociatedData []byte
 }
 
 // DecryptionResponse represents the result of decryption
@@ -40,6 +46,102 @@ type DecryptionResponse struct {
 	Plaintext []string
 }
 
+// FileEncryptionRequest represents a request to encrypt a file as a sequence of chunks
+type FileEncryptionRequest struct {
+	Plaintext      []byte
+	Key            []byte
+	AssociatedData []byte
+}
+
+// FileEncryptionResponse represents the sealed chunks of an encrypted file
+type FileEncryptionResponse struct {
+	Chunks [][]byte
+}
+
+// FileDecryptionRequest represents a request to decrypt a chunked file
+type FileDecryptionRequest struct {
+	Chunks         [][]byte
+	Key            []byte
+	AssociatedData []byte
+}
+
+// FileDecryptionResponse represents the result of file decryption
+type FileDecryptionResponse struct {
+	Plaintext []byte
+}
+
+// PublicKeyType identifies the kind of recipient key a message is sealed to
+type PublicKeyType string
+
+const (
+	// PublicKeyTypeAge is an age X25519 recipient ("age1...")
+	PublicKeyTypeAge PublicKeyType = "age"
+	// PublicKeyTypeOpenPGP is an ASCII-armored OpenPGP public key
+	PublicKeyTypeOpenPGP PublicKeyType = "openpgp"
+)
+
+// PublicKeyEncryptionRequest represents a request to seal plaintext to a recipient's public key
+type PublicKeyEncryptionRequest struct {
+	Plaintext []byte
+	PublicKey string
+}
+
+// PublicKeyEncryptionResponse carries the ASCII-armored ciphertext, readable with the age or gpg CLI
+type PublicKeyEncryptionResponse struct {
+	Ciphertext string
+	KeyType    PublicKeyType
+}
+
+// PublicKeyDecryptionRequest represents a request to open armored ciphertext with the recipient's
+// private key. Passphrase unlocks a protected OpenPGP key and is ignored for age identities.
+type PublicKeyDecryptionRequest struct {
+	Ciphertext string
+	PrivateKey string
+	Passphrase string
+}
+
+// PublicKeyDecryptionResponse represents the result of public-key decryption
+type PublicKeyDecryptionResponse struct {
+	Plaintext []byte
+}
+
+// SplitSecretRequest represents a request to split a secret into Shares shares, any
+// Threshold of which reconstruct it
+type SplitSecretRequest struct {
+	Secret    []byte
+	Shares    int
+	Threshold int
+}
+
+// SplitSecretResponse carries the encoded shares, ordered by share index
+type SplitSecretResponse struct {
+	Shares []string
+}
+
+// CombineSharesRequest represents a request to reconstruct a secret from encoded shares
+type CombineSharesRequest struct {
+	Shares []string
+}
+
+// CombineSharesResponse represents the reconstructed secret
+type CombineSharesResponse struct {
+	Secret []byte
+}
+
+// PassphraseKeyRequest represents a request to mix a passphrase into a 32-byte message key.
+// Salt is empty when encrypting a new message and the stored salt when decrypting.
+type PassphraseKeyRequest struct {
+	Key        []byte
+	Passphrase string
+	Salt       []byte
+}
+
+// PassphraseKeyResponse carries the derived content key and the salt it was derived with
+type PassphraseKeyResponse struct {
+	Key  []byte
+	Salt []byte
+}
+
 // RandomRequest represents a request for random key generation
 type RandomRequest struct {
 	Length int32
@@ -55,4 +157,4 @@ type RandomResponse struct {
 type KeyGenerator interface {
 	GenerateKey(ctx context.Context, length int32) (EncryptionKey, error)
 	GenerateID(ctx context.Context) string
-}
\ No newline at end of file
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// CipherAlgorithm identifies the AEAD that sealed an envelope. The values are stored in
+// ciphertexts, so existing ones must never be renumbered.
+type CipherAlgorithm byte
+
+const (
+	// AlgorithmAES256GCM is AES-256 in GCM mode with a 96-bit random nonce
+	AlgorithmAES256GCM CipherAlgorithm = 1
+	// AlgorithmXChaCha20Poly1305 is XChaCha20-Poly1305, whose 192-bit nonce is safe to pick at random
+	AlgorithmXChaCha20Poly1305 CipherAlgorithm = 2
+)
+
+// Envelope layout, base64url-encoded after EnvelopePrefix:
+//
+//	version (1) | algorithm (1) | key ID length (1) | key ID | nonce | ciphertext and tag
+//
+// The header is authenticated together with the caller's associated data, so the algorithm
+// and key ID cannot be altered and the ciphertext cannot be moved to another message.
+// Legacy ciphertexts are plain base64url nonce||ciphertext; they never contain '.', so the
+// prefix tells the two apart.
+const (
+	EnvelopePrefix    = "pxe."
+	EnvelopeVersion1  = 1
+	MaxEnvelopeKeyID  = 255
+	envelopeHeaderLen = 3
+)
+
+// String returns the configuration name of the algorithm
+func (a CipherAlgorithm) String() string {
+	switch a {
+	case AlgorithmAES256GCM:
+		return "aes-256-gcm"
+	case AlgorithmXChaCha20Poly1305:
+		return "xchacha20-poly1305"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(a))
+	}
+}
+
+// ParseCipherAlgorithm maps a configuration name to an algorithm; empty selects AES-256-GCM
+func ParseCipherAlgorithm(name string) (CipherAlgorithm, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "aes-256-gcm":
+		return AlgorithmAES256GCM, nil
+	case "xchacha20-poly1305":
+		return AlgorithmXChaCha20Poly1305, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, name)
+	}
+}
+
+// envelopeHeader is the authenticated, unencrypted part of an envelope
+type envelopeHeader struct {
+	version   byte
+	algorithm CipherAlgorithm
+	keyID     string
+}
+
+// marshal encodes the header as it is stored in front of the nonce
+func (h envelopeHeader) marshal() []byte {
+	header := make([]byte, 0, envelopeHeaderLen+len(h.keyID))
+	header = append(header, h.version, byte(h.algorithm), byte(len(h.keyID)))
+	return append(header, h.keyID...)
+}
+
+// associatedData binds the header to the caller's associated data. The header carries its own
+// length, so the concatenation is unambiguous.
+func (h envelopeHeader) associatedData(associatedData []byte) []byte {
+	return append(h.marshal(), associatedData...)
+}
+
+// newAEAD creates the AEAD for an algorithm with a 32-byte key
+func newAEAD(algorithm CipherAlgorithm, key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		logging.Error().Int("keyLength", len(key)).Msg("Invalid key length for envelope")
+		return nil, ErrInvalidKeyLength
+	}
+
+	switch algorithm {
+	case AlgorithmAES256GCM:
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to create AES cipher")
+			return nil, fmt.Errorf("%w: %v", ErrCipherCreationFailed, err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to create GCM")
+			return nil, fmt.Errorf("%w: %v", ErrGCMCreationFailed, err)
+		}
+		return gcm, nil
+	case AlgorithmXChaCha20Poly1305:
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to create XChaCha20-Poly1305")
+			return nil, fmt.Errorf("%w: %v", ErrCipherCreationFailed, err)
+		}
+		return aead, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedAlgorithm, byte(algorithm))
+	}
+}
+
+// sealEnvelope encrypts plaintext under aead with the given nonce and returns the encoded envelope
+func sealEnvelope(aead cipher.AEAD, header envelopeHeader, nonce, plaintext, associatedData []byte) string {
+	sealed := header.marshal()
+	sealed = append(sealed, nonce...)
+	sealed = aead.Seal(sealed, nonce, plaintext, header.associatedData(associatedData))
+	return EnvelopePrefix + base64.URLEncoding.EncodeToString(sealed)
+}
+
+// parseEnvelope decodes an envelope into its header and the nonce||ciphertext that follows it
+func parseEnvelope(encoded string) (envelopeHeader, []byte, error) {
+	raw, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(encoded, EnvelopePrefix))
+	if err != nil {
+		return envelopeHeader{}, nil, fmt.Errorf("%w: %v", ErrBase64DecodingFailed, err)
+	}
+	if len(raw) < envelopeHeaderLen {
+		return envelopeHeader{}, nil, fmt.Errorf("%w: envelope header truncated", ErrInvalidCiphertext)
+	}
+
+	header := envelopeHeader{version: raw[0], algorithm: CipherAlgorithm(raw[1])}
+	if header.version != EnvelopeVersion1 {
+		return envelopeHeader{}, nil, fmt.Errorf("%w: %d", ErrUnsupportedEnvelopeVersion, header.version)
+	}
+
+	keyIDEnd := envelopeHeaderLen + int(raw[2])
+	if len(raw) < keyIDEnd {
+		return envelopeHeader{}, nil, fmt.Errorf("%w: envelope key ID truncated", ErrInvalidCiphertext)
+	}
+	header.keyID = string(raw[envelopeHeaderLen:keyIDEnd])
+
+	return header, raw[keyIDEnd:], nil
+}
+
+// openEnvelope authenticates and decrypts an encoded envelope
+func openEnvelope(encoded string, key, associatedData []byte) ([]byte, envelopeHeader, error) {
+	header, sealed, err := parseEnvelope(encoded)
+	if err != nil {
+		return nil, envelopeHeader{}, err
+	}
+
+	aead, err := newAEAD(header.algorithm, key)
+	if err != nil {
+		return nil, envelopeHeader{}, err
+	}
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, envelopeHeader{}, fmt.Errorf("%w: envelope ciphertext truncated", ErrInvalidCiphertext)
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, header.associatedData(associatedData))
+	if err != nil {
+		return nil, envelopeHeader{}, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, header, nil
+}
+
+// IsEnvelope reports whether a ciphertext uses the versioned envelope rather than the legacy format
+func IsEnvelope(ciphertext string) bool {
+	return strings.HasPrefix(ciphertext, EnvelopePrefix)
+}
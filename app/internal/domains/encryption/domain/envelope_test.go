@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodePlaintext(t *testing.T, encoded string) string {
+	t.Helper()
+	decoded, err := base64.URLEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	return string(decoded)
+}
+
+func TestEncrypt_EnvelopeRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	for _, algorithm := range []CipherAlgorithm{AlgorithmAES256GCM, AlgorithmXChaCha20Poly1305} {
+		t.Run(algorithm.String(), func(t *testing.T) {
+			service := NewEncryptionServiceWithAlgorithm(nil, algorithm)
+
+			encrypted, err := service.Encrypt(ctx, EncryptionRequest{
+				Plaintext:      []string{"first", "second"},
+				Key:            testKey(),
+				AssociatedData: []byte("message-id"),
+				KeyID:          "key-1",
+			})
+			require.NoError(t, err)
+			require.Len(t, encrypted.Ciphertext, 2)
+
+			header, _, err := parseEnvelope(encrypted.Ciphertext[0])
+			require.NoError(t, err)
+			assert.Equal(t, envelopeHeader{version: EnvelopeVersion1, algorithm: algorithm, keyID: "key-1"}, header)
+
+			// Decryption follows the envelope, whatever the service's own algorithm
+			decrypted, err := NewEncryptionService(nil).Decrypt(ctx, DecryptionRequest{
+				Ciphertext:     encrypted.Ciphertext,
+				Key:            testKey(),
+				AssociatedData: []byte("message-id"),
+			})
+			require.NoError(t, err)
+			require.Len(t, decrypted.Plaintext, 2)
+			assert.Equal(t, "first", decodePlaintext(t, decrypted.Plaintext[0]))
+			assert.Equal(t, "second", decodePlaintext(t, decrypted.Plaintext[1]))
+		})
+	}
+}
+
+func TestDecrypt_RejectsTamperedEnvelopes(t *testing.T) {
+	ctx := context.Background()
+	service := NewEncryptionService(nil)
+
+	encrypted, err := service.Encrypt(ctx, EncryptionRequest{
+		Plaintext:      []string{"a secret long enough to outlast either nonce size"},
+		Key:            testKey(),
+		AssociatedData: []byte("message-id"),
+	})
+	require.NoError(t, err)
+	envelope := encrypted.Ciphertext[0]
+
+	raw, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(envelope, EnvelopePrefix))
+	require.NoError(t, err)
+	reencode := func(mutate func([]byte) []byte) string {
+		copied := append([]byte(nil), raw...)
+		return EnvelopePrefix + base64.URLEncoding.EncodeToString(mutate(copied))
+	}
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		aad        []byte
+		wantErr    error
+	}{
+		{"moved to another message", envelope, []byte("other-id"), ErrDecryptionFailed},
+		{"associated data dropped", envelope, nil, ErrDecryptionFailed},
+		{"algorithm swapped", reencode(func(b []byte) []byte { b[1] = byte(AlgorithmXChaCha20Poly1305); return b }), []byte("message-id"), ErrDecryptionFailed},
+		{"key ID injected", reencode(func(b []byte) []byte {
+			return append([]byte{b[0], b[1], 1, 'x'}, b[3:]...)
+		}), []byte("message-id"), ErrDecryptionFailed},
+		{"unknown version", reencode(func(b []byte) []byte { b[0] = 9; return b }), []byte("message-id"), ErrUnsupportedEnvelopeVersion},
+		{"unknown algorithm", reencode(func(b []byte) []byte { b[1] = 99; return b }), []byte("message-id"), ErrUnsupportedAlgorithm},
+		{"truncated", reencode(func(b []byte) []byte { return b[:10] }), []byte("message-id"), ErrInvalidCiphertext},
+		{"not base64", EnvelopePrefix + "!!!", []byte("message-id"), ErrBase64DecodingFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.Decrypt(ctx, DecryptionRequest{
+				Ciphertext:     []string{tt.ciphertext},
+				Key:            testKey(),
+				AssociatedData: tt.aad,
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestDecrypt_LegacyCiphertext(t *testing.T) {
+	// Sealed the way Encrypt did before envelopes: base64url(nonce || AES-GCM) without associated data
+	block, err := aes.NewCipher(testKey())
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	legacy := base64.URLEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte("old secret"), nil))
+	require.False(t, IsEnvelope(legacy))
+
+	decrypted, err := NewEncryptionService(nil).Decrypt(context.Background(), DecryptionRequest{
+		Ciphertext:     []string{legacy},
+		Key:            testKey(),
+		AssociatedData: []byte("message-id"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "old secret", decodePlaintext(t, decrypted.Plaintext[0]))
+}
+
+func TestParseCipherAlgorithm(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    CipherAlgorithm
+		wantErr error
+	}{
+		{"", AlgorithmAES256GCM, nil},
+		{"aes-256-gcm", AlgorithmAES256GCM, nil},
+		{"XChaCha20-Poly1305", AlgorithmXChaCha20Poly1305, nil},
+		{"des", 0, ErrUnsupportedAlgorithm},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCipherAlgorithm(tt.name)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
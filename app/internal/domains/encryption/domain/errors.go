@@ -24,6 +24,33 @@ var (
 	// ErrEncryptionFailed indicates encryption operation failed
 	ErrEncryptionFailed = errors.New("encryption failed")
 	
+	// ErrInvalidChunkSequence indicates file chunks are missing, reordered or truncated
+	ErrInvalidChunkSequence = errors.New("invalid chunk sequence")
+	
 	// ErrBase64DecodingFailed indicates base64 decoding failed
 	ErrBase64DecodingFailed = errors.New("base64 decoding failed")
+	
+	// ErrInvalidPublicKey indicates the recipient key is not a usable age or OpenPGP public key
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	
+	// ErrInvalidPrivateKey indicates the private key is malformed, locked or cannot open the ciphertext
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+	
+	// ErrInvalidKeyDerivationInput indicates the passphrase or salt cannot be used to derive a key
+	ErrInvalidKeyDerivationInput = errors.New("invalid key derivation input")
+	
+	// ErrInvalidSplitParameters indicates the share count or threshold cannot be used to split a secret
+	ErrInvalidSplitParameters = errors.New("invalid split parameters")
+	
+	// ErrInvalidShare indicates a share is malformed, belongs to another split or does not reconstruct the secret
+	ErrInvalidShare = errors.New("invalid secret share")
+	
+	// ErrInsufficientShares indicates fewer shares were supplied than the split's threshold
+	ErrInsufficientShares = errors.New("not enough shares to reconstruct the secret")
+	
+	// ErrUnsupportedAlgorithm indicates a cipher algorithm this service does not implement
+	ErrUnsupportedAlgorithm = errors.New("unsupported cipher algorithm")
+	
+	// ErrUnsupportedEnvelopeVersion indicates a ciphertext envelope from an unknown format version
+	ErrUnsupportedEnvelopeVersion = errors.New("unsupported ciphertext envelope version")
 )
\ No newline at end of file
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// Argon2id parameters for passphrase-derived keys, following the second recommended option of
+// RFC 9106. They are part of the stored format: changing them makes existing messages unreadable.
+const (
+	PassphraseKeySaltLength = 16
+	argon2Time              = 3
+	argon2MemoryKiB         = 64 * 1024
+	argon2Threads           = 4
+	passphraseKeyInfo       = "password-exchange passphrase content key v1"
+)
+
+// DerivePassphraseKey mixes an Argon2id key stretched from the passphrase into the message key.
+// The result encrypts the content, so neither the URL key nor the passphrase alone can decrypt it.
+// An empty salt generates a fresh one, which the caller must store to derive the same key again.
+func (s *EncryptionService) DerivePassphraseKey(ctx context.Context, req PassphraseKeyRequest) (*PassphraseKeyResponse, error) {
+	if len(req.Key) != 32 {
+		return nil, fmt.Errorf("%w: message key must be 32 bytes, got %d", ErrInvalidKeyLength, len(req.Key))
+	}
+	if req.Passphrase == "" {
+		return nil, fmt.Errorf("%w: passphrase is empty", ErrInvalidKeyDerivationInput)
+	}
+
+	salt := req.Salt
+	if len(salt) == 0 {
+		salt = make([]byte, PassphraseKeySaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			logging.Error().Err(err).Msg("Failed to generate passphrase key salt")
+			return nil, fmt.Errorf("%w: %v", ErrInsufficientRandomness, err)
+		}
+	} else if len(salt) < PassphraseKeySaltLength {
+		return nil, fmt.Errorf("%w: salt must be at least %d bytes", ErrInvalidKeyDerivationInput, PassphraseKeySaltLength)
+	}
+
+	stretched := argon2.IDKey([]byte(req.Passphrase), salt, argon2Time, argon2MemoryKiB, argon2Threads, 32)
+
+	// HKDF binds both secrets together, so learning one of them says nothing about the content key
+	secret := make([]byte, 0, len(req.Key)+len(stretched))
+	secret = append(secret, req.Key...)
+	secret = append(secret, stretched...)
+	derived := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(passphraseKeyInfo)), derived); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+
+	logging.Debug().Bool("newSalt", len(req.Salt) == 0).Msg("Derived passphrase-protected content key")
+	return &PassphraseKeyResponse{Key: derived, Salt: salt}, nil
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivePassphraseKey(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{0x42}, 32)
+
+	first, err := service.DerivePassphraseKey(ctx, PassphraseKeyRequest{Key: key, Passphrase: "correct horse"})
+	require.NoError(t, err)
+	assert.Len(t, first.Key, 32)
+	assert.Len(t, first.Salt, PassphraseKeySaltLength)
+	assert.NotEqual(t, key, first.Key)
+
+	again, err := service.DerivePassphraseKey(ctx, PassphraseKeyRequest{Key: key, Passphrase: "correct horse", Salt: first.Salt})
+	require.NoError(t, err)
+	assert.Equal(t, first.Key, again.Key, "the stored salt must reproduce the content key")
+	assert.Equal(t, first.Salt, again.Salt)
+
+	wrongPassphrase, err := service.DerivePassphraseKey(ctx, PassphraseKeyRequest{Key: key, Passphrase: "wrong", Salt: first.Salt})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Key, wrongPassphrase.Key)
+
+	otherKey := bytes.Repeat([]byte{0x43}, 32)
+	wrongKey, err := service.DerivePassphraseKey(ctx, PassphraseKeyRequest{Key: otherKey, Passphrase: "correct horse", Salt: first.Salt})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Key, wrongKey.Key)
+
+	fresh, err := service.DerivePassphraseKey(ctx, PassphraseKeyRequest{Key: key, Passphrase: "correct horse"})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Salt, fresh.Salt)
+	assert.NotEqual(t, first.Key, fresh.Key)
+}
+
+func TestDerivePassphraseKey_InvalidInput(t *testing.T) {
+	service := NewEncryptionService(nil)
+	key := bytes.Repeat([]byte{0x42}, 32)
+
+	tests := []struct {
+		name    string
+		req     PassphraseKeyRequest
+		wantErr error
+	}{
+		{"short key", PassphraseKeyRequest{Key: key[:16], Passphrase: "correct horse"}, ErrInvalidKeyLength},
+		{"empty passphrase", PassphraseKeyRequest{Key: key}, ErrInvalidKeyDerivationInput},
+		{"short salt", PassphraseKeyRequest{Key: key, Passphrase: "correct horse", Salt: []byte("salt")}, ErrInvalidKeyDerivationInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.DerivePassphraseKey(context.Background(), tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
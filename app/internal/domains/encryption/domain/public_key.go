@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	agearmor "filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+const (
+	pgpPublicKeyHeader  = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+	pgpMessageHeader    = "-----BEGIN PGP MESSAGE-----"
+	pgpMessageType      = "PGP MESSAGE"
+	agePrivateKeyPrefix = "AGE-SECRET-KEY-1"
+)
+
+// DetectPublicKeyType reports whether a public key looks like an OpenPGP key block or an age recipient.
+// It does not validate the key; EncryptToPublicKey does that when it parses it.
+func DetectPublicKeyType(publicKey string) PublicKeyType {
+	if strings.Contains(publicKey, pgpPublicKeyHeader) {
+		return PublicKeyTypeOpenPGP
+	}
+	return PublicKeyTypeAge
+}
+
+// EncryptToPublicKey seals plaintext to an age X25519 recipient or an OpenPGP public key.
+// The result is ASCII-armored so the recipient can also open it with the age or gpg CLI.
+func (s *EncryptionService) EncryptToPublicKey(ctx context.Context, req PublicKeyEncryptionRequest) (*PublicKeyEncryptionResponse, error) {
+	keyType := DetectPublicKeyType(req.PublicKey)
+
+	var ciphertext string
+	var err error
+	switch keyType {
+	case PublicKeyTypeOpenPGP:
+		ciphertext, err = encryptToOpenPGPKey(req.Plaintext, req.PublicKey)
+	default:
+		ciphertext, err = encryptToAgeRecipient(req.Plaintext, req.PublicKey)
+	}
+	if err != nil {
+		logging.Error().Err(err).Str("keyType", string(keyType)).Msg("Failed to encrypt to public key")
+		return nil, err
+	}
+
+	logging.Debug().Str("keyType", string(keyType)).Int("plaintextSize", len(req.Plaintext)).Msg("Successfully encrypted to public key")
+	return &PublicKeyEncryptionResponse{
+		Ciphertext: ciphertext,
+		KeyType:    keyType,
+	}, nil
+}
+
+// DecryptWithPrivateKey opens an armored age or OpenPGP ciphertext produced by EncryptToPublicKey.
+// Passphrase unlocks a protected OpenPGP private key and is ignored for age identities.
+func (s *EncryptionService) DecryptWithPrivateKey(ctx context.Context, req PublicKeyDecryptionRequest) (*PublicKeyDecryptionResponse, error) {
+	var plaintext []byte
+	var err error
+	switch {
+	case strings.Contains(req.Ciphertext, agearmor.Header):
+		plaintext, err = decryptWithAgeIdentity(req.Ciphertext, req.PrivateKey)
+	case strings.Contains(req.Ciphertext, pgpMessageHeader):
+		plaintext, err = decryptWithOpenPGPKey(req.Ciphertext, req.PrivateKey, req.Passphrase)
+	default:
+		err = fmt.Errorf("%w: not an armored age or OpenPGP message", ErrInvalidCiphertext)
+	}
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to decrypt with private key")
+		return nil, err
+	}
+
+	logging.Debug().Int("plaintextSize", len(plaintext)).Msg("Successfully decrypted with private key")
+	return &PublicKeyDecryptionResponse{Plaintext: plaintext}, nil
+}
+
+func encryptToAgeRecipient(plaintext []byte, publicKey string) (string, error) {
+	recipients, err := age.ParseRecipients(strings.NewReader(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+
+	var buf bytes.Buffer
+	armorWriter := agearmor.NewWriter(&buf)
+	w, err := age.Encrypt(armorWriter, recipients...)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	return buf.String(), nil
+}
+
+func decryptWithAgeIdentity(ciphertext, privateKey string) ([]byte, error) {
+	if !strings.Contains(privateKey, agePrivateKeyPrefix) {
+		return nil, fmt.Errorf("%w: message was sealed with age, expected an AGE-SECRET-KEY", ErrInvalidPrivateKey)
+	}
+	identities, err := age.ParseIdentities(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+
+	r, err := age.Decrypt(agearmor.NewReader(strings.NewReader(strings.TrimSpace(ciphertext))), identities...)
+	if err != nil {
+		var noMatch *age.NoIdentityMatchError
+		if errors.As(err, &noMatch) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+func encryptToOpenPGPKey(plaintext []byte, publicKey string) (string, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKey))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidPublicKey, err)
+	}
+	now := time.Now()
+	for _, entity := range entities {
+		if _, ok := entity.EncryptionKey(now); !ok {
+			return "", fmt.Errorf("%w: key has no valid encryption subkey", ErrInvalidPublicKey)
+		}
+	}
+
+	var buf bytes.Buffer
+	armorWriter, err := pgparmor.Encode(&buf, pgpMessageType, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	w, err := openpgp.Encrypt(armorWriter, entities, nil, nil, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	if err := armorWriter.Close(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+	return buf.String(), nil
+}
+
+func decryptWithOpenPGPKey(ciphertext, privateKey, passphrase string) ([]byte, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	for _, entity := range keyring {
+		if entity.PrivateKey == nil {
+			return nil, fmt.Errorf("%w: key block contains no private key", ErrInvalidPrivateKey)
+		}
+		if passphrase != "" {
+			if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+			}
+		}
+	}
+
+	block, err := pgparmor.Decode(strings.NewReader(strings.TrimSpace(ciphertext)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	if block.Type != pgpMessageType {
+		return nil, fmt.Errorf("%w: unexpected armor type %q", ErrInvalidCiphertext, block.Type)
+	}
+
+	md, err := openpgp.ReadMessage(block.Body, keyring, nil, nil)
+	if err != nil {
+		// ErrKeyIncorrect covers both a key the message was not sealed to and a key still locked by its passphrase
+		if errors.Is(err, pgperrors.ErrKeyIncorrect) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOpenPGPKeyPair(t *testing.T, passphrase string) (publicKey, privateKey string) {
+	t.Helper()
+
+	entity, err := openpgp.NewEntity("Rita", "", "rita@example.com", nil)
+	require.NoError(t, err)
+
+	var pub bytes.Buffer
+	w, err := armor.Encode(&pub, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+
+	if passphrase != "" {
+		require.NoError(t, entity.EncryptPrivateKeys([]byte(passphrase), nil))
+	}
+	var priv bytes.Buffer
+	w, err = armor.Encode(&priv, openpgp.PrivateKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.SerializePrivateWithoutSigning(w, nil))
+	require.NoError(t, w.Close())
+
+	return pub.String(), priv.String()
+}
+
+func TestPublicKeyEncryption_RoundTrip(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	pgpPublic, pgpPrivate := testOpenPGPKeyPair(t, "")
+	lockedPublic, lockedPrivate := testOpenPGPKeyPair(t, "correct horse")
+
+	tests := []struct {
+		name       string
+		publicKey  string
+		privateKey string
+		passphrase string
+		wantType   PublicKeyType
+		wantHeader string
+	}{
+		{"age", identity.Recipient().String(), identity.String(), "", PublicKeyTypeAge, "-----BEGIN AGE ENCRYPTED FILE-----"},
+		{"openpgp", pgpPublic, pgpPrivate, "", PublicKeyTypeOpenPGP, pgpMessageHeader},
+		{"passphrase-protected openpgp", lockedPublic, lockedPrivate, "correct horse", PublicKeyTypeOpenPGP, pgpMessageHeader},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypted, err := service.EncryptToPublicKey(ctx, PublicKeyEncryptionRequest{
+				Plaintext: []byte("the vault code is 1234"),
+				PublicKey: tt.publicKey,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantType, encrypted.KeyType)
+			assert.Contains(t, encrypted.Ciphertext, tt.wantHeader)
+
+			decrypted, err := service.DecryptWithPrivateKey(ctx, PublicKeyDecryptionRequest{
+				Ciphertext: encrypted.Ciphertext,
+				PrivateKey: tt.privateKey,
+				Passphrase: tt.passphrase,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "the vault code is 1234", string(decrypted.Plaintext))
+		})
+	}
+}
+
+func TestEncryptToPublicKey_InvalidKey(t *testing.T) {
+	service := NewEncryptionService(nil)
+	_, pgpPrivate := testOpenPGPKeyPair(t, "")
+
+	for _, key := range []string{"", "age1notakey", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\ngarbage\n-----END PGP PUBLIC KEY BLOCK-----", pgpPrivate} {
+		_, err := service.EncryptToPublicKey(context.Background(), PublicKeyEncryptionRequest{
+			Plaintext: []byte("secret"),
+			PublicKey: key,
+		})
+		assert.ErrorIs(t, err, ErrInvalidPublicKey)
+	}
+}
+
+func TestDecryptWithPrivateKey_Rejections(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherIdentity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	pgpPublic, _ := testOpenPGPKeyPair(t, "")
+	_, otherPGPPrivate := testOpenPGPKeyPair(t, "")
+	lockedPublic, lockedPrivate := testOpenPGPKeyPair(t, "correct horse")
+
+	seal := func(publicKey string) string {
+		resp, err := service.EncryptToPublicKey(ctx, PublicKeyEncryptionRequest{Plaintext: []byte("secret"), PublicKey: publicKey})
+		require.NoError(t, err)
+		return resp.Ciphertext
+	}
+	ageCiphertext := seal(identity.Recipient().String())
+	pgpCiphertext := seal(pgpPublic)
+	lockedCiphertext := seal(lockedPublic)
+
+	tests := []struct {
+		name       string
+		ciphertext string
+		privateKey string
+		passphrase string
+		wantErr    error
+	}{
+		{"wrong age identity", ageCiphertext, otherIdentity.String(), "", ErrInvalidPrivateKey},
+		{"openpgp key for age message", ageCiphertext, otherPGPPrivate, "", ErrInvalidPrivateKey},
+		{"wrong openpgp key", pgpCiphertext, otherPGPPrivate, "", ErrInvalidPrivateKey},
+		{"locked openpgp key without passphrase", lockedCiphertext, lockedPrivate, "", ErrInvalidPrivateKey},
+		{"locked openpgp key with wrong passphrase", lockedCiphertext, lockedPrivate, "wrong", ErrInvalidPrivateKey},
+		{"not armored", "plain text", identity.String(), "", ErrInvalidCiphertext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.DecryptWithPrivateKey(ctx, PublicKeyDecryptionRequest{
+				Ciphertext: tt.ciphertext,
+				PrivateKey: tt.privateKey,
+				Passphrase: tt.passphrase,
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
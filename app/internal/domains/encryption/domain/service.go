@@ -6,113 +6,239 @@ import (
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"io"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 )
 
+// FileChunkSize is the plaintext size of each chunk sealed by EncryptFile.
+// Every chunk is its own AES-GCM message with a fresh nonce.
+const FileChunkSize = 64 * 1024
+
 // EncryptionService provides cryptographic operations
 type EncryptionService struct {
 	keyGenerator KeyGenerator
+	algorithm    CipherAlgorithm
 }
 
-// NewEncryptionService creates a new encryption service
+// NewEncryptionService creates a new encryption service that seals messages with AES-256-GCM
 func NewEncryptionService(keyGenerator KeyGenerator) *EncryptionService {
+	return NewEncryptionServiceWithAlgorithm(keyGenerator, AlgorithmAES256GCM)
+}
+
+// NewEncryptionServiceWithAlgorithm creates an encryption service that seals new messages with
+// the given algorithm. Decryption follows whatever algorithm each envelope names.
+func NewEncryptionServiceWithAlgorithm(keyGenerator KeyGenerator, algorithm CipherAlgorithm) *EncryptionService {
 	return &EncryptionService{
 		keyGenerator: keyGenerator,
+		algorithm:    algorithm,
 	}
 }
 
-// Encrypt encrypts multiple plaintext messages using AES-GCM
+// Encrypt seals multiple plaintext messages into versioned envelopes, authenticating
+// req.AssociatedData (normally the message ID) alongside each one
 func (s *EncryptionService) Encrypt(ctx context.Context, req EncryptionRequest) (*EncryptionResponse, error) {
-	if len(req.Key) != 32 {
-		logging.Error().Int("keyLength", len(req.Key)).Msg("Invalid key length for encryption")
-		return nil, ErrInvalidKeyLength
+	if len(req.KeyID) > MaxEnvelopeKeyID {
+		logging.Error().Int("keyIdLength", len(req.KeyID)).Msg("Key ID too long for envelope")
+		return nil, fmt.Errorf("%w: key ID longer than %d bytes", ErrEncryptionFailed, MaxEnvelopeKeyID)
 	}
 
-	block, err := aes.NewCipher(req.Key)
-	if err != nil {
-		logging.Error().Err(err).Msg("Failed to create AES cipher")
-		return nil, fmt.Errorf("%w: %v", ErrCipherCreationFailed, err)
+	algorithm := s.algorithm
+	if algorithm == 0 {
+		algorithm = AlgorithmAES256GCM
 	}
-
-	gcm, err := cipher.NewGCM(block)
+	aead, err := newAEAD(algorithm, req.Key)
 	if err != nil {
-		logging.Error().Err(err).Msg("Failed to create GCM")
-		return nil, fmt.Errorf("%w: %v", ErrGCMCreationFailed, err)
+		return nil, err
 	}
+	header := envelopeHeader{version: EnvelopeVersion1, algorithm: algorithm, keyID: req.KeyID}
 
 	response := &EncryptionResponse{
 		Ciphertext: make([]string, 0, len(req.Plaintext)),
 	}
 
 	for _, plaintext := range req.Plaintext {
-		nonce := make([]byte, gcm.NonceSize())
+		nonce := make([]byte, aead.NonceSize())
 		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
 			logging.Error().Err(err).Msg("Failed to generate nonce")
 			return nil, fmt.Errorf("%w: %v", ErrInsufficientRandomness, err)
 		}
 
-		ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
-		encodedCiphertext := base64.URLEncoding.EncodeToString(ciphertext)
-		response.Ciphertext = append(response.Ciphertext, encodedCiphertext)
+		response.Ciphertext = append(response.Ciphertext, sealEnvelope(aead, header, nonce, []byte(plaintext), req.AssociatedData))
 	}
 
-	logging.Debug().Int("plaintextCount", len(req.Plaintext)).Msg("Successfully encrypted messages")
+	logging.Debug().
+		Int("plaintextCount", len(req.Plaintext)).
+		Str("algorithm", algorithm.String()).
+		Msg("Successfully encrypted messages")
 	return response, nil
 }
 
-// Decrypt decrypts multiple ciphertext messages using AES-GCM
+// Decrypt decrypts multiple ciphertext messages. Envelopes are opened with the algorithm they
+// name and must carry the same associated data they were sealed with; legacy unversioned
+// AES-GCM ciphertexts predate associated data and are opened without it.
 func (s *EncryptionService) Decrypt(ctx context.Context, req DecryptionRequest) (*DecryptionResponse, error) {
 	if len(req.Key) != 32 {
 		logging.Error().Int("keyLength", len(req.Key)).Msg("Invalid key length for decryption")
 		return nil, ErrInvalidKeyLength
 	}
 
-	block, err := aes.NewCipher(req.Key)
+	response := &DecryptionResponse{
+		Plaintext: make([]string, 0, len(req.Ciphertext)),
+	}
+
+	for _, encodedCiphertext := range req.Ciphertext {
+		var plaintext []byte
+		var err error
+		if IsEnvelope(encodedCiphertext) {
+			plaintext, _, err = openEnvelope(encodedCiphertext, req.Key, req.AssociatedData)
+		} else {
+			plaintext, err = openLegacy(encodedCiphertext, req.Key)
+		}
+		if err != nil {
+			logging.Error().Err(err).Bool("envelope", IsEnvelope(encodedCiphertext)).Msg("Failed to decrypt message")
+			return nil, err
+		}
+
+		encodedPlaintext := base64.URLEncoding.EncodeToString(plaintext)
+		response.Plaintext = append(response.Plaintext, encodedPlaintext)
+	}
+
+	logging.Debug().Int("ciphertextCount", len(req.Ciphertext)).Msg("Successfully decrypted messages")
+	return response, nil
+}
+
+// openLegacy decrypts the original format: base64url(nonce || AES-GCM ciphertext) with no
+// associated data
+func openLegacy(encodedCiphertext string, key []byte) ([]byte, error) {
+	gcm, err := newAEAD(AlgorithmAES256GCM, key)
 	if err != nil {
-		logging.Error().Err(err).Msg("Failed to create AES cipher")
-		return nil, fmt.Errorf("%w: %v", ErrCipherCreationFailed, err)
+		return nil, err
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	ciphertext, err := base64.URLEncoding.DecodeString(encodedCiphertext)
 	if err != nil {
-		logging.Error().Err(err).Msg("Failed to create GCM")
-		return nil, fmt.Errorf("%w: %v", ErrGCMCreationFailed, err)
+		return nil, fmt.Errorf("%w: %v", ErrBase64DecodingFailed, err)
 	}
 
-	response := &DecryptionResponse{
-		Plaintext: make([]string, 0, len(req.Ciphertext)),
+	if len(ciphertext) < gcm.NonceSize() {
+		logging.Error().Int("ciphertextLength", len(ciphertext)).Int("nonceSize", gcm.NonceSize()).Msg("Ciphertext too short")
+		return nil, ErrInvalidCiphertext
 	}
 
-	for _, encodedCiphertext := range req.Ciphertext {
-		ciphertext, err := base64.URLEncoding.DecodeString(encodedCiphertext)
-		if err != nil {
-			logging.Error().Err(err).Str("ciphertext", encodedCiphertext).Msg("Failed to decode base64 ciphertext")
-			return nil, fmt.Errorf("%w: %v", ErrBase64DecodingFailed, err)
+	nonce := ciphertext[:gcm.NonceSize()]
+	encryptedData := ciphertext[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, encryptedData, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
+
+// EncryptFile splits a file into FileChunkSize chunks and seals each with AES-GCM.
+// Each chunk is returned as nonce||ciphertext and authenticated together with
+// req.AssociatedData, its index and whether it is the last chunk.
+func (s *EncryptionService) EncryptFile(ctx context.Context, req FileEncryptionRequest) (*FileEncryptionResponse, error) {
+	gcm, err := newChunkAEAD(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty file is still sealed as a single final chunk
+	chunkCount := (len(req.Plaintext) + FileChunkSize - 1) / FileChunkSize
+	if chunkCount == 0 {
+		chunkCount = 1
+	}
+
+	response := &FileEncryptionResponse{
+		Chunks: make([][]byte, 0, chunkCount),
+	}
+
+	for i := 0; i < chunkCount; i++ {
+		start := i * FileChunkSize
+		end := min(start+FileChunkSize, len(req.Plaintext))
+
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			logging.Error().Err(err).Msg("Failed to generate nonce")
+			return nil, fmt.Errorf("%w: %v", ErrInsufficientRandomness, err)
 		}
 
-		if len(ciphertext) < gcm.NonceSize() {
-			logging.Error().Int("ciphertextLength", len(ciphertext)).Int("nonceSize", gcm.NonceSize()).Msg("Ciphertext too short")
+		aad := chunkAssociatedData(req.AssociatedData, i, i == chunkCount-1)
+		response.Chunks = append(response.Chunks, gcm.Seal(nonce, nonce, req.Plaintext[start:end], aad))
+	}
+
+	logging.Debug().Int("plaintextBytes", len(req.Plaintext)).Int("chunkCount", chunkCount).Msg("Successfully encrypted file")
+	return response, nil
+}
+
+// DecryptFile opens every chunk produced by EncryptFile and reassembles the file.
+// Missing, reordered or trailing chunks fail authentication.
+func (s *EncryptionService) DecryptFile(ctx context.Context, req FileDecryptionRequest) (*FileDecryptionResponse, error) {
+	if len(req.Chunks) == 0 {
+		logging.Error().Msg("No chunks provided for file decryption")
+		return nil, ErrInvalidChunkSequence
+	}
+
+	gcm, err := newChunkAEAD(req.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, 0, len(req.Chunks)*FileChunkSize)
+	for i, chunk := range req.Chunks {
+		if len(chunk) < gcm.NonceSize()+gcm.Overhead() {
+			logging.Error().Int("chunkIndex", i).Int("chunkLength", len(chunk)).Msg("Chunk too short")
 			return nil, ErrInvalidCiphertext
 		}
 
-		nonce := ciphertext[:gcm.NonceSize()]
-		encryptedData := ciphertext[gcm.NonceSize():]
-
-		plaintext, err := gcm.Open(nil, nonce, encryptedData, nil)
+		nonce := chunk[:gcm.NonceSize()]
+		aad := chunkAssociatedData(req.AssociatedData, i, i == len(req.Chunks)-1)
+		plaintext, err = gcm.Open(plaintext, nonce, chunk[gcm.NonceSize():], aad)
 		if err != nil {
-			logging.Error().Err(err).Msg("Failed to decrypt message")
+			logging.Error().Err(err).Int("chunkIndex", i).Msg("Failed to decrypt file chunk")
 			return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 		}
+	}
 
-		encodedPlaintext := base64.URLEncoding.EncodeToString(plaintext)
-		response.Plaintext = append(response.Plaintext, encodedPlaintext)
+	logging.Debug().Int("chunkCount", len(req.Chunks)).Int("plaintextBytes", len(plaintext)).Msg("Successfully decrypted file")
+	return &FileDecryptionResponse{Plaintext: plaintext}, nil
+}
+
+// newChunkAEAD creates the AES-GCM instance used for file chunks
+func newChunkAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		logging.Error().Int("keyLength", len(key)).Msg("Invalid key length for file encryption")
+		return nil, ErrInvalidKeyLength
 	}
 
-	logging.Debug().Int("ciphertextCount", len(req.Ciphertext)).Msg("Successfully decrypted messages")
-	return response, nil
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to create AES cipher")
+		return nil, fmt.Errorf("%w: %v", ErrCipherCreationFailed, err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to create GCM")
+		return nil, fmt.Errorf("%w: %v", ErrGCMCreationFailed, err)
+	}
+	return gcm, nil
+}
+
+// chunkAssociatedData binds a chunk to its file, its position and whether it ends the file:
+// associatedData || uint32 big-endian index || final flag byte.
+func chunkAssociatedData(associatedData []byte, index int, final bool) []byte {
+	aad := make([]byte, len(associatedData)+5)
+	copy(aad, associatedData)
+	binary.BigEndian.PutUint32(aad[len(associatedData):], uint32(index))
+	if final {
+		aad[len(aad)-1] = 1
+	}
+	return aad
 }
 
 // GenerateRandomKey generates a new random encryption key
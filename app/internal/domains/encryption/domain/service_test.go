@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey() []byte {
+	return bytes.Repeat([]byte{0x42}, 32)
+}
+
+func TestEncryptFile_RoundTrip(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		size       int
+		wantChunks int
+	}{
+		{"empty file", 0, 1},
+		{"smaller than one chunk", 100, 1},
+		{"exactly one chunk", FileChunkSize, 1},
+		{"spans several chunks", 3*FileChunkSize + 17, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext := bytes.Repeat([]byte{0xAB}, tt.size)
+
+			encrypted, err := service.EncryptFile(ctx, FileEncryptionRequest{
+				Plaintext:      plaintext,
+				Key:            testKey(),
+				AssociatedData: []byte("message-id"),
+			})
+			require.NoError(t, err)
+			assert.Len(t, encrypted.Chunks, tt.wantChunks)
+
+			decrypted, err := service.DecryptFile(ctx, FileDecryptionRequest{
+				Chunks:         encrypted.Chunks,
+				Key:            testKey(),
+				AssociatedData: []byte("message-id"),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, plaintext, decrypted.Plaintext)
+		})
+	}
+}
+
+func TestDecryptFile_RejectsTamperedSequences(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+
+	encrypted, err := service.EncryptFile(ctx, FileEncryptionRequest{
+		Plaintext:      bytes.Repeat([]byte{0x01}, 3*FileChunkSize),
+		Key:            testKey(),
+		AssociatedData: []byte("message-id"),
+	})
+	require.NoError(t, err)
+	chunks := encrypted.Chunks
+	require.Len(t, chunks, 3)
+
+	tests := []struct {
+		name    string
+		chunks  [][]byte
+		aad     []byte
+		wantErr error
+	}{
+		{"truncated", chunks[:2], []byte("message-id"), ErrDecryptionFailed},
+		{"reordered", [][]byte{chunks[1], chunks[0], chunks[2]}, []byte("message-id"), ErrDecryptionFailed},
+		{"extra chunk appended", append(append([][]byte{}, chunks...), chunks[2]), []byte("message-id"), ErrDecryptionFailed},
+		{"different associated data", chunks, []byte("other-id"), ErrDecryptionFailed},
+		{"no chunks", nil, []byte("message-id"), ErrInvalidChunkSequence},
+		{"chunk too short", [][]byte{{0x00}}, []byte("message-id"), ErrInvalidCiphertext},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.DecryptFile(ctx, FileDecryptionRequest{
+				Chunks:         tt.chunks,
+				Key:            testKey(),
+				AssociatedData: tt.aad,
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestEncryptFile_InvalidKeyLength(t *testing.T) {
+	service := NewEncryptionService(nil)
+
+	_, err := service.EncryptFile(context.Background(), FileEncryptionRequest{
+		Plaintext: []byte("data"),
+		Key:       []byte("short"),
+	})
+	assert.ErrorIs(t, err, ErrInvalidKeyLength)
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+const (
+	// MinShares is the smallest number of shares, and the smallest threshold, a secret can be split with
+	MinShares = 2
+	// MaxShares is the largest number of shares; each share needs a distinct non-zero x-coordinate in GF(2^8)
+	MaxShares = 255
+
+	sharePrefix       = "pxs1"
+	shareSplitIDBytes = 8
+	// shareChecksumSize bytes of SHA-256 are appended to the secret before splitting, so the checksum is
+	// itself shared and reveals nothing, yet lets CombineShares reject mismatched or corrupted shares
+	shareChecksumSize = 8
+)
+
+// SecretShare is one share of a secret split with Shamir's Secret Sharing over GF(2^8).
+// Shares are exchanged in the text form produced by String: "pxs1.<split id>.<threshold>.<index>.<value>".
+type SecretShare struct {
+	SplitID   string
+	Threshold int
+	Index     int
+	Value     []byte
+}
+
+// String encodes the share so it can be stored and shown as ordinary message content
+func (s SecretShare) String() string {
+	return fmt.Sprintf("%s.%s.%d.%d.%s",
+		sharePrefix, s.SplitID, s.Threshold, s.Index, base64.RawURLEncoding.EncodeToString(s.Value))
+}
+
+// ParseSecretShare decodes a share produced by SecretShare.String
+func ParseSecretShare(encoded string) (SecretShare, error) {
+	parts := strings.Split(strings.TrimSpace(encoded), ".")
+	if len(parts) != 5 || parts[0] != sharePrefix {
+		return SecretShare{}, fmt.Errorf("%w: unrecognized share format", ErrInvalidShare)
+	}
+	if id, err := hex.DecodeString(parts[1]); err != nil || len(id) != shareSplitIDBytes {
+		return SecretShare{}, fmt.Errorf("%w: malformed split id", ErrInvalidShare)
+	}
+	threshold, err := strconv.Atoi(parts[2])
+	if err != nil || threshold < MinShares || threshold > MaxShares {
+		return SecretShare{}, fmt.Errorf("%w: malformed threshold", ErrInvalidShare)
+	}
+	index, err := strconv.Atoi(parts[3])
+	if err != nil || index < 1 || index > MaxShares {
+		return SecretShare{}, fmt.Errorf("%w: malformed share index", ErrInvalidShare)
+	}
+	value, err := base64.RawURLEncoding.DecodeString(parts[4])
+	if err != nil || len(value) <= shareChecksumSize {
+		return SecretShare{}, fmt.Errorf("%w: malformed share value", ErrInvalidShare)
+	}
+	return SecretShare{SplitID: parts[1], Threshold: threshold, Index: index, Value: value}, nil
+}
+
+// SplitSecret splits a secret into req.Shares shares such that any req.Threshold of them
+// reconstruct it and fewer reveal nothing about it
+func (s *EncryptionService) SplitSecret(ctx context.Context, req SplitSecretRequest) (*SplitSecretResponse, error) {
+	if req.Threshold < MinShares || req.Shares < req.Threshold || req.Shares > MaxShares {
+		logging.Error().Int("shares", req.Shares).Int("threshold", req.Threshold).Msg("Invalid secret split parameters")
+		return nil, fmt.Errorf("%w: need %d <= threshold <= shares <= %d", ErrInvalidSplitParameters, MinShares, MaxShares)
+	}
+	if len(req.Secret) == 0 {
+		return nil, fmt.Errorf("%w: secret is empty", ErrInvalidSplitParameters)
+	}
+
+	splitID := make([]byte, shareSplitIDBytes)
+	if _, err := io.ReadFull(rand.Reader, splitID); err != nil {
+		logging.Error().Err(err).Msg("Failed to generate split id")
+		return nil, fmt.Errorf("%w: %v", ErrInsufficientRandomness, err)
+	}
+
+	checksum := sha256.Sum256(req.Secret)
+	payload := make([]byte, 0, len(req.Secret)+shareChecksumSize)
+	payload = append(payload, req.Secret...)
+	payload = append(payload, checksum[:shareChecksumSize]...)
+
+	values := make([][]byte, req.Shares)
+	for i := range values {
+		values[i] = make([]byte, len(payload))
+	}
+
+	// One random polynomial of degree threshold-1 per payload byte, with the byte as its constant term.
+	// Share i holds each polynomial evaluated at x = i+1.
+	coefficients := make([]byte, req.Threshold)
+	for pos, b := range payload {
+		if _, err := io.ReadFull(rand.Reader, coefficients[1:]); err != nil {
+			logging.Error().Err(err).Msg("Failed to generate polynomial coefficients")
+			return nil, fmt.Errorf("%w: %v", ErrInsufficientRandomness, err)
+		}
+		coefficients[0] = b
+		for i := range values {
+			values[i][pos] = evaluatePolynomial(coefficients, byte(i+1))
+		}
+	}
+
+	encodedID := hex.EncodeToString(splitID)
+	response := &SplitSecretResponse{Shares: make([]string, req.Shares)}
+	for i, value := range values {
+		response.Shares[i] = SecretShare{
+			SplitID:   encodedID,
+			Threshold: req.Threshold,
+			Index:     i + 1,
+			Value:     value,
+		}.String()
+	}
+
+	logging.Debug().Int("shares", req.Shares).Int("threshold", req.Threshold).Msg("Successfully split secret")
+	return response, nil
+}
+
+// CombineShares reconstructs a secret from at least threshold shares of the same split.
+// Every supplied share is used, so a corrupted extra share is detected rather than ignored.
+func (s *EncryptionService) CombineShares(ctx context.Context, req CombineSharesRequest) (*CombineSharesResponse, error) {
+	if len(req.Shares) == 0 {
+		return nil, fmt.Errorf("%w: no shares supplied", ErrInsufficientShares)
+	}
+
+	shares := make([]SecretShare, 0, len(req.Shares))
+	seen := make(map[int]bool, len(req.Shares))
+	for _, encoded := range req.Shares {
+		share, err := ParseSecretShare(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if len(shares) > 0 {
+			first := shares[0]
+			if share.SplitID != first.SplitID || share.Threshold != first.Threshold || len(share.Value) != len(first.Value) {
+				return nil, fmt.Errorf("%w: shares belong to different secrets", ErrInvalidShare)
+			}
+		}
+		if seen[share.Index] {
+			return nil, fmt.Errorf("%w: share %d supplied more than once", ErrInvalidShare, share.Index)
+		}
+		seen[share.Index] = true
+		shares = append(shares, share)
+	}
+
+	if len(shares) < shares[0].Threshold {
+		return nil, fmt.Errorf("%w: have %d of %d", ErrInsufficientShares, len(shares), shares[0].Threshold)
+	}
+
+	xs := make([]byte, len(shares))
+	for i, share := range shares {
+		xs[i] = byte(share.Index)
+	}
+	ys := make([]byte, len(shares))
+	payload := make([]byte, len(shares[0].Value))
+	for pos := range payload {
+		for i, share := range shares {
+			ys[i] = share.Value[pos]
+		}
+		payload[pos] = interpolateAtZero(xs, ys)
+	}
+
+	secret := payload[:len(payload)-shareChecksumSize]
+	checksum := sha256.Sum256(secret)
+	if subtle.ConstantTimeCompare(checksum[:shareChecksumSize], payload[len(secret):]) != 1 {
+		logging.Warn().Int("shares", len(shares)).Msg("Shares did not reconstruct a valid secret")
+		return nil, fmt.Errorf("%w: shares do not reconstruct the secret", ErrInvalidShare)
+	}
+
+	logging.Debug().Int("shares", len(shares)).Msg("Successfully combined secret shares")
+	return &CombineSharesResponse{Secret: secret}, nil
+}
+
+// evaluatePolynomial evaluates the polynomial with the given coefficients, lowest degree first, at x
+func evaluatePolynomial(coefficients []byte, x byte) byte {
+	var result byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+	return result
+}
+
+// interpolateAtZero returns f(0) for the lowest-degree polynomial through the points (xs[i], ys[i])
+func interpolateAtZero(xs, ys []byte) byte {
+	var result byte
+	for i := range xs {
+		// Lagrange basis at zero: product of x_j / (x_j - x_i); subtraction is XOR in GF(2^8)
+		basis := byte(1)
+		for j := range xs {
+			if i == j {
+				continue
+			}
+			basis = gfMul(basis, gfMul(xs[j], gfInverse(xs[j]^xs[i])))
+		}
+		result ^= gfMul(ys[i], basis)
+	}
+	return result
+}
+
+// gfMul multiplies in GF(2^8) with the AES polynomial x^8 + x^4 + x^3 + x + 1,
+// without branches or table lookups that depend on the operands
+func gfMul(a, b byte) byte {
+	var product byte
+	for i := 0; i < 8; i++ {
+		product ^= a & -(b & 1)
+		carry := a >> 7
+		a = a<<1 ^ 0x1b&-carry
+		b >>= 1
+	}
+	return product
+}
+
+// gfInverse returns the multiplicative inverse of a in GF(2^8) as a^254; zero maps to zero
+func gfInverse(a byte) byte {
+	b := gfMul(a, a)   // a^2
+	c := gfMul(a, b)   // a^3
+	b = gfMul(c, c)    // a^6
+	b = gfMul(b, b)    // a^12
+	c = gfMul(b, c)    // a^15
+	b = gfMul(b, b)    // a^24
+	b = gfMul(b, b)    // a^48
+	b = gfMul(b, c)    // a^63
+	b = gfMul(b, b)    // a^126
+	b = gfMul(a, b)    // a^127
+	return gfMul(b, b) // a^254
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGFInverse(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		assert.Equal(t, byte(1), gfMul(byte(a), gfInverse(byte(a))), "a=%d", a)
+	}
+	assert.Equal(t, byte(0), gfInverse(0))
+}
+
+func TestSplitSecret_CombineAnyThreshold(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+	secret := []byte("root:correct horse battery staple")
+
+	split, err := service.SplitSecret(ctx, SplitSecretRequest{Secret: secret, Shares: 5, Threshold: 3})
+	require.NoError(t, err)
+	require.Len(t, split.Shares, 5)
+	for i, encoded := range split.Shares {
+		share, err := ParseSecretShare(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, i+1, share.Index)
+		assert.Equal(t, 3, share.Threshold)
+		assert.NotContains(t, encoded, string(secret))
+	}
+
+	subsets := [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}}
+	for _, subset := range subsets {
+		shares := make([]string, 0, len(subset))
+		for _, i := range subset {
+			shares = append(shares, split.Shares[i])
+		}
+		combined, err := service.CombineShares(ctx, CombineSharesRequest{Shares: shares})
+		require.NoError(t, err, "subset %v", subset)
+		assert.Equal(t, secret, combined.Secret)
+	}
+}
+
+func TestSplitSecret_InvalidParameters(t *testing.T) {
+	service := NewEncryptionService(nil)
+
+	tests := []struct {
+		name      string
+		secret    []byte
+		shares    int
+		threshold int
+	}{
+		{"threshold below minimum", []byte("s"), 3, 1},
+		{"threshold above shares", []byte("s"), 2, 3},
+		{"too many shares", []byte("s"), MaxShares + 1, 2},
+		{"empty secret", nil, 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.SplitSecret(context.Background(), SplitSecretRequest{
+				Secret:    tt.secret,
+				Shares:    tt.shares,
+				Threshold: tt.threshold,
+			})
+			assert.ErrorIs(t, err, ErrInvalidSplitParameters)
+		})
+	}
+}
+
+func TestCombineShares_Rejections(t *testing.T) {
+	service := NewEncryptionService(nil)
+	ctx := context.Background()
+
+	split, err := service.SplitSecret(ctx, SplitSecretRequest{Secret: []byte("first"), Shares: 3, Threshold: 2})
+	require.NoError(t, err)
+	other, err := service.SplitSecret(ctx, SplitSecretRequest{Secret: []byte("other"), Shares: 3, Threshold: 2})
+	require.NoError(t, err)
+
+	// Flip a byte of the second share's value while keeping the encoding valid
+	tampered, err := ParseSecretShare(split.Shares[1])
+	require.NoError(t, err)
+	tampered.Value[0] ^= 0x01
+
+	tests := []struct {
+		name    string
+		shares  []string
+		wantErr error
+	}{
+		{"no shares", nil, ErrInsufficientShares},
+		{"below threshold", split.Shares[:1], ErrInsufficientShares},
+		{"duplicate share", []string{split.Shares[0], split.Shares[0]}, ErrInvalidShare},
+		{"mixed splits", []string{split.Shares[0], other.Shares[1]}, ErrInvalidShare},
+		{"tampered share", []string{split.Shares[0], tampered.String()}, ErrInvalidShare},
+		{"malformed share", []string{split.Shares[0], strings.Replace(split.Shares[1], "pxs1", "pxs9", 1)}, ErrInvalidShare},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CombineShares(ctx, CombineSharesRequest{Shares: tt.shares})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -14,6 +14,27 @@ type EncryptionServicePort interface {
 	// Decrypt decrypts multiple ciphertext messages
 	Decrypt(ctx context.Context, req domain.DecryptionRequest) (*domain.DecryptionResponse, error)
 	
+	// EncryptFile encrypts a file as a sequence of independently authenticated chunks
+	EncryptFile(ctx context.Context, req domain.FileEncryptionRequest) (*domain.FileEncryptionResponse, error)
+	
+	// DecryptFile decrypts and reassembles a chunked file
+	DecryptFile(ctx context.Context, req domain.FileDecryptionRequest) (*domain.FileDecryptionResponse, error)
+	
+	// EncryptToPublicKey seals plaintext to an age or OpenPGP public key
+	EncryptToPublicKey(ctx context.Context, req domain.PublicKeyEncryptionRequest) (*domain.PublicKeyEncryptionResponse, error)
+	
+	// DecryptWithPrivateKey opens a ciphertext sealed by EncryptToPublicKey
+	DecryptWithPrivateKey(ctx context.Context, req domain.PublicKeyDecryptionRequest) (*domain.PublicKeyDecryptionResponse, error)
+	
+	// SplitSecret splits a secret into Shamir shares, any threshold of which reconstruct it
+	SplitSecret(ctx context.Context, req domain.SplitSecretRequest) (*domain.SplitSecretResponse, error)
+	
+	// CombineShares reconstructs a secret from at least threshold shares of the same split
+	CombineShares(ctx context.Context, req domain.CombineSharesRequest) (*domain.CombineSharesResponse, error)
+	
+	// DerivePassphraseKey mixes an Argon2id-stretched passphrase into a message key
+	DerivePassphraseKey(ctx context.Context, req domain.PassphraseKeyRequest) (*domain.PassphraseKeyResponse, error)
+	
 	// GenerateRandomKey generates a new random encryption key
 	GenerateRandomKey(ctx context.Context, req domain.RandomRequest) (*domain.RandomResponse, error)
 	
@@ -3,7 +3,14 @@ package api
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/primary/api/middleware"
@@ -29,8 +36,14 @@ func NewMessageAPIHandler(messageService primary.MessageServicePort) *MessageAPI
 // SubmitMessage handles POST /api/v1/messages
 // @Summary Submit a new message
 // @Description Creates a new encrypted message that can be accessed via a unique URL. Optionally sends email notifications to the recipient.
+// @Description When clientEncrypted is true, content must already be AES-GCM ciphertext; the server stores it as-is and returns a URL the client completes with "#<key>".
+// @Description To share with several people, list them in "recipients"; each gets an independent message returned in the response's "recipients".
+// @Description To seal the message to the recipient's age or OpenPGP public key, set "recipientPublicKey"; no key is returned and the recipient opens it with their private key.
+// @Description To require k of n custodians, set "split"; each share becomes its own message, returned in the response's "shares", and POST /shares/combine reconstructs the secret.
+// @Description To attach a file, send multipart/form-data with the JSON request in a "request" field and the file (up to 10 MiB) in a "file" field. Content may be empty when a file is attached.
 // @Tags Messages
 // @Accept json
+// @Accept multipart/form-data
 // @Produce json
 // @Param request body models.MessageSubmissionRequest true "Message submission request"
 // @Success 201 {object} models.MessageSubmissionResponse "Message successfully created"
@@ -46,9 +59,16 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 		Interface("correlation_id", correlationID).
 		Msg("Processing API message submission request")
 
-	// Parse and validate JSON request
+	// Parse the JSON request, either as the body or as the "request" field of a multipart upload
 	var req models.MessageSubmissionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var file *multipart.FileHeader
+	var err error
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err = bindMultipartSubmission(c, &req)
+	} else {
+		err = c.ShouldBindJSON(&req)
+	}
+	if err != nil {
 		middleware.JSONErrorResponse(
 			c,
 			http.StatusBadRequest,
@@ -62,7 +82,7 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 	}
 
 	// Validate request using enhanced validation middleware
-	if validationErrors := middleware.ValidateMessageSubmission(&req); validationErrors != nil {
+	if validationErrors := middleware.ValidateMessageSubmissionWithAttachment(&req, file); validationErrors != nil {
 		middleware.JSONErrorResponse(
 			c,
 			http.StatusBadRequest,
@@ -75,14 +95,18 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 
 	// Convert API request to domain request
 	domainReq := domain.MessageSubmissionRequest{
-		Content:          req.Content,
-		Passphrase:       req.Passphrase,
-		AdditionalInfo:   req.AdditionalInfo,
-		Captcha:          req.AntiSpamAnswer,
-		TurnstileToken:   req.TurnstileToken,
-		SendNotification: req.SendNotification,
-		MaxViewCount:     req.MaxViewCount,
-		ExpirationHours:  req.ExpirationHours,
+		Content:            req.Content,
+		Passphrase:         req.Passphrase,
+		AdditionalInfo:     req.AdditionalInfo,
+		Captcha:            req.AntiSpamAnswer,
+		TurnstileToken:     req.TurnstileToken,
+		SendNotification:   req.SendNotification,
+		MaxViewCount:       req.MaxViewCount,
+		ExpirationHours:    req.ExpirationHours,
+		ClientEncrypted:    req.ClientEncrypted,
+		PassphraseKey:      req.PassphraseKey,
+		NotifyOnView:       req.NotifyOnView,
+		RecipientPublicKey: req.RecipientPublicKey,
 	}
 
 	if req.Sender != nil {
@@ -95,6 +119,39 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 		domainReq.RecipientEmail = req.Recipient.Email
 	}
 
+	for _, recipient := range req.Recipients {
+		domainReq.Recipients = append(domainReq.Recipients, domain.MessageRecipient{
+			Name:  recipient.Name,
+			Email: recipient.Email,
+		})
+	}
+
+	if req.Split != nil {
+		domainReq.SplitShares = req.Split.Shares
+		domainReq.SplitThreshold = req.Split.Threshold
+	}
+
+	if req.Webhook != nil {
+		domainReq.WebhookURL = req.Webhook.URL
+		domainReq.WebhookSecret = req.Webhook.Secret
+	}
+
+	if file != nil {
+		domainReq.Attachment, err = readAttachment(file)
+		if err != nil {
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeValidationFailed,
+				"Invalid attachment",
+				map[string]interface{}{
+					"attachment": err.Error(),
+				},
+			)
+			return
+		}
+	}
+
 	// Add remote IP to context for Turnstile validation
 	remoteIP := c.ClientIP()
 	ctxWithIP := context.WithValue(ctx, "RemoteIP", remoteIP)
@@ -107,6 +164,19 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 			Interface("correlation_id", correlationID).
 			Msg("Failed to submit message")
 
+		if errors.Is(err, domain.ErrInvalidPublicKey) {
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeValidationFailed,
+				"Request validation failed",
+				map[string]interface{}{
+					"recipientPublicKey": "Must be an age recipient (age1...) or an ASCII-armored OpenPGP public key with an encryption subkey",
+				},
+			)
+			return
+		}
+
 		middleware.JSONErrorResponse(
 			c,
 			http.StatusInternalServerError,
@@ -123,9 +193,37 @@ func (h *MessageAPIHandler) SubmitMessage(c *gin.Context) {
 		DecryptURL:       response.DecryptURL,
 		Key:              response.Key,
 		WebURL:           response.DecryptURL, // Same URL works for both
+		RevocationToken:  response.RevocationToken,
+		RevokeURL:        response.RevokeURL,
+		StatusURL:        response.StatusURL,
 		ExpiresAt:        response.ExpiresAt,
 		NotificationSent: req.SendNotification && response.Success,
 	}
+	for _, recipient := range response.Recipients {
+		apiResponse.Recipients = append(apiResponse.Recipients, models.RecipientMessageResponse{
+			Name:            recipient.RecipientName,
+			Email:           recipient.RecipientEmail,
+			MessageID:       recipient.MessageID,
+			DecryptURL:      recipient.DecryptURL,
+			Key:             recipient.Key,
+			RevocationToken: recipient.RevocationToken,
+			RevokeURL:       recipient.RevokeURL,
+			StatusURL:       recipient.StatusURL,
+		})
+	}
+	for _, share := range response.Shares {
+		apiResponse.Shares = append(apiResponse.Shares, models.ShareMessageResponse{
+			ShareIndex:      share.ShareIndex,
+			Name:            share.RecipientName,
+			Email:           share.RecipientEmail,
+			MessageID:       share.MessageID,
+			DecryptURL:      share.DecryptURL,
+			Key:             share.Key,
+			RevocationToken: share.RevocationToken,
+			RevokeURL:       share.RevokeURL,
+			StatusURL:       share.StatusURL,
+		})
+	}
 
 	logging.Info().
 		Str("messageId", response.MessageID).
@@ -189,11 +287,16 @@ func (h *MessageAPIHandler) GetMessageInfo(c *gin.Context) {
 
 	// Build response — pass ExpiresAt pointer directly from domain (nil for legacy messages)
 	response := models.MessageAccessInfoResponse{
-		MessageID:          messageID,
-		Exists:             accessInfo.Exists,
-		RequiresPassphrase: accessInfo.RequiresPassphrase,
-		HasBeenAccessed:    false, // TODO: Add this to domain if needed
-		ExpiresAt:          accessInfo.ExpiresAt,
+		MessageID:                   messageID,
+		Exists:                      accessInfo.Exists,
+		RequiresPassphrase:          accessInfo.RequiresPassphrase,
+		HasBeenAccessed:             false, // TODO: Add this to domain if needed
+		ClientEncrypted:             accessInfo.ClientEncrypted,
+		PublicKeyEncrypted:          accessInfo.PublicKeyEncrypted,
+		HasAttachment:               accessInfo.HasAttachment,
+		AttachmentSize:              accessInfo.AttachmentSize,
+		ExpiresAt:                   accessInfo.ExpiresAt,
+		RemainingPassphraseAttempts: accessInfo.RemainingPassphraseAttempts,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -202,15 +305,18 @@ func (h *MessageAPIHandler) GetMessageInfo(c *gin.Context) {
 // DecryptMessage handles POST /api/v1/messages/{id}/decrypt
 // @Summary Decrypt a message
 // @Description Decrypts and retrieves the message content. This is a one-time operation that will delete the message after successful decryption.
+// @Description Client-encrypted messages are returned as ciphertext with clientEncrypted set; no decryptionKey is needed.
+// @Description Messages sealed to a public key need no decryptionKey either: supply privateKey to receive the plaintext, or omit it to receive the armored ciphertext. A wrong private key does not use a view.
 // @Tags Messages
 // @Accept json
 // @Produce json
 // @Param id path string true "Message ID" format(uuid)
 // @Param request body models.MessageDecryptRequest true "Decryption request"
 // @Success 200 {object} models.MessageDecryptResponse "Message successfully decrypted"
-// @Failure 401 {object} models.StandardErrorResponse "Invalid passphrase"
+// @Failure 401 {object} models.StandardErrorResponse "Invalid passphrase or private key; details.remainingAttempts counts the passphrase attempts left"
 // @Failure 404 {object} models.StandardErrorResponse "Message not found or expired"
-// @Failure 410 {object} models.StandardErrorResponse "Message already consumed"
+// @Failure 410 {object} models.StandardErrorResponse "Message already consumed or destroyed after too many wrong passphrases"
+// @Failure 423 {object} models.StandardErrorResponse "Message locked after too many wrong passphrases"
 // @Failure 500 {object} models.StandardErrorResponse "Internal server error"
 // @Router /messages/{id}/decrypt [post]
 func (h *MessageAPIHandler) DecryptMessage(c *gin.Context) {
@@ -253,9 +359,11 @@ func (h *MessageAPIHandler) DecryptMessage(c *gin.Context) {
 
 	// Create domain retrieval request
 	domainReq := domain.MessageRetrievalRequest{
-		MessageID:     messageID,
-		DecryptionKey: decryptionKey,
-		Passphrase:    req.Passphrase,
+		MessageID:            messageID,
+		DecryptionKey:        decryptionKey,
+		Passphrase:           req.Passphrase,
+		PrivateKey:           req.PrivateKey,
+		PrivateKeyPassphrase: req.PrivateKeyPassphrase,
 	}
 
 	// Retrieve and decrypt the message
@@ -268,17 +376,32 @@ func (h *MessageAPIHandler) DecryptMessage(c *gin.Context) {
 			Msg("Failed to retrieve message")
 
 		// Handle specific error types
-		if err == domain.ErrInvalidPassphrase {
+		if writePassphraseError(c, err) {
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidPrivateKey) {
 			middleware.JSONErrorResponse(
 				c,
 				http.StatusUnauthorized,
-				models.ErrorCodeInvalidPassphrase,
-				"Invalid passphrase provided",
+				models.ErrorCodeInvalidPrivateKey,
+				"Private key cannot decrypt this message",
 				nil,
 			)
 			return
 		}
 
+		if errors.Is(err, domain.ErrMissingDecryptionKey) {
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeValidationFailed,
+				"Decryption key is required",
+				map[string]interface{}{"decryptionKey": "Required for this message"},
+			)
+			return
+		}
+
 		// Check for message already consumed (this would need to be added to domain errors)
 		middleware.JSONErrorResponse(
 			c,
@@ -292,12 +415,21 @@ func (h *MessageAPIHandler) DecryptMessage(c *gin.Context) {
 
 	// Build API response
 	apiResponse := models.MessageDecryptResponse{
-		MessageID:    messageID,
-		Content:      response.Content,
-		ViewCount:    response.ViewCount,
-		MaxViewCount: response.MaxViewCount,
-		DecryptedAt:  time.Now(),
-		ExpiresAt:    response.ExpiresAt,
+		MessageID:          messageID,
+		Content:            response.Content,
+		ViewCount:          response.ViewCount,
+		MaxViewCount:       response.MaxViewCount,
+		DecryptedAt:        time.Now(),
+		ExpiresAt:          response.ExpiresAt,
+		ClientEncrypted:    response.ClientEncrypted,
+		PublicKeyEncrypted: response.PublicKeyEncrypted,
+	}
+	if response.Attachment != nil {
+		apiResponse.Attachment = &models.AttachmentInfo{
+			Filename:    response.Attachment.Filename,
+			ContentType: response.Attachment.ContentType,
+			Size:        response.Attachment.Size,
+		}
 	}
 
 	logging.Debug().
@@ -308,6 +440,685 @@ func (h *MessageAPIHandler) DecryptMessage(c *gin.Context) {
 	c.JSON(http.StatusOK, apiResponse)
 }
 
+// DownloadAttachment handles POST /api/v1/messages/{id}/attachment
+// @Summary Download a message attachment
+// @Description Decrypts and returns the file attached to a message. Each download counts toward the message's max view count; a request with the wrong key or passphrase does not.
+// @Tags Messages
+// @Accept json
+// @Produce octet-stream
+// @Param id path string true "Message ID" format(uuid)
+// @Param request body models.MessageDecryptRequest true "Decryption request"
+// @Success 200 {file} file "Decrypted attachment"
+// @Header 200 {integer} X-View-Count "Views used, including this download"
+// @Header 200 {integer} X-Max-View-Count "Views allowed before the message is deleted"
+// @Failure 400 {object} models.StandardErrorResponse "Invalid decryption key format"
+// @Failure 401 {object} models.StandardErrorResponse "Invalid passphrase; details.remainingAttempts counts the attempts left"
+// @Failure 404 {object} models.StandardErrorResponse "Message or attachment not found or expired"
+// @Failure 410 {object} models.StandardErrorResponse "Message destroyed after too many wrong passphrases"
+// @Failure 423 {object} models.StandardErrorResponse "Message locked after too many wrong passphrases"
+// @Router /messages/{id}/attachment [post]
+func (h *MessageAPIHandler) DownloadAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+	messageID := c.Param("id")
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Str("messageId", messageID).
+		Interface("correlation_id", correlationID).
+		Msg("Processing attachment download via API")
+
+	var req models.MessageDecryptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid request format",
+			map[string]interface{}{
+				"parse_error": err.Error(),
+			},
+		)
+		return
+	}
+
+	decryptionKey, err := base64.URLEncoding.DecodeString(req.DecryptionKey)
+	if err != nil || len(decryptionKey) == 0 {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid decryption key format",
+			nil,
+		)
+		return
+	}
+
+	response, err := h.messageService.RetrieveAttachment(ctx, domain.MessageRetrievalRequest{
+		MessageID:     messageID,
+		DecryptionKey: decryptionKey,
+		Passphrase:    req.Passphrase,
+	})
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("messageId", messageID).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to retrieve attachment")
+
+		if writePassphraseError(c, err) {
+			return
+		}
+
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusNotFound,
+			models.ErrorCodeMessageNotFound,
+			"Attachment not found or has expired",
+			nil,
+		)
+		return
+	}
+
+	// Always serve as a download so an uploaded HTML or SVG file is never rendered by the browser
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": response.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.Header("Content-Disposition", disposition)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "no-store")
+	c.Header("X-View-Count", fmt.Sprint(response.ViewCount))
+	c.Header("X-Max-View-Count", fmt.Sprint(response.MaxViewCount))
+
+	logging.Debug().
+		Str("messageId", messageID).
+		Interface("correlation_id", correlationID).
+		Msg("Attachment downloaded successfully via API")
+
+	c.Data(http.StatusOK, "application/octet-stream", response.Data)
+}
+
+// RevokeMessage handles DELETE /api/v1/messages/{id}
+// @Summary Revoke a message
+// @Description Permanently deletes a message before it is viewed or expires. Requires the revocationToken returned when the message was submitted.
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID" format(uuid)
+// @Param request body models.MessageRevokeRequest true "Revocation request"
+// @Success 204 "Message revoked"
+// @Failure 400 {object} models.StandardErrorResponse "Missing revocation token"
+// @Failure 403 {object} models.StandardErrorResponse "Invalid revocation token"
+// @Failure 404 {object} models.StandardErrorResponse "Message not found or expired"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /messages/{id} [delete]
+func (h *MessageAPIHandler) RevokeMessage(c *gin.Context) {
+	ctx := c.Request.Context()
+	messageID := c.Param("id")
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Str("messageId", messageID).
+		Interface("correlation_id", correlationID).
+		Msg("Processing message revocation via API")
+
+	var req models.MessageRevokeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid request format",
+			map[string]interface{}{
+				"parse_error": err.Error(),
+			},
+		)
+		return
+	}
+
+	err := h.messageService.RevokeMessage(ctx, domain.MessageRevocationRequest{
+		MessageID:       messageID,
+		RevocationToken: req.RevocationToken,
+	})
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("messageId", messageID).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to revoke message")
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidRevocationToken):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusForbidden,
+				models.ErrorCodeInvalidRevocation,
+				"Invalid revocation token",
+				nil,
+			)
+		case errors.Is(err, domain.ErrMessageNotFound):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusNotFound,
+				models.ErrorCodeMessageNotFound,
+				"Message not found or has expired",
+				nil,
+			)
+		default:
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				models.ErrorCodeInternalError,
+				"Failed to revoke message",
+				nil,
+			)
+		}
+		return
+	}
+
+	logging.Info().
+		Str("messageId", messageID).
+		Interface("correlation_id", correlationID).
+		Msg("Message revoked via API")
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMessageStatus handles GET /api/v1/messages/{id}/status
+// @Summary Get message status
+// @Description Returns view counts, expiry and reminder history to the message's sender. Authenticate with the revocationToken returned when the message was submitted. The message content is never returned and no view is counted.
+// @Tags Messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID" format(uuid)
+// @Param Authorization header string true "Bearer revocation token"
+// @Success 200 {object} models.MessageStatusResponse "Message status"
+// @Failure 401 {object} models.StandardErrorResponse "Missing revocation token"
+// @Failure 403 {object} models.StandardErrorResponse "Invalid revocation token"
+// @Failure 404 {object} models.StandardErrorResponse "Message not found or expired"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /messages/{id}/status [get]
+func (h *MessageAPIHandler) GetMessageStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	messageID := c.Param("id")
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Str("messageId", messageID).
+		Interface("correlation_id", correlationID).
+		Msg("Processing message status request via API")
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		c.Header("WWW-Authenticate", "Bearer")
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusUnauthorized,
+			models.ErrorCodeInvalidRevocation,
+			"Authorization header with a Bearer revocation token is required",
+			nil,
+		)
+		return
+	}
+
+	status, err := h.messageService.GetMessageStatus(ctx, domain.MessageStatusRequest{
+		MessageID:       messageID,
+		RevocationToken: strings.TrimSpace(token),
+	})
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("messageId", messageID).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to get message status")
+
+		switch {
+		case errors.Is(err, domain.ErrInvalidRevocationToken):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusForbidden,
+				models.ErrorCodeInvalidRevocation,
+				"Invalid revocation token",
+				nil,
+			)
+		case errors.Is(err, domain.ErrMessageNotFound):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusNotFound,
+				models.ErrorCodeMessageNotFound,
+				"Message not found or has expired",
+				nil,
+			)
+		default:
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				models.ErrorCodeInternalError,
+				"Failed to get message status",
+				nil,
+			)
+		}
+		return
+	}
+
+	reminders := make([]models.ReminderHistoryEntry, 0, len(status.Reminders))
+	for _, entry := range status.Reminders {
+		reminders = append(reminders, models.ReminderHistoryEntry{
+			ReminderCount:    entry.ReminderCount,
+			LastReminderSent: entry.LastReminderSent,
+		})
+	}
+
+	// Status is only for the sender; keep it out of shared caches
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, models.MessageStatusResponse{
+		MessageID:                status.MessageID,
+		ViewCount:                status.ViewCount,
+		MaxViewCount:             status.MaxViewCount,
+		RemainingViews:           status.RemainingViews,
+		ClientEncrypted:          status.ClientEncrypted,
+		PublicKeyEncrypted:       status.PublicKeyEncrypted,
+		RequiresPassphrase:       status.HasPassphrase,
+		HasAttachment:            status.HasAttachment,
+		ReadReceipts:             status.ReadReceipts,
+		ExpiresAt:                status.ExpiresAt,
+		Reminders:                reminders,
+		FailedPassphraseAttempts: status.FailedPassphraseAttempts,
+	})
+}
+
+// CreateSecretRequest handles POST /api/v1/requests
+// @Summary Request a secret
+// @Description Creates a link the requester shares with whoever holds a secret. When that person submits the secret through the link, it is encrypted and the link to read it is emailed only to the requester.
+// @Tags Secret Requests
+// @Accept json
+// @Produce json
+// @Param request body models.SecretRequestCreateRequest true "Secret request"
+// @Success 201 {object} models.SecretRequestCreateResponse "Secret request created"
+// @Failure 400 {object} models.StandardErrorResponse "Validation error"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /requests [post]
+func (h *MessageAPIHandler) CreateSecretRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Info().
+		Interface("correlation_id", correlationID).
+		Msg("Processing API secret request creation")
+
+	var req models.SecretRequestCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid request format",
+			map[string]interface{}{
+				"parse_error": err.Error(),
+			},
+		)
+		return
+	}
+
+	if validationErrors := middleware.ValidateSecretRequestCreation(&req); validationErrors != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Request validation failed",
+			validationErrors,
+		)
+		return
+	}
+
+	// Add remote IP to context for Turnstile validation
+	ctxWithIP := context.WithValue(ctx, "RemoteIP", c.ClientIP())
+
+	response, err := h.messageService.CreateSecretRequest(ctxWithIP, domain.SecretRequestCreationRequest{
+		RequesterName:   req.Requester.Name,
+		RequesterEmail:  req.Requester.Email,
+		Description:     req.Description,
+		ExpirationHours: req.ExpirationHours,
+		TurnstileToken:  req.TurnstileToken,
+	})
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to create secret request")
+
+		if errors.Is(err, domain.ErrInvalidMessageRequest) {
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeValidationFailed,
+				"Secret request was rejected",
+				nil,
+			)
+			return
+		}
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusInternalServerError,
+			models.ErrorCodeInternalError,
+			"Failed to create secret request",
+			nil,
+		)
+		return
+	}
+
+	logging.Info().
+		Str("requestId", response.RequestID).
+		Interface("correlation_id", correlationID).
+		Msg("Secret request created via API")
+
+	c.JSON(http.StatusCreated, models.SecretRequestCreateResponse{
+		RequestID:  response.RequestID,
+		RequestURL: response.RequestURL,
+		ExpiresAt:  response.ExpiresAt,
+	})
+}
+
+// GetSecretRequest handles GET /api/v1/requests/{id}
+// @Summary Get an open secret request
+// @Description Returns the requester's name and description so the person holding the secret knows what is being asked for. The requester's email address is never returned.
+// @Tags Secret Requests
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID" format(uuid)
+// @Success 200 {object} models.SecretRequestInfoResponse "Open secret request"
+// @Failure 404 {object} models.StandardErrorResponse "Secret request not found"
+// @Failure 410 {object} models.StandardErrorResponse "Secret request already fulfilled or expired"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /requests/{id} [get]
+func (h *MessageAPIHandler) GetSecretRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.Param("id")
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Str("requestId", requestID).
+		Interface("correlation_id", correlationID).
+		Msg("Retrieving secret request via API")
+
+	info, err := h.messageService.GetSecretRequest(ctx, requestID)
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("requestId", requestID).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to get secret request")
+
+		respondSecretRequestError(c, err, "Failed to get secret request")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SecretRequestInfoResponse{
+		RequestID:     info.RequestID,
+		RequesterName: info.RequesterName,
+		Description:   info.Description,
+		ExpiresAt:     info.ExpiresAt,
+	})
+}
+
+// FulfillSecretRequest handles POST /api/v1/requests/{id}/fulfill
+// @Summary Fulfill a secret request
+// @Description Encrypts the secret and emails the link to read it to the requester. A request can only be fulfilled once, and the link is never returned to the caller.
+// @Tags Secret Requests
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID" format(uuid)
+// @Param request body models.SecretRequestFulfillRequest true "Secret to send"
+// @Success 204 "Secret sent to the requester"
+// @Failure 400 {object} models.StandardErrorResponse "Validation error"
+// @Failure 404 {object} models.StandardErrorResponse "Secret request not found"
+// @Failure 410 {object} models.StandardErrorResponse "Secret request already fulfilled or expired"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /requests/{id}/fulfill [post]
+func (h *MessageAPIHandler) FulfillSecretRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.Param("id")
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Str("requestId", requestID).
+		Interface("correlation_id", correlationID).
+		Msg("Processing secret request fulfillment via API")
+
+	var req models.SecretRequestFulfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid request format",
+			map[string]interface{}{
+				"parse_error": err.Error(),
+			},
+		)
+		return
+	}
+
+	if validationErrors := middleware.ValidateStruct(&req); validationErrors != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Request validation failed",
+			validationErrors,
+		)
+		return
+	}
+
+	err := h.messageService.FulfillSecretRequest(ctx, domain.SecretRequestFulfillmentRequest{
+		RequestID:  requestID,
+		Content:    req.Content,
+		SenderName: req.SenderName,
+	})
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("requestId", requestID).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to fulfill secret request")
+
+		respondSecretRequestError(c, err, "Failed to fulfill secret request")
+		return
+	}
+
+	logging.Info().
+		Str("requestId", requestID).
+		Interface("correlation_id", correlationID).
+		Msg("Secret request fulfilled via API")
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondSecretRequestError maps secret request errors to API error responses
+func respondSecretRequestError(c *gin.Context, err error, fallbackMessage string) {
+	switch {
+	case errors.Is(err, domain.ErrSecretRequestNotFound):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusNotFound,
+			models.ErrorCodeRequestNotFound,
+			"Secret request not found",
+			nil,
+		)
+	case errors.Is(err, domain.ErrSecretRequestUnavailable):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusGone,
+			models.ErrorCodeRequestUnavailable,
+			"Secret request has already been fulfilled or has expired",
+			nil,
+		)
+	case errors.Is(err, domain.ErrInvalidMessageRequest):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Secret was rejected",
+			nil,
+		)
+	default:
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusInternalServerError,
+			models.ErrorCodeInternalError,
+			fallbackMessage,
+			nil,
+		)
+	}
+}
+
+// CombineShares handles POST /api/v1/shares/combine
+// @Summary Combine secret shares
+// @Description Reconstructs a secret that was split across custodians with "split" once at least its threshold of shares are supplied. Shares are combined in memory and nothing is stored.
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param request body models.ShareCombineRequest true "Shares to combine"
+// @Success 200 {object} models.ShareCombineResponse "Secret reconstructed"
+// @Failure 400 {object} models.StandardErrorResponse "Validation error or invalid share"
+// @Failure 422 {object} models.StandardErrorResponse "Fewer shares than the threshold"
+// @Failure 500 {object} models.StandardErrorResponse "Internal server error"
+// @Router /shares/combine [post]
+func (h *MessageAPIHandler) CombineShares(c *gin.Context) {
+	ctx := c.Request.Context()
+	correlationID, _ := c.Get(middleware.CorrelationIDKey)
+
+	logging.Debug().
+		Interface("correlation_id", correlationID).
+		Msg("Processing share combination via API")
+
+	var req models.ShareCombineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Invalid request format",
+			map[string]interface{}{
+				"parse_error": err.Error(),
+			},
+		)
+		return
+	}
+
+	if validationErrors := middleware.ValidateStruct(&req); validationErrors != nil {
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusBadRequest,
+			models.ErrorCodeValidationFailed,
+			"Request validation failed",
+			validationErrors,
+		)
+		return
+	}
+
+	response, err := h.messageService.CombineShares(ctx, domain.ShareCombinationRequest{Shares: req.Shares})
+	if err != nil {
+		logging.Warn().
+			Err(err).
+			Interface("correlation_id", correlationID).
+			Msg("Failed to combine shares")
+
+		switch {
+		case errors.Is(err, domain.ErrInsufficientShares):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusUnprocessableEntity,
+				models.ErrorCodeInsufficientShares,
+				"Not enough shares to reconstruct the secret",
+				nil,
+			)
+		case errors.Is(err, domain.ErrInvalidShare):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeInvalidShare,
+				"A share is malformed, belongs to a different secret or has been altered",
+				nil,
+			)
+		case errors.Is(err, domain.ErrInvalidMessageRequest):
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusBadRequest,
+				models.ErrorCodeValidationFailed,
+				"Request validation failed",
+				nil,
+			)
+		default:
+			middleware.JSONErrorResponse(
+				c,
+				http.StatusInternalServerError,
+				models.ErrorCodeInternalError,
+				"Failed to combine shares",
+				nil,
+			)
+		}
+		return
+	}
+
+	logging.Info().
+		Int("shares", len(req.Shares)).
+		Interface("correlation_id", correlationID).
+		Msg("Shares combined via API")
+
+	c.JSON(http.StatusOK, models.ShareCombineResponse{Content: response.Content})
+}
+
+// bindMultipartSubmission parses a multipart submission. The "request" field carries the same JSON
+// document as a plain JSON submission; the optional "file" field carries the attachment.
+func bindMultipartSubmission(c *gin.Context, req *models.MessageSubmissionRequest) (*multipart.FileHeader, error) {
+	form, err := c.MultipartForm()
+	if err != nil {
+		return nil, fmt.Errorf("invalid multipart body: %w", err)
+	}
+
+	fields := form.Value["request"]
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("multipart body must contain exactly one request field")
+	}
+	if err := json.Unmarshal([]byte(fields[0]), req); err != nil {
+		return nil, fmt.Errorf("invalid request field: %w", err)
+	}
+
+	files := form.File["file"]
+	switch len(files) {
+	case 0:
+		return nil, nil
+	case 1:
+		return files[0], nil
+	default:
+		return nil, fmt.Errorf("only one file can be attached")
+	}
+}
+
+// readAttachment reads an uploaded file into a domain attachment
+func readAttachment(file *multipart.FileHeader) (*domain.Attachment, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, domain.MaxAttachmentBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if len(data) > domain.MaxAttachmentBytes {
+		return nil, fmt.Errorf("file must be no larger than %d MiB", domain.MaxAttachmentBytes>>20)
+	}
+
+	return &domain.Attachment{
+		Filename:    file.Filename,
+		ContentType: file.Header.Get("Content-Type"),
+		Data:        data,
+	}, nil
+}
+
 // HealthCheck handles GET /api/v1/health
 // @Summary Health check
 // @Description Returns the health status of the API and its dependencies
@@ -357,19 +1168,77 @@ func (h *MessageAPIHandler) APIInfo(c *gin.Context) {
 		Version:       "1.0.0",
 		Documentation: "/api/v1/docs", // TODO: Implement swagger docs
 		Endpoints: map[string]string{
-			"submit":  "POST /api/v1/messages",
-			"access":  "GET /api/v1/messages/{id}",
-			"decrypt": "POST /api/v1/messages/{id}/decrypt",
-			"health":  "GET /api/v1/health",
-			"info":    "GET /api/v1/info",
+			"submit":      "POST /api/v1/messages",
+			"access":      "GET /api/v1/messages/{id}",
+			"decrypt":     "POST /api/v1/messages/{id}/decrypt",
+			"attachment":  "POST /api/v1/messages/{id}/attachment",
+			"revoke":      "DELETE /api/v1/messages/{id}",
+			"status":      "GET /api/v1/messages/{id}/status",
+			"request":     "POST /api/v1/requests",
+			"requestInfo": "GET /api/v1/requests/{id}",
+			"fulfill":     "POST /api/v1/requests/{id}/fulfill",
+			"combine":     "POST /api/v1/shares/combine",
+			"health":      "GET /api/v1/health",
+			"info":        "GET /api/v1/info",
 		},
 		Features: map[string]bool{
 			"emailNotifications":   true,
 			"passphraseProtection": true,
 			"antiSpamProtection":   true,
 			"emailReminders":       true,
+			"clientSideEncryption": true,
+			"fileAttachments":      true,
+			"senderRevocation":     true,
+			"senderStatus":         true,
+			"webhooks":             true,
+			"multipleRecipients":   true,
+			"secretRequests":       true,
+			"secretSharing":        true,
 		},
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// writePassphraseError responds to a wrong passphrase, or to a message locked or destroyed after
+// too many of them, and reports whether err was one of those
+func writePassphraseError(c *gin.Context, err error) bool {
+	var attemptErr *domain.PassphraseAttemptError
+	switch {
+	case errors.As(err, &attemptErr):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusUnauthorized,
+			models.ErrorCodeInvalidPassphrase,
+			"Invalid passphrase provided",
+			map[string]interface{}{"remainingAttempts": attemptErr.RemainingAttempts},
+		)
+	case errors.Is(err, domain.ErrInvalidPassphrase):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusUnauthorized,
+			models.ErrorCodeInvalidPassphrase,
+			"Invalid passphrase provided",
+			nil,
+		)
+	case errors.Is(err, domain.ErrMessageLocked):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusLocked,
+			models.ErrorCodeMessageLocked,
+			"Message is locked after too many wrong passphrases",
+			nil,
+		)
+	case errors.Is(err, domain.ErrMessageDestroyed):
+		middleware.JSONErrorResponse(
+			c,
+			http.StatusGone,
+			models.ErrorCodeMessageDestroyed,
+			"Message was destroyed after too many wrong passphrases",
+			nil,
+		)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientInfo middleware stores the client IP and user agent in the request context, so
+// storage operations made for the request are attributed to them in the audit log
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := domain.WithClientInfo(c.Request.Context(), domain.ClientInfo{
+			IP:        c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientInfo(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var got domain.ClientInfo
+	router := gin.New()
+	router.Use(ClientInfo())
+	router.GET("/test", func(c *gin.Context) {
+		got = domain.ClientInfoFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = "203.0.113.7:52100"
+	req.Header.Set("User-Agent", "curl/8.0")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, domain.ClientInfo{IP: "203.0.113.7", UserAgent: "curl/8.0"}, got)
+}
@@ -45,10 +45,12 @@ func JSONErrorResponse(c *gin.Context, statusCode int, errorCode, message string
 		Msg("API error response")
 
 	var errorResponse *models.StandardErrorResponse
-	if details != nil {
+	if details != nil && errorCode == models.ErrorCodeValidationFailed {
 		errorResponse = models.NewValidationError(c.Request.URL.Path, details)
 	} else {
+		// Other errors keep their own code and may still carry details, e.g. remaining attempts
 		errorResponse = models.NewStandardError(errorCode, message, c.Request.URL.Path)
+		errorResponse.Details = details
 	}
 
 	c.AbortWithStatusJSON(statusCode, errorResponse)
@@ -3,18 +3,32 @@ package middleware
 import (
 	"context"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/primary/api/models"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
 
 var validate *validator.Validate
 
+// maxClientEncryptedContentLength bounds client-encrypted content: base64url of a
+// 10000-byte plaintext plus its 12-byte nonce and 16-byte authentication tag.
+const maxClientEncryptedContentLength = 13372
+
+// MaxMultipartBodyBytes bounds multipart submissions: the largest attachment plus
+// room for the JSON request field and multipart framing.
+const MaxMultipartBodyBytes = domain.MaxAttachmentBytes + 1<<20
+
+// maxAttachmentFilenameLength is the longest attachment file name accepted.
+const maxAttachmentFilenameLength = 255
+
 func init() {
 	validate = validator.New()
 
@@ -35,8 +49,12 @@ func init() {
 // ValidationMiddleware creates a middleware that validates request bodies
 func ValidationMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Set request size limits
-		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, 1<<20) // 1MB limit
+		// Set request size limits; multipart submissions carry attachments
+		limit := int64(1 << 20) // 1MB limit
+		if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+			limit = MaxMultipartBodyBytes
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
 
 		c.Next()
 	}
@@ -72,6 +90,20 @@ func ValidateMessageSubmission(req *models.MessageSubmissionRequest) map[string]
 		}
 	}
 
+	// Ciphertext is longer than the plaintext it carries, so client-encrypted
+	// content is held to the encoded-ciphertext limit instead of the plaintext one
+	if req.ClientEncrypted {
+		delete(errors, "content")
+		if req.Content == "" {
+			errors["content"] = "content is required"
+		} else if len(req.Content) > maxClientEncryptedContentLength {
+			errors["content"] = fmt.Sprintf("Must be no more than %d characters", maxClientEncryptedContentLength)
+		}
+		if req.SendNotification {
+			errors["sendNotification"] = "Email notifications are not available for client-encrypted messages"
+		}
+	}
+
 	// Conditional validation for notifications
 	if req.SendNotification {
 		if req.Sender == nil {
@@ -89,9 +121,9 @@ func ValidateMessageSubmission(req *models.MessageSubmissionRequest) map[string]
 			}
 		}
 
-		if req.Recipient == nil {
+		if req.Recipient == nil && len(req.Recipients) == 0 {
 			errors["recipient"] = "Recipient information is required when notifications are enabled"
-		} else {
+		} else if req.Recipient != nil {
 			if req.Recipient.Name == "" {
 				errors["recipient.name"] = "Recipient name is required when notifications are enabled"
 			}
@@ -112,6 +144,186 @@ func ValidateMessageSubmission(req *models.MessageSubmissionRequest) map[string]
 		}
 	}
 
+	// Read receipts need the sender but not the recipient
+	if req.NotifyOnView && !req.SendNotification {
+		if req.Sender == nil || req.Sender.Email == "" {
+			errors["sender.email"] = "Sender email is required when read receipts are enabled"
+		} else if senderErrors := ValidateStruct(req.Sender); senderErrors != nil {
+			for k, v := range senderErrors {
+				errors["sender."+k] = v
+			}
+		}
+	}
+
+	if len(req.Recipients) > 0 {
+		validateRecipients(req, errors)
+	}
+
+	if req.Webhook != nil {
+		validateWebhook(req.Webhook, errors)
+	}
+
+	if req.Split != nil {
+		validateSplit(req, errors)
+	}
+
+	if req.RecipientPublicKey != "" {
+		if req.ClientEncrypted {
+			errors["recipientPublicKey"] = "A recipient public key cannot be combined with client-encrypted content"
+		} else if len(req.Recipients) > 0 {
+			errors["recipientPublicKey"] = "A recipient public key cannot be combined with recipients"
+		}
+	}
+
+	if req.PassphraseKey {
+		if strings.TrimSpace(req.Passphrase) == "" {
+			errors["passphraseKey"] = "A passphrase is required to derive the content key from it"
+		} else if req.ClientEncrypted || req.RecipientPublicKey != "" {
+			errors["passphraseKey"] = "The passphrase key is only available for server-encrypted messages"
+		}
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// validateRecipients adds errors for an unusable multi-recipient list. Each recipient gets a
+// separately encrypted copy, which the server cannot make of client-encrypted content.
+func validateRecipients(req *models.MessageSubmissionRequest, errors map[string]interface{}) {
+	if req.Recipient != nil {
+		errors["recipients"] = "Use either recipient or recipients, not both"
+	} else if len(req.Recipients) > domain.MaxRecipients {
+		errors["recipients"] = fmt.Sprintf("Must list no more than %d recipients", domain.MaxRecipients)
+	} else if req.ClientEncrypted {
+		errors["recipients"] = "Multiple recipients are not available for client-encrypted messages"
+	}
+
+	seen := make(map[string]bool, len(req.Recipients))
+	for i, recipient := range req.Recipients {
+		prefix := fmt.Sprintf("recipients[%d].", i)
+		if recipientErrors := ValidateStruct(recipient); recipientErrors != nil {
+			for k, v := range recipientErrors {
+				errors[prefix+k] = v
+			}
+			continue
+		}
+
+		email := strings.ToLower(strings.TrimSpace(recipient.Email))
+		if seen[email] {
+			errors[prefix+"email"] = "Each recipient may only be listed once"
+		}
+		seen[email] = true
+	}
+}
+
+// validateSplit adds errors for an unusable k-of-n split. The server splits the plaintext, and
+// each share goes to its own custodian, so a single recipient cannot receive them all.
+func validateSplit(req *models.MessageSubmissionRequest, errors map[string]interface{}) {
+	if req.Split.Shares < 2 || req.Split.Shares > domain.MaxSecretShares {
+		errors["split.shares"] = fmt.Sprintf("Must be between 2 and %d", domain.MaxSecretShares)
+	} else if req.Split.Threshold < 2 || req.Split.Threshold > req.Split.Shares {
+		errors["split.threshold"] = "Must be between 2 and split.shares"
+	}
+
+	switch {
+	case req.ClientEncrypted:
+		errors["split"] = "Client-encrypted messages cannot be split"
+	case req.RecipientPublicKey != "":
+		errors["split"] = "Messages sealed to a public key cannot be split"
+	case req.Recipient != nil:
+		errors["split"] = "Use recipients, one per share, instead of recipient"
+	case len(req.Recipients) > 0 && len(req.Recipients) != req.Split.Shares:
+		errors["split"] = "recipients must list exactly one recipient per share"
+	}
+}
+
+// validateWebhook adds errors for an unusable per-message webhook
+func validateWebhook(webhook *models.WebhookConfig, errors map[string]interface{}) {
+	if webhook.URL == "" {
+		errors["webhook.url"] = "Webhook URL is required"
+	} else if len(webhook.URL) > domain.MaxWebhookURLLength {
+		errors["webhook.url"] = fmt.Sprintf("Must be no more than %d characters", domain.MaxWebhookURLLength)
+	} else if parsed, err := url.Parse(webhook.URL); err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" {
+		errors["webhook.url"] = "Must be an absolute https URL"
+	} else if parsed.User != nil {
+		errors["webhook.url"] = "Must not contain credentials"
+	}
+
+	if len(webhook.Secret) < domain.MinWebhookSecretLength || len(webhook.Secret) > domain.MaxWebhookSecretLength {
+		errors["webhook.secret"] = fmt.Sprintf(
+			"Must be between %d and %d characters",
+			domain.MinWebhookSecretLength,
+			domain.MaxWebhookSecretLength,
+		)
+	}
+}
+
+// ValidateMessageSubmissionWithAttachment validates a submission together with its optional
+// attachment. A file may stand in for the message content; a nil file validates like
+// ValidateMessageSubmission.
+func ValidateMessageSubmissionWithAttachment(
+	req *models.MessageSubmissionRequest,
+	file *multipart.FileHeader,
+) map[string]interface{} {
+	errors := ValidateMessageSubmission(req)
+	if file == nil {
+		return errors
+	}
+	if errors == nil {
+		errors = make(map[string]interface{})
+	}
+
+	if req.Content == "" {
+		delete(errors, "content")
+	}
+	if req.ClientEncrypted {
+		errors["attachment"] = "Attachments are not available for client-encrypted messages"
+	} else if req.RecipientPublicKey != "" {
+		errors["attachment"] = "Attachments are not available for messages sealed to a public key"
+	} else if req.Split != nil {
+		errors["attachment"] = "Attachments are not available for split messages"
+	} else if file.Size > domain.MaxAttachmentBytes {
+		errors["attachment"] = fmt.Sprintf("Must be no larger than %d MiB", domain.MaxAttachmentBytes>>20)
+	} else if strings.TrimSpace(file.Filename) == "" {
+		errors["attachment"] = "File name is required"
+	} else if len(file.Filename) > maxAttachmentFilenameLength {
+		errors["attachment"] = fmt.Sprintf("File name must be no more than %d characters", maxAttachmentFilenameLength)
+	}
+	if req.MaxViewCount != 0 && req.MaxViewCount < domain.MinAttachmentViewCount {
+		errors["maxViewCount"] = fmt.Sprintf(
+			"Must be at least %d when a file is attached: one view opens the message and one downloads the file",
+			domain.MinAttachmentViewCount,
+		)
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors
+}
+
+// ValidateSecretRequestCreation validates a secret request, including the requester who will be emailed
+func ValidateSecretRequestCreation(req *models.SecretRequestCreateRequest) map[string]interface{} {
+	errors := make(map[string]interface{})
+
+	if structErrors := ValidateStruct(req); structErrors != nil {
+		for k, v := range structErrors {
+			errors[k] = v
+		}
+	}
+
+	if req.Requester == nil {
+		errors["requester"] = "Requester information is required"
+	} else if requesterErrors := ValidateStruct(req.Requester); requesterErrors != nil {
+		for k, v := range requesterErrors {
+			errors["requester."+k] = v
+		}
+	}
+
 	if len(errors) == 0 {
 		return nil
 	}
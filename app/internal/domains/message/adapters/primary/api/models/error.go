@@ -46,7 +46,15 @@ const (
 	ErrorCodeValidationFailed   = "validation_failed"
 	ErrorCodeMessageNotFound    = "message_not_found"
 	ErrorCodeInvalidPassphrase  = "invalid_passphrase"
+	ErrorCodeInvalidPrivateKey  = "invalid_private_key"
+	ErrorCodeInvalidRevocation  = "invalid_revocation_token"
 	ErrorCodeMessageConsumed    = "message_consumed"
+	ErrorCodeMessageLocked      = "message_locked"
+	ErrorCodeMessageDestroyed   = "message_destroyed"
+	ErrorCodeRequestNotFound    = "request_not_found"
+	ErrorCodeRequestUnavailable = "request_unavailable"
+	ErrorCodeInvalidShare       = "invalid_share"
+	ErrorCodeInsufficientShares = "insufficient_shares"
 	ErrorCodeRateLimitExceeded  = "rate_limit_exceeded"
 	ErrorCodeInternalError      = "internal_error"
 	ErrorCodeServiceUnavailable = "service_unavailable"
@@ -19,6 +19,45 @@ type MessageSubmissionRequest struct {
 	// ExpirationHours specifies a custom expiration in hours. When 0 or omitted, the server default (7 days / 168 hours) applies.
 	// Valid range: 1–2160 (1 hour to 90 days).
 	ExpirationHours int `json:"expirationHours,omitempty" validate:"min=0,max=2160"`
+	// ClientEncrypted marks content as base64url(nonce || AES-GCM ciphertext) encrypted by the client.
+	// The key must never be sent to the server; email notifications are unavailable in this mode.
+	ClientEncrypted bool `json:"clientEncrypted,omitempty"`
+	// PassphraseKey mixes the passphrase into the content key with Argon2id, so the message
+	// cannot be decrypted without it even with database access. Requires passphrase and cannot
+	// be combined with clientEncrypted or recipientPublicKey.
+	PassphraseKey bool `json:"passphraseKey,omitempty"`
+	// NotifyOnView emails sender.email a read receipt each time the message is viewed.
+	// Requires sender.email and a turnstileToken.
+	NotifyOnView bool `json:"notifyOnView,omitempty"`
+	// Webhook receives signed message.created, message.viewed, message.exhausted,
+	// message.expired and message.revoked events for this message.
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	// Recipients shares the message with up to 10 people, each receiving their own link, key
+	// and view counter. Cannot be combined with recipient or clientEncrypted.
+	Recipients []Recipient `json:"recipients,omitempty"`
+	// RecipientPublicKey seals content to an age X25519 recipient ("age1...") or an ASCII-armored
+	// OpenPGP public key. No key is returned and only the recipient's private key can open the
+	// message. Cannot be combined with clientEncrypted, recipients or an attachment.
+	RecipientPublicKey string `json:"recipientPublicKey,omitempty" validate:"max=16384"`
+	// Split divides content with Shamir's Secret Sharing into shares, each stored as its own
+	// message. With recipients, share i is sent to recipients[i].
+	Split *SplitConfig `json:"split,omitempty"`
+}
+
+// SplitConfig configures k-of-n splitting of a message across custodians
+type SplitConfig struct {
+	// Shares is how many shares, and so messages, to create; 2–10
+	Shares int `json:"shares"`
+	// Threshold is how many shares are needed to reconstruct the content; 2–shares
+	Threshold int `json:"threshold"`
+}
+
+// WebhookConfig configures lifecycle event delivery for a message
+type WebhookConfig struct {
+	// URL must be an absolute https URL
+	URL string `json:"url"`
+	// Secret signs each payload with HMAC-SHA256; 16–256 characters
+	Secret string `json:"secret"`
 }
 
 // Sender represents sender information for message submission
@@ -39,9 +78,48 @@ type MessageSubmissionResponse struct {
 	DecryptURL string `json:"decryptUrl"`
 	Key        string `json:"key"`
 	WebURL     string `json:"webUrl"`
+	// RevocationToken deletes the message early via DELETE /api/v1/messages/{id} and unlocks
+	// GET /api/v1/messages/{id}/status. It is shown only once.
+	RevocationToken string `json:"revocationToken"`
+	// RevokeURL opens the web revocation page with the token in the URL fragment.
+	RevokeURL string `json:"revokeUrl"`
+	// StatusURL opens the web status page with the token in the URL fragment.
+	StatusURL string `json:"statusUrl"`
 	// ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.
 	ExpiresAt        *time.Time `json:"expiresAt"`
 	NotificationSent bool       `json:"notificationSent"`
+	// Recipients lists the per-recipient messages of a multi-recipient submission. The fields
+	// above describe the first of them. Omitted for single-recipient submissions.
+	Recipients []RecipientMessageResponse `json:"recipients,omitempty"`
+	// Shares lists the message holding each share of a split submission, ordered by share
+	// index. The fields above describe share 1. Omitted unless split was requested.
+	Shares []ShareMessageResponse `json:"shares,omitempty"`
+}
+
+// ShareMessageResponse describes the message created for one share of a split submission
+type ShareMessageResponse struct {
+	ShareIndex int `json:"shareIndex"`
+	// Name and Email identify the custodian the share was sent to. Empty without recipients.
+	Name            string `json:"name,omitempty"`
+	Email           string `json:"email,omitempty"`
+	MessageID       string `json:"messageId"`
+	DecryptURL      string `json:"decryptUrl"`
+	Key             string `json:"key"`
+	RevocationToken string `json:"revocationToken"`
+	RevokeURL       string `json:"revokeUrl"`
+	StatusURL       string `json:"statusUrl"`
+}
+
+// RecipientMessageResponse describes the message created for one recipient of a submission
+type RecipientMessageResponse struct {
+	Name            string `json:"name"`
+	Email           string `json:"email"`
+	MessageID       string `json:"messageId"`
+	DecryptURL      string `json:"decryptUrl"`
+	Key             string `json:"key"`
+	RevocationToken string `json:"revocationToken"`
+	RevokeURL       string `json:"revokeUrl"`
+	StatusURL       string `json:"statusUrl"`
 }
 
 // MessageAccessInfoResponse represents information about message access requirements
@@ -50,14 +128,62 @@ type MessageAccessInfoResponse struct {
 	Exists             bool   `json:"exists"`
 	RequiresPassphrase bool   `json:"requiresPassphrase"`
 	HasBeenAccessed    bool   `json:"hasBeenAccessed"`
+	ClientEncrypted    bool   `json:"clientEncrypted"`
+	// PublicKeyEncrypted indicates the message is sealed to the recipient's public key and
+	// is opened with privateKey instead of a decryption key.
+	PublicKeyEncrypted bool `json:"publicKeyEncrypted"`
+	HasAttachment      bool `json:"hasAttachment"`
+	// AttachmentSize is the attachment's size in bytes. Omitted when there is no attachment.
+	AttachmentSize int64 `json:"attachmentSize,omitempty"`
 	// ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.
 	ExpiresAt *time.Time `json:"expiresAt"`
+	// RemainingPassphraseAttempts is how many wrong passphrases are left before the message is
+	// locked or destroyed. Zero when the message needs no passphrase.
+	RemainingPassphraseAttempts int `json:"remainingPassphraseAttempts"`
 }
 
 // MessageDecryptRequest represents a request to decrypt a message
 type MessageDecryptRequest struct {
-	DecryptionKey string `json:"decryptionKey"        validate:"required"`
+	// DecryptionKey is omitted for client-encrypted messages, whose key never leaves the client.
+	DecryptionKey string `json:"decryptionKey,omitempty"`
 	Passphrase    string `json:"passphrase,omitempty"`
+	// PrivateKey opens a message sealed to the recipient's public key: an age identity
+	// ("AGE-SECRET-KEY-1...") or an ASCII-armored OpenPGP private key. When omitted, such a
+	// message is returned as armored ciphertext for the recipient to decrypt locally.
+	PrivateKey string `json:"privateKey,omitempty"`
+	// PrivateKeyPassphrase unlocks a passphrase-protected OpenPGP private key.
+	PrivateKeyPassphrase string `json:"privateKeyPassphrase,omitempty"`
+}
+
+// MessageRevokeRequest represents a sender's request to delete a message before it expires
+type MessageRevokeRequest struct {
+	RevocationToken string `json:"revocationToken" binding:"required"`
+}
+
+// MessageStatusResponse describes a message to its sender without revealing its content
+type MessageStatusResponse struct {
+	MessageID          string `json:"messageId"`
+	ViewCount          int    `json:"viewCount"`
+	MaxViewCount       int    `json:"maxViewCount"`
+	RemainingViews     int    `json:"remainingViews"`
+	ClientEncrypted    bool   `json:"clientEncrypted"`
+	PublicKeyEncrypted bool   `json:"publicKeyEncrypted"`
+	RequiresPassphrase bool   `json:"requiresPassphrase"`
+	HasAttachment      bool   `json:"hasAttachment"`
+	// ReadReceipts reports whether the sender is emailed each time the message is viewed.
+	ReadReceipts bool `json:"readReceipts"`
+	// ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.
+	ExpiresAt *time.Time `json:"expiresAt"`
+	// Reminders lists reminder emails sent to the recipient. Empty when none have been sent.
+	Reminders []ReminderHistoryEntry `json:"reminders"`
+	// FailedPassphraseAttempts counts the wrong passphrases submitted for the message.
+	FailedPassphraseAttempts int `json:"failedPassphraseAttempts"`
+}
+
+// ReminderHistoryEntry records the reminders sent for an unread message
+type ReminderHistoryEntry struct {
+	ReminderCount    int       `json:"reminderCount"`
+	LastReminderSent time.Time `json:"lastReminderSent"`
 }
 
 // MessageDecryptResponse represents the response to a message decryption
@@ -69,6 +195,64 @@ type MessageDecryptResponse struct {
 	DecryptedAt  time.Time `json:"decryptedAt"`
 	// ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.
 	ExpiresAt *time.Time `json:"expiresAt"`
+	// ClientEncrypted indicates Content is still ciphertext and must be decrypted by the client.
+	ClientEncrypted bool `json:"clientEncrypted"`
+	// PublicKeyEncrypted indicates the message is sealed to the recipient's public key. Content is
+	// the armored age or OpenPGP ciphertext unless privateKey was supplied.
+	PublicKeyEncrypted bool `json:"publicKeyEncrypted"`
+	// Attachment describes a file that can be downloaded from POST /messages/{id}/attachment.
+	Attachment *AttachmentInfo `json:"attachment,omitempty"`
+}
+
+// AttachmentInfo describes a message attachment without its contents
+type AttachmentInfo struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// SecretRequestCreateRequest represents a REST API request asking someone to send the requester a secret
+type SecretRequestCreateRequest struct {
+	// Requester is emailed the link to the secret once the request is fulfilled
+	Requester *Sender `json:"requester" validate:"-"`
+	// Description tells whoever fulfills the request what is being asked for
+	Description string `json:"description,omitempty" validate:"max=1000"`
+	// ExpirationHours is how long the request stays open. When 0 or omitted, the server default (7 days / 168 hours) applies.
+	ExpirationHours int    `json:"expirationHours,omitempty" validate:"min=0,max=2160"`
+	TurnstileToken  string `json:"turnstileToken"            validate:"required,max=2048"`
+}
+
+// SecretRequestCreateResponse represents a newly created secret request
+type SecretRequestCreateResponse struct {
+	RequestID string `json:"requestId"`
+	// RequestURL is the link to share with whoever holds the secret
+	RequestURL string     `json:"requestUrl"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+}
+
+// SecretRequestInfoResponse describes an open secret request without revealing the requester's email
+type SecretRequestInfoResponse struct {
+	RequestID     string     `json:"requestId"`
+	RequesterName string     `json:"requesterName"`
+	Description   string     `json:"description"`
+	ExpiresAt     *time.Time `json:"expiresAt"`
+}
+
+// SecretRequestFulfillRequest represents the secret sent in answer to a request
+type SecretRequestFulfillRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=10000"`
+	// SenderName is shown to the requester in the notification email
+	SenderName string `json:"senderName,omitempty" validate:"max=100"`
+}
+
+// ShareCombineRequest carries the shares of a split secret, as shown when each share's message was opened
+type ShareCombineRequest struct {
+	Shares []string `json:"shares" validate:"required,min=1,max=10,dive,required,max=14000"`
+}
+
+// ShareCombineResponse carries the reconstructed secret
+type ShareCombineResponse struct {
+	Content string `json:"content"`
 }
 
 // HealthCheckResponse represents the response to a health check
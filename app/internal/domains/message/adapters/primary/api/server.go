@@ -55,6 +55,7 @@ func setupRouter(
 	router.Use(gin.Logger())
 	router.Use(middleware.ErrorHandler())
 	router.Use(middleware.CorrelationID())
+	router.Use(middleware.ClientInfo())
 	router.Use(middleware.PrometheusMiddleware(prometheusMetrics)) // Add Prometheus metrics collection
 	router.Use(middleware.CustomRateLimitErrorHandler())
 	router.Use(middleware.ValidationMiddleware())
@@ -86,8 +87,22 @@ func setupRouter(
 			messages.POST("", middleware.MessageSubmissionRateLimit(), handler.SubmitMessage)
 			messages.GET("/:id", middleware.MessageAccessRateLimit(), handler.GetMessageInfo)
 			messages.POST("/:id/decrypt", middleware.MessageDecryptRateLimit(), handler.DecryptMessage)
+			messages.POST("/:id/attachment", middleware.MessageDecryptRateLimit(), handler.DownloadAttachment)
+			messages.DELETE("/:id", middleware.MessageDecryptRateLimit(), handler.RevokeMessage)
+			messages.GET("/:id/status", middleware.MessageDecryptRateLimit(), handler.GetMessageStatus)
 		}
 
+		// Secret request endpoints
+		requests := v1.Group("/requests")
+		{
+			requests.POST("", middleware.MessageSubmissionRateLimit(), handler.CreateSecretRequest)
+			requests.GET("/:id", middleware.MessageAccessRateLimit(), handler.GetSecretRequest)
+			requests.POST("/:id/fulfill", middleware.MessageSubmissionRateLimit(), handler.FulfillSecretRequest)
+		}
+
+		// Combining shares verifies guesses against the share checksum, so it is limited like decryption
+		v1.POST("/shares/combine", middleware.MessageDecryptRateLimit(), handler.CombineShares)
+
 		// Utility endpoints with lenient rate limits
 		v1.GET("/health", middleware.HealthCheckRateLimit(), handler.HealthCheck)
 		v1.GET("/info", middleware.MessageAccessRateLimit(), handler.APIInfo)
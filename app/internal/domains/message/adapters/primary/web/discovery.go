@@ -27,6 +27,9 @@ func (h *MessageHandler) RobotsTxt(c *gin.Context) {
 		"Allow: /\n" +
 		"Disallow: /decrypt/\n" +
 		"Disallow: /confirmation\n" +
+		"Disallow: /revoke/\n" +
+		"Disallow: /status/\n" +
+		"Disallow: /request/\n" +
 		"\n" +
 		"Sitemap: " + baseURL(c) + "/sitemap.xml\n"
 	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(body))
@@ -50,6 +53,8 @@ func (h *MessageHandler) SitemapXML(c *gin.Context) {
 		URLs: []sitemapURL{
 			{Loc: base + "/"},
 			{Loc: base + "/about"},
+			{Loc: base + "/request"},
+			{Loc: base + "/combine"},
 			{Loc: base + "/api/v1/docs/"},
 		},
 	}
@@ -38,6 +38,8 @@ func TestRobotsTxt(t *testing.T) {
 	assert.Contains(t, body, "Sitemap: http://example.test/sitemap.xml")
 	assert.Contains(t, body, "Disallow: /decrypt/")
 	assert.Contains(t, body, "Disallow: /confirmation")
+	assert.Contains(t, body, "Disallow: /status/")
+	assert.Contains(t, body, "Disallow: /request/")
 	assert.Contains(t, body, "User-agent: *")
 }
 
@@ -69,6 +71,8 @@ func TestSitemapXML(t *testing.T) {
 	assert.Contains(t, body, `xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"`)
 	assert.Contains(t, body, "<loc>http://example.test/</loc>")
 	assert.Contains(t, body, "<loc>http://example.test/about</loc>")
+	assert.Contains(t, body, "<loc>http://example.test/request</loc>")
+	assert.Contains(t, body, "<loc>http://example.test/combine</loc>")
 	assert.Contains(t, body, "<loc>http://example.test/api/v1/docs/</loc>")
 	assert.NotContains(t, body, "/decrypt/")
 }
@@ -3,11 +3,13 @@ package web
 import (
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"mime"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/primary/api/middleware"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
@@ -23,10 +25,11 @@ type MessageHandler struct {
 }
 
 const (
-	acceptHeaderName       = "Accept"
-	markdownMediaType      = "text/markdown"
-	markdownContentType    = markdownMediaType + "; charset=utf-8"
-	wrongPassphraseMessage = "Wrong Passphrase/Lastname. Please try again(can be empty)"
+	acceptHeaderName                 = "Accept"
+	markdownMediaType                = "text/markdown"
+	markdownContentType              = markdownMediaType + "; charset=utf-8"
+	wrongPassphraseMessage           = "Wrong Passphrase/Lastname. Please try again(can be empty)"
+	tooManyPassphraseAttemptsMessage = "Too many wrong passphrases. This message can no longer be opened."
 )
 
 // markdownBuilder produces markdown directly from handler data, bypassing the
@@ -117,8 +120,11 @@ func (h *MessageHandler) SubmitMessage(c *gin.Context) {
 		Captcha:        c.PostForm("h-captcha-response"),
 		SendNotification: c.PostForm("enableEmail") != "" &&
 			webAntiSpamCheck(c.PostForm("questionId"), c.PostForm("color")),
-		MaxViewCount:    maxViewCount,
-		ExpirationHours: expirationHours,
+		MaxViewCount:       maxViewCount,
+		ExpirationHours:    expirationHours,
+		NotifyOnView:       c.PostForm("notifyOnView") != "",
+		PassphraseKey:      c.PostForm("passphraseKey") != "",
+		RecipientPublicKey: strings.TrimSpace(c.PostForm("recipient_public_key")),
 	}
 
 	// Submit the message
@@ -158,8 +164,9 @@ func (h *MessageHandler) DisplayDecrypted(c *gin.Context) {
 
 	// Render decryption page
 	data := gin.H{
-		"Title":       "passwordExchange Decrypted",
-		"HasPassword": accessInfo.RequiresPassphrase,
+		"Title":              "passwordExchange Decrypted",
+		"HasPassword":        accessInfo.RequiresPassphrase,
+		"PublicKeyEncrypted": accessInfo.PublicKeyEncrypted,
 	}
 
 	h.renderHTMLOrMarkdown(c, http.StatusOK, "decryption.html", data, displayDecryptedMarkdown)
@@ -171,6 +178,7 @@ func (h *MessageHandler) DecryptMessage(c *gin.Context) {
 	messageID := c.Param("uuid")
 	keyParam := c.Param("key")
 	passphrase := c.PostForm("passphrase")
+	privateKey := c.PostForm("private_key")
 
 	logging.Debug().Str("messageId", messageID).Msg("Processing message decryption")
 
@@ -188,9 +196,11 @@ func (h *MessageHandler) DecryptMessage(c *gin.Context) {
 
 	// Create retrieval request
 	req := domain.MessageRetrievalRequest{
-		MessageID:     messageID,
-		DecryptionKey: decryptionKey,
-		Passphrase:    passphrase,
+		MessageID:            messageID,
+		DecryptionKey:        decryptionKey,
+		Passphrase:           passphrase,
+		PrivateKey:           privateKey,
+		PrivateKeyPassphrase: c.PostForm("private_key_passphrase"),
 	}
 
 	// Retrieve and decrypt the message
@@ -199,16 +209,41 @@ func (h *MessageHandler) DecryptMessage(c *gin.Context) {
 		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to retrieve message")
 
 		// Check if it's a passphrase error
-		if err == domain.ErrInvalidPassphrase {
+		if errors.Is(err, domain.ErrInvalidPassphrase) {
 			data := gin.H{
 				"Title":            "passwordExchange Decrypted",
 				"DecryptedMessage": wrongPassphraseMessage,
 				"WrongPassphrase":  true,
 			}
+			var attemptErr *domain.PassphraseAttemptError
+			if errors.As(err, &attemptErr) {
+				data["RemainingAttempts"] = attemptErr.RemainingAttempts
+				data["DecryptedMessage"] = fmt.Sprintf("%s. Attempts remaining: %d", wrongPassphraseMessage, attemptErr.RemainingAttempts)
+			}
 			h.renderHTMLOrMarkdown(c, http.StatusOK, "decryption.html", data, decryptMessageMarkdown)
 			return
 		}
 
+		// Once the passphrase attempts are used up, another guess cannot help
+		if errors.Is(err, domain.ErrMessageLocked) || errors.Is(err, domain.ErrMessageDestroyed) {
+			h.renderError(c, tooManyPassphraseAttemptsMessage, err)
+			return
+		}
+
+		if errors.Is(err, domain.ErrMissingDecryptionKey) {
+			h.renderError(c, "Invalid decryption key", err)
+			return
+		}
+
+		if err == domain.ErrInvalidPrivateKey {
+			data := gin.H{
+				"Title":           "passwordExchange Decrypted",
+				"WrongPrivateKey": true,
+			}
+			h.renderHTMLOrMarkdown(c, http.StatusUnauthorized, "decryption.html", data, decryptMessageMarkdown)
+			return
+		}
+
 		h.render404(c)
 		return
 	}
@@ -219,12 +254,203 @@ func (h *MessageHandler) DecryptMessage(c *gin.Context) {
 		"DecryptedMessage": response.Content,
 		"ViewCount":        response.ViewCount,
 		"MaxViewCount":     response.MaxViewCount,
+		"ClientEncrypted":  response.ClientEncrypted,
+		// Without a private key, a public-key message is returned still sealed
+		"PublicKeyEncrypted": response.PublicKeyEncrypted && privateKey == "",
+		"MessageID":          messageID,
+	}
+	if response.Attachment != nil {
+		data["Attachment"] = response.Attachment
 	}
 
 	h.renderHTMLOrMarkdown(c, http.StatusOK, "decryption.html", data, decryptMessageMarkdown)
 	logging.Debug().Str("messageId", messageID).Msg("Message decrypted and displayed successfully")
 }
 
+// DisplayRevoke handles GET requests to display the revocation page. The token is
+// read from the URL fragment by the page's script, so it never reaches the server here.
+func (h *MessageHandler) DisplayRevoke(c *gin.Context) {
+	data := gin.H{
+		"Title": "passwordExchange Revoke",
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "revoke.html", data, revokeMarkdown)
+}
+
+// RevokeMessage handles POST requests to delete a message with the sender's revocation token
+func (h *MessageHandler) RevokeMessage(c *gin.Context) {
+	ctx := c.Request.Context()
+	messageID := c.Param("uuid")
+
+	logging.Debug().Str("messageId", messageID).Msg("Processing message revocation")
+
+	err := h.messageService.RevokeMessage(ctx, domain.MessageRevocationRequest{
+		MessageID:       messageID,
+		RevocationToken: strings.TrimSpace(c.PostForm("token")),
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to revoke message")
+
+		if errors.Is(err, domain.ErrInvalidRevocationToken) {
+			data := gin.H{
+				"Title": "passwordExchange Revoke",
+				"Error": "Invalid revocation token. Check that you used the full revoke link.",
+			}
+			h.renderHTMLOrMarkdown(c, http.StatusForbidden, "revoke.html", data, revokeMarkdown)
+			return
+		}
+
+		h.render404(c)
+		return
+	}
+
+	data := gin.H{
+		"Title":   "passwordExchange Revoke",
+		"Revoked": true,
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "revoke.html", data, revokeMarkdown)
+	logging.Info().Str("messageId", messageID).Msg("Message revoked successfully")
+}
+
+// DisplayStatus handles GET requests to display the sender's status page. As with
+// revocation, the token stays in the URL fragment and is posted by the page's script.
+func (h *MessageHandler) DisplayStatus(c *gin.Context) {
+	data := gin.H{
+		"Title": "passwordExchange Status",
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "status.html", data, statusMarkdown)
+}
+
+// MessageStatus handles POST requests to show a message's status to the holder of the sender's token
+func (h *MessageHandler) MessageStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	messageID := c.Param("uuid")
+
+	logging.Debug().Str("messageId", messageID).Msg("Processing message status request")
+
+	status, err := h.messageService.GetMessageStatus(ctx, domain.MessageStatusRequest{
+		MessageID:       messageID,
+		RevocationToken: strings.TrimSpace(c.PostForm("token")),
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to get message status")
+
+		if errors.Is(err, domain.ErrInvalidRevocationToken) {
+			data := gin.H{
+				"Title": "passwordExchange Status",
+				"Error": "Invalid token. Check that you used the full status link.",
+			}
+			h.renderHTMLOrMarkdown(c, http.StatusForbidden, "status.html", data, statusMarkdown)
+			return
+		}
+
+		h.render404(c)
+		return
+	}
+
+	data := gin.H{
+		"Title":  "passwordExchange Status",
+		"Status": status,
+	}
+	c.Header("Cache-Control", "no-store")
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "status.html", data, statusMarkdown)
+}
+
+// DisplaySecretRequestForm handles GET requests for the page where a requester asks for a secret.
+// The form is submitted by the page's script to POST /api/v1/requests with a Turnstile token.
+func (h *MessageHandler) DisplaySecretRequestForm(c *gin.Context) {
+	data := gin.H{
+		"Title": "Request a Secret - Password Exchange",
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "request.html", data, nil)
+}
+
+// DisplayCombineShares handles GET requests for the page where custodians combine the shares of a split secret.
+// The shares are submitted by the page's script to POST /api/v1/shares/combine.
+func (h *MessageHandler) DisplayCombineShares(c *gin.Context) {
+	data := gin.H{
+		"Title": "Combine Secret Shares - Password Exchange",
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "combine.html", data, nil)
+}
+
+// DisplayFulfillRequest handles GET requests for the page where someone answers a secret request
+func (h *MessageHandler) DisplayFulfillRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.Param("uuid")
+
+	info, err := h.messageService.GetSecretRequest(ctx, requestID)
+	if err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to load secret request")
+		h.renderSecretRequestError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"Title":   "Send a Secret - Password Exchange",
+		"Request": info,
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "fulfill.html", data, nil)
+}
+
+// FulfillSecretRequest handles POST requests answering a secret request. The link to the secret
+// is emailed to the requester and never shown on this page.
+func (h *MessageHandler) FulfillSecretRequest(c *gin.Context) {
+	ctx := c.Request.Context()
+	requestID := c.Param("uuid")
+
+	logging.Debug().Str("requestId", requestID).Msg("Processing secret request fulfillment")
+
+	err := h.messageService.FulfillSecretRequest(ctx, domain.SecretRequestFulfillmentRequest{
+		RequestID:  requestID,
+		Content:    c.PostForm("content"),
+		SenderName: c.PostForm("sender_name"),
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to fulfill secret request")
+
+		if errors.Is(err, domain.ErrInvalidMessageRequest) {
+			info, getErr := h.messageService.GetSecretRequest(ctx, requestID)
+			if getErr != nil {
+				h.renderSecretRequestError(c, getErr)
+				return
+			}
+			data := gin.H{
+				"Title":   "Send a Secret - Password Exchange",
+				"Request": info,
+				"Error":   "Please enter the secret to send.",
+			}
+			h.renderHTMLOrMarkdown(c, http.StatusBadRequest, "fulfill.html", data, nil)
+			return
+		}
+
+		h.renderSecretRequestError(c, err)
+		return
+	}
+
+	data := gin.H{
+		"Title":     "Send a Secret - Password Exchange",
+		"Fulfilled": true,
+	}
+	h.renderHTMLOrMarkdown(c, http.StatusOK, "fulfill.html", data, nil)
+	logging.Info().Str("requestId", requestID).Msg("Secret request fulfilled successfully")
+}
+
+// renderSecretRequestError shows why a secret request cannot be answered
+func (h *MessageHandler) renderSecretRequestError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSecretRequestUnavailable):
+		data := gin.H{
+			"Title":       "Send a Secret - Password Exchange",
+			"Unavailable": true,
+		}
+		h.renderHTMLOrMarkdown(c, http.StatusGone, "fulfill.html", data, nil)
+	case errors.Is(err, domain.ErrSecretRequestNotFound):
+		h.render404(c)
+	default:
+		h.renderError(c, "Failed to send the secret", err)
+	}
+}
+
 // Static page handlers
 func (h *MessageHandler) Home(c *gin.Context) {
 	c.Writer.Header().Add("Link", `</.well-known/api-catalog>; rel="api-catalog"`)
@@ -368,6 +594,12 @@ func displayDecryptedMarkdown(data gin.H) string {
 	b.WriteString("with `Accept: text/markdown` and form field `passphrase` ")
 	b.WriteString("(may be empty if no passphrase was set).\n\n")
 	fmt.Fprintf(&b, "- requires_passphrase: %t\n", requires)
+	if sealed, _ := data["PublicKeyEncrypted"].(bool); sealed {
+		b.WriteString("- public_key_encrypted: true\n\n")
+		b.WriteString("This message is sealed to the recipient's age or OpenPGP public key. ")
+		b.WriteString("Add form field `private_key` (and `private_key_passphrase` for a protected OpenPGP key) ")
+		b.WriteString("to decrypt it, or omit it to receive the armored ciphertext.\n")
+	}
 	return b.String()
 }
 
@@ -375,15 +607,32 @@ func displayDecryptedMarkdown(data gin.H) string {
 // covering both the success path and the wrong-passphrase case.
 func decryptMessageMarkdown(data gin.H) string {
 	if wrong, _ := data["WrongPassphrase"].(bool); wrong {
+		if remaining, ok := data["RemainingAttempts"].(int); ok {
+			return fmt.Sprintf("# Decryption failed\n\nWrong passphrase. Please try again (may be empty). Attempts remaining: %d\n", remaining)
+		}
 		return "# Decryption failed\n\nWrong passphrase. Please try again (may be empty).\n"
 	}
+	if wrong, _ := data["WrongPrivateKey"].(bool); wrong {
+		return "# Decryption failed\n\nThe private key does not match this message, or its passphrase is wrong.\n"
+	}
 	msg, _ := data["DecryptedMessage"].(string)
 	if msg == "" {
-		return "# Decrypted message\n\n(empty)\n"
+		return "# Decrypted message\n\n(empty)\n" + attachmentMarkdown(data)
 	}
 	fence := pickFence(msg)
 	var b strings.Builder
-	b.WriteString("# Decrypted message\n\n")
+	if clientEncrypted, _ := data["ClientEncrypted"].(bool); clientEncrypted {
+		b.WriteString("# Encrypted message\n\n")
+		b.WriteString("This message was encrypted in the sender's browser. ")
+		b.WriteString("Decrypt it with AES-256-GCM using the base64url key from the link's `#` fragment; ")
+		b.WriteString("the first 12 bytes of the decoded content are the nonce.\n\n")
+	} else if sealed, _ := data["PublicKeyEncrypted"].(bool); sealed {
+		b.WriteString("# Encrypted message\n\n")
+		b.WriteString("This message is sealed to the recipient's public key. ")
+		b.WriteString("Decrypt it with `age -d -i <identity file>` or `gpg --decrypt`.\n\n")
+	} else {
+		b.WriteString("# Decrypted message\n\n")
+	}
 	b.WriteString(fence)
 	b.WriteByte('\n')
 	b.WriteString(msg)
@@ -396,6 +645,77 @@ func decryptMessageMarkdown(data gin.H) string {
 		mvc, _ := data["MaxViewCount"].(int)
 		fmt.Fprintf(&b, "\n- view_count: %d\n- max_view_count: %d\n", vc, mvc)
 	}
+	b.WriteString(attachmentMarkdown(data))
+	return b.String()
+}
+
+// revokeMarkdown emits markdown for the revocation page. The GET template
+// fills the token from the URL fragment with JavaScript, so agents are told
+// to POST it instead.
+func revokeMarkdown(data gin.H) string {
+	if revoked, _ := data["Revoked"].(bool); revoked {
+		return "# Message revoked\n\nThe message has been permanently deleted.\n"
+	}
+	var b strings.Builder
+	b.WriteString("# Revoke Message\n\n")
+	if msg, _ := data["Error"].(string); msg != "" {
+		b.WriteString(msg)
+		b.WriteString("\n\n")
+	}
+	b.WriteString("To delete the message, POST to this URL with `Accept: text/markdown` ")
+	b.WriteString("and form field `token` set to the revocation token from the `#` fragment of the revoke link.\n")
+	return b.String()
+}
+
+// statusMarkdown emits markdown for the status page. Like the revoke page, the
+// GET template reads the token from the URL fragment, so agents POST it instead.
+func statusMarkdown(data gin.H) string {
+	status, ok := data["Status"].(*domain.MessageStatusResponse)
+	if !ok || status == nil {
+		var b strings.Builder
+		b.WriteString("# Message Status\n\n")
+		if msg, _ := data["Error"].(string); msg != "" {
+			b.WriteString(msg)
+			b.WriteString("\n\n")
+		}
+		b.WriteString("To see the status, POST to this URL with `Accept: text/markdown` ")
+		b.WriteString("and form field `token` set to the token from the `#` fragment of the status link.\n")
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteString("# Message Status\n\n")
+	fmt.Fprintf(&b, "- view_count: %d\n- max_view_count: %d\n- remaining_views: %d\n",
+		status.ViewCount, status.MaxViewCount, status.RemainingViews)
+	if status.ExpiresAt != nil {
+		fmt.Fprintf(&b, "- expires_at: %s\n", status.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if status.HasPassphrase {
+		fmt.Fprintf(&b, "- failed_passphrase_attempts: %d\n", status.FailedPassphraseAttempts)
+	}
+	fmt.Fprintf(&b, "- read_receipts: %t\n", status.ReadReceipts)
+	if len(status.Reminders) > 0 {
+		b.WriteString("\n## Reminders\n\n")
+		for _, entry := range status.Reminders {
+			fmt.Fprintf(&b, "- %d sent, last at %s\n", entry.ReminderCount, entry.LastReminderSent.UTC().Format(time.RFC3339))
+		}
+	}
+	return b.String()
+}
+
+// attachmentMarkdown describes an attached file and how to download it; the
+// file itself is never inlined into the markdown.
+func attachmentMarkdown(data gin.H) string {
+	attachment, ok := data["Attachment"].(*domain.AttachmentInfo)
+	if !ok || attachment == nil {
+		return ""
+	}
+	messageID, _ := data["MessageID"].(string)
+	var b strings.Builder
+	b.WriteString("\n## Attachment\n\n")
+	fmt.Fprintf(&b, "- filename: %s\n- content_type: %s\n- size_bytes: %d\n", attachment.Filename, attachment.ContentType, attachment.Size)
+	fmt.Fprintf(&b, "\nDownload it with `POST /api/v1/messages/%s/attachment` and a JSON body of ", messageID)
+	b.WriteString("`decryptionKey` (the key from this link) and `passphrase`. Each download counts as a view.\n")
 	return b.String()
 }
 
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
 	"github.com/gin-gonic/gin"
@@ -50,6 +51,65 @@ func (m *MockMessageService) RetrieveMessage(
 	return args.Get(0).(*domain.MessageRetrievalResponse), args.Error(1)
 }
 
+func (m *MockMessageService) RetrieveAttachment(
+	ctx context.Context,
+	req domain.MessageRetrievalRequest,
+) (*domain.AttachmentDownloadResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AttachmentDownloadResponse), args.Error(1)
+}
+
+func (m *MockMessageService) RevokeMessage(ctx context.Context, req domain.MessageRevocationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) GetMessageStatus(
+	ctx context.Context,
+	req domain.MessageStatusRequest,
+) (*domain.MessageStatusResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MessageStatusResponse), args.Error(1)
+}
+
+func (m *MockMessageService) CreateSecretRequest(
+	ctx context.Context,
+	req domain.SecretRequestCreationRequest,
+) (*domain.SecretRequestCreationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SecretRequestCreationResponse), args.Error(1)
+}
+
+func (m *MockMessageService) GetSecretRequest(ctx context.Context, requestID string) (*domain.SecretRequestInfo, error) {
+	args := m.Called(ctx, requestID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.SecretRequestInfo), args.Error(1)
+}
+
+func (m *MockMessageService) FulfillSecretRequest(ctx context.Context, req domain.SecretRequestFulfillmentRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockMessageService) CombineShares(ctx context.Context, req domain.ShareCombinationRequest) (*domain.ShareCombinationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ShareCombinationResponse), args.Error(1)
+}
+
 func TestDisplayDecrypted_ShouldNotCallRetrieveMessage(t *testing.T) {
 	// This test verifies the fix: DisplayDecrypted should NOT call RetrieveMessage
 	// regardless of whether a passphrase is required or not
@@ -465,6 +525,19 @@ func TestDecryptMessageMarkdown_RendersFencedContent(t *testing.T) {
 	assert.Contains(t, body, "- max_view_count: 5")
 }
 
+func TestDecryptMessageMarkdown_ClientEncrypted(t *testing.T) {
+	body := decryptMessageMarkdown(gin.H{
+		"DecryptedMessage": "Y2lwaGVydGV4dA==",
+		"ViewCount":        1,
+		"MaxViewCount":     5,
+		"ClientEncrypted":  true,
+	})
+
+	assert.True(t, strings.HasPrefix(body, "# Encrypted message"))
+	assert.Contains(t, body, "AES-256-GCM")
+	assert.Contains(t, body, "```\nY2lwaGVydGV4dA==\n```")
+}
+
 func TestDecryptMessageMarkdown_WrongPassphrase(t *testing.T) {
 	body := decryptMessageMarkdown(gin.H{
 		"DecryptedMessage": wrongPassphraseMessage,
@@ -480,6 +553,23 @@ func TestDecryptMessageMarkdown_EmptyContent(t *testing.T) {
 	assert.Equal(t, "# Decrypted message\n\n(empty)\n", body)
 }
 
+func TestDecryptMessageMarkdown_DescribesAttachment(t *testing.T) {
+	body := decryptMessageMarkdown(gin.H{
+		"DecryptedMessage": "",
+		"MessageID":        "test-message-id",
+		"Attachment": &domain.AttachmentInfo{
+			Filename:    "id_ed25519",
+			ContentType: "application/octet-stream",
+			Size:        411,
+		},
+	})
+
+	assert.Contains(t, body, "## Attachment")
+	assert.Contains(t, body, "- filename: id_ed25519")
+	assert.Contains(t, body, "- size_bytes: 411")
+	assert.Contains(t, body, "POST /api/v1/messages/test-message-id/attachment")
+}
+
 func TestPickFence_AvoidsBacktickCollision(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -557,6 +647,33 @@ func TestDecryptMessage_MarkdownPathReturnsWrongPassphrase(t *testing.T) {
 	assert.True(t, strings.HasPrefix(w.Body.String(), "# Decryption failed"))
 }
 
+func TestDecryptMessage_MarkdownPathWithPrivateKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockMessageService)
+	handler := NewMessageHandler(mockService)
+
+	mockService.On("RetrieveMessage", mock.Anything, mock.MatchedBy(func(req domain.MessageRetrievalRequest) bool {
+		return req.PrivateKey == "AGE-SECRET-KEY-1TEST" && req.PrivateKeyPassphrase == ""
+	})).Return(nil, domain.ErrInvalidPrivateKey)
+
+	router := gin.New()
+	router.SetHTMLTemplate(createMockTemplate())
+	router.POST("/decrypt/:uuid/*key", handler.DecryptMessage)
+
+	w := httptest.NewRecorder()
+	form := url.Values{}
+	form.Set("private_key", "AGE-SECRET-KEY-1TEST")
+	req, _ := http.NewRequest("POST", "/decrypt/abc/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "text/markdown")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "# Decryption failed"))
+	assert.Contains(t, w.Body.String(), "private key")
+}
+
 func TestDisplayDecrypted_MarkdownPathReturnsInstructions(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockService := new(MockMessageService)
@@ -642,6 +759,132 @@ func TestMarkdownPath_VaryHeaderMergesWithUpstream(t *testing.T) {
 }
 
 // createMockTemplate creates a simple mock template for testing
+func TestRevokeMessage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success", wantStatus: http.StatusOK, wantBody: "Message Revoked"},
+		{name: "invalid token", serviceErr: domain.ErrInvalidRevocationToken, wantStatus: http.StatusForbidden, wantBody: "Invalid revocation token"},
+		{name: "not found", serviceErr: domain.ErrMessageNotFound, wantStatus: http.StatusNotFound, wantBody: "404 Not Found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			handler := NewMessageHandler(mockService)
+			mockService.On("RevokeMessage", mock.Anything, domain.MessageRevocationRequest{
+				MessageID:       "test-message-id",
+				RevocationToken: "token",
+			}).Return(tc.serviceErr)
+
+			router := gin.New()
+			router.SetHTMLTemplate(createMockTemplate())
+			router.POST("/revoke/:uuid", handler.RevokeMessage)
+
+			req, _ := http.NewRequest("POST", "/revoke/test-message-id", strings.NewReader("token=token"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.wantBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDisplayRevoke_MarkdownExplainsPost(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewMessageHandler(new(MockMessageService))
+
+	router := gin.New()
+	router.SetHTMLTemplate(createMockTemplate())
+	router.GET("/revoke/:uuid", handler.DisplayRevoke)
+
+	req, _ := http.NewRequest("GET", "/revoke/test-message-id", nil)
+	req.Header.Set("Accept", "text/markdown")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "form field `token`")
+}
+
+func TestMessageStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	status := &domain.MessageStatusResponse{MessageID: "test-message-id", ViewCount: 1, MaxViewCount: 3, RemainingViews: 2}
+
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "success", wantStatus: http.StatusOK, wantBody: "Viewed 1 of 3"},
+		{name: "invalid token", serviceErr: domain.ErrInvalidRevocationToken, wantStatus: http.StatusForbidden, wantBody: "Invalid token"},
+		{name: "not found", serviceErr: domain.ErrMessageNotFound, wantStatus: http.StatusNotFound, wantBody: "404 Not Found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := new(MockMessageService)
+			handler := NewMessageHandler(mockService)
+			statusReq := domain.MessageStatusRequest{MessageID: "test-message-id", RevocationToken: "token"}
+			if tc.serviceErr != nil {
+				mockService.On("GetMessageStatus", mock.Anything, statusReq).Return(nil, tc.serviceErr)
+			} else {
+				mockService.On("GetMessageStatus", mock.Anything, statusReq).Return(status, nil)
+			}
+
+			router := gin.New()
+			router.SetHTMLTemplate(createMockTemplate())
+			router.POST("/status/:uuid", handler.MessageStatus)
+
+			req, _ := http.NewRequest("POST", "/status/test-message-id", strings.NewReader("token=token"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tc.wantBody)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMessageStatus_Markdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockMessageService)
+	handler := NewMessageHandler(mockService)
+	mockService.On("GetMessageStatus", mock.Anything, mock.Anything).Return(&domain.MessageStatusResponse{
+		ViewCount:      1,
+		MaxViewCount:   3,
+		RemainingViews: 2,
+		Reminders: []domain.ReminderHistoryEntry{
+			{ReminderCount: 2, LastReminderSent: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		},
+	}, nil)
+
+	router := gin.New()
+	router.SetHTMLTemplate(createMockTemplate())
+	router.POST("/status/:uuid", handler.MessageStatus)
+
+	req, _ := http.NewRequest("POST", "/status/test-message-id", strings.NewReader("token=token"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "text/markdown")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "- remaining_views: 2")
+	assert.Contains(t, w.Body.String(), "- 2 sent, last at 2026-03-01T12:00:00Z")
+}
+
 func createMockTemplate() *template.Template {
 	tmpl := template.New("templates")
 	tmpl, _ = tmpl.New("decryption.html").
@@ -650,5 +893,9 @@ func createMockTemplate() *template.Template {
 	tmpl, _ = tmpl.New("home.html").
 		Parse(`<html><body><h1>{{.Title}}</h1><p>Share secrets securely.</p>{{range $key, $value := .Errors}}<div class="error">{{$key}}: {{$value}}</div>{{end}}</body></html>`)
 	tmpl, _ = tmpl.New("confirmation.html").Parse(`<html><body><h1>{{.Title}}</h1><p>URL: {{.Url}}</p><p>Save this link carefully.</p></body></html>`)
+	tmpl, _ = tmpl.New("revoke.html").
+		Parse(`<html><body><h1>{{.Title}}</h1>{{if .Revoked}}<p>Message Revoked</p>{{end}}{{if .Error}}<p>{{.Error}}</p>{{end}}</body></html>`)
+	tmpl, _ = tmpl.New("status.html").
+		Parse(`<html><body><h1>{{.Title}}</h1>{{with .Status}}<p>Viewed {{.ViewCount}} of {{.MaxViewCount}}</p>{{end}}{{if .Error}}<p>{{.Error}}</p>{{end}}</body></html>`)
 	return tmpl
 }
@@ -27,6 +27,7 @@ func NewWebServer(messageService primary.MessageServicePort) *WebServer {
 	apiServer := api.NewServer(messageService)
 
 	router := gin.Default()
+	router.Use(middleware.ClientInfo())
 
 	// Create template functions
 	funcMap := template.FuncMap{
@@ -67,6 +68,18 @@ func (s *WebServer) SetupRoutes() {
 	s.router.POST("/", s.messageHandler.SubmitMessage)
 	s.router.GET("/decrypt/:uuid/*key", s.messageHandler.DisplayDecrypted)
 	s.router.POST("/decrypt/:uuid/*key", s.messageHandler.DecryptMessage)
+	s.router.GET("/revoke/:uuid", s.messageHandler.DisplayRevoke)
+	s.router.POST("/revoke/:uuid", s.messageHandler.RevokeMessage)
+	s.router.GET("/status/:uuid", s.messageHandler.DisplayStatus)
+	s.router.POST("/status/:uuid", s.messageHandler.MessageStatus)
+
+	// Secret requests
+	s.router.GET("/request", s.messageHandler.DisplaySecretRequestForm)
+	s.router.GET("/request/:uuid", s.messageHandler.DisplayFulfillRequest)
+	s.router.POST("/request/:uuid", s.messageHandler.FulfillSecretRequest)
+
+	// Split secrets
+	s.router.GET("/combine", s.messageHandler.DisplayCombineShares)
 
 	// 404 handler
 	s.router.NoRoute(s.messageHandler.NotFound)
@@ -108,6 +121,17 @@ func (s *WebServer) setupAPIRoutes() {
 		v1.POST("/messages", apiHandler.SubmitMessage)
 		v1.GET("/messages/:id", apiHandler.GetMessageInfo)
 		v1.POST("/messages/:id/decrypt", apiHandler.DecryptMessage)
+		v1.POST("/messages/:id/attachment", apiHandler.DownloadAttachment)
+		v1.DELETE("/messages/:id", apiHandler.RevokeMessage)
+		v1.GET("/messages/:id/status", apiHandler.GetMessageStatus)
+
+		// Secret request endpoints
+		v1.POST("/requests", apiHandler.CreateSecretRequest)
+		v1.GET("/requests/:id", apiHandler.GetSecretRequest)
+		v1.POST("/requests/:id/fulfill", apiHandler.FulfillSecretRequest)
+
+		// Split secret endpoints
+		v1.POST("/shares/combine", apiHandler.CombineShares)
 
 		// Utility endpoints
 		v1.GET("/health", apiHandler.HealthCheck)
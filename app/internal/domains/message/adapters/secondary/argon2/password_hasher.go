@@ -0,0 +1,157 @@
+package argon2
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"golang.org/x/crypto/argon2"
+)
+
+// Defaults follow the OWASP minimum for Argon2id. The hash only gates access; when a message opts
+// into a passphrase-derived key, that derivation is separate and carries its own cost.
+const (
+	DefaultMemoryKiB   = 19 * 1024
+	DefaultIterations  = 2
+	DefaultParallelism = 1
+
+	saltLength = 16
+	keyLength  = 32
+	hashPrefix = "$argon2id$"
+)
+
+// ErrInvalidHash is returned when a hash is not a well-formed Argon2id PHC string
+var ErrInvalidHash = errors.New("invalid argon2id hash")
+
+// Params are the Argon2id cost parameters. Zero values fall back to the defaults.
+type Params struct {
+	MemoryKiB   uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// PasswordHasher implements the PasswordHasherPort using Argon2id. Unlike bcrypt it reads the
+// whole passphrase, so long passphrases are not truncated at 72 bytes.
+type PasswordHasher struct {
+	params Params
+}
+
+// NewPasswordHasher creates a new Argon2id password hasher
+func NewPasswordHasher(params Params) *PasswordHasher {
+	if params.MemoryKiB == 0 {
+		params.MemoryKiB = DefaultMemoryKiB
+	}
+	if params.Iterations == 0 {
+		params.Iterations = DefaultIterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = DefaultParallelism
+	}
+
+	return &PasswordHasher{
+		params: params,
+	}
+}
+
+// Hash creates a PHC-format hash of the provided password,
+// e.g. $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>
+func (h *PasswordHasher) Hash(ctx context.Context, password string) (string, error) {
+	if strings.TrimSpace(password) == "" {
+		return "", fmt.Errorf("password cannot be empty")
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		logging.Error().Err(err).Msg("Failed to generate password salt")
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.MemoryKiB, h.params.Parallelism, keyLength)
+
+	logging.Debug().Msg("Password hashed successfully")
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		hashPrefix,
+		argon2.Version,
+		h.params.MemoryKiB,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks if a password matches the provided hash, using the parameters stored in it
+func (h *PasswordHasher) Verify(ctx context.Context, password, hash string) (bool, error) {
+	// If hash is empty, consider it as no password required
+	if strings.TrimSpace(hash) == "" {
+		logging.Debug().Msg("No password hash provided, allowing access")
+		return true, nil
+	}
+
+	params, salt, key, err := decodeHash(hash)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to verify password")
+		return false, fmt.Errorf("failed to verify password: %w", err)
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, params.Iterations, params.MemoryKiB, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		logging.Debug().Msg("Password verification failed - mismatch")
+		return false, nil
+	}
+
+	logging.Debug().Msg("Password verified successfully")
+	return true, nil
+}
+
+// NeedsRehash reports whether a hash is not Argon2id or was made with different parameters
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	params, _, _, err := decodeHash(hash)
+	return err != nil || params != h.params
+}
+
+// Recognizes reports whether the hash is in Argon2id PHC format
+func (h *PasswordHasher) Recognizes(hash string) bool {
+	return strings.HasPrefix(hash, hashPrefix)
+}
+
+// decodeHash splits a PHC string into its parameters, salt and key
+func decodeHash(hash string) (Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Params{}, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	if version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidHash, version)
+	}
+
+	var params Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.MemoryKiB, &params.Iterations, &params.Parallelism); err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: %v", ErrInvalidHash, err)
+	}
+	if params.MemoryKiB == 0 || params.Iterations == 0 || params.Parallelism == 0 {
+		return Params{}, nil, nil, fmt.Errorf("%w: parameters must be positive", ErrInvalidHash)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Params{}, nil, nil, fmt.Errorf("%w: salt: %v", ErrInvalidHash, err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, fmt.Errorf("%w: key: %v", ErrInvalidHash, err)
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,132 @@
+package argon2
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Cheap parameters keep the tests fast; production uses the defaults
+var testParams = Params{MemoryKiB: 64, Iterations: 1, Parallelism: 1}
+
+func TestPasswordHasher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	hasher := NewPasswordHasher(testParams)
+
+	hash, err := hasher.Hash(ctx, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	assert.True(t, hasher.Recognizes(hash))
+
+	valid, err := hasher.Verify(ctx, "correct horse battery staple", hash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// Each hash gets its own salt
+	again, err := hasher.Hash(ctx, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, again)
+}
+
+func TestPasswordHasher_RejectsWrongPassword(t *testing.T) {
+	ctx := context.Background()
+	hasher := NewPasswordHasher(testParams)
+
+	hash, err := hasher.Hash(ctx, "correct horse battery staple")
+	require.NoError(t, err)
+
+	for _, password := range []string{"wrong", "correct horse battery stapl", "correct horse battery staple "} {
+		valid, err := hasher.Verify(ctx, password, hash)
+		require.NoError(t, err)
+		assert.False(t, valid, password)
+	}
+}
+
+func TestPasswordHasher_VerifiesWithStoredParameters(t *testing.T) {
+	ctx := context.Background()
+	hash, err := NewPasswordHasher(Params{MemoryKiB: 128, Iterations: 2, Parallelism: 2}).Hash(ctx, "passphrase")
+	require.NoError(t, err)
+
+	// A hasher configured differently still verifies older hashes with the cost they were made with
+	valid, err := NewPasswordHasher(testParams).Verify(ctx, "passphrase", hash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPasswordHasher_EmptyInput(t *testing.T) {
+	ctx := context.Background()
+	hasher := NewPasswordHasher(testParams)
+
+	_, err := hasher.Hash(ctx, "   ")
+	assert.Error(t, err)
+
+	// Messages without a passphrase have no hash and need none
+	valid, err := hasher.Verify(ctx, "anything", "")
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPasswordHasher_MalformedHash(t *testing.T) {
+	const salt = "c29tZXNhbHRzb21lc2FsdA"
+	const key = "a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2U"
+
+	tests := []struct {
+		name string
+		hash string
+	}{
+		{"bcrypt", "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"},
+		{"wrong algorithm", "$argon2i$v=19$m=64,t=1,p=1$" + salt + "$" + key},
+		{"missing key", "$argon2id$v=19$m=64,t=1,p=1$" + salt},
+		{"extra field", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$" + key + "$x"},
+		{"unreadable version", "$argon2id$version$m=64,t=1,p=1$" + salt + "$" + key},
+		{"unsupported version", "$argon2id$v=16$m=64,t=1,p=1$" + salt + "$" + key},
+		{"unreadable parameters", "$argon2id$v=19$m=a,t=1,p=1$" + salt + "$" + key},
+		{"zero memory", "$argon2id$v=19$m=0,t=1,p=1$" + salt + "$" + key},
+		{"zero iterations", "$argon2id$v=19$m=64,t=0,p=1$" + salt + "$" + key},
+		{"zero parallelism", "$argon2id$v=19$m=64,t=1,p=0$" + salt + "$" + key},
+		{"salt not base64", "$argon2id$v=19$m=64,t=1,p=1$!!!$" + key},
+		{"key not base64", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$!!!"},
+		{"empty key", "$argon2id$v=19$m=64,t=1,p=1$" + salt + "$"},
+	}
+
+	hasher := NewPasswordHasher(testParams)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, err := hasher.Verify(context.Background(), "passphrase", tt.hash)
+			assert.ErrorIs(t, err, ErrInvalidHash)
+			assert.False(t, valid)
+			assert.True(t, hasher.NeedsRehash(tt.hash))
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	hash, err := NewPasswordHasher(testParams).Hash(context.Background(), "passphrase")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		params Params
+		want   bool
+	}{
+		{"same parameters", testParams, false},
+		{"more memory", Params{MemoryKiB: 128, Iterations: 1, Parallelism: 1}, true},
+		{"more iterations", Params{MemoryKiB: 64, Iterations: 2, Parallelism: 1}, true},
+		{"more parallelism", Params{MemoryKiB: 64, Iterations: 1, Parallelism: 2}, true},
+		{"defaults", Params{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewPasswordHasher(tt.params).NeedsRehash(hash))
+		})
+	}
+}
+
+func TestNewPasswordHasher_Defaults(t *testing.T) {
+	hasher := NewPasswordHasher(Params{Iterations: 3})
+	assert.Equal(t, Params{MemoryKiB: DefaultMemoryKiB, Iterations: 3, Parallelism: DefaultParallelism}, hasher.params)
+}
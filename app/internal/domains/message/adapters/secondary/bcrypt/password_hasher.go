@@ -63,3 +63,15 @@ func (h *PasswordHasher) Verify(ctx context.Context, password, hash string) (boo
 	logging.Debug().Msg("Password verified successfully")
 	return true, nil
 }
+
+// NeedsRehash reports whether a hash is not bcrypt or was made with a different cost
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != h.cost
+}
+
+// Recognizes reports whether the hash is a bcrypt hash
+func (h *PasswordHasher) Recognizes(hash string) bool {
+	_, err := bcrypt.Cost([]byte(hash))
+	return err == nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/encryption"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // EncryptionClient implements the EncryptionServicePort using gRPC
@@ -17,7 +20,14 @@ type EncryptionClient struct {
 
 // NewEncryptionClient creates a new encryption gRPC client
 func NewEncryptionClient(endpoint string) (*EncryptionClient, error) {
-	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	conn, err := grpc.Dial(
+		endpoint,
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxCallMessageBytes),
+			grpc.MaxCallSendMsgSize(maxCallMessageBytes),
+		),
+	)
 	if err != nil {
 		logging.Error().Err(err).Str("endpoint", endpoint).Msg("Failed to connect to encryption service")
 		return nil, fmt.Errorf("failed to connect to encryption service: %w", err)
@@ -47,11 +57,12 @@ func (c *EncryptionClient) GenerateKey(ctx context.Context, length int32) ([]byt
 	return resp.GetEncryptionBytes(), nil
 }
 
-// Encrypt encrypts plaintext using the provided key
-func (c *EncryptionClient) Encrypt(ctx context.Context, plaintext []string, key []byte) ([]string, error) {
+// Encrypt encrypts plaintext using the provided key, binding each ciphertext to associatedData
+func (c *EncryptionClient) Encrypt(ctx context.Context, plaintext []string, key []byte, associatedData []byte) ([]string, error) {
 	req := &pb.EncryptedMessageRequest{
-		PlainText: plaintext,
-		Key:       key,
+		PlainText:      plaintext,
+		Key:            key,
+		AssociatedData: associatedData,
 	}
 
 	resp, err := c.client.EncryptMessage(ctx, req)
@@ -67,11 +78,12 @@ func (c *EncryptionClient) Encrypt(ctx context.Context, plaintext []string, key
 	return resp.GetCiphertext(), nil
 }
 
-// Decrypt decrypts ciphertext using the provided key
-func (c *EncryptionClient) Decrypt(ctx context.Context, ciphertext []string, key []byte) ([]string, error) {
+// Decrypt decrypts ciphertext using the provided key and the associatedData it was sealed with
+func (c *EncryptionClient) Decrypt(ctx context.Context, ciphertext []string, key []byte, associatedData []byte) ([]string, error) {
 	req := &pb.DecryptedMessageRequest{
-		Ciphertext: ciphertext,
-		Key:        key,
+		Ciphertext:     ciphertext,
+		Key:            key,
+		AssociatedData: associatedData,
 	}
 
 	resp, err := c.client.DecryptMessage(ctx, req)
@@ -87,6 +99,142 @@ func (c *EncryptionClient) Decrypt(ctx context.Context, ciphertext []string, key
 	return resp.GetPlaintext(), nil
 }
 
+// EncryptFile encrypts a file into AES-GCM chunks bound to associatedData
+func (c *EncryptionClient) EncryptFile(ctx context.Context, data []byte, key []byte, associatedData []byte) ([][]byte, error) {
+	req := &pb.EncryptFileRequest{
+		Plaintext:      data,
+		Key:            key,
+		AssociatedData: associatedData,
+	}
+
+	resp, err := c.client.EncryptFile(ctx, req)
+	if err != nil {
+		logging.Error().Err(err).Int("plaintextBytes", len(data)).Msg("Failed to encrypt file")
+		return nil, fmt.Errorf("failed to encrypt file: %w", err)
+	}
+
+	logging.Debug().
+		Int("plaintextBytes", len(data)).
+		Int("chunkCount", len(resp.GetChunks())).
+		Msg("Encrypted file successfully")
+	return resp.GetChunks(), nil
+}
+
+// DecryptFile decrypts and reassembles chunks produced by EncryptFile
+func (c *EncryptionClient) DecryptFile(ctx context.Context, chunks [][]byte, key []byte, associatedData []byte) ([]byte, error) {
+	req := &pb.DecryptFileRequest{
+		Chunks:         chunks,
+		Key:            key,
+		AssociatedData: associatedData,
+	}
+
+	resp, err := c.client.DecryptFile(ctx, req)
+	if err != nil {
+		logging.Error().Err(err).Int("chunkCount", len(chunks)).Msg("Failed to decrypt file")
+		return nil, fmt.Errorf("failed to decrypt file: %w", err)
+	}
+
+	logging.Debug().
+		Int("chunkCount", len(chunks)).
+		Int("plaintextBytes", len(resp.GetPlaintext())).
+		Msg("Decrypted file successfully")
+	return resp.GetPlaintext(), nil
+}
+
+// EncryptToPublicKey seals plaintext to an age or OpenPGP public key and returns it armored
+func (c *EncryptionClient) EncryptToPublicKey(ctx context.Context, plaintext []byte, publicKey string) (string, error) {
+	req := &pb.PublicKeyEncryptRequest{
+		Plaintext: plaintext,
+		PublicKey: publicKey,
+	}
+
+	resp, err := c.client.EncryptToPublicKey(ctx, req)
+	if err != nil {
+		logging.Error().Err(err).Int("plaintextBytes", len(plaintext)).Msg("Failed to encrypt to public key")
+		if status.Code(err) == codes.InvalidArgument {
+			return "", fmt.Errorf("%w: %v", domain.ErrInvalidPublicKey, err)
+		}
+		return "", fmt.Errorf("failed to encrypt to public key: %w", err)
+	}
+
+	logging.Debug().Str("keyType", resp.GetKeyType()).Msg("Encrypted to public key successfully")
+	return resp.GetCiphertext(), nil
+}
+
+// DecryptWithPrivateKey opens an armored ciphertext produced by EncryptToPublicKey
+func (c *EncryptionClient) DecryptWithPrivateKey(ctx context.Context, ciphertext string, privateKey string, passphrase string) ([]byte, error) {
+	req := &pb.PublicKeyDecryptRequest{
+		Ciphertext: ciphertext,
+		PrivateKey: privateKey,
+		Passphrase: passphrase,
+	}
+
+	resp, err := c.client.DecryptWithPrivateKey(ctx, req)
+	if err != nil {
+		logging.Error().Err(err).Int("ciphertextBytes", len(ciphertext)).Msg("Failed to decrypt with private key")
+		if status.Code(err) == codes.PermissionDenied {
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidPrivateKey, err)
+		}
+		return nil, fmt.Errorf("failed to decrypt with private key: %w", err)
+	}
+
+	logging.Debug().Int("plaintextBytes", len(resp.GetPlaintext())).Msg("Decrypted with private key successfully")
+	return resp.GetPlaintext(), nil
+}
+
+// SplitSecret splits a secret into Shamir shares, any threshold of which reconstruct it
+func (c *EncryptionClient) SplitSecret(ctx context.Context, secret []byte, shares int, threshold int) ([]string, error) {
+	req := &pb.SplitSecretRequest{
+		Secret:    secret,
+		Shares:    int32(shares),
+		Threshold: int32(threshold),
+	}
+
+	resp, err := c.client.SplitSecret(ctx, req)
+	if err != nil {
+		logging.Error().Err(err).Int("shares", shares).Int("threshold", threshold).Msg("Failed to split secret")
+		return nil, fmt.Errorf("failed to split secret: %w", err)
+	}
+
+	logging.Debug().Int("shares", len(resp.GetShares())).Msg("Split secret successfully")
+	return resp.GetShares(), nil
+}
+
+// CombineShares reconstructs a secret from shares produced by SplitSecret
+func (c *EncryptionClient) CombineShares(ctx context.Context, shares []string) ([]byte, error) {
+	resp, err := c.client.CombineShares(ctx, &pb.CombineSharesRequest{Shares: shares})
+	if err != nil {
+		logging.Error().Err(err).Int("shares", len(shares)).Msg("Failed to combine secret shares")
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			return nil, fmt.Errorf("%w: %v", domain.ErrInvalidShare, status.Convert(err).Message())
+		case codes.FailedPrecondition:
+			return nil, fmt.Errorf("%w: %v", domain.ErrInsufficientShares, status.Convert(err).Message())
+		}
+		return nil, fmt.Errorf("failed to combine secret shares: %w", err)
+	}
+
+	logging.Debug().Msg("Combined secret shares successfully")
+	return resp.GetSecret(), nil
+}
+
+// DerivePassphraseKey mixes the passphrase into key and returns the content key and the salt it
+// used. An empty salt generates a new one.
+func (c *EncryptionClient) DerivePassphraseKey(ctx context.Context, key []byte, passphrase string, salt []byte) ([]byte, []byte, error) {
+	resp, err := c.client.DerivePassphraseKey(ctx, &pb.PassphraseKeyRequest{
+		Key:        key,
+		Passphrase: passphrase,
+		Salt:       salt,
+	})
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to derive passphrase key")
+		return nil, nil, fmt.Errorf("failed to derive passphrase key: %w", err)
+	}
+
+	logging.Debug().Msg("Derived passphrase key successfully")
+	return resp.GetKey(), resp.GetSalt(), nil
+}
+
 // GenerateID generates a unique identifier
 func (c *EncryptionClient) GenerateID(ctx context.Context) (string, error) {
 	// For now, we'll use the encryption service to generate a key and use it as an ID
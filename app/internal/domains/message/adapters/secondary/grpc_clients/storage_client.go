@@ -5,10 +5,25 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/queue"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	db "github.com/Anthony-Bible/password-exchange/app/pkg/pb/database"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxCallMessageBytes raises gRPC's 4 MiB default so whole encrypted attachments
+// fit in a single call to the storage and encryption services.
+const maxCallMessageBytes = 16 << 20
+
+// Metadata keys carrying the client a call is made for, recorded in the storage service's
+// audit log. Must match the storage gRPC server's keys.
+const (
+	clientIPMetadataKey        = "x-client-ip"
+	clientUserAgentMetadataKey = "x-client-user-agent"
 )
 
 // StorageClient implements the StorageServicePort using gRPC
@@ -19,7 +34,15 @@ type StorageClient struct {
 
 // NewStorageClient creates a new storage gRPC client
 func NewStorageClient(endpoint string) (*StorageClient, error) {
-	conn, err := grpc.Dial(endpoint, grpc.WithInsecure())
+	conn, err := grpc.Dial(
+		endpoint,
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxCallMessageBytes),
+			grpc.MaxCallSendMsgSize(maxCallMessageBytes),
+		),
+		grpc.WithUnaryInterceptor(clientInfoInterceptor),
+	)
 	if err != nil {
 		logging.Error().Err(err).Str("endpoint", endpoint).Msg("Failed to connect to storage service")
 		return nil, fmt.Errorf("failed to connect to storage service: %w", err)
@@ -33,18 +56,53 @@ func NewStorageClient(endpoint string) (*StorageClient, error) {
 	}, nil
 }
 
+// clientInfoInterceptor forwards the client in the call's context as gRPC metadata
+func clientInfoInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	info := domain.ClientInfoFromContext(ctx)
+	if info.IP != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, clientIPMetadataKey, info.IP)
+	}
+	if info.UserAgent != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, clientUserAgentMetadataKey, info.UserAgent)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
 // StoreMessage stores an encrypted message
 func (c *StorageClient) StoreMessage(ctx context.Context, req domain.MessageStorageRequest) error {
 	grpcReq := &db.InsertRequest{
-		Uuid:           req.MessageID,
-		Content:        req.Content,
-		Passphrase:     req.Passphrase,
-		MaxViewCount:   int32(req.MaxViewCount),
-		RecipientEmail: req.RecipientEmail,
+		Uuid:                req.MessageID,
+		Content:             req.Content,
+		Passphrase:          req.Passphrase,
+		MaxViewCount:        int32(req.MaxViewCount),
+		RecipientEmail:      req.RecipientEmail,
+		ClientEncrypted:     req.ClientEncrypted,
+		Attachment:          toPBAttachment(req.Attachment),
+		RevocationTokenHash: req.RevocationTokenHash,
+		SenderEmail:         req.SenderEmail,
+		WebhookUrl:          req.WebhookURL,
+		WebhookSecret:       req.WebhookSecret,
+		PublicKeyEncrypted:  req.PublicKeyEncrypted,
+		PassphraseKeySalt:   req.PassphraseKeySalt,
 	}
 	if req.ExpiresAt != nil {
 		grpcReq.ExpiresAt = req.ExpiresAt.UTC().Format(time.RFC3339)
 	}
+	if req.Notification != nil {
+		notification, err := queue.EncodeMessageNotification(*req.Notification)
+		if err != nil {
+			logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to encode notification")
+			return fmt.Errorf("failed to store message: %w", err)
+		}
+		grpcReq.Notification = notification
+	}
 
 	_, err := c.client.Insert(ctx, grpcReq)
 	if err != nil {
@@ -56,6 +114,35 @@ func (c *StorageClient) StoreMessage(ctx context.Context, req domain.MessageStor
 	return nil
 }
 
+// toPBAttachment converts a stored attachment into its protobuf form, returning nil when absent.
+func toPBAttachment(a *domain.StoredAttachment) *db.Attachment {
+	if a == nil {
+		return nil
+	}
+	return &db.Attachment{
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		Chunks:      a.Chunks,
+	}
+}
+
+// fromPBAttachment converts a protobuf attachment into a stored attachment, returning nil when absent.
+func fromPBAttachment(a *db.Attachment) *domain.StoredAttachment {
+	if a == nil {
+		return nil
+	}
+	return &domain.StoredAttachment{
+		Filename:    a.GetFilename(),
+		ContentType: a.GetContentType(),
+		SizeBytes:   a.GetSizeBytes(),
+		Chunks:      a.GetChunks(),
+	}
+}
+
+// reminderTimeLayout is the format the storage service uses for last_reminder_sent
+const reminderTimeLayout = "2006-01-02 15:04:05"
+
 // parseExpiresAt parses an RFC3339 timestamp string into a *time.Time, returning nil on empty or error.
 func parseExpiresAt(s string) *time.Time {
 	if s == "" {
@@ -90,13 +177,23 @@ func (c *StorageClient) RetrieveMessage(
 	hasPassphrase := resp.GetPassphrase() != ""
 
 	response := &domain.MessageStorageResponse{
-		MessageID:        req.MessageID,
-		EncryptedContent: resp.GetContent(),
-		HashedPassphrase: resp.GetPassphrase(),
-		HasPassphrase:    hasPassphrase,
-		ViewCount:        int(resp.GetViewCount()),
-		MaxViewCount:     int(resp.GetMaxViewCount()),
-		ExpiresAt:        parseExpiresAt(resp.GetExpiresAt()),
+		MessageID:                req.MessageID,
+		EncryptedContent:         resp.GetContent(),
+		HashedPassphrase:         resp.GetPassphrase(),
+		HasPassphrase:            hasPassphrase,
+		ViewCount:                int(resp.GetViewCount()),
+		MaxViewCount:             int(resp.GetMaxViewCount()),
+		ExpiresAt:                parseExpiresAt(resp.GetExpiresAt()),
+		ClientEncrypted:          resp.GetClientEncrypted(),
+		Attachment:               fromPBAttachment(resp.GetAttachment()),
+		RevocationTokenHash:      resp.GetRevocationTokenHash(),
+		SenderEmail:              resp.GetSenderEmail(),
+		StorageID:                resp.GetId(),
+		WebhookURL:               resp.GetWebhookUrl(),
+		WebhookSecret:            resp.GetWebhookSecret(),
+		PublicKeyEncrypted:       resp.GetPublicKeyEncrypted(),
+		PassphraseKeySalt:        resp.GetPassphraseKeySalt(),
+		FailedPassphraseAttempts: int(resp.GetFailedPassphraseAttempts()),
 	}
 
 	logging.Debug().
@@ -127,13 +224,23 @@ func (c *StorageClient) GetMessage(
 	hasPassphrase := resp.GetPassphrase() != ""
 
 	response := &domain.MessageStorageResponse{
-		MessageID:        req.MessageID,
-		EncryptedContent: resp.GetContent(),
-		HashedPassphrase: resp.GetPassphrase(),
-		HasPassphrase:    hasPassphrase,
-		ViewCount:        int(resp.GetViewCount()),
-		MaxViewCount:     int(resp.GetMaxViewCount()),
-		ExpiresAt:        parseExpiresAt(resp.GetExpiresAt()),
+		MessageID:                req.MessageID,
+		EncryptedContent:         resp.GetContent(),
+		HashedPassphrase:         resp.GetPassphrase(),
+		HasPassphrase:            hasPassphrase,
+		ViewCount:                int(resp.GetViewCount()),
+		MaxViewCount:             int(resp.GetMaxViewCount()),
+		ExpiresAt:                parseExpiresAt(resp.GetExpiresAt()),
+		ClientEncrypted:          resp.GetClientEncrypted(),
+		Attachment:               fromPBAttachment(resp.GetAttachment()),
+		RevocationTokenHash:      resp.GetRevocationTokenHash(),
+		SenderEmail:              resp.GetSenderEmail(),
+		StorageID:                resp.GetId(),
+		WebhookURL:               resp.GetWebhookUrl(),
+		WebhookSecret:            resp.GetWebhookSecret(),
+		PublicKeyEncrypted:       resp.GetPublicKeyEncrypted(),
+		PassphraseKeySalt:        resp.GetPassphraseKeySalt(),
+		FailedPassphraseAttempts: int(resp.GetFailedPassphraseAttempts()),
 	}
 
 	logging.Debug().
@@ -143,6 +250,218 @@ func (c *StorageClient) GetMessage(
 	return response, nil
 }
 
+// GetAttachment retrieves a message's encrypted attachment without incrementing view count
+func (c *StorageClient) GetAttachment(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (*domain.StoredAttachment, error) {
+	grpcReq := &db.SelectRequest{
+		Uuid: req.MessageID,
+	}
+
+	resp, err := c.client.GetAttachment(ctx, grpcReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to retrieve attachment")
+		return nil, fmt.Errorf("failed to retrieve attachment: %w", err)
+	}
+
+	logging.Debug().
+		Str("messageId", req.MessageID).
+		Int("chunkCount", len(resp.GetChunks())).
+		Msg("Retrieved attachment successfully")
+	return fromPBAttachment(resp), nil
+}
+
+// DeleteMessage permanently removes a message
+func (c *StorageClient) DeleteMessage(ctx context.Context, req domain.MessageRetrievalStorageRequest) error {
+	grpcReq := &db.SelectRequest{
+		Uuid: req.MessageID,
+	}
+
+	if _, err := c.client.DeleteMessage(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to delete message")
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Deleted message successfully")
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (c *StorageClient) ReservePassphraseAttempt(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (int, error) {
+	grpcReq := &db.SelectRequest{
+		Uuid: req.MessageID,
+	}
+
+	resp, err := c.client.ReservePassphraseAttempt(ctx, grpcReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to record failed passphrase attempt")
+		return 0, fmt.Errorf("failed to record failed passphrase attempt: %w", err)
+	}
+
+	logging.Debug().
+		Str("messageId", req.MessageID).
+		Int32("failedAttempts", resp.GetFailedAttempts()).
+		Msg("Recorded failed passphrase attempt successfully")
+	return int(resp.GetFailedAttempts()), nil
+}
+
+// ReleasePassphraseAttempt takes back a reserved attempt once the passphrase turned out right
+func (c *StorageClient) ReleasePassphraseAttempt(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) error {
+	grpcReq := &db.SelectRequest{
+		Uuid: req.MessageID,
+	}
+
+	if _, err := c.client.ReleasePassphraseAttempt(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to release passphrase attempt")
+		return fmt.Errorf("failed to release passphrase attempt: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Released passphrase attempt successfully")
+	return nil
+}
+
+// RecordPassphraseFailure audits a wrong passphrase whose attempt was already reserved
+func (c *StorageClient) RecordPassphraseFailure(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) error {
+	grpcReq := &db.SelectRequest{
+		Uuid: req.MessageID,
+	}
+
+	if _, err := c.client.RecordPassphraseFailure(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to record passphrase failure")
+		return fmt.Errorf("failed to record passphrase failure: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Recorded passphrase failure successfully")
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash
+func (c *StorageClient) UpdatePassphraseHash(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+	hashedPassphrase string,
+) error {
+	grpcReq := &db.UpdatePassphraseHashRequest{
+		Uuid:       req.MessageID,
+		Passphrase: hashedPassphrase,
+	}
+
+	if _, err := c.client.UpdatePassphraseHash(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to update passphrase hash")
+		return fmt.Errorf("failed to update passphrase hash: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Updated passphrase hash successfully")
+	return nil
+}
+
+// GetReminderHistory retrieves the reminders sent for a message by its storage row ID
+func (c *StorageClient) GetReminderHistory(ctx context.Context, storageID int64) ([]domain.ReminderHistoryEntry, error) {
+	grpcReq := &db.GetReminderHistoryRequest{
+		MessageId: int32(storageID),
+	}
+
+	resp, err := c.client.GetReminderHistory(ctx, grpcReq)
+	if err != nil {
+		logging.Error().Err(err).Int64("storageId", storageID).Msg("Failed to retrieve reminder history")
+		return nil, fmt.Errorf("failed to retrieve reminder history: %w", err)
+	}
+
+	entries := make([]domain.ReminderHistoryEntry, 0, len(resp.GetEntries()))
+	for _, entry := range resp.GetEntries() {
+		lastSent, err := time.Parse(reminderTimeLayout, entry.GetLastReminderSent())
+		if err != nil {
+			logging.Warn().
+				Err(err).
+				Str("value", entry.GetLastReminderSent()).
+				Msg("Failed to parse last_reminder_sent timestamp from storage response")
+		}
+		entries = append(entries, domain.ReminderHistoryEntry{
+			ReminderCount:    int(entry.GetReminderCount()),
+			LastReminderSent: lastSent,
+		})
+	}
+
+	logging.Debug().Int64("storageId", storageID).Int("count", len(entries)).Msg("Retrieved reminder history successfully")
+	return entries, nil
+}
+
+// StoreSecretRequest stores a request for someone to send the requester a secret
+func (c *StorageClient) StoreSecretRequest(ctx context.Context, req domain.SecretRequestStorageRequest) error {
+	grpcReq := &db.SecretRequest{
+		Uuid:           req.RequestID,
+		RequesterName:  req.RequesterName,
+		RequesterEmail: req.RequesterEmail,
+		Description:    req.Description,
+	}
+	if req.ExpiresAt != nil {
+		grpcReq.ExpiresAt = req.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	if _, err := c.client.InsertSecretRequest(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("requestId", req.RequestID).Msg("Failed to store secret request")
+		return fmt.Errorf("failed to store secret request: %w", err)
+	}
+
+	logging.Debug().Str("requestId", req.RequestID).Msg("Stored secret request successfully")
+	return nil
+}
+
+// GetSecretRequest retrieves a secret request by ID, whether or not it is still open
+func (c *StorageClient) GetSecretRequest(ctx context.Context, requestID string) (*domain.StoredSecretRequest, error) {
+	resp, err := c.client.GetSecretRequest(ctx, &db.SelectRequest{Uuid: requestID})
+	if err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to retrieve secret request")
+		if status.Code(err) == codes.NotFound {
+			return nil, fmt.Errorf("%w: %v", domain.ErrSecretRequestNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve secret request: %w", err)
+	}
+
+	stored := &domain.StoredSecretRequest{
+		RequestID:      resp.GetUuid(),
+		RequesterName:  resp.GetRequesterName(),
+		RequesterEmail: resp.GetRequesterEmail(),
+		Description:    resp.GetDescription(),
+		ExpiresAt:      parseExpiresAt(resp.GetExpiresAt()),
+		FulfilledAt:    parseExpiresAt(resp.GetFulfilledAt()),
+		MessageID:      resp.GetMessageUuid(),
+	}
+	if createdAt := parseExpiresAt(resp.GetCreatedAt()); createdAt != nil {
+		stored.CreatedAt = *createdAt
+	}
+	return stored, nil
+}
+
+// FulfillSecretRequest marks an open secret request as answered by the given message
+func (c *StorageClient) FulfillSecretRequest(ctx context.Context, requestID string, messageID string) error {
+	grpcReq := &db.FulfillSecretRequestRequest{
+		Uuid:        requestID,
+		MessageUuid: messageID,
+	}
+
+	if _, err := c.client.FulfillSecretRequest(ctx, grpcReq); err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to fulfill secret request")
+		if status.Code(err) == codes.FailedPrecondition {
+			return fmt.Errorf("%w: %v", domain.ErrSecretRequestUnavailable, err)
+		}
+		return fmt.Errorf("failed to fulfill secret request: %w", err)
+	}
+
+	logging.Debug().Str("requestId", requestID).Str("messageId", messageID).Msg("Fulfilled secret request successfully")
+	return nil
+}
+
 // Close closes the gRPC connection
 func (c *StorageClient) Close() error {
 	if c.conn != nil {
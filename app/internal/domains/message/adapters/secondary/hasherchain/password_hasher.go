@@ -0,0 +1,73 @@
+package hasherchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// ErrUnrecognizedHash is returned when no hasher in the chain understands a stored hash
+var ErrUnrecognizedHash = errors.New("unrecognized password hash format")
+
+// Hasher is a password hasher that can tell which stored hashes it produced
+type Hasher interface {
+	Hash(ctx context.Context, password string) (string, error)
+	Verify(ctx context.Context, password, hash string) (bool, error)
+	NeedsRehash(hash string) bool
+	Recognizes(hash string) bool
+}
+
+// PasswordHasher implements the PasswordHasherPort by hashing with one algorithm while still
+// verifying hashes made by older ones, so the algorithm can change without breaking live messages
+type PasswordHasher struct {
+	current Hasher
+	legacy  []Hasher
+}
+
+// NewPasswordHasher creates a chain that hashes with current and verifies with current or legacy
+func NewPasswordHasher(current Hasher, legacy ...Hasher) *PasswordHasher {
+	return &PasswordHasher{
+		current: current,
+		legacy:  legacy,
+	}
+}
+
+// Hash creates a hash of the provided password with the current hasher
+func (h *PasswordHasher) Hash(ctx context.Context, password string) (string, error) {
+	return h.current.Hash(ctx, password)
+}
+
+// Verify checks a password with whichever hasher produced the hash
+func (h *PasswordHasher) Verify(ctx context.Context, password, hash string) (bool, error) {
+	// If hash is empty, consider it as no password required
+	if strings.TrimSpace(hash) == "" {
+		logging.Debug().Msg("No password hash provided, allowing access")
+		return true, nil
+	}
+
+	if h.current.Recognizes(hash) {
+		return h.current.Verify(ctx, password, hash)
+	}
+	for _, legacy := range h.legacy {
+		if legacy.Recognizes(hash) {
+			return legacy.Verify(ctx, password, hash)
+		}
+	}
+
+	logging.Error().Msg("Failed to verify password - unrecognized hash format")
+	return false, fmt.Errorf("failed to verify password: %w", ErrUnrecognizedHash)
+}
+
+// NeedsRehash reports whether a hash was made by a legacy hasher or with outdated parameters
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	if strings.TrimSpace(hash) == "" {
+		return false
+	}
+	if h.current.Recognizes(hash) {
+		return h.current.NeedsRehash(hash)
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package hasherchain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	argon2Adapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/argon2"
+	bcryptAdapter "github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/bcrypt"
+)
+
+// Cheap parameters keep the tests fast; production uses the adapter defaults
+var testParams = argon2Adapter.Params{MemoryKiB: 64, Iterations: 1, Parallelism: 1}
+
+func TestPasswordHasher_HashesWithArgon2id(t *testing.T) {
+	ctx := context.Background()
+	chain := NewPasswordHasher(argon2Adapter.NewPasswordHasher(testParams), bcryptAdapter.NewPasswordHasher(4))
+
+	hash, err := chain.Hash(ctx, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	assert.False(t, chain.NeedsRehash(hash))
+
+	valid, err := chain.Verify(ctx, "correct horse battery staple", hash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = chain.Verify(ctx, "wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestPasswordHasher_VerifiesLegacyBcrypt(t *testing.T) {
+	ctx := context.Background()
+	legacyHash, err := bcryptAdapter.NewPasswordHasher(4).Hash(ctx, "old passphrase")
+	require.NoError(t, err)
+
+	chain := NewPasswordHasher(argon2Adapter.NewPasswordHasher(testParams), bcryptAdapter.NewPasswordHasher(4))
+
+	valid, err := chain.Verify(ctx, "old passphrase", legacyHash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.True(t, chain.NeedsRehash(legacyHash))
+
+	valid, err = chain.Verify(ctx, "wrong", legacyHash)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestPasswordHasher_LongPassphrase(t *testing.T) {
+	ctx := context.Background()
+	chain := NewPasswordHasher(argon2Adapter.NewPasswordHasher(testParams), bcryptAdapter.NewPasswordHasher(4))
+	long := strings.Repeat("a", 100)
+
+	hash, err := chain.Hash(ctx, long)
+	require.NoError(t, err)
+
+	// bcrypt would ignore everything past byte 72, so these two would collide
+	valid, err := chain.Verify(ctx, strings.Repeat("a", 72)+strings.Repeat("b", 28), hash)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	valid, err = chain.Verify(ctx, long, hash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestPasswordHasher_ChangedParametersNeedRehash(t *testing.T) {
+	ctx := context.Background()
+	hash, err := argon2Adapter.NewPasswordHasher(testParams).Hash(ctx, "passphrase")
+	require.NoError(t, err)
+
+	stronger := testParams
+	stronger.Iterations = 2
+	chain := NewPasswordHasher(argon2Adapter.NewPasswordHasher(stronger), bcryptAdapter.NewPasswordHasher(4))
+
+	valid, err := chain.Verify(ctx, "passphrase", hash)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.True(t, chain.NeedsRehash(hash))
+}
+
+func TestPasswordHasher_UnrecognizedHash(t *testing.T) {
+	chain := NewPasswordHasher(argon2Adapter.NewPasswordHasher(testParams), bcryptAdapter.NewPasswordHasher(4))
+
+	_, err := chain.Verify(context.Background(), "passphrase", "$scrypt$unknown")
+	assert.ErrorIs(t, err, ErrUnrecognizedHash)
+
+	_, err = chain.Verify(context.Background(), "passphrase", "$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5")
+	assert.ErrorIs(t, err, argon2Adapter.ErrInvalidHash)
+
+	valid, err := chain.Verify(context.Background(), "anything", "")
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.False(t, chain.NeedsRehash(""))
+}
@@ -0,0 +1,177 @@
+// Package queue publishes the message service's notifications to the queue the email consumer
+// reads, over whichever backend the configured payload publisher speaks.
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	messagepb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	"google.golang.org/protobuf/proto"
+)
+
+// Notification types understood by the email consumer; they must match the
+// notification domain's contracts.NotificationType constants.
+const (
+	notificationTypeInitial          = "initial"
+	notificationTypeReadReceipt      = "read_receipt"
+	notificationTypeWebhook          = "webhook"
+	notificationTypeRequestFulfilled = "request_fulfilled"
+	notificationTypePassphraseAlert  = "passphrase_alert"
+)
+
+// PayloadPublisher delivers an encoded notification to the email consumer's queue. The storage
+// service's outbox publishers implement it for each queue backend.
+type PayloadPublisher interface {
+	PublishOutboxEntry(ctx context.Context, payload []byte) error
+}
+
+// NotificationPublisher implements the NotificationServicePort by encoding notifications and
+// handing them to a PayloadPublisher
+type NotificationPublisher struct {
+	publisher  PayloadPublisher
+	webhookURL string
+}
+
+// NewNotificationPublisher returns a notification publisher sending through publisher.
+// webhookURL is the globally configured webhook; events for messages without their own webhook
+// are only published when it is set.
+func NewNotificationPublisher(publisher PayloadPublisher, webhookURL string) *NotificationPublisher {
+	return &NotificationPublisher{
+		publisher:  publisher,
+		webhookURL: webhookURL,
+	}
+}
+
+// EncodeMessageNotification serializes a new-message notification in the form the email consumer
+// reads. Storage writes it to the outbox with the message, and the relay publishes it unchanged.
+func EncodeMessageNotification(req domain.MessageNotificationRequest) ([]byte, error) {
+	pbMsg := &messagepb.Message{
+		Email:            req.SenderEmail,
+		FirstName:        req.SenderName,
+		OtherFirstName:   req.RecipientName,
+		OtherEmail:       req.RecipientEmail,
+		Content:          fmt.Sprintf("Please click this link to get your encrypted message\n<a href=\"%s\">here</a>", req.MessageURL),
+		Url:              req.MessageURL,
+		Hidden:           req.AdditionalInfo,
+		NotificationType: notificationTypeInitial,
+	}
+
+	data, err := proto.Marshal(pbMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification message: %w", err)
+	}
+	return data, nil
+}
+
+// SendReadReceipt tells the original sender that their message was viewed
+func (p *NotificationPublisher) SendReadReceipt(ctx context.Context, req domain.MessageReadReceiptRequest) error {
+	logging.Debug().Str("senderEmail", validation.SanitizeEmailForLogging(req.SenderEmail)).Msg("Sending read receipt")
+
+	pbMsg := &messagepb.Message{
+		Email:            req.SenderEmail,
+		UniqueId:         req.MessageID,
+		NotificationType: notificationTypeReadReceipt,
+		ViewCount:        int32(req.ViewCount),
+		MaxViewCount:     int32(req.MaxViewCount),
+	}
+
+	if err := p.publish(ctx, pbMsg); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to publish read receipt")
+		return err
+	}
+
+	logging.Info().Str("messageId", req.MessageID).Msg("Read receipt published successfully")
+	return nil
+}
+
+// SendPassphraseAlert tells the original sender that wrong passphrases were entered for their
+// message. The event travels in the webhook event field.
+func (p *NotificationPublisher) SendPassphraseAlert(ctx context.Context, req domain.MessagePassphraseAlertRequest) error {
+	logging.Debug().Str("senderEmail", validation.SanitizeEmailForLogging(req.SenderEmail)).Msg("Sending passphrase alert")
+
+	pbMsg := &messagepb.Message{
+		Email:            req.SenderEmail,
+		UniqueId:         req.MessageID,
+		NotificationType: notificationTypePassphraseAlert,
+		ViewCount:        int32(req.ViewCount),
+		MaxViewCount:     int32(req.MaxViewCount),
+		WebhookEvent:     req.Event,
+	}
+
+	if err := p.publish(ctx, pbMsg); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to publish passphrase alert")
+		return err
+	}
+
+	logging.Info().Str("messageId", req.MessageID).Str("event", req.Event).Msg("Passphrase alert published successfully")
+	return nil
+}
+
+// SendSecretRequestFulfilled emails the requester the link to the secret someone sent them.
+// The requester is the email's recipient and whoever fulfilled the request is its sender.
+func (p *NotificationPublisher) SendSecretRequestFulfilled(ctx context.Context, req domain.SecretRequestFulfilledNotification) error {
+	logging.Debug().Str("requesterEmail", validation.SanitizeEmailForLogging(req.RequesterEmail)).Msg("Sending secret request fulfilled notification")
+
+	pbMsg := &messagepb.Message{
+		FirstName:        req.SenderName,
+		OtherFirstName:   req.RequesterName,
+		OtherEmail:       req.RequesterEmail,
+		Url:              req.MessageURL,
+		Hidden:           req.Description,
+		NotificationType: notificationTypeRequestFulfilled,
+	}
+
+	if err := p.publish(ctx, pbMsg); err != nil {
+		logging.Error().Err(err).Str("requesterEmail", validation.SanitizeEmailForLogging(req.RequesterEmail)).Msg("Failed to publish secret request fulfilled notification")
+		return err
+	}
+
+	logging.Info().Str("requesterEmail", validation.SanitizeEmailForLogging(req.RequesterEmail)).Msg("Secret request fulfilled notification published successfully")
+	return nil
+}
+
+// SendWebhookEvent queues a lifecycle event for delivery to the message's webhook, or to the
+// global webhook when the message has none
+func (p *NotificationPublisher) SendWebhookEvent(ctx context.Context, event domain.MessageWebhookEvent) error {
+	if event.WebhookURL == "" && p.webhookURL == "" {
+		return nil
+	}
+
+	pbMsg := &messagepb.Message{
+		UniqueId:         event.MessageID,
+		NotificationType: notificationTypeWebhook,
+		ViewCount:        int32(event.ViewCount),
+		MaxViewCount:     int32(event.MaxViewCount),
+		WebhookEvent:     event.Event,
+		WebhookUrl:       event.WebhookURL,
+		WebhookSecret:    event.WebhookSecret,
+		OccurredAt:       event.OccurredAt.UTC().Format(time.RFC3339),
+	}
+
+	if err := p.publish(ctx, pbMsg); err != nil {
+		logging.Error().Err(err).Str("messageId", event.MessageID).Str("event", event.Event).Msg("Failed to publish webhook event")
+		return err
+	}
+
+	logging.Debug().Str("messageId", event.MessageID).Str("event", event.Event).Msg("Webhook event published successfully")
+	return nil
+}
+
+// publish encodes a notification and hands it to the payload publisher
+func (p *NotificationPublisher) publish(ctx context.Context, pbMsg *messagepb.Message) error {
+	data, err := proto.Marshal(pbMsg)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to marshal notification message")
+		return fmt.Errorf("failed to marshal notification message: %w", err)
+	}
+
+	if err := p.publisher.PublishOutboxEntry(ctx, data); err != nil {
+		return fmt.Errorf("failed to publish notification message: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps every payload it is asked to publish
+type recordingPublisher struct {
+	payloads [][]byte
+	err      error
+}
+
+func (p *recordingPublisher) PublishOutboxEntry(ctx context.Context, payload []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+// decoded returns the only published payload as the email consumer reads it
+func (p *recordingPublisher) decoded(t *testing.T) contracts.QueueMessage {
+	t.Helper()
+	require.Len(t, p.payloads, 1)
+	msg, err := queuemsg.DecodeMessage(p.payloads[0])
+	require.NoError(t, err)
+	return msg
+}
+
+func TestNotificationPublisher_SendReadReceipt(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	err := NewNotificationPublisher(publisher, "").SendReadReceipt(context.Background(), domain.MessageReadReceiptRequest{
+		MessageID:    "message-1",
+		SenderEmail:  "sender@example.com",
+		ViewCount:    1,
+		MaxViewCount: 3,
+	})
+	require.NoError(t, err)
+
+	msg := publisher.decoded(t)
+	assert.Equal(t, contracts.NotificationTypeReadReceipt, msg.NotificationType)
+	assert.Equal(t, "sender@example.com", msg.Email)
+	assert.Equal(t, "message-1", msg.UniqueID)
+	assert.Equal(t, 1, msg.ViewCount)
+	assert.Equal(t, 3, msg.MaxViewCount)
+}
+
+func TestNotificationPublisher_SendPassphraseAlert(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	err := NewNotificationPublisher(publisher, "").SendPassphraseAlert(context.Background(), domain.MessagePassphraseAlertRequest{
+		MessageID:    "message-1",
+		SenderEmail:  "sender@example.com",
+		Event:        domain.WebhookEventLocked,
+		ViewCount:    1,
+		MaxViewCount: 3,
+	})
+	require.NoError(t, err)
+
+	msg := publisher.decoded(t)
+	assert.Equal(t, contracts.NotificationTypePassphraseAlert, msg.NotificationType)
+	assert.Equal(t, "sender@example.com", msg.Email)
+	assert.Equal(t, "message-1", msg.UniqueID)
+	assert.Equal(t, domain.WebhookEventLocked, msg.WebhookEvent)
+	assert.Empty(t, msg.WebhookURL)
+}
+
+func TestNotificationPublisher_SendSecretRequestFulfilled(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	err := NewNotificationPublisher(publisher, "").SendSecretRequestFulfilled(context.Background(), domain.SecretRequestFulfilledNotification{
+		RequesterEmail: "requester@example.com",
+		RequesterName:  "Bob",
+		SenderName:     "Alice",
+		MessageURL:     "https://password.exchange/decrypt/abc/key",
+		Description:    "database password",
+	})
+	require.NoError(t, err)
+
+	msg := publisher.decoded(t)
+	assert.Equal(t, contracts.NotificationTypeRequestFulfilled, msg.NotificationType)
+	assert.Equal(t, "requester@example.com", msg.OtherEmail)
+	assert.Equal(t, "Bob", msg.OtherFirstName)
+	assert.Equal(t, "Alice", msg.FirstName)
+	assert.Equal(t, "https://password.exchange/decrypt/abc/key", msg.URL)
+	assert.Equal(t, "database password", msg.Hidden)
+}
+
+func TestNotificationPublisher_SendWebhookEvent(t *testing.T) {
+	occurredAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := domain.MessageWebhookEvent{
+		MessageID:     "message-1",
+		Event:         "message.viewed",
+		ViewCount:     1,
+		MaxViewCount:  3,
+		WebhookURL:    "https://hooks.example.com/px",
+		WebhookSecret: "secret",
+		OccurredAt:    occurredAt,
+	}
+
+	t.Run("publishes to the message webhook", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		require.NoError(t, NewNotificationPublisher(publisher, "").SendWebhookEvent(context.Background(), event))
+
+		msg := publisher.decoded(t)
+		assert.Equal(t, contracts.NotificationTypeWebhook, msg.NotificationType)
+		assert.Equal(t, "message.viewed", msg.WebhookEvent)
+		assert.Equal(t, "https://hooks.example.com/px", msg.WebhookURL)
+		assert.Equal(t, "secret", msg.WebhookSecret)
+		assert.True(t, msg.OccurredAt.Equal(occurredAt))
+	})
+
+	t.Run("skips events without any webhook", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		withoutWebhook := event
+		withoutWebhook.WebhookURL = ""
+		require.NoError(t, NewNotificationPublisher(publisher, "").SendWebhookEvent(context.Background(), withoutWebhook))
+		assert.Empty(t, publisher.payloads)
+
+		require.NoError(t, NewNotificationPublisher(publisher, "https://hooks.example.com/global").SendWebhookEvent(context.Background(), withoutWebhook))
+		assert.Len(t, publisher.payloads, 1)
+	})
+}
+
+func TestNotificationPublisher_ReturnsPublishError(t *testing.T) {
+	publisher := &recordingPublisher{err: errors.New("queue unavailable")}
+
+	err := NewNotificationPublisher(publisher, "").SendReadReceipt(context.Background(), domain.MessageReadReceiptRequest{MessageID: "message-1"})
+	assert.ErrorContains(t, err, "queue unavailable")
+}
@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/adapters/secondary/queue"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/message/domain"
+	storageDomain "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	storagePorts "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// StorageAdapter implements the message domain's StorageService by calling the storage
+// service in-process. It replaces the gRPC StorageClient when the web command runs
+// self-contained with an embedded database.
+type StorageAdapter struct {
+	storageService storagePorts.StorageServicePort
+}
+
+// NewStorageAdapter creates a new in-process storage adapter
+func NewStorageAdapter(storageService storagePorts.StorageServicePort) *StorageAdapter {
+	return &StorageAdapter{
+		storageService: storageService,
+	}
+}
+
+// StoreMessage stores an encrypted message
+func (a *StorageAdapter) StoreMessage(ctx context.Context, req domain.MessageStorageRequest) error {
+	message := &storageDomain.Message{
+		Content:             req.Content,
+		UniqueID:            req.MessageID,
+		Passphrase:          req.Passphrase,
+		RecipientEmail:      req.RecipientEmail,
+		MaxViewCount:        req.MaxViewCount,
+		ExpiresAt:           req.ExpiresAt,
+		ClientEncrypted:     req.ClientEncrypted,
+		Attachment:          toStorageAttachment(req.Attachment),
+		RevocationTokenHash: req.RevocationTokenHash,
+		SenderEmail:         req.SenderEmail,
+		WebhookURL:          req.WebhookURL,
+		WebhookSecret:       req.WebhookSecret,
+		PublicKeyEncrypted:  req.PublicKeyEncrypted,
+		PassphraseKeySalt:   req.PassphraseKeySalt,
+	}
+	if req.Notification != nil {
+		notification, err := queue.EncodeMessageNotification(*req.Notification)
+		if err != nil {
+			logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to encode notification")
+			return fmt.Errorf("failed to store message: %w", err)
+		}
+		message.Notification = notification
+	}
+
+	if err := a.storageService.StoreMessage(withAuditClient(ctx), message); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to store message")
+		return fmt.Errorf("failed to store message: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Int("maxViewCount", req.MaxViewCount).Msg("Stored message successfully")
+	return nil
+}
+
+// RetrieveMessage retrieves a stored message by ID and increments its view count
+func (a *StorageAdapter) RetrieveMessage(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (*domain.MessageStorageResponse, error) {
+	message, err := a.storageService.RetrieveMessage(withAuditClient(ctx), req.MessageID)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to retrieve message")
+		return nil, fmt.Errorf("failed to retrieve message: %w", err)
+	}
+
+	return toStorageResponse(req.MessageID, message), nil
+}
+
+// GetMessage retrieves a message by its unique ID without incrementing view count
+func (a *StorageAdapter) GetMessage(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (*domain.MessageStorageResponse, error) {
+	message, err := a.storageService.GetMessage(ctx, req.MessageID)
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Str("messageId", req.MessageID).
+			Msg("Failed to retrieve message without incrementing view count")
+		return nil, fmt.Errorf("failed to retrieve message without incrementing view count: %w", err)
+	}
+
+	return toStorageResponse(req.MessageID, message), nil
+}
+
+// GetAttachment retrieves a message's encrypted attachment without incrementing view count
+func (a *StorageAdapter) GetAttachment(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (*domain.StoredAttachment, error) {
+	attachment, err := a.storageService.GetAttachment(ctx, req.MessageID)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to retrieve attachment")
+		return nil, fmt.Errorf("failed to retrieve attachment: %w", err)
+	}
+
+	return fromStorageAttachment(attachment), nil
+}
+
+// DeleteMessage permanently removes a message
+func (a *StorageAdapter) DeleteMessage(ctx context.Context, req domain.MessageRetrievalStorageRequest) error {
+	if err := a.storageService.DeleteMessage(withAuditClient(ctx), req.MessageID); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to delete message")
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Deleted message successfully")
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (a *StorageAdapter) ReservePassphraseAttempt(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) (int, error) {
+	failedAttempts, err := a.storageService.ReservePassphraseAttempt(withAuditClient(ctx), req.MessageID)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to record failed passphrase attempt")
+		return 0, fmt.Errorf("failed to record failed passphrase attempt: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Int("failedAttempts", failedAttempts).Msg("Recorded failed passphrase attempt successfully")
+	return failedAttempts, nil
+}
+
+// ReleasePassphraseAttempt takes back a reserved attempt once the passphrase turned out right
+func (a *StorageAdapter) ReleasePassphraseAttempt(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) error {
+	if err := a.storageService.ReleasePassphraseAttempt(ctx, req.MessageID); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to release passphrase attempt")
+		return fmt.Errorf("failed to release passphrase attempt: %w", err)
+	}
+
+	return nil
+}
+
+// RecordPassphraseFailure audits a wrong passphrase whose attempt was already reserved
+func (a *StorageAdapter) RecordPassphraseFailure(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+) error {
+	if err := a.storageService.RecordPassphraseFailure(ctx, req.MessageID); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to record passphrase failure")
+		return fmt.Errorf("failed to record passphrase failure: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash
+func (a *StorageAdapter) UpdatePassphraseHash(
+	ctx context.Context,
+	req domain.MessageRetrievalStorageRequest,
+	hashedPassphrase string,
+) error {
+	if err := a.storageService.UpdatePassphraseHash(ctx, req.MessageID, hashedPassphrase); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to update passphrase hash")
+		return fmt.Errorf("failed to update passphrase hash: %w", err)
+	}
+
+	logging.Debug().Str("messageId", req.MessageID).Msg("Updated passphrase hash successfully")
+	return nil
+}
+
+// GetReminderHistory retrieves the reminders sent for a message by its storage row ID
+func (a *StorageAdapter) GetReminderHistory(ctx context.Context, storageID int64) ([]domain.ReminderHistoryEntry, error) {
+	history, err := a.storageService.GetReminderHistory(ctx, int(storageID))
+	if err != nil {
+		logging.Error().Err(err).Int64("storageId", storageID).Msg("Failed to retrieve reminder history")
+		return nil, fmt.Errorf("failed to retrieve reminder history: %w", err)
+	}
+
+	entries := make([]domain.ReminderHistoryEntry, 0, len(history))
+	for _, entry := range history {
+		entries = append(entries, domain.ReminderHistoryEntry{
+			ReminderCount:    entry.ReminderCount,
+			LastReminderSent: entry.LastReminderSent,
+		})
+	}
+	return entries, nil
+}
+
+// StoreSecretRequest stores a request for someone to send the requester a secret
+func (a *StorageAdapter) StoreSecretRequest(ctx context.Context, req domain.SecretRequestStorageRequest) error {
+	secretRequest := &storageDomain.SecretRequest{
+		UniqueID:       req.RequestID,
+		RequesterName:  req.RequesterName,
+		RequesterEmail: req.RequesterEmail,
+		Description:    req.Description,
+		ExpiresAt:      req.ExpiresAt,
+	}
+
+	if err := a.storageService.StoreSecretRequest(ctx, secretRequest); err != nil {
+		logging.Error().Err(err).Str("requestId", req.RequestID).Msg("Failed to store secret request")
+		return fmt.Errorf("failed to store secret request: %w", err)
+	}
+
+	logging.Debug().Str("requestId", req.RequestID).Msg("Stored secret request successfully")
+	return nil
+}
+
+// GetSecretRequest retrieves a secret request by ID, whether or not it is still open
+func (a *StorageAdapter) GetSecretRequest(ctx context.Context, requestID string) (*domain.StoredSecretRequest, error) {
+	secretRequest, err := a.storageService.GetSecretRequest(ctx, requestID)
+	if err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to retrieve secret request")
+		if errors.Is(err, storageDomain.ErrSecretRequestNotFound) {
+			return nil, fmt.Errorf("%w: %v", domain.ErrSecretRequestNotFound, err)
+		}
+		return nil, fmt.Errorf("failed to retrieve secret request: %w", err)
+	}
+
+	return &domain.StoredSecretRequest{
+		RequestID:      secretRequest.UniqueID,
+		RequesterName:  secretRequest.RequesterName,
+		RequesterEmail: secretRequest.RequesterEmail,
+		Description:    secretRequest.Description,
+		CreatedAt:      secretRequest.CreatedAt,
+		ExpiresAt:      secretRequest.ExpiresAt,
+		FulfilledAt:    secretRequest.FulfilledAt,
+		MessageID:      secretRequest.MessageUniqueID,
+	}, nil
+}
+
+// FulfillSecretRequest marks an open secret request as answered by the given message
+func (a *StorageAdapter) FulfillSecretRequest(ctx context.Context, requestID string, messageID string) error {
+	if err := a.storageService.FulfillSecretRequest(ctx, requestID, messageID); err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to fulfill secret request")
+		if errors.Is(err, storageDomain.ErrSecretRequestUnavailable) {
+			return fmt.Errorf("%w: %v", domain.ErrSecretRequestUnavailable, err)
+		}
+		return fmt.Errorf("failed to fulfill secret request: %w", err)
+	}
+
+	logging.Debug().Str("requestId", requestID).Str("messageId", messageID).Msg("Fulfilled secret request successfully")
+	return nil
+}
+
+// toStorageResponse converts a storage domain message into the message domain's storage response
+// withAuditClient hands the request's client to the storage service's audit log
+func withAuditClient(ctx context.Context) context.Context {
+	info := domain.ClientInfoFromContext(ctx)
+	return storageDomain.WithAuditClient(ctx, storageDomain.AuditClient{IP: info.IP, UserAgent: info.UserAgent})
+}
+
+func toStorageResponse(messageID string, message *storageDomain.Message) *domain.MessageStorageResponse {
+	return &domain.MessageStorageResponse{
+		MessageID:                messageID,
+		EncryptedContent:         message.Content,
+		HashedPassphrase:         message.Passphrase,
+		HasPassphrase:            message.Passphrase != "",
+		ViewCount:                message.ViewCount,
+		MaxViewCount:             message.MaxViewCount,
+		ExpiresAt:                message.ExpiresAt,
+		ClientEncrypted:          message.ClientEncrypted,
+		Attachment:               fromStorageAttachment(message.Attachment),
+		RevocationTokenHash:      message.RevocationTokenHash,
+		SenderEmail:              message.SenderEmail,
+		StorageID:                message.ID,
+		WebhookURL:               message.WebhookURL,
+		WebhookSecret:            message.WebhookSecret,
+		PublicKeyEncrypted:       message.PublicKeyEncrypted,
+		PassphraseKeySalt:        message.PassphraseKeySalt,
+		FailedPassphraseAttempts: message.FailedPassphraseAttempts,
+	}
+}
+
+// toStorageAttachment converts a stored attachment into the storage domain type, returning nil when absent.
+func toStorageAttachment(a *domain.StoredAttachment) *storageDomain.Attachment {
+	if a == nil {
+		return nil
+	}
+	return &storageDomain.Attachment{
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		Chunks:      a.Chunks,
+	}
+}
+
+// fromStorageAttachment converts a storage domain attachment into a stored attachment, returning nil when absent.
+func fromStorageAttachment(a *storageDomain.Attachment) *domain.StoredAttachment {
+	if a == nil {
+		return nil
+	}
+	return &domain.StoredAttachment{
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		Chunks:      a.Chunks,
+	}
+}
@@ -27,3 +27,28 @@ func (u *URLBuilder) BuildDecryptURL(messageID string, encryptionKey []byte) str
 	logging.Debug().Str("messageId", messageID).Str("url", decryptURL).Msg("Built decrypt URL")
 	return decryptURL
 }
+
+// BuildRevokeURL builds a URL for revoking a message. The token goes in the fragment
+// so it is never sent to the server in the page request or recorded in access logs.
+func (u *URLBuilder) BuildRevokeURL(messageID string, revocationToken string) string {
+	revokeURL := fmt.Sprintf("%srevoke/%s#%s", u.baseURL, messageID, revocationToken)
+
+	logging.Debug().Str("messageId", messageID).Msg("Built revoke URL")
+	return revokeURL
+}
+
+// BuildStatusURL builds a URL for the sender's status page, keeping the token in the fragment
+func (u *URLBuilder) BuildStatusURL(messageID string, revocationToken string) string {
+	statusURL := fmt.Sprintf("%sstatus/%s#%s", u.baseURL, messageID, revocationToken)
+
+	logging.Debug().Str("messageId", messageID).Msg("Built status URL")
+	return statusURL
+}
+
+// BuildSecretRequestURL builds the link a requester shares with whoever holds the secret
+func (u *URLBuilder) BuildSecretRequestURL(requestID string) string {
+	requestURL := fmt.Sprintf("%srequest/%s", u.baseURL, requestID)
+
+	logging.Debug().Str("requestId", requestID).Msg("Built secret request URL")
+	return requestURL
+}
@@ -11,6 +11,81 @@ const DefaultMessageTTL = 7 * 24 * time.Hour
 // MaxExpirationHours is the maximum allowed expiration time (90 days).
 const MaxExpirationHours = 90 * 24
 
+// MinClientCiphertextBytes is the smallest valid client-encrypted payload:
+// a 12-byte AES-GCM nonce followed by the 16-byte authentication tag.
+const MinClientCiphertextBytes = 12 + 16
+
+// MaxAttachmentBytes is the largest file that can be attached to a message (10 MiB).
+const MaxAttachmentBytes = 10 << 20
+
+// MaxRecipients is the most recipients a single submission can be shared with.
+const MaxRecipients = 10
+
+// MaxWebhookURLLength is the longest webhook URL that can be stored with a message.
+const MaxWebhookURLLength = 2048
+
+// MinWebhookSecretLength and MaxWebhookSecretLength bound the shared secret used to sign webhook payloads.
+const (
+	MinWebhookSecretLength = 16
+	MaxWebhookSecretLength = 256
+)
+
+// Webhook event names sent for each stage of a message's lifecycle.
+const (
+	WebhookEventCreated   = "message.created"
+	WebhookEventViewed    = "message.viewed"
+	WebhookEventExhausted = "message.exhausted"
+	WebhookEventExpired   = "message.expired"
+	WebhookEventRevoked   = "message.revoked"
+	// WebhookEventPassphraseFailed is sent for each wrong passphrase that still leaves attempts
+	WebhookEventPassphraseFailed = "message.passphrase_failed"
+	// WebhookEventLocked and WebhookEventDestroyed are sent when the last attempt is used up
+	WebhookEventLocked    = "message.locked"
+	WebhookEventDestroyed = "message.destroyed"
+)
+
+// DefaultPassphraseMaxAttempts is how many wrong passphrases a message tolerates when
+// passphrasemaxattempts is not configured.
+const DefaultPassphraseMaxAttempts = 5
+
+// Actions taken once a message has used up its passphrase attempts, set by passphraselockoutaction.
+const (
+	// PassphraseLockoutDestroy deletes the message; it is the default
+	PassphraseLockoutDestroy = "destroy"
+	// PassphraseLockoutLock keeps the message, so its sender can still see its status, but
+	// refuses every further retrieval
+	PassphraseLockoutLock = "lock"
+)
+
+// MaxSecretRequestDescriptionLength is the longest note a requester can attach to a secret request.
+const MaxSecretRequestDescriptionLength = 1000
+
+// MaxRecipientPublicKeyLength is the longest recipient public key accepted. Armored OpenPGP
+// keys with several subkeys and signatures run to a few KiB; age recipients are 62 characters.
+const MaxRecipientPublicKeyLength = 16 << 10
+
+// MaxSecretShares is the most shares a secret can be split into. Each share is stored as its own
+// message, like a recipient of a multi-recipient submission.
+const MaxSecretShares = MaxRecipients
+
+// MinAttachmentViewCount is the lowest max view count allowed for messages with an attachment:
+// one view opens the message and another downloads the file.
+const MinAttachmentViewCount = 2
+
+// Attachment represents a file submitted alongside a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// AttachmentInfo describes a decrypted attachment without its contents
+type AttachmentInfo struct {
+	Filename    string
+	ContentType string
+	Size        int64
+}
+
 // MessageSubmissionRequest represents a request to submit a new message
 type MessageSubmissionRequest struct {
 	Content          string
@@ -27,6 +102,65 @@ type MessageSubmissionRequest struct {
 	// ExpirationHours specifies a custom expiration duration in hours.
 	// If zero, DefaultMessageTTL applies. Valid range: 1–MaxExpirationHours.
 	ExpirationHours int
+	// ClientEncrypted indicates Content is base64url(nonce || AES-GCM ciphertext) produced
+	// in the browser. The server stores it as-is and never sees the key.
+	ClientEncrypted bool
+	// PassphraseKey mixes an Argon2id key stretched from Passphrase into the content key, so the
+	// message cannot be decrypted without the passphrase even with database access. Each view
+	// then pays for the derivation. Requires Passphrase and server-side encryption.
+	PassphraseKey bool
+	// Attachment is an optional file encrypted with the same key as Content.
+	// Not available for client-encrypted messages.
+	Attachment *Attachment
+	// NotifyOnView emails SenderEmail a read receipt each time the message is viewed.
+	NotifyOnView bool
+	// WebhookURL receives signed lifecycle events for this message. Must be https.
+	WebhookURL string
+	// WebhookSecret signs webhook payloads with HMAC-SHA256. Required with WebhookURL.
+	WebhookSecret string
+	// Recipients shares the message with several people, each receiving an independent copy
+	// with its own link, key and view counter. Replaces RecipientName and RecipientEmail.
+	Recipients []MessageRecipient
+	// RecipientPublicKey seals Content to an age X25519 recipient ("age1...") or an ASCII-armored
+	// OpenPGP public key instead of a symmetric key. No key is placed in the decrypt URL and only
+	// the recipient's private key can open the message.
+	RecipientPublicKey string
+	// SplitShares splits Content with Shamir's Secret Sharing into this many shares, each stored
+	// as its own message with its own link, key and view counter. Zero disables splitting.
+	SplitShares int
+	// SplitThreshold is how many shares are needed to reconstruct Content. Required with SplitShares.
+	SplitThreshold int
+}
+
+// MessageRecipient identifies one recipient of a multi-recipient submission
+type MessageRecipient struct {
+	Name  string
+	Email string
+}
+
+// RecipientMessage describes the copy of a message stored for one recipient
+type RecipientMessage struct {
+	RecipientName   string
+	RecipientEmail  string
+	MessageID       string
+	Key             string
+	DecryptURL      string
+	RevocationToken string
+	RevokeURL       string
+	StatusURL       string
+}
+
+// ShareMessage describes the message stored for one share of a split secret
+type ShareMessage struct {
+	ShareIndex      int
+	RecipientName   string
+	RecipientEmail  string
+	MessageID       string
+	Key             string
+	DecryptURL      string
+	RevocationToken string
+	RevokeURL       string
+	StatusURL       string
 }
 
 // MessageSubmissionResponse represents the response to a message submission
@@ -34,9 +168,22 @@ type MessageSubmissionResponse struct {
 	MessageID  string
 	Key        string
 	DecryptURL string
-	ExpiresAt  *time.Time
-	Success    bool
-	Error      error
+	// RevocationToken lets the sender delete the message early or check its status.
+	// Only its hash is stored.
+	RevocationToken string
+	// RevokeURL opens the revocation page with the token in the URL fragment.
+	RevokeURL string
+	// StatusURL opens the sender's status page with the token in the URL fragment.
+	StatusURL string
+	// Recipients lists the per-recipient copies of a multi-recipient submission. The fields
+	// above describe the first of them. Empty for single-recipient submissions.
+	Recipients []RecipientMessage
+	// Shares lists the messages holding each share of a split secret, ordered by share index.
+	// The fields above describe the first of them. Empty unless the submission was split.
+	Shares    []ShareMessage
+	ExpiresAt *time.Time
+	Success   bool
+	Error     error
 }
 
 // MessageRetrievalRequest represents a request to retrieve and decrypt a message
@@ -44,6 +191,11 @@ type MessageRetrievalRequest struct {
 	MessageID     string
 	DecryptionKey []byte
 	Passphrase    string
+	// PrivateKey opens a message sealed to the recipient's public key. When empty, such a
+	// message is returned as its armored ciphertext for the recipient to decrypt locally.
+	PrivateKey string
+	// PrivateKeyPassphrase unlocks a passphrase-protected OpenPGP private key.
+	PrivateKeyPassphrase string
 }
 
 // MessageRetrievalResponse represents the response to a message retrieval
@@ -54,8 +206,63 @@ type MessageRetrievalResponse struct {
 	MaxViewCount int
 	// ExpiresAt is the time the message will expire. Null for legacy messages that predate expiry tracking.
 	ExpiresAt *time.Time
-	Success   bool
-	Error     error
+	// ClientEncrypted indicates Content is ciphertext the caller must decrypt with the fragment key.
+	ClientEncrypted bool
+	// PublicKeyEncrypted indicates the message was sealed to the recipient's public key. Content
+	// is the armored ciphertext unless a private key was supplied to decrypt it.
+	PublicKeyEncrypted bool
+	// Attachment describes the message's file, which is downloaded separately. Nil when there is none.
+	Attachment *AttachmentInfo
+	Success    bool
+	Error      error
+}
+
+// AttachmentDownloadResponse represents a decrypted attachment ready for download
+type AttachmentDownloadResponse struct {
+	MessageID    string
+	Filename     string
+	ContentType  string
+	Data         []byte
+	ViewCount    int
+	MaxViewCount int
+}
+
+// MessageRevocationRequest represents a sender's request to delete a message before it expires
+type MessageRevocationRequest struct {
+	MessageID       string
+	RevocationToken string
+}
+
+// MessageStatusRequest represents a sender's request to see how a message has been accessed
+type MessageStatusRequest struct {
+	MessageID       string
+	RevocationToken string
+}
+
+// ReminderHistoryEntry records the reminder emails sent for an unread message.
+// The recipient's address is deliberately left out.
+type ReminderHistoryEntry struct {
+	ReminderCount    int
+	LastReminderSent time.Time
+}
+
+// MessageStatusResponse describes a message to its sender without revealing its content
+type MessageStatusResponse struct {
+	MessageID      string
+	ViewCount      int
+	MaxViewCount   int
+	RemainingViews int
+	// ExpiresAt is nil for legacy messages that predate expiry tracking
+	ExpiresAt          *time.Time
+	ClientEncrypted    bool
+	PublicKeyEncrypted bool
+	HasPassphrase      bool
+	HasAttachment      bool
+	// ReadReceipts reports whether the sender asked to be emailed on each view
+	ReadReceipts bool
+	Reminders    []ReminderHistoryEntry
+	// FailedPassphraseAttempts counts the wrong passphrases submitted so far
+	FailedPassphraseAttempts int
 }
 
 // MessageAccessInfo provides information about message access requirements
@@ -63,17 +270,49 @@ type MessageAccessInfo struct {
 	MessageID          string
 	Exists             bool
 	RequiresPassphrase bool
+	ClientEncrypted    bool
+	PublicKeyEncrypted bool
+	HasAttachment      bool
+	AttachmentSize     int64
 	ExpiresAt          *time.Time
+	// RemainingPassphraseAttempts is how many wrong passphrases are left before the message is
+	// locked or destroyed; zero when it needs no passphrase
+	RemainingPassphraseAttempts int
 }
 
 // MessageStorageRequest represents a request to store an encrypted message
 type MessageStorageRequest struct {
-	MessageID      string
-	Content        string
-	Passphrase     string
-	MaxViewCount   int
-	RecipientEmail string     // Optional, for notification purposes
-	ExpiresAt      *time.Time // Optional custom expiration; nil means use default TTL
+	MessageID       string
+	Content         string
+	Passphrase      string
+	MaxViewCount    int
+	RecipientEmail  string            // Optional, for notification purposes
+	ExpiresAt       *time.Time        // Optional custom expiration; nil means use default TTL
+	ClientEncrypted bool              // Content is browser-encrypted ciphertext
+	Attachment      *StoredAttachment // Optional encrypted file
+	// RevocationTokenHash is the hex SHA-256 of the sender's revocation token
+	RevocationTokenHash string
+	// SenderEmail receives read receipts; only set when the sender opted in
+	SenderEmail string
+	// WebhookURL and WebhookSecret configure lifecycle events; both empty when none was requested
+	WebhookURL    string
+	WebhookSecret string
+	// PublicKeyEncrypted marks Content as an armored age or OpenPGP message sealed to the recipient's key
+	PublicKeyEncrypted bool
+	// PassphraseKeySalt is the base64url Argon2id salt that mixes the passphrase into the content
+	// key; empty when the passphrase is only checked against Passphrase
+	PassphraseKeySalt string
+	// Notification is written to the storage outbox in the same write as the message and published
+	// from there, so it is sent even if the queue is down; nil when no email was requested
+	Notification *MessageNotificationRequest
+}
+
+// StoredAttachment represents an encrypted attachment as held by storage
+type StoredAttachment struct {
+	Filename    string   // Encrypted file name
+	ContentType string   // Encrypted MIME type
+	SizeBytes   int64    // Plaintext size
+	Chunks      [][]byte // AES-GCM sealed chunks; not loaded by GetMessage
 }
 
 // MessageRetrievalStorageRequest represents a request to retrieve a stored message
@@ -90,6 +329,24 @@ type MessageStorageResponse struct {
 	ViewCount        int
 	MaxViewCount     int
 	ExpiresAt        *time.Time
+	ClientEncrypted  bool
+	Attachment       *StoredAttachment
+	// RevocationTokenHash is empty for legacy messages, which cannot be revoked
+	RevocationTokenHash string
+	// SenderEmail is empty unless the sender asked for read receipts
+	SenderEmail string
+	// StorageID is the storage row ID, used to look up reminder history
+	StorageID int64
+	// WebhookURL and WebhookSecret are empty unless the sender asked for lifecycle events
+	WebhookURL    string
+	WebhookSecret string
+	// PublicKeyEncrypted marks EncryptedContent as an armored message only the recipient's private key opens
+	PublicKeyEncrypted bool
+	// PassphraseKeySalt is set when the content key is derived from the decryption key and the
+	// passphrase; empty for messages whose passphrase is only checked against HashedPassphrase
+	PassphraseKeySalt string
+	// FailedPassphraseAttempts counts the wrong passphrases submitted for the message
+	FailedPassphraseAttempts int
 }
 
 // MessageNotificationRequest represents a request to send a message notification
@@ -102,11 +359,125 @@ type MessageNotificationRequest struct {
 	AdditionalInfo string
 }
 
+// MessageReadReceiptRequest represents a request to tell the sender their message was viewed
+type MessageReadReceiptRequest struct {
+	MessageID    string
+	SenderEmail  string
+	ViewCount    int
+	MaxViewCount int
+}
+
+// MessagePassphraseAlertRequest represents a request to tell the sender that wrong passphrases
+// were entered for their message. Event is the matching webhook event: passphrase_failed, locked
+// or destroyed.
+type MessagePassphraseAlertRequest struct {
+	MessageID    string
+	SenderEmail  string
+	Event        string
+	ViewCount    int
+	MaxViewCount int
+}
+
+// MessageWebhookEvent represents a lifecycle event to deliver to the sender's webhook.
+// WebhookURL may be empty, in which case the globally configured webhook is used.
+type MessageWebhookEvent struct {
+	Event         string
+	MessageID     string
+	ViewCount     int
+	MaxViewCount  int
+	OccurredAt    time.Time
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// SecretRequestCreationRequest represents a request for someone else to send the requester a secret
+type SecretRequestCreationRequest struct {
+	RequesterName  string
+	RequesterEmail string
+	// Description tells whoever fulfills the request what is being asked for
+	Description string
+	// ExpirationHours is how long the request link stays open. If zero, DefaultMessageTTL applies.
+	ExpirationHours int
+	TurnstileToken  string
+}
+
+// SecretRequestCreationResponse represents a newly created secret request
+type SecretRequestCreationResponse struct {
+	RequestID string
+	// RequestURL is the link the requester shares with whoever holds the secret
+	RequestURL string
+	ExpiresAt  *time.Time
+}
+
+// SecretRequestInfo describes an open secret request to the person fulfilling it.
+// The requester's email address is deliberately left out.
+type SecretRequestInfo struct {
+	RequestID     string
+	RequesterName string
+	Description   string
+	ExpiresAt     *time.Time
+}
+
+// SecretRequestFulfillmentRequest represents the answer to a secret request
+type SecretRequestFulfillmentRequest struct {
+	RequestID  string
+	Content    string
+	SenderName string
+}
+
+// SecretRequestStorageRequest represents a request to store a secret request
+type SecretRequestStorageRequest struct {
+	RequestID      string
+	RequesterName  string
+	RequesterEmail string
+	Description    string
+	ExpiresAt      *time.Time
+}
+
+// StoredSecretRequest represents a secret request from storage
+type StoredSecretRequest struct {
+	RequestID      string
+	RequesterName  string
+	RequesterEmail string
+	Description    string
+	CreatedAt      time.Time
+	ExpiresAt      *time.Time
+	// FulfilledAt and MessageID are set once someone has answered the request
+	FulfilledAt *time.Time
+	MessageID   string
+}
+
+// SecretRequestFulfilledNotification represents a request to send the requester the link to their secret
+type SecretRequestFulfilledNotification struct {
+	RequesterName  string
+	RequesterEmail string
+	SenderName     string
+	Description    string
+	MessageURL     string
+}
+
+// ShareCombinationRequest represents a request to reconstruct a split secret from its shares
+type ShareCombinationRequest struct {
+	Shares []string
+}
+
+// ShareCombinationResponse carries the reconstructed secret
+type ShareCombinationResponse struct {
+	Content string
+}
+
 // EncryptionService defines the interface for encryption operations
 type EncryptionService interface {
 	GenerateKey(ctx context.Context, length int32) ([]byte, error)
-	Encrypt(ctx context.Context, plaintext []string, key []byte) ([]string, error)
-	Decrypt(ctx context.Context, ciphertext []string, key []byte) ([]string, error)
+	Encrypt(ctx context.Context, plaintext []string, key []byte, associatedData []byte) ([]string, error)
+	Decrypt(ctx context.Context, ciphertext []string, key []byte, associatedData []byte) ([]string, error)
+	EncryptFile(ctx context.Context, data []byte, key []byte, associatedData []byte) ([][]byte, error)
+	DecryptFile(ctx context.Context, chunks [][]byte, key []byte, associatedData []byte) ([]byte, error)
+	EncryptToPublicKey(ctx context.Context, plaintext []byte, publicKey string) (string, error)
+	DecryptWithPrivateKey(ctx context.Context, ciphertext string, privateKey string, passphrase string) ([]byte, error)
+	SplitSecret(ctx context.Context, secret []byte, shares int, threshold int) ([]string, error)
+	CombineShares(ctx context.Context, shares []string) ([]byte, error)
+	DerivePassphraseKey(ctx context.Context, key []byte, passphrase string, salt []byte) ([]byte, []byte, error)
 	GenerateID(ctx context.Context) (string, error)
 }
 
@@ -115,22 +486,39 @@ type StorageService interface {
 	StoreMessage(ctx context.Context, req MessageStorageRequest) error
 	RetrieveMessage(ctx context.Context, req MessageRetrievalStorageRequest) (*MessageStorageResponse, error)
 	GetMessage(ctx context.Context, req MessageRetrievalStorageRequest) (*MessageStorageResponse, error)
+	GetAttachment(ctx context.Context, req MessageRetrievalStorageRequest) (*StoredAttachment, error)
+	DeleteMessage(ctx context.Context, req MessageRetrievalStorageRequest) error
+	ReservePassphraseAttempt(ctx context.Context, req MessageRetrievalStorageRequest) (int, error)
+	ReleasePassphraseAttempt(ctx context.Context, req MessageRetrievalStorageRequest) error
+	RecordPassphraseFailure(ctx context.Context, req MessageRetrievalStorageRequest) error
+	UpdatePassphraseHash(ctx context.Context, req MessageRetrievalStorageRequest, hashedPassphrase string) error
+	GetReminderHistory(ctx context.Context, storageID int64) ([]ReminderHistoryEntry, error)
+	StoreSecretRequest(ctx context.Context, req SecretRequestStorageRequest) error
+	GetSecretRequest(ctx context.Context, requestID string) (*StoredSecretRequest, error)
+	FulfillSecretRequest(ctx context.Context, requestID string, messageID string) error
 }
 
 // NotificationService defines the interface for notification operations
 type NotificationService interface {
-	SendMessageNotification(ctx context.Context, req MessageNotificationRequest) error
+	SendReadReceipt(ctx context.Context, req MessageReadReceiptRequest) error
+	SendPassphraseAlert(ctx context.Context, req MessagePassphraseAlertRequest) error
+	SendWebhookEvent(ctx context.Context, event MessageWebhookEvent) error
+	SendSecretRequestFulfilled(ctx context.Context, req SecretRequestFulfilledNotification) error
 }
 
 // PasswordHasher defines the interface for password hashing operations
 type PasswordHasher interface {
 	Hash(ctx context.Context, password string) (string, error)
 	Verify(ctx context.Context, password, hash string) (bool, error)
+	NeedsRehash(hash string) bool
 }
 
 // URLBuilder defines the interface for building message URLs
 type URLBuilder interface {
 	BuildDecryptURL(messageID string, encryptionKey []byte) string
+	BuildRevokeURL(messageID string, revocationToken string) string
+	BuildStatusURL(messageID string, revocationToken string) string
+	BuildSecretRequestURL(requestID string) string
 }
 
 // TurnstileValidator defines the interface for Cloudflare Turnstile validation
@@ -144,3 +532,23 @@ type WebRenderer interface {
 	RenderJSON(ctx context.Context, data interface{}) error
 	Redirect(ctx context.Context, url string) error
 }
+
+// ClientInfo identifies who made a request. It is passed to the storage service, which
+// records it (hashed) in the audit log.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type clientInfoContextKey struct{}
+
+// WithClientInfo returns a context carrying the client making the request
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, info)
+}
+
+// ClientInfoFromContext returns the client stored by WithClientInfo, or the zero value
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoContextKey{}).(ClientInfo)
+	return info
+}
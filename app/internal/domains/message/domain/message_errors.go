@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrInvalidMessageRequest indicates the message request is invalid
@@ -12,6 +15,9 @@ var (
 	// ErrEncryptionFailed indicates encryption operation failed
 	ErrEncryptionFailed = errors.New("encryption failed")
 
+	// ErrMissingDecryptionKey indicates a server-encrypted message was requested without its key
+	ErrMissingDecryptionKey = errors.New("decryption key is required")
+
 	// ErrDecryptionFailed indicates decryption operation failed
 	ErrDecryptionFailed = errors.New("decryption failed")
 
@@ -21,6 +27,18 @@ var (
 	// ErrMessageNotFound indicates the requested message was not found
 	ErrMessageNotFound = errors.New("message not found")
 
+	// ErrAttachmentNotFound indicates the message has no attachment
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	// ErrInvalidRevocationToken indicates the revocation token does not match the message
+	ErrInvalidRevocationToken = errors.New("invalid revocation token")
+
+	// ErrSecretRequestNotFound indicates the requested secret request was not found
+	ErrSecretRequestNotFound = errors.New("secret request not found")
+
+	// ErrSecretRequestUnavailable indicates the secret request was already fulfilled or has expired
+	ErrSecretRequestUnavailable = errors.New("secret request already fulfilled or expired")
+
 	// ErrPasswordHashFailed indicates password hashing failed
 	ErrPasswordHashFailed = errors.New("password hashing failed")
 
@@ -30,6 +48,24 @@ var (
 	// ErrInvalidPassphrase indicates the provided passphrase is incorrect
 	ErrInvalidPassphrase = errors.New("invalid passphrase")
 
+	// ErrMessageLocked indicates the message used up its passphrase attempts and refuses retrieval
+	ErrMessageLocked = errors.New("message locked after too many wrong passphrases")
+
+	// ErrMessageDestroyed indicates the message was deleted after its last passphrase attempt was used up
+	ErrMessageDestroyed = errors.New("message destroyed after too many wrong passphrases")
+
+	// ErrInvalidPublicKey indicates the recipient public key is not a usable age or OpenPGP key
+	ErrInvalidPublicKey = errors.New("invalid recipient public key")
+
+	// ErrInvalidPrivateKey indicates the private key is malformed, locked or not the one the message was sealed to
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+
+	// ErrInvalidShare indicates a secret share is malformed, belongs to another secret or does not reconstruct it
+	ErrInvalidShare = errors.New("invalid secret share")
+
+	// ErrInsufficientShares indicates fewer shares were supplied than are needed to reconstruct the secret
+	ErrInsufficientShares = errors.New("not enough shares to reconstruct the secret")
+
 	// ErrGenerateIDFailed indicates ID generation failed
 	ErrGenerateIDFailed = errors.New("failed to generate unique ID")
 
@@ -42,3 +78,17 @@ var (
 	// ErrTemplateRenderFailed indicates template rendering failed
 	ErrTemplateRenderFailed = errors.New("template rendering failed")
 )
+
+// PassphraseAttemptError reports a wrong passphrase together with how many attempts remain
+// before the message is locked or destroyed. It matches ErrInvalidPassphrase with errors.Is.
+type PassphraseAttemptError struct {
+	RemainingAttempts int
+}
+
+func (e *PassphraseAttemptError) Error() string {
+	return fmt.Sprintf("%v: %d attempts remaining", ErrInvalidPassphrase, e.RemainingAttempts)
+}
+
+func (e *PassphraseAttemptError) Unwrap() error {
+	return ErrInvalidPassphrase
+}
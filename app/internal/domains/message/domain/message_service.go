@@ -2,8 +2,14 @@ package domain
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -56,56 +62,180 @@ func (s *MessageService) SubmitMessage(
 		return nil, fmt.Errorf("%w: %v", ErrInvalidMessageRequest, err)
 	}
 
-	// Validate Turnstile token only if sending email notifications
-	if req.SendNotification {
-		if strings.TrimSpace(req.TurnstileToken) == "" {
-			logging.Error().Msg("Missing Turnstile token for email notification")
-			return nil, fmt.Errorf("%w: missing Turnstile token", ErrInvalidMessageRequest)
+	// Validate Turnstile token only if the submission will cause emails to be sent
+	if req.SendNotification || req.NotifyOnView {
+		if err := s.validateTurnstileToken(ctx, req.TurnstileToken); err != nil {
+			return nil, err
 		}
+	} else {
+		logging.Debug().Msg("Skipping Turnstile validation - email notifications disabled")
+	}
 
-		// Extract remote IP from context if available
-		remoteIP := ""
-		if ip := ctx.Value("RemoteIP"); ip != nil {
-			if ipStr, ok := ip.(string); ok {
-				remoteIP = ipStr
-			}
+	if req.SplitShares > 0 {
+		return s.submitSplitMessage(ctx, req)
+	}
+	if len(req.Recipients) == 0 {
+		return s.submitMessageForRecipient(ctx, req)
+	}
+	return s.submitMessageForRecipients(ctx, req)
+}
+
+// validateTurnstileToken checks a Turnstile token against the remote IP stored in the context
+func (s *MessageService) validateTurnstileToken(ctx context.Context, token string) error {
+	if strings.TrimSpace(token) == "" {
+		logging.Error().Msg("Missing Turnstile token for email notification")
+		return fmt.Errorf("%w: missing Turnstile token", ErrInvalidMessageRequest)
+	}
+
+	// Extract remote IP from context if available
+	remoteIP := ""
+	if ip := ctx.Value("RemoteIP"); ip != nil {
+		if ipStr, ok := ip.(string); ok {
+			remoteIP = ipStr
 		}
+	}
 
-		valid, err := s.turnstileValidator.ValidateToken(ctx, req.TurnstileToken, remoteIP)
+	valid, err := s.turnstileValidator.ValidateToken(ctx, token, remoteIP)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to validate Turnstile token")
+		return fmt.Errorf("%w: turnstile validation error: %v", ErrInvalidMessageRequest, err)
+	}
+	if !valid {
+		logging.Warn().Msg("Turnstile token validation failed")
+		return fmt.Errorf("%w: turnstile validation failed", ErrInvalidMessageRequest)
+	}
+	logging.Debug().Msg("Turnstile token validated successfully")
+	return nil
+}
+
+// submitMessageForRecipients stores an independent copy of the message for each recipient, so
+// every recipient gets their own ID, key, view counter and reminders. The top-level response
+// fields describe the first recipient's message.
+func (s *MessageService) submitMessageForRecipients(
+	ctx context.Context,
+	req MessageSubmissionRequest,
+) (*MessageSubmissionResponse, error) {
+	var response *MessageSubmissionResponse
+	recipients := make([]RecipientMessage, 0, len(req.Recipients))
+
+	for _, recipient := range req.Recipients {
+		recipientReq := req
+		recipientReq.Recipients = nil
+		recipientReq.RecipientName = recipient.Name
+		recipientReq.RecipientEmail = recipient.Email
+
+		recipientResp, err := s.submitMessageForRecipient(ctx, recipientReq)
 		if err != nil {
-			logging.Error().Err(err).Msg("Failed to validate Turnstile token")
-			return nil, fmt.Errorf("%w: turnstile validation error: %v", ErrInvalidMessageRequest, err)
+			// Don't leave a partial set of links behind; the caller never received them
+			messageIDs := make([]string, 0, len(recipients))
+			for _, stored := range recipients {
+				messageIDs = append(messageIDs, stored.MessageID)
+			}
+			s.deleteSubmittedMessages(ctx, messageIDs)
+			return nil, err
 		}
-		if !valid {
-			logging.Warn().Msg("Turnstile token validation failed")
-			return nil, fmt.Errorf("%w: turnstile validation failed", ErrInvalidMessageRequest)
+
+		if response == nil {
+			response = recipientResp
 		}
-		logging.Debug().Msg("Turnstile token validated successfully")
-	} else {
-		logging.Debug().Msg("Skipping Turnstile validation - email notifications disabled")
-	}
-	// Generate encryption key
-	encryptionKey, err := s.encryptionService.GenerateKey(ctx, 32)
-	if err != nil {
-		logging.Error().Err(err).Msg("Failed to generate encryption key")
-		return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		recipients = append(recipients, RecipientMessage{
+			RecipientName:   recipient.Name,
+			RecipientEmail:  recipient.Email,
+			MessageID:       recipientResp.MessageID,
+			Key:             recipientResp.Key,
+			DecryptURL:      recipientResp.DecryptURL,
+			RevocationToken: recipientResp.RevocationToken,
+			RevokeURL:       recipientResp.RevokeURL,
+			StatusURL:       recipientResp.StatusURL,
+		})
 	}
 
-	// Encrypt the message content
-	encryptedContent, err := s.encryptionService.Encrypt(ctx, []string{req.Content}, encryptionKey)
-	if err != nil {
-		logging.Error().Err(err).Msg("Failed to encrypt message content")
-		return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	response.Recipients = recipients
+	logging.Info().Int("recipients", len(recipients)).Msg("Multi-recipient message submitted successfully")
+	return response, nil
+}
+
+// deleteSubmittedMessages removes the messages already stored by a submission that failed part way
+func (s *MessageService) deleteSubmittedMessages(ctx context.Context, messageIDs []string) {
+	for _, messageID := range messageIDs {
+		deleteReq := MessageRetrievalStorageRequest{MessageID: messageID}
+		if err := s.storageService.DeleteMessage(ctx, deleteReq); err != nil {
+			logging.Error().
+				Err(err).
+				Str("messageId", messageID).
+				Msg("Failed to delete message after submission failed")
+		}
 	}
+}
 
-	// Generate unique ID
+// submitMessageForRecipient encrypts and stores the message for req.RecipientEmail and sends
+// its notification. The request must already be validated.
+func (s *MessageService) submitMessageForRecipient(
+	ctx context.Context,
+	req MessageSubmissionRequest,
+) (*MessageSubmissionResponse, error) {
+	// Client-encrypted content arrives as ciphertext and the key never reaches the server,
+	// so key generation and server-side encryption only apply to plaintext submissions.
+	// Content sealed to a recipient public key has no symmetric key at all.
+	// With PassphraseKey, the content key is derived from both the URL key and the passphrase,
+	// so the content cannot be decrypted without the passphrase even with database access.
+	// The stored salt records this so retrieval knows to derive the key again.
+	// The ID is generated first so server-side ciphertexts can be bound to it.
 	messageID, err := s.encryptionService.GenerateID(ctx)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to generate message ID")
 		return nil, fmt.Errorf("%w: %v", ErrGenerateIDFailed, err)
 	}
 
-	// Hash passphrase if provided
+	var encryptionKey, contentKey, passphraseKeySalt []byte
+	encryptedContent := []string{req.Content}
+	switch {
+	case req.RecipientPublicKey != "":
+		sealed, err := s.encryptionService.EncryptToPublicKey(ctx, []byte(req.Content), req.RecipientPublicKey)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to encrypt message content to recipient public key")
+			if errors.Is(err, ErrInvalidPublicKey) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+		encryptedContent = []string{sealed}
+	case !req.ClientEncrypted:
+		encryptionKey, err = s.encryptionService.GenerateKey(ctx, 32)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to generate encryption key")
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+
+		contentKey = encryptionKey
+		if req.PassphraseKey {
+			contentKey, passphraseKeySalt, err = s.encryptionService.DerivePassphraseKey(ctx, encryptionKey, req.Passphrase, nil)
+			if err != nil {
+				logging.Error().Err(err).Msg("Failed to derive passphrase key")
+				return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+			}
+		}
+
+		// Encrypt the message content, bound to its ID so it cannot be moved to another message
+		encryptedContent, err = s.encryptionService.Encrypt(ctx, []string{req.Content}, contentKey, []byte(messageID))
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to encrypt message content")
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+	}
+
+	// Encrypt the attachment with the content key, binding its chunks to the message ID
+	var storedAttachment *StoredAttachment
+	if req.Attachment != nil {
+		storedAttachment, err = s.encryptAttachment(ctx, messageID, req.Attachment, contentKey)
+		if err != nil {
+			logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to encrypt attachment")
+			return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+		}
+	}
+
+	// Hash passphrase if provided. With PassphraseKey it also protects the content key, and the
+	// hash only lets a wrong passphrase be rejected early with a clear error.
 	hashedPassphrase := ""
 	if strings.TrimSpace(req.Passphrase) != "" {
 		hashedPassphrase, err = s.passwordHasher.Hash(ctx, req.Passphrase)
@@ -115,9 +245,17 @@ func (s *MessageService) SubmitMessage(
 		}
 	}
 
-	// Build the decryption URL
+	// Build the decryption URL. For client-encrypted messages the key is empty, leaving
+	// a trailing slash the browser completes with "#<key>" so the key stays out of requests.
 	decryptURL := s.urlBuilder.BuildDecryptURL(messageID, encryptionKey)
 
+	// The revocation token lets the sender delete the message early; only its hash is stored
+	revocationToken, err := generateRevocationToken()
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to generate revocation token")
+		return nil, fmt.Errorf("%w: %v", ErrGenerateIDFailed, err)
+	}
+
 	// Determine max view count (use request value or default from config)
 	maxViewCount := req.MaxViewCount
 	if maxViewCount <= 0 {
@@ -126,6 +264,9 @@ func (s *MessageService) SubmitMessage(
 			maxViewCount = 5 // Fallback default
 		}
 	}
+	if req.Attachment != nil && maxViewCount < MinAttachmentViewCount {
+		maxViewCount = MinAttachmentViewCount
+	}
 
 	// Determine TTL: use provided ExpirationHours or fall back to default
 	ttl := DefaultMessageTTL
@@ -136,11 +277,18 @@ func (s *MessageService) SubmitMessage(
 
 	// Store the encrypted message
 	storeReq := MessageStorageRequest{
-		MessageID:    messageID,
-		Content:      strings.Join(encryptedContent, ""),
-		Passphrase:   hashedPassphrase,
-		MaxViewCount: maxViewCount,
-		ExpiresAt:    &expiresAt,
+		MessageID:           messageID,
+		Content:             strings.Join(encryptedContent, ""),
+		Passphrase:          hashedPassphrase,
+		MaxViewCount:        maxViewCount,
+		ExpiresAt:           &expiresAt,
+		ClientEncrypted:     req.ClientEncrypted,
+		Attachment:          storedAttachment,
+		RevocationTokenHash: hashRevocationToken(revocationToken),
+		PublicKeyEncrypted:  req.RecipientPublicKey != "",
+	}
+	if passphraseKeySalt != nil {
+		storeReq.PassphraseKeySalt = base64.RawURLEncoding.EncodeToString(passphraseKeySalt)
 	}
 
 	// Only store recipient email if email notifications are enabled
@@ -148,15 +296,20 @@ func (s *MessageService) SubmitMessage(
 		storeReq.RecipientEmail = req.RecipientEmail
 	}
 
-	err = s.storageService.StoreMessage(ctx, storeReq)
-	if err != nil {
-		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to store message")
-		return nil, fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	// Only store sender email if the sender asked for read receipts
+	if req.NotifyOnView {
+		storeReq.SenderEmail = req.SenderEmail
+	}
+
+	if req.WebhookURL != "" {
+		storeReq.WebhookURL = req.WebhookURL
+		storeReq.WebhookSecret = req.WebhookSecret
 	}
 
-	// Send notification if requested
+	// The notification is stored with the message and relayed to the queue from the outbox, so a
+	// queue outage delays the email instead of losing it
 	if req.SendNotification && strings.TrimSpace(req.RecipientEmail) != "" {
-		notificationReq := MessageNotificationRequest{
+		storeReq.Notification = &MessageNotificationRequest{
 			SenderName:     req.SenderName,
 			SenderEmail:    req.SenderEmail,
 			RecipientName:  req.RecipientName,
@@ -164,20 +317,33 @@ func (s *MessageService) SubmitMessage(
 			MessageURL:     decryptURL,
 			AdditionalInfo: req.AdditionalInfo,
 		}
+	}
 
-		err = s.notificationService.SendMessageNotification(ctx, notificationReq)
-		if err != nil {
-			logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to send notification")
-			// Don't fail the entire operation for notification errors
-		}
+	err = s.storageService.StoreMessage(ctx, storeReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to store message")
+		return nil, fmt.Errorf("%w: %v", ErrStorageFailed, err)
 	}
 
+	s.sendWebhookEvent(ctx, MessageWebhookEvent{
+		Event:         WebhookEventCreated,
+		MessageID:     messageID,
+		MaxViewCount:  maxViewCount,
+		WebhookURL:    req.WebhookURL,
+		WebhookSecret: req.WebhookSecret,
+	})
+
 	response := &MessageSubmissionResponse{
-		MessageID:  messageID,
-		DecryptURL: decryptURL,
-		Key:        base64.URLEncoding.EncodeToString(encryptionKey),
-		ExpiresAt:  &expiresAt,
-		Success:    true,
+		MessageID:       messageID,
+		DecryptURL:      decryptURL,
+		RevocationToken: revocationToken,
+		RevokeURL:       s.urlBuilder.BuildRevokeURL(messageID, revocationToken),
+		StatusURL:       s.urlBuilder.BuildStatusURL(messageID, revocationToken),
+		ExpiresAt:       &expiresAt,
+		Success:         true,
+	}
+	if encryptionKey != nil {
+		response.Key = base64.URLEncoding.EncodeToString(encryptionKey)
 	}
 
 	logging.Info().Str("messageId", messageID).Str("url", decryptURL).Msg("Message submitted successfully")
@@ -202,16 +368,39 @@ func (s *MessageService) RetrieveMessage(
 		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
 	}
 
+	// Without its key a server-encrypted message cannot be decrypted, so refuse before a view
+	// or passphrase attempt is used up
+	if len(req.DecryptionKey) == 0 && !storedMessageMeta.ClientEncrypted && !storedMessageMeta.PublicKeyEncrypted {
+		logging.Warn().Str("messageId", req.MessageID).Msg("Decryption key missing for server-encrypted message")
+		return nil, ErrMissingDecryptionKey
+	}
+
 	// Verify passphrase if required BEFORE retrieving full message and incrementing view count
-	if storedMessageMeta.HasPassphrase {
-		valid, err := s.passwordHasher.Verify(ctx, req.Passphrase, storedMessageMeta.HashedPassphrase)
+	if err := s.verifyPassphrase(ctx, req, storedMessageMeta); err != nil {
+		return nil, err
+	}
+
+	contentKey, err := s.contentKey(ctx, req, storedMessageMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Open a public-key message before counting the view, so a wrong or locked private key
+	// does not use one up
+	var sealedPlaintext []byte
+	if storedMessageMeta.PublicKeyEncrypted && req.PrivateKey != "" {
+		sealedPlaintext, err = s.encryptionService.DecryptWithPrivateKey(
+			ctx,
+			storedMessageMeta.EncryptedContent,
+			req.PrivateKey,
+			req.PrivateKeyPassphrase,
+		)
 		if err != nil {
-			logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to verify passphrase")
-			return nil, fmt.Errorf("%w: %v", ErrPasswordVerificationFailed, err)
-		}
-		if !valid {
-			logging.Warn().Str("messageId", req.MessageID).Msg("Invalid passphrase provided")
-			return nil, ErrInvalidPassphrase
+			logging.Warn().Err(err).Str("messageId", req.MessageID).Msg("Failed to decrypt message with private key")
+			if errors.Is(err, ErrInvalidPrivateKey) {
+				return nil, ErrInvalidPrivateKey
+			}
+			return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
 		}
 	}
 
@@ -222,11 +411,56 @@ func (s *MessageService) RetrieveMessage(
 		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
 	}
 
+	// Public-key messages are returned armored unless the recipient handed over their private key
+	if storedMessage.PublicKeyEncrypted {
+		content := storedMessage.EncryptedContent
+		if sealedPlaintext != nil {
+			content = string(sealedPlaintext)
+		}
+		logging.Debug().
+			Str("messageId", req.MessageID).
+			Bool("decrypted", sealedPlaintext != nil).
+			Int("viewCount", storedMessage.ViewCount).
+			Msg("Public-key message retrieved successfully")
+		s.sendReadReceipt(ctx, req.MessageID, storedMessage)
+		s.sendViewWebhookEvents(ctx, req.MessageID, storedMessage)
+		return &MessageRetrievalResponse{
+			MessageID:          req.MessageID,
+			Content:            content,
+			ViewCount:          storedMessage.ViewCount,
+			MaxViewCount:       storedMessage.MaxViewCount,
+			ExpiresAt:          storedMessage.ExpiresAt,
+			PublicKeyEncrypted: true,
+			Success:            true,
+		}, nil
+	}
+
+	// Client-encrypted messages are returned as stored; only the browser holding the
+	// fragment key can decrypt them.
+	if storedMessage.ClientEncrypted {
+		logging.Debug().
+			Str("messageId", req.MessageID).
+			Int("viewCount", storedMessage.ViewCount).
+			Msg("Client-encrypted message retrieved successfully")
+		s.sendReadReceipt(ctx, req.MessageID, storedMessage)
+		s.sendViewWebhookEvents(ctx, req.MessageID, storedMessage)
+		return &MessageRetrievalResponse{
+			MessageID:       req.MessageID,
+			Content:         storedMessage.EncryptedContent,
+			ViewCount:       storedMessage.ViewCount,
+			MaxViewCount:    storedMessage.MaxViewCount,
+			ExpiresAt:       storedMessage.ExpiresAt,
+			ClientEncrypted: true,
+			Success:         true,
+		}, nil
+	}
+
 	// Decrypt the message content
 	decryptedContent, err := s.encryptionService.Decrypt(
 		ctx,
 		[]string{storedMessage.EncryptedContent},
-		req.DecryptionKey,
+		contentKey,
+		[]byte(req.MessageID),
 	)
 	if err != nil {
 		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to decrypt message content")
@@ -253,13 +487,143 @@ func (s *MessageService) RetrieveMessage(
 		Success:      true,
 	}
 
+	if storedMessage.Attachment != nil {
+		response.Attachment, err = s.decryptAttachmentInfo(ctx, req.MessageID, storedMessage.Attachment, contentKey)
+		if err != nil {
+			logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to decrypt attachment metadata")
+			return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+		}
+	}
+
 	logging.Debug().
 		Str("messageId", req.MessageID).
 		Int("viewCount", storedMessage.ViewCount).
 		Msg("Message retrieved successfully")
+	s.sendReadReceipt(ctx, req.MessageID, storedMessage)
+	s.sendViewWebhookEvents(ctx, req.MessageID, storedMessage)
 	return response, nil
 }
 
+// sendReadReceipt tells the sender their message was viewed if they opted in. Failures
+// are logged but never fail the retrieval, since the recipient has already used a view.
+func (s *MessageService) sendReadReceipt(ctx context.Context, messageID string, storedMessage *MessageStorageResponse) {
+	if strings.TrimSpace(storedMessage.SenderEmail) == "" {
+		return
+	}
+
+	err := s.notificationService.SendReadReceipt(ctx, MessageReadReceiptRequest{
+		MessageID:    messageID,
+		SenderEmail:  storedMessage.SenderEmail,
+		ViewCount:    storedMessage.ViewCount,
+		MaxViewCount: storedMessage.MaxViewCount,
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to send read receipt")
+	}
+}
+
+// sendViewWebhookEvents reports a view, and the view that used up the message, to the sender's webhook
+func (s *MessageService) sendViewWebhookEvents(ctx context.Context, messageID string, storedMessage *MessageStorageResponse) {
+	event := MessageWebhookEvent{
+		Event:         WebhookEventViewed,
+		MessageID:     messageID,
+		ViewCount:     storedMessage.ViewCount,
+		MaxViewCount:  storedMessage.MaxViewCount,
+		WebhookURL:    storedMessage.WebhookURL,
+		WebhookSecret: storedMessage.WebhookSecret,
+	}
+	s.sendWebhookEvent(ctx, event)
+
+	if storedMessage.MaxViewCount > 0 && storedMessage.ViewCount >= storedMessage.MaxViewCount {
+		event.Event = WebhookEventExhausted
+		s.sendWebhookEvent(ctx, event)
+	}
+}
+
+// sendWebhookEvent stamps and queues a lifecycle event. Like read receipts, failures are
+// logged but never fail the operation that triggered them.
+func (s *MessageService) sendWebhookEvent(ctx context.Context, event MessageWebhookEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	if err := s.notificationService.SendWebhookEvent(ctx, event); err != nil {
+		logging.Error().Err(err).Str("messageId", event.MessageID).Str("event", event.Event).Msg("Failed to send webhook event")
+	}
+}
+
+// RetrieveAttachment decrypts a message's attachment for download. The download counts
+// toward the message's max view count, but only once the file has been decrypted, so a
+// wrong key does not consume a view.
+func (s *MessageService) RetrieveAttachment(
+	ctx context.Context,
+	req MessageRetrievalRequest,
+) (*AttachmentDownloadResponse, error) {
+	logging.Debug().Str("messageId", req.MessageID).Msg("Processing attachment retrieval")
+
+	storageReq := MessageRetrievalStorageRequest{
+		MessageID: req.MessageID,
+	}
+
+	storedMessageMeta, err := s.storageService.GetMessage(ctx, storageReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to get stored message metadata")
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+	if storedMessageMeta.Attachment == nil {
+		logging.Warn().Str("messageId", req.MessageID).Msg("Attachment requested for message without one")
+		return nil, ErrAttachmentNotFound
+	}
+
+	if err := s.verifyPassphrase(ctx, req, storedMessageMeta); err != nil {
+		return nil, err
+	}
+
+	contentKey, err := s.contentKey(ctx, req, storedMessageMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	storedAttachment, err := s.storageService.GetAttachment(ctx, storageReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to retrieve stored attachment")
+		return nil, fmt.Errorf("%w: %v", ErrAttachmentNotFound, err)
+	}
+
+	data, err := s.encryptionService.DecryptFile(ctx, storedAttachment.Chunks, contentKey, []byte(req.MessageID))
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to decrypt attachment")
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	info, err := s.decryptAttachmentInfo(ctx, req.MessageID, storedAttachment, contentKey)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to decrypt attachment metadata")
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	// Count the download as a view; this deletes the message once the limit is reached
+	storedMessage, err := s.storageService.RetrieveMessage(ctx, storageReq)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to record attachment download")
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+
+	logging.Debug().
+		Str("messageId", req.MessageID).
+		Int("viewCount", storedMessage.ViewCount).
+		Int("attachmentBytes", len(data)).
+		Msg("Attachment retrieved successfully")
+	s.sendViewWebhookEvents(ctx, req.MessageID, storedMessage)
+	return &AttachmentDownloadResponse{
+		MessageID:    req.MessageID,
+		Filename:     info.Filename,
+		ContentType:  info.ContentType,
+		Data:         data,
+		ViewCount:    storedMessage.ViewCount,
+		MaxViewCount: storedMessage.MaxViewCount,
+	}, nil
+}
+
 // CheckMessageAccess checks if a message exists and whether it requires a passphrase
 func (s *MessageService) CheckMessageAccess(ctx context.Context, messageID string) (*MessageAccessInfo, error) {
 	logging.Debug().Str("messageId", messageID).Msg("Checking message access")
@@ -277,9 +641,18 @@ func (s *MessageService) CheckMessageAccess(ctx context.Context, messageID strin
 	accessInfo := &MessageAccessInfo{
 		MessageID:          messageID,
 		RequiresPassphrase: storedMessage.HasPassphrase,
+		ClientEncrypted:    storedMessage.ClientEncrypted,
+		PublicKeyEncrypted: storedMessage.PublicKeyEncrypted,
 		Exists:             true,
 		ExpiresAt:          storedMessage.ExpiresAt,
 	}
+	if storedMessage.HasPassphrase {
+		accessInfo.RemainingPassphraseAttempts = max(passphraseMaxAttempts()-storedMessage.FailedPassphraseAttempts, 0)
+	}
+	if storedMessage.Attachment != nil {
+		accessInfo.HasAttachment = true
+		accessInfo.AttachmentSize = storedMessage.Attachment.SizeBytes
+	}
 
 	logging.Debug().
 		Str("messageId", messageID).
@@ -288,12 +661,135 @@ func (s *MessageService) CheckMessageAccess(ctx context.Context, messageID strin
 	return accessInfo, nil
 }
 
+// RevokeMessage deletes a message before it is viewed or expires. The request must carry
+// the revocation token returned when the message was submitted.
+func (s *MessageService) RevokeMessage(ctx context.Context, req MessageRevocationRequest) error {
+	logging.Info().Str("messageId", req.MessageID).Msg("Processing message revocation")
+
+	storedMessage, err := s.getMessageForSender(ctx, req.MessageID, req.RevocationToken)
+	if err != nil {
+		return err
+	}
+
+	storageReq := MessageRetrievalStorageRequest{
+		MessageID: req.MessageID,
+	}
+	if err := s.storageService.DeleteMessage(ctx, storageReq); err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to delete revoked message")
+		return fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+
+	s.sendWebhookEvent(ctx, MessageWebhookEvent{
+		Event:         WebhookEventRevoked,
+		MessageID:     req.MessageID,
+		ViewCount:     storedMessage.ViewCount,
+		MaxViewCount:  storedMessage.MaxViewCount,
+		WebhookURL:    storedMessage.WebhookURL,
+		WebhookSecret: storedMessage.WebhookSecret,
+	})
+
+	logging.Info().Str("messageId", req.MessageID).Msg("Message revoked successfully")
+	return nil
+}
+
+// GetMessageStatus reports how a message has been accessed to the holder of the sender's token.
+// It never decrypts the message or returns the recipient's address.
+func (s *MessageService) GetMessageStatus(ctx context.Context, req MessageStatusRequest) (*MessageStatusResponse, error) {
+	logging.Info().Str("messageId", req.MessageID).Msg("Processing message status request")
+
+	storedMessage, err := s.getMessageForSender(ctx, req.MessageID, req.RevocationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.storageService.GetReminderHistory(ctx, storedMessage.StorageID)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to retrieve reminder history")
+		return nil, fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+
+	remainingViews := storedMessage.MaxViewCount - storedMessage.ViewCount
+	if remainingViews < 0 {
+		remainingViews = 0
+	}
+
+	status := &MessageStatusResponse{
+		MessageID:                req.MessageID,
+		ViewCount:                storedMessage.ViewCount,
+		MaxViewCount:             storedMessage.MaxViewCount,
+		RemainingViews:           remainingViews,
+		ExpiresAt:                storedMessage.ExpiresAt,
+		ClientEncrypted:          storedMessage.ClientEncrypted,
+		PublicKeyEncrypted:       storedMessage.PublicKeyEncrypted,
+		HasPassphrase:            storedMessage.HasPassphrase,
+		HasAttachment:            storedMessage.Attachment != nil,
+		ReadReceipts:             storedMessage.SenderEmail != "",
+		Reminders:                reminders,
+		FailedPassphraseAttempts: storedMessage.FailedPassphraseAttempts,
+	}
+
+	logging.Info().
+		Str("messageId", req.MessageID).
+		Int("viewCount", status.ViewCount).
+		Int("reminderCount", len(reminders)).
+		Msg("Message status retrieved")
+	return status, nil
+}
+
+// getMessageForSender loads a message without counting a view and checks the sender's token
+// against the stored hash in constant time.
+func (s *MessageService) getMessageForSender(
+	ctx context.Context,
+	messageID string,
+	revocationToken string,
+) (*MessageStorageResponse, error) {
+	if strings.TrimSpace(revocationToken) == "" {
+		return nil, ErrInvalidRevocationToken
+	}
+
+	storedMessage, err := s.storageService.GetMessage(ctx, MessageRetrievalStorageRequest{MessageID: messageID})
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to retrieve message for sender")
+		return nil, fmt.Errorf("%w: %v", ErrMessageNotFound, err)
+	}
+
+	// Messages stored before revocation tokens existed have no hash and cannot be managed
+	if storedMessage.RevocationTokenHash == "" ||
+		subtle.ConstantTimeCompare(
+			[]byte(hashRevocationToken(revocationToken)),
+			[]byte(storedMessage.RevocationTokenHash),
+		) != 1 {
+		logging.Warn().Str("messageId", messageID).Msg("Invalid revocation token provided")
+		return nil, ErrInvalidRevocationToken
+	}
+
+	return storedMessage, nil
+}
+
 // validateSubmissionRequest validates the message submission request
 func (s *MessageService) validateSubmissionRequest(req MessageSubmissionRequest) error {
-	if strings.TrimSpace(req.Content) == "" {
+	// A message may consist of just an attachment
+	if strings.TrimSpace(req.Content) == "" && req.Attachment == nil {
 		return fmt.Errorf("message content is required")
 	}
 
+	if req.Attachment != nil {
+		if err := validateAttachment(req); err != nil {
+			return err
+		}
+	}
+
+	if req.ClientEncrypted {
+		if err := validateClientCiphertext(req.Content); err != nil {
+			return err
+		}
+		// Notification emails carry the decrypt link, which the server cannot build
+		// without the key.
+		if req.SendNotification {
+			return fmt.Errorf("email notifications are not supported for client-encrypted messages")
+		}
+	}
+
 	// Validate max view count if provided
 	if req.MaxViewCount != 0 {
 		if req.MaxViewCount < 1 || req.MaxViewCount > 100 {
@@ -327,19 +823,464 @@ func (s *MessageService) validateSubmissionRequest(req MessageSubmissionRequest)
 			return ErrInvalidEmailAddress
 		}
 
-		if strings.TrimSpace(req.RecipientName) == "" {
-			return fmt.Errorf("recipient name is required when sending notifications")
+		// Multi-recipient submissions are checked below
+		if len(req.Recipients) == 0 {
+			if strings.TrimSpace(req.RecipientName) == "" {
+				return fmt.Errorf("recipient name is required when sending notifications")
+			}
+
+			if strings.TrimSpace(req.RecipientEmail) == "" {
+				return fmt.Errorf("recipient email is required when sending notifications")
+			}
+
+			// Basic email validation for recipient
+			if !strings.Contains(req.RecipientEmail, "@") {
+				return ErrInvalidEmailAddress
+			}
+		}
+	}
+
+	if len(req.Recipients) > 0 {
+		if err := validateRecipients(req); err != nil {
+			return err
+		}
+	}
+
+	if req.RecipientPublicKey != "" {
+		if err := validateRecipientPublicKey(req); err != nil {
+			return err
 		}
+	}
 
-		if strings.TrimSpace(req.RecipientEmail) == "" {
-			return fmt.Errorf("recipient email is required when sending notifications")
+	if req.SplitShares != 0 || req.SplitThreshold != 0 {
+		if err := validateSplit(req); err != nil {
+			return err
 		}
+	}
 
-		// Basic email validation for recipient
-		if !strings.Contains(req.RecipientEmail, "@") {
+	// The passphrase key is derived on the server from the passphrase and the URL key
+	if req.PassphraseKey {
+		if strings.TrimSpace(req.Passphrase) == "" {
+			return fmt.Errorf("a passphrase is required to derive the content key from it")
+		}
+		if req.ClientEncrypted || req.RecipientPublicKey != "" {
+			return fmt.Errorf("the passphrase key is only available for server-encrypted messages")
+		}
+	}
+
+	// Read receipts go back to the sender, so they need somewhere to go
+	if req.NotifyOnView {
+		if strings.TrimSpace(req.SenderEmail) == "" {
+			return fmt.Errorf("sender email is required for read receipts")
+		}
+		if !strings.Contains(req.SenderEmail, "@") {
 			return ErrInvalidEmailAddress
 		}
 	}
 
+	if req.WebhookURL != "" || req.WebhookSecret != "" {
+		if err := validateWebhook(req.WebhookURL, req.WebhookSecret); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// validateRecipients checks a multi-recipient submission. Each recipient gets a separately
+// encrypted copy, which is impossible when the key never reaches the server.
+func validateRecipients(req MessageSubmissionRequest) error {
+	if req.RecipientName != "" || req.RecipientEmail != "" {
+		return fmt.Errorf("use either a single recipient or a recipient list, not both")
+	}
+	if len(req.Recipients) > MaxRecipients {
+		return fmt.Errorf("a message can be shared with at most %d recipients", MaxRecipients)
+	}
+	if req.ClientEncrypted {
+		return fmt.Errorf("multiple recipients are not supported for client-encrypted messages")
+	}
+
+	seen := make(map[string]bool, len(req.Recipients))
+	for _, recipient := range req.Recipients {
+		if strings.TrimSpace(recipient.Email) == "" {
+			return fmt.Errorf("recipient email is required for every recipient")
+		}
+		if !strings.Contains(recipient.Email, "@") {
+			return ErrInvalidEmailAddress
+		}
+		if req.SendNotification && strings.TrimSpace(recipient.Name) == "" {
+			return fmt.Errorf("recipient name is required when sending notifications")
+		}
+
+		email := strings.ToLower(strings.TrimSpace(recipient.Email))
+		if seen[email] {
+			return fmt.Errorf("each recipient may only be listed once")
+		}
+		seen[email] = true
+	}
+	return nil
+}
+
+// validateRecipientPublicKey checks that a submission sealed to a public key uses only features
+// that work without a symmetric key. The key itself is parsed when the content is encrypted.
+func validateRecipientPublicKey(req MessageSubmissionRequest) error {
+	if len(req.RecipientPublicKey) > MaxRecipientPublicKeyLength {
+		return fmt.Errorf("recipient public key must be no longer than %d characters", MaxRecipientPublicKeyLength)
+	}
+	if req.ClientEncrypted {
+		return fmt.Errorf("a recipient public key cannot be combined with client-side encryption")
+	}
+	// One key means one recipient; a recipient list would seal every copy to the same person
+	if len(req.Recipients) > 0 {
+		return fmt.Errorf("a recipient public key cannot be combined with a recipient list")
+	}
+	if req.Attachment != nil {
+		return fmt.Errorf("attachments are not supported for messages sealed to a public key")
+	}
+	return nil
+}
+
+// validateWebhook checks a per-message webhook. Payloads are signed with the secret, so
+// it must be long enough to resist guessing, and only https targets are accepted.
+func validateWebhook(webhookURL, secret string) error {
+	if webhookURL == "" {
+		return fmt.Errorf("webhook URL is required when a webhook secret is set")
+	}
+	if len(webhookURL) > MaxWebhookURLLength {
+		return fmt.Errorf("webhook URL must be no longer than %d characters", MaxWebhookURLLength)
+	}
+	parsed, err := url.Parse(webhookURL)
+	if err != nil {
+		return fmt.Errorf("webhook URL is invalid: %w", err)
+	}
+	if parsed.Scheme != "https" || parsed.Hostname() == "" {
+		return fmt.Errorf("webhook URL must be an absolute https URL")
+	}
+	if parsed.User != nil {
+		return fmt.Errorf("webhook URL must not contain credentials")
+	}
+	if len(secret) < MinWebhookSecretLength || len(secret) > MaxWebhookSecretLength {
+		return fmt.Errorf(
+			"webhook secret must be between %d and %d characters",
+			MinWebhookSecretLength,
+			MaxWebhookSecretLength,
+		)
+	}
+	return nil
+}
+
+// validateClientCiphertext checks that client-encrypted content is well-formed
+// base64url(nonce || ciphertext || tag). It cannot verify the ciphertext itself.
+func validateClientCiphertext(content string) error {
+	decoded, err := base64.URLEncoding.DecodeString(content)
+	if err != nil {
+		return fmt.Errorf("client-encrypted content must be base64url encoded: %w", err)
+	}
+	if len(decoded) < MinClientCiphertextBytes {
+		return fmt.Errorf("client-encrypted content is too short to contain a nonce and authentication tag")
+	}
+	return nil
+}
+
+// validateAttachment checks that an attachment can be stored with the submission
+func validateAttachment(req MessageSubmissionRequest) error {
+	// The server cannot encrypt the file with a key it never sees
+	if req.ClientEncrypted {
+		return fmt.Errorf("attachments are not supported for client-encrypted messages")
+	}
+	if strings.TrimSpace(req.Attachment.Filename) == "" {
+		return fmt.Errorf("attachment file name is required")
+	}
+	if len(req.Attachment.Data) > MaxAttachmentBytes {
+		return fmt.Errorf("attachment must be no larger than %d MiB", MaxAttachmentBytes>>20)
+	}
+	if req.MaxViewCount != 0 && req.MaxViewCount < MinAttachmentViewCount {
+		return fmt.Errorf(
+			"messages with an attachment need a max view count of at least %d: one to open the message and one to download the file",
+			MinAttachmentViewCount,
+		)
+	}
+	return nil
+}
+
+// encryptAttachment seals the attachment data in chunks bound to the message ID and
+// encrypts its file name and content type with the message key.
+func (s *MessageService) encryptAttachment(
+	ctx context.Context,
+	messageID string,
+	attachment *Attachment,
+	key []byte,
+) (*StoredAttachment, error) {
+	chunks, err := s.encryptionService.EncryptFile(ctx, attachment.Data, key, []byte(messageID))
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := attachment.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	metadata, err := s.encryptionService.Encrypt(ctx, []string{attachment.Filename, contentType}, key, []byte(messageID))
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) != 2 {
+		return nil, fmt.Errorf("expected 2 encrypted attachment fields, got %d", len(metadata))
+	}
+
+	return &StoredAttachment{
+		Filename:    metadata[0],
+		ContentType: metadata[1],
+		SizeBytes:   int64(len(attachment.Data)),
+		Chunks:      chunks,
+	}, nil
+}
+
+// decryptAttachmentInfo decrypts an attachment's file name and content type
+func (s *MessageService) decryptAttachmentInfo(
+	ctx context.Context,
+	messageID string,
+	stored *StoredAttachment,
+	key []byte,
+) (*AttachmentInfo, error) {
+	decrypted, err := s.encryptionService.Decrypt(ctx, []string{stored.Filename, stored.ContentType}, key, []byte(messageID))
+	if err != nil {
+		return nil, err
+	}
+	if len(decrypted) != 2 {
+		return nil, fmt.Errorf("expected 2 decrypted attachment fields, got %d", len(decrypted))
+	}
+
+	// Decrypt returns base64url-encoded plaintext
+	fields := make([]string, len(decrypted))
+	for i, encoded := range decrypted {
+		decoded, err := base64.URLEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDecodingFailed, err)
+		}
+		fields[i] = string(decoded)
+	}
+
+	return &AttachmentInfo{
+		Filename:    fields[0],
+		ContentType: fields[1],
+		Size:        stored.SizeBytes,
+	}, nil
+}
+
+// verifyPassphrase checks the request passphrase against the stored hash when the message has one.
+// Every guess reserves an attempt in storage before it is verified, so concurrent guesses cannot
+// get past the limit, which also holds across server instances and restarts. A right guess gives
+// its attempt back.
+func (s *MessageService) verifyPassphrase(
+	ctx context.Context,
+	req MessageRetrievalRequest,
+	stored *MessageStorageResponse,
+) error {
+	if !stored.HasPassphrase {
+		return nil
+	}
+
+	maxAttempts := passphraseMaxAttempts()
+	if stored.FailedPassphraseAttempts >= maxAttempts {
+		logging.Warn().Str("messageId", req.MessageID).Msg("Retrieval refused for locked message")
+		return ErrMessageLocked
+	}
+
+	// Pages probe with an empty passphrase to learn whether to ask for one. It can never be
+	// right, since blank passphrases are not stored, so it does not count as a guess.
+	if req.Passphrase == "" {
+		return ErrInvalidPassphrase
+	}
+
+	storageReq := MessageRetrievalStorageRequest{MessageID: req.MessageID}
+	attempts, err := s.storageService.ReservePassphraseAttempt(ctx, storageReq)
+	if err != nil {
+		// Refuse the guess without a remaining count; an uncounted attempt must not go unnoticed
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to reserve passphrase attempt")
+		return fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+	if attempts > maxAttempts {
+		// Concurrent guesses used up the attempts since the message was read. This one was never
+		// verified, so it gives its reservation back and leaves the others to lock or destroy.
+		logging.Warn().Str("messageId", req.MessageID).Msg("Passphrase attempt refused after the limit was reached")
+		s.releasePassphraseAttempt(ctx, req.MessageID)
+		return ErrMessageLocked
+	}
+
+	valid, err := s.passwordHasher.Verify(ctx, req.Passphrase, stored.HashedPassphrase)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to verify passphrase")
+		s.releasePassphraseAttempt(ctx, req.MessageID)
+		return fmt.Errorf("%w: %v", ErrPasswordVerificationFailed, err)
+	}
+	if !valid {
+		logging.Warn().Str("messageId", req.MessageID).Msg("Invalid passphrase provided")
+		return s.handleFailedPassphraseAttempt(ctx, req.MessageID, stored, attempts, maxAttempts)
+	}
+
+	s.releasePassphraseAttempt(ctx, req.MessageID)
+	s.rehashPassphrase(ctx, req, stored)
+	return nil
+}
+
+// releasePassphraseAttempt gives back an attempt reserved by a guess that turned out right or
+// was never checked. It is best effort: a leaked attempt only brings the lockout one guess closer.
+func (s *MessageService) releasePassphraseAttempt(ctx context.Context, messageID string) {
+	storageReq := MessageRetrievalStorageRequest{MessageID: messageID}
+	if err := s.storageService.ReleasePassphraseAttempt(ctx, storageReq); err != nil {
+		logging.Warn().Err(err).Str("messageId", messageID).Msg("Failed to release passphrase attempt")
+	}
+}
+
+// rehashPassphrase upgrades a hash made with an older algorithm or cost now that the plaintext
+// passphrase is known to be right. It is best effort: the message stays readable either way.
+func (s *MessageService) rehashPassphrase(
+	ctx context.Context,
+	req MessageRetrievalRequest,
+	stored *MessageStorageResponse,
+) {
+	// A message on its last view is deleted right after this, so the new hash would never be used
+	if stored.MaxViewCount-stored.ViewCount <= 1 || !s.passwordHasher.NeedsRehash(stored.HashedPassphrase) {
+		return
+	}
+
+	hashed, err := s.passwordHasher.Hash(ctx, req.Passphrase)
+	if err != nil {
+		logging.Warn().Err(err).Str("messageId", req.MessageID).Msg("Failed to rehash passphrase")
+		return
+	}
+	storageReq := MessageRetrievalStorageRequest{MessageID: req.MessageID}
+	if err := s.storageService.UpdatePassphraseHash(ctx, storageReq, hashed); err != nil {
+		logging.Warn().Err(err).Str("messageId", req.MessageID).Msg("Failed to store rehashed passphrase")
+		return
+	}
+
+	logging.Info().Str("messageId", req.MessageID).Msg("Passphrase hash upgraded")
+}
+
+// handleFailedPassphraseAttempt keeps the attempt reserved by a wrong passphrase, audits it and, once
+// the last attempt is used up, locks or destroys the message. The sender hears about every failure.
+func (s *MessageService) handleFailedPassphraseAttempt(
+	ctx context.Context,
+	messageID string,
+	stored *MessageStorageResponse,
+	failedAttempts int,
+	maxAttempts int,
+) error {
+	storageReq := MessageRetrievalStorageRequest{MessageID: messageID}
+	// The audit entry is best effort; the attempt itself is already counted
+	if err := s.storageService.RecordPassphraseFailure(ctx, storageReq); err != nil {
+		logging.Warn().Err(err).Str("messageId", messageID).Msg("Failed to record passphrase failure")
+	}
+
+	event := MessageWebhookEvent{
+		Event:         WebhookEventPassphraseFailed,
+		MessageID:     messageID,
+		ViewCount:     stored.ViewCount,
+		MaxViewCount:  stored.MaxViewCount,
+		WebhookURL:    stored.WebhookURL,
+		WebhookSecret: stored.WebhookSecret,
+	}
+
+	remaining := maxAttempts - failedAttempts
+	if remaining > 0 {
+		s.sendPassphraseEvent(ctx, event, stored)
+		return &PassphraseAttemptError{RemainingAttempts: remaining}
+	}
+
+	if passphraseLockoutAction() == PassphraseLockoutLock {
+		logging.Warn().Str("messageId", messageID).Int("failedAttempts", failedAttempts).Msg("Message locked after too many wrong passphrases")
+		event.Event = WebhookEventLocked
+		s.sendPassphraseEvent(ctx, event, stored)
+		return ErrMessageLocked
+	}
+
+	// If the delete fails the message stays locked, since its attempts are already used up
+	if err := s.storageService.DeleteMessage(ctx, storageReq); err != nil {
+		logging.Error().Err(err).Str("messageId", messageID).Msg("Failed to destroy message after too many wrong passphrases")
+		event.Event = WebhookEventLocked
+		s.sendPassphraseEvent(ctx, event, stored)
+		return ErrMessageLocked
+	}
+
+	logging.Warn().Str("messageId", messageID).Int("failedAttempts", failedAttempts).Msg("Message destroyed after too many wrong passphrases")
+	event.Event = WebhookEventDestroyed
+	s.sendPassphraseEvent(ctx, event, stored)
+	return ErrMessageDestroyed
+}
+
+// sendPassphraseEvent reports a wrong passphrase, or the lockout it caused, to the sender's webhook
+// and, when the sender left an address for read receipts, by email. Failures are only logged.
+func (s *MessageService) sendPassphraseEvent(ctx context.Context, event MessageWebhookEvent, stored *MessageStorageResponse) {
+	s.sendWebhookEvent(ctx, event)
+
+	if strings.TrimSpace(stored.SenderEmail) == "" {
+		return
+	}
+	err := s.notificationService.SendPassphraseAlert(ctx, MessagePassphraseAlertRequest{
+		MessageID:    event.MessageID,
+		SenderEmail:  stored.SenderEmail,
+		Event:        event.Event,
+		ViewCount:    event.ViewCount,
+		MaxViewCount: event.MaxViewCount,
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", event.MessageID).Str("event", event.Event).Msg("Failed to send passphrase alert")
+	}
+}
+
+// passphraseMaxAttempts returns the configured number of wrong passphrases a message tolerates
+func passphraseMaxAttempts() int {
+	if config.AppConfig.PassphraseMaxAttempts > 0 {
+		return config.AppConfig.PassphraseMaxAttempts
+	}
+	return DefaultPassphraseMaxAttempts
+}
+
+// passphraseLockoutAction returns the configured lockout action; anything but "lock" destroys
+func passphraseLockoutAction() string {
+	if strings.EqualFold(strings.TrimSpace(config.AppConfig.PassphraseLockoutAction), PassphraseLockoutLock) {
+		return PassphraseLockoutLock
+	}
+	return PassphraseLockoutDestroy
+}
+
+// contentKey returns the key the message content was encrypted with: the decryption key from
+// the URL, or for passphrase-protected messages the key derived from it and the passphrase
+func (s *MessageService) contentKey(
+	ctx context.Context,
+	req MessageRetrievalRequest,
+	stored *MessageStorageResponse,
+) ([]byte, error) {
+	if stored.PassphraseKeySalt == "" {
+		return req.DecryptionKey, nil
+	}
+
+	salt, err := base64.RawURLEncoding.DecodeString(stored.PassphraseKeySalt)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Stored passphrase key salt is malformed")
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	key, _, err := s.encryptionService.DerivePassphraseKey(ctx, req.DecryptionKey, req.Passphrase, salt)
+	if err != nil {
+		logging.Error().Err(err).Str("messageId", req.MessageID).Msg("Failed to derive passphrase key")
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+	return key, nil
+}
+
+// generateRevocationToken returns a random URL-safe token for revoking a message
+func generateRevocationToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// hashRevocationToken returns the hex SHA-256 of a revocation token as stored alongside the message
+func hashRevocationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
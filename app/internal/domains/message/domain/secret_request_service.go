@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+)
+
+// CreateSecretRequest opens a request for someone else to send the requester a secret. The
+// returned link carries no key; whoever answers it never learns where the secret goes, and
+// only the requester is emailed the link to read it.
+func (s *MessageService) CreateSecretRequest(
+	ctx context.Context,
+	req SecretRequestCreationRequest,
+) (*SecretRequestCreationResponse, error) {
+	logging.Info().
+		Str("requesterEmail", validation.SanitizeEmailForLogging(req.RequesterEmail)).
+		Msg("Processing secret request creation")
+
+	if err := validateSecretRequestCreation(req); err != nil {
+		logging.Error().Err(err).Msg("Invalid secret request")
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMessageRequest, err)
+	}
+
+	// Fulfilling the request emails the requester, so creation is protected like notifications
+	if err := s.validateTurnstileToken(ctx, req.TurnstileToken); err != nil {
+		return nil, err
+	}
+
+	requestID, err := s.encryptionService.GenerateID(ctx)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to generate secret request ID")
+		return nil, fmt.Errorf("%w: %v", ErrGenerateIDFailed, err)
+	}
+
+	ttl := DefaultMessageTTL
+	if req.ExpirationHours > 0 {
+		ttl = time.Duration(req.ExpirationHours) * time.Hour
+	}
+	expiresAt := time.Now().UTC().Add(ttl)
+
+	storeReq := SecretRequestStorageRequest{
+		RequestID:      requestID,
+		RequesterName:  strings.TrimSpace(req.RequesterName),
+		RequesterEmail: strings.TrimSpace(req.RequesterEmail),
+		Description:    strings.TrimSpace(req.Description),
+		ExpiresAt:      &expiresAt,
+	}
+	if err := s.storageService.StoreSecretRequest(ctx, storeReq); err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to store secret request")
+		return nil, fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+
+	logging.Info().Str("requestId", requestID).Msg("Secret request created successfully")
+	return &SecretRequestCreationResponse{
+		RequestID:  requestID,
+		RequestURL: s.urlBuilder.BuildSecretRequestURL(requestID),
+		ExpiresAt:  &expiresAt,
+	}, nil
+}
+
+// GetSecretRequest describes an open secret request to the person fulfilling it
+func (s *MessageService) GetSecretRequest(ctx context.Context, requestID string) (*SecretRequestInfo, error) {
+	logging.Debug().Str("requestId", requestID).Msg("Retrieving secret request")
+
+	stored, err := s.getOpenSecretRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SecretRequestInfo{
+		RequestID:     stored.RequestID,
+		RequesterName: stored.RequesterName,
+		Description:   stored.Description,
+		ExpiresAt:     stored.ExpiresAt,
+	}, nil
+}
+
+// FulfillSecretRequest encrypts the answer to a secret request as a new message addressed to the
+// requester and emails them its link. The decrypt URL is never returned to the caller.
+func (s *MessageService) FulfillSecretRequest(ctx context.Context, req SecretRequestFulfillmentRequest) error {
+	logging.Info().Str("requestId", req.RequestID).Msg("Processing secret request fulfillment")
+
+	submission := MessageSubmissionRequest{
+		Content:    req.Content,
+		SenderName: strings.TrimSpace(req.SenderName),
+	}
+	if err := s.validateSubmissionRequest(submission); err != nil {
+		logging.Error().Err(err).Str("requestId", req.RequestID).Msg("Invalid secret request fulfillment")
+		return fmt.Errorf("%w: %v", ErrInvalidMessageRequest, err)
+	}
+
+	stored, err := s.getOpenSecretRequest(ctx, req.RequestID)
+	if err != nil {
+		return err
+	}
+
+	// The requester's address is only used for the email below, so no reminders are scheduled
+	submission.RecipientName = stored.RequesterName
+	submission.RecipientEmail = stored.RequesterEmail
+	submitted, err := s.submitMessageForRecipient(ctx, submission)
+	if err != nil {
+		return err
+	}
+
+	// Marking the request fulfilled only succeeds while it is still open, so concurrent answers
+	// cannot both be delivered
+	if err := s.storageService.FulfillSecretRequest(ctx, req.RequestID, submitted.MessageID); err != nil {
+		logging.Error().Err(err).Str("requestId", req.RequestID).Msg("Failed to mark secret request fulfilled")
+		deleteReq := MessageRetrievalStorageRequest{MessageID: submitted.MessageID}
+		if deleteErr := s.storageService.DeleteMessage(ctx, deleteReq); deleteErr != nil {
+			logging.Error().
+				Err(deleteErr).
+				Str("messageId", submitted.MessageID).
+				Msg("Failed to delete message after secret request fulfillment failed")
+		}
+		if errors.Is(err, ErrSecretRequestUnavailable) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+
+	// The email is the only way the requester learns the link, so a failure here is reported
+	notification := SecretRequestFulfilledNotification{
+		RequesterName:  stored.RequesterName,
+		RequesterEmail: stored.RequesterEmail,
+		SenderName:     submission.SenderName,
+		Description:    stored.Description,
+		MessageURL:     submitted.DecryptURL,
+	}
+	if err := s.notificationService.SendSecretRequestFulfilled(ctx, notification); err != nil {
+		logging.Error().
+			Err(err).
+			Str("requestId", req.RequestID).
+			Str("messageId", submitted.MessageID).
+			Msg("Failed to notify requester of fulfilled secret request")
+		return fmt.Errorf("%w: %v", ErrNotificationFailed, err)
+	}
+
+	logging.Info().
+		Str("requestId", req.RequestID).
+		Str("messageId", submitted.MessageID).
+		Msg("Secret request fulfilled successfully")
+	return nil
+}
+
+// getOpenSecretRequest loads a secret request that can still be answered
+func (s *MessageService) getOpenSecretRequest(ctx context.Context, requestID string) (*StoredSecretRequest, error) {
+	if strings.TrimSpace(requestID) == "" {
+		return nil, ErrSecretRequestNotFound
+	}
+
+	stored, err := s.storageService.GetSecretRequest(ctx, requestID)
+	if err != nil {
+		logging.Error().Err(err).Str("requestId", requestID).Msg("Failed to retrieve secret request")
+		if errors.Is(err, ErrSecretRequestNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrStorageFailed, err)
+	}
+
+	// Expired requests may linger until the next cleanup run
+	if stored.FulfilledAt != nil || (stored.ExpiresAt != nil && !time.Now().Before(*stored.ExpiresAt)) {
+		logging.Warn().Str("requestId", requestID).Msg("Secret request is no longer open")
+		return nil, ErrSecretRequestUnavailable
+	}
+
+	return stored, nil
+}
+
+// validateSecretRequestCreation validates a new secret request
+func validateSecretRequestCreation(req SecretRequestCreationRequest) error {
+	if strings.TrimSpace(req.RequesterName) == "" {
+		return fmt.Errorf("requester name is required")
+	}
+
+	if strings.TrimSpace(req.RequesterEmail) == "" {
+		return fmt.Errorf("requester email is required")
+	}
+	if !strings.Contains(req.RequesterEmail, "@") {
+		return ErrInvalidEmailAddress
+	}
+
+	if len(req.Description) > MaxSecretRequestDescriptionLength {
+		return fmt.Errorf("description must be at most %d characters", MaxSecretRequestDescriptionLength)
+	}
+
+	if req.ExpirationHours != 0 {
+		if req.ExpirationHours < 1 || req.ExpirationHours > MaxExpirationHours {
+			return fmt.Errorf(
+				"expiration must be between 1 and %d hours (%d days)",
+				MaxExpirationHours,
+				MaxExpirationHours/24,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSecretRequest(t *testing.T) {
+	enc := new(mockEncryptionService)
+	stor := new(mockStorageService)
+	urlb := new(mockURLBuilder)
+	turnstile := new(mockTurnstileValidator)
+	svc := NewMessageService(enc, stor, new(mockNotificationService), new(mockPasswordHasher), urlb, turnstile)
+
+	turnstile.On("ValidateToken", mock.Anything, "turnstile-token", "").Return(true, nil)
+	enc.On("GenerateID", mock.Anything).Return("req-123", nil)
+	stor.On("StoreSecretRequest", mock.Anything, mock.MatchedBy(func(req SecretRequestStorageRequest) bool {
+		return req.RequestID == "req-123" &&
+			req.RequesterName == "Rita" &&
+			req.RequesterEmail == "rita@example.com" &&
+			req.Description == "VPN password" &&
+			req.ExpiresAt != nil
+	})).Return(nil)
+	urlb.On("BuildSecretRequestURL", "req-123").Return("https://example.com/request/req-123")
+
+	before := time.Now().UTC()
+	resp, err := svc.CreateSecretRequest(context.Background(), SecretRequestCreationRequest{
+		RequesterName:   " Rita ",
+		RequesterEmail:  "rita@example.com",
+		Description:     "VPN password",
+		ExpirationHours: 48,
+		TurnstileToken:  "turnstile-token",
+	})
+
+	assert.NoError(t, err)
+	stor.AssertExpectations(t)
+	assert.Equal(t, "req-123", resp.RequestID)
+	assert.Equal(t, "https://example.com/request/req-123", resp.RequestURL)
+	if assert.NotNil(t, resp.ExpiresAt) {
+		assert.WithinDuration(t, before.Add(48*time.Hour), *resp.ExpiresAt, 5*time.Second)
+	}
+}
+
+func TestCreateSecretRequest_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  SecretRequestCreationRequest
+	}{
+		{"missing name", SecretRequestCreationRequest{RequesterEmail: "rita@example.com", TurnstileToken: "t"}},
+		{"missing email", SecretRequestCreationRequest{RequesterName: "Rita", TurnstileToken: "t"}},
+		{"invalid email", SecretRequestCreationRequest{RequesterName: "Rita", RequesterEmail: "rita", TurnstileToken: "t"}},
+		{"expiration too long", SecretRequestCreationRequest{
+			RequesterName:   "Rita",
+			RequesterEmail:  "rita@example.com",
+			ExpirationHours: MaxExpirationHours + 1,
+			TurnstileToken:  "t",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor := new(mockStorageService)
+			svc := NewMessageService(
+				new(mockEncryptionService), stor, new(mockNotificationService),
+				new(mockPasswordHasher), new(mockURLBuilder), new(mockTurnstileValidator),
+			)
+
+			_, err := svc.CreateSecretRequest(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, ErrInvalidMessageRequest)
+			stor.AssertNotCalled(t, "StoreSecretRequest", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetSecretRequest(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		stored  *StoredSecretRequest
+		err     error
+		wantErr error
+	}{
+		{
+			name:   "open request",
+			stored: &StoredSecretRequest{RequestID: "req-123", RequesterName: "Rita", ExpiresAt: &future},
+		},
+		{
+			name:    "fulfilled request",
+			stored:  &StoredSecretRequest{RequestID: "req-123", ExpiresAt: &future, FulfilledAt: &past},
+			wantErr: ErrSecretRequestUnavailable,
+		},
+		{
+			name:    "expired request",
+			stored:  &StoredSecretRequest{RequestID: "req-123", ExpiresAt: &past},
+			wantErr: ErrSecretRequestUnavailable,
+		},
+		{
+			name:    "missing request",
+			err:     ErrSecretRequestNotFound,
+			wantErr: ErrSecretRequestNotFound,
+		},
+		{
+			name:    "storage failure",
+			err:     errors.New("database down"),
+			wantErr: ErrStorageFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stor := new(mockStorageService)
+			svc := NewMessageService(
+				new(mockEncryptionService), stor, new(mockNotificationService),
+				new(mockPasswordHasher), new(mockURLBuilder), new(mockTurnstileValidator),
+			)
+			stor.On("GetSecretRequest", mock.Anything, "req-123").Return(tt.stored, tt.err)
+
+			info, err := svc.GetSecretRequest(context.Background(), "req-123")
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "Rita", info.RequesterName)
+		})
+	}
+}
+
+func TestFulfillSecretRequest_EmailsRequesterTheDecryptLink(t *testing.T) {
+	enc := new(mockEncryptionService)
+	stor := new(mockStorageService)
+	notif := new(mockNotificationService)
+	urlb := new(mockURLBuilder)
+	svc := NewMessageService(enc, stor, notif, new(mockPasswordHasher), urlb, new(mockTurnstileValidator))
+
+	future := time.Now().Add(time.Hour)
+	stor.On("GetSecretRequest", mock.Anything, "req-123").Return(&StoredSecretRequest{
+		RequestID:      "req-123",
+		RequesterName:  "Rita",
+		RequesterEmail: "rita@example.com",
+		Description:    "VPN password",
+		ExpiresAt:      &future,
+	}, nil)
+	enc.On("GenerateKey", mock.Anything, int32(32)).Return([]byte("key12345678901234567890123456789"), nil)
+	enc.On("Encrypt", mock.Anything, []string{"hunter2"}, mock.Anything, []byte("msg-456")).Return([]string{"ciphertext"}, nil)
+	enc.On("GenerateID", mock.Anything).Return("msg-456", nil)
+	stor.On("StoreMessage", mock.Anything, mock.MatchedBy(func(req MessageStorageRequest) bool {
+		// No recipient email is stored, so the message never schedules reminders
+		return req.MessageID == "msg-456" && req.RecipientEmail == "" && req.Notification == nil
+	})).Return(nil)
+	urlb.On("BuildDecryptURL", "msg-456", mock.Anything).Return("https://example.com/decrypt/msg-456")
+	urlb.On("BuildRevokeURL", "msg-456", mock.Anything).Return("https://example.com/revoke/msg-456")
+	urlb.On("BuildStatusURL", "msg-456", mock.Anything).Return("https://example.com/status/msg-456")
+	stor.On("FulfillSecretRequest", mock.Anything, "req-123", "msg-456").Return(nil)
+	notif.On("SendSecretRequestFulfilled", mock.Anything, SecretRequestFulfilledNotification{
+		RequesterName:  "Rita",
+		RequesterEmail: "rita@example.com",
+		SenderName:     "Sam",
+		Description:    "VPN password",
+		MessageURL:     "https://example.com/decrypt/msg-456",
+	}).Return(nil)
+
+	err := svc.FulfillSecretRequest(context.Background(), SecretRequestFulfillmentRequest{
+		RequestID:  "req-123",
+		Content:    "hunter2",
+		SenderName: "Sam",
+	})
+
+	assert.NoError(t, err)
+	stor.AssertExpectations(t)
+	notif.AssertExpectations(t)
+}
+
+func TestFulfillSecretRequest_DeletesMessageWhenAlreadyAnswered(t *testing.T) {
+	enc := new(mockEncryptionService)
+	stor := new(mockStorageService)
+	notif := new(mockNotificationService)
+	urlb := new(mockURLBuilder)
+	svc := NewMessageService(enc, stor, notif, new(mockPasswordHasher), urlb, new(mockTurnstileValidator))
+
+	stor.On("GetSecretRequest", mock.Anything, "req-123").Return(&StoredSecretRequest{
+		RequestID:      "req-123",
+		RequesterName:  "Rita",
+		RequesterEmail: "rita@example.com",
+	}, nil)
+	enc.On("GenerateKey", mock.Anything, int32(32)).Return([]byte("key12345678901234567890123456789"), nil)
+	enc.On("Encrypt", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string{"ciphertext"}, nil)
+	enc.On("GenerateID", mock.Anything).Return("msg-456", nil)
+	stor.On("StoreMessage", mock.Anything, mock.Anything).Return(nil)
+	urlb.On("BuildDecryptURL", mock.Anything, mock.Anything).Return("https://example.com/decrypt/msg-456")
+	urlb.On("BuildRevokeURL", mock.Anything, mock.Anything).Return("https://example.com/revoke/msg-456")
+	urlb.On("BuildStatusURL", mock.Anything, mock.Anything).Return("https://example.com/status/msg-456")
+	stor.On("FulfillSecretRequest", mock.Anything, "req-123", "msg-456").Return(ErrSecretRequestUnavailable)
+	stor.On("DeleteMessage", mock.Anything, MessageRetrievalStorageRequest{MessageID: "msg-456"}).Return(nil)
+
+	err := svc.FulfillSecretRequest(context.Background(), SecretRequestFulfillmentRequest{
+		RequestID: "req-123",
+		Content:   "hunter2",
+	})
+
+	assert.ErrorIs(t, err, ErrSecretRequestUnavailable)
+	stor.AssertCalled(t, "DeleteMessage", mock.Anything, MessageRetrievalStorageRequest{MessageID: "msg-456"})
+	notif.AssertNotCalled(t, "SendSecretRequestFulfilled", mock.Anything, mock.Anything)
+}
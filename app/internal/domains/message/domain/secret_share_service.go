@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// submitSplitMessage splits the content into Shamir shares and stores each share as its own
+// message, so every custodian gets an independent link, key and view counter. When a recipient
+// list is given, share i goes to recipient i. The top-level response fields describe share 1.
+func (s *MessageService) submitSplitMessage(
+	ctx context.Context,
+	req MessageSubmissionRequest,
+) (*MessageSubmissionResponse, error) {
+	shares, err := s.encryptionService.SplitSecret(ctx, []byte(req.Content), req.SplitShares, req.SplitThreshold)
+	if err != nil {
+		logging.Error().Err(err).Int("shares", req.SplitShares).Msg("Failed to split message content")
+		return nil, fmt.Errorf("%w: %v", ErrEncryptionFailed, err)
+	}
+
+	var response *MessageSubmissionResponse
+	shareMessages := make([]ShareMessage, 0, len(shares))
+	messageIDs := make([]string, 0, len(shares))
+
+	for i, share := range shares {
+		shareReq := req
+		shareReq.Content = share
+		shareReq.SplitShares = 0
+		shareReq.SplitThreshold = 0
+		shareReq.Recipients = nil
+		if len(req.Recipients) > 0 {
+			shareReq.RecipientName = req.Recipients[i].Name
+			shareReq.RecipientEmail = req.Recipients[i].Email
+		}
+
+		shareResp, err := s.submitMessageForRecipient(ctx, shareReq)
+		if err != nil {
+			// A partial set of shares may fall below the threshold; don't leave it behind
+			s.deleteSubmittedMessages(ctx, messageIDs)
+			return nil, err
+		}
+
+		if response == nil {
+			response = shareResp
+		}
+		messageIDs = append(messageIDs, shareResp.MessageID)
+		shareMessages = append(shareMessages, ShareMessage{
+			ShareIndex:      i + 1,
+			RecipientName:   shareReq.RecipientName,
+			RecipientEmail:  shareReq.RecipientEmail,
+			MessageID:       shareResp.MessageID,
+			Key:             shareResp.Key,
+			DecryptURL:      shareResp.DecryptURL,
+			RevocationToken: shareResp.RevocationToken,
+			RevokeURL:       shareResp.RevokeURL,
+			StatusURL:       shareResp.StatusURL,
+		})
+	}
+
+	response.Shares = shareMessages
+	logging.Info().
+		Int("shares", len(shareMessages)).
+		Int("threshold", req.SplitThreshold).
+		Msg("Split message submitted successfully")
+	return response, nil
+}
+
+// CombineShares reconstructs a secret split by a submission once at least its threshold of
+// shares are supplied. Shares are combined in memory and nothing is stored.
+func (s *MessageService) CombineShares(
+	ctx context.Context,
+	req ShareCombinationRequest,
+) (*ShareCombinationResponse, error) {
+	shares := make([]string, 0, len(req.Shares))
+	for _, share := range req.Shares {
+		if trimmed := strings.TrimSpace(share); trimmed != "" {
+			shares = append(shares, trimmed)
+		}
+	}
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("%w: at least one share is required", ErrInvalidMessageRequest)
+	}
+	if len(shares) > MaxSecretShares {
+		return nil, fmt.Errorf("%w: a secret has at most %d shares", ErrInvalidMessageRequest, MaxSecretShares)
+	}
+
+	secret, err := s.encryptionService.CombineShares(ctx, shares)
+	if err != nil {
+		logging.Warn().Err(err).Int("shares", len(shares)).Msg("Failed to combine secret shares")
+		if errors.Is(err, ErrInvalidShare) || errors.Is(err, ErrInsufficientShares) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrDecryptionFailed, err)
+	}
+
+	logging.Info().Int("shares", len(shares)).Msg("Secret shares combined successfully")
+	return &ShareCombinationResponse{Content: string(secret)}, nil
+}
+
+// validateSplit checks a submission that splits its content into shares. The server must see
+// the plaintext to split it, and each share is plain text, so the content must be a plain message.
+func validateSplit(req MessageSubmissionRequest) error {
+	if req.SplitShares < 2 || req.SplitShares > MaxSecretShares {
+		return fmt.Errorf("a secret must be split into between 2 and %d shares", MaxSecretShares)
+	}
+	if req.SplitThreshold < 2 || req.SplitThreshold > req.SplitShares {
+		return fmt.Errorf("the share threshold must be between 2 and the number of shares")
+	}
+	if req.ClientEncrypted {
+		return fmt.Errorf("client-encrypted messages cannot be split into shares")
+	}
+	if req.RecipientPublicKey != "" {
+		return fmt.Errorf("messages sealed to a public key cannot be split into shares")
+	}
+	if req.Attachment != nil {
+		return fmt.Errorf("attachments cannot be split into shares")
+	}
+	// Sending every share to one person would hand them the whole secret
+	if req.RecipientName != "" || req.RecipientEmail != "" {
+		return fmt.Errorf("use a recipient list with one recipient per share when splitting a secret")
+	}
+	if len(req.Recipients) > 0 && len(req.Recipients) != req.SplitShares {
+		return fmt.Errorf("a split secret needs exactly one recipient per share")
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSubmitMessage_SplitStoresEachShareForItsCustodian(t *testing.T) {
+	enc := new(mockEncryptionService)
+	stor := new(mockStorageService)
+	notif := new(mockNotificationService)
+	urlb := new(mockURLBuilder)
+	turnstile := new(mockTurnstileValidator)
+	svc := NewMessageService(enc, stor, notif, new(mockPasswordHasher), urlb, turnstile)
+
+	shares := []string{"pxs1.share-one", "pxs1.share-two", "pxs1.share-three"}
+	ids := []string{"msg-one", "msg-two", "msg-three"}
+	emails := []string{"alice@example.com", "bob@example.com", "carol@example.com"}
+
+	turnstile.On("ValidateToken", mock.Anything, "turnstile-token", "").Return(true, nil).Once()
+	enc.On("SplitSecret", mock.Anything, []byte("root password"), 3, 2).Return(shares, nil)
+	enc.On("GenerateKey", mock.Anything, int32(32)).Return([]byte("key12345678901234567890123456789"), nil)
+	for i := range shares {
+		enc.On("Encrypt", mock.Anything, []string{shares[i]}, mock.Anything, []byte(ids[i])).Return([]string{"ciphertext-" + ids[i]}, nil).Once()
+		enc.On("GenerateID", mock.Anything).Return(ids[i], nil).Once()
+		id, email := ids[i], emails[i]
+		stor.On("StoreMessage", mock.Anything, mock.MatchedBy(func(req MessageStorageRequest) bool {
+			return req.MessageID == id && req.Content == "ciphertext-"+id && req.RecipientEmail == email && req.MaxViewCount == 1 &&
+				req.Notification != nil && req.Notification.RecipientEmail == email &&
+				req.Notification.MessageURL == "https://example.com/decrypt/"+id
+		})).Return(nil).Once()
+		urlb.On("BuildDecryptURL", id, mock.Anything).Return("https://example.com/decrypt/" + id)
+		urlb.On("BuildRevokeURL", id, mock.Anything).Return("https://example.com/revoke/" + id)
+		urlb.On("BuildStatusURL", id, mock.Anything).Return("https://example.com/status/" + id)
+	}
+
+	resp, err := svc.SubmitMessage(context.Background(), MessageSubmissionRequest{
+		Content:          "root password",
+		SenderName:       "Sam",
+		SenderEmail:      "sam@example.com",
+		SendNotification: true,
+		TurnstileToken:   "turnstile-token",
+		MaxViewCount:     1,
+		SplitShares:      3,
+		SplitThreshold:   2,
+		Recipients: []MessageRecipient{
+			{Name: "Alice", Email: emails[0]},
+			{Name: "Bob", Email: emails[1]},
+			{Name: "Carol", Email: emails[2]},
+		},
+	})
+
+	assert.NoError(t, err)
+	stor.AssertExpectations(t)
+	notif.AssertExpectations(t)
+	assert.Equal(t, "msg-one", resp.MessageID)
+	assert.Empty(t, resp.Recipients)
+	if assert.Len(t, resp.Shares, 3) {
+		for i, share := range resp.Shares {
+			assert.Equal(t, i+1, share.ShareIndex)
+			assert.Equal(t, ids[i], share.MessageID)
+			assert.Equal(t, emails[i], share.RecipientEmail)
+			assert.Equal(t, "https://example.com/decrypt/"+ids[i], share.DecryptURL)
+		}
+	}
+}
+
+func TestSubmitMessage_SplitRollsBackOnFailure(t *testing.T) {
+	enc := new(mockEncryptionService)
+	stor := new(mockStorageService)
+	urlb := new(mockURLBuilder)
+	svc := NewMessageService(enc, stor, new(mockNotificationService), new(mockPasswordHasher), urlb, new(mockTurnstileValidator))
+
+	enc.On("SplitSecret", mock.Anything, []byte("root password"), 2, 2).Return([]string{"pxs1.a", "pxs1.b"}, nil)
+	enc.On("GenerateKey", mock.Anything, int32(32)).Return([]byte("key12345678901234567890123456789"), nil)
+	enc.On("Encrypt", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]string{"ciphertext"}, nil)
+	enc.On("GenerateID", mock.Anything).Return("msg-one", nil).Once()
+	enc.On("GenerateID", mock.Anything).Return("msg-two", nil).Once()
+	stor.On("StoreMessage", mock.Anything, mock.MatchedBy(func(req MessageStorageRequest) bool {
+		return req.MessageID == "msg-one"
+	})).Return(nil)
+	stor.On("StoreMessage", mock.Anything, mock.MatchedBy(func(req MessageStorageRequest) bool {
+		return req.MessageID == "msg-two"
+	})).Return(errors.New("database down"))
+	stor.On("DeleteMessage", mock.Anything, MessageRetrievalStorageRequest{MessageID: "msg-one"}).Return(nil)
+	urlb.On("BuildDecryptURL", mock.Anything, mock.Anything).Return("https://example.com/decrypt/")
+	urlb.On("BuildRevokeURL", mock.Anything, mock.Anything).Return("https://example.com/revoke/")
+	urlb.On("BuildStatusURL", mock.Anything, mock.Anything).Return("https://example.com/status/")
+
+	_, err := svc.SubmitMessage(context.Background(), MessageSubmissionRequest{
+		Content:        "root password",
+		SplitShares:    2,
+		SplitThreshold: 2,
+	})
+
+	assert.ErrorIs(t, err, ErrStorageFailed)
+	stor.AssertCalled(t, "DeleteMessage", mock.Anything, MessageRetrievalStorageRequest{MessageID: "msg-one"})
+}
+
+func TestSubmitMessage_SplitValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  MessageSubmissionRequest
+	}{
+		{"too few shares", MessageSubmissionRequest{Content: "s", SplitShares: 1, SplitThreshold: 1}},
+		{"too many shares", MessageSubmissionRequest{Content: "s", SplitShares: MaxSecretShares + 1, SplitThreshold: 2}},
+		{"threshold above shares", MessageSubmissionRequest{Content: "s", SplitShares: 3, SplitThreshold: 4}},
+		{"threshold without shares", MessageSubmissionRequest{Content: "s", SplitThreshold: 2}},
+		{"client encrypted", MessageSubmissionRequest{
+			Content:         "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+			ClientEncrypted: true,
+			SplitShares:     3,
+			SplitThreshold:  2,
+		}},
+		{"public key", MessageSubmissionRequest{Content: "s", RecipientPublicKey: "age1abc", SplitShares: 3, SplitThreshold: 2}},
+		{"single recipient", MessageSubmissionRequest{Content: "s", RecipientEmail: "alice@example.com", SplitShares: 3, SplitThreshold: 2}},
+		{"recipient count mismatch", MessageSubmissionRequest{
+			Content:        "s",
+			SplitShares:    3,
+			SplitThreshold: 2,
+			Recipients:     []MessageRecipient{{Email: "alice@example.com"}, {Email: "bob@example.com"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := new(mockEncryptionService)
+			svc := NewMessageService(
+				enc, new(mockStorageService), new(mockNotificationService),
+				new(mockPasswordHasher), new(mockURLBuilder), new(mockTurnstileValidator),
+			)
+
+			_, err := svc.SubmitMessage(context.Background(), tt.req)
+
+			assert.ErrorIs(t, err, ErrInvalidMessageRequest)
+			enc.AssertNotCalled(t, "SplitSecret", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCombineShares(t *testing.T) {
+	tests := []struct {
+		name       string
+		shares     []string
+		combined   []byte
+		combineErr error
+		wantErr    error
+	}{
+		{name: "reconstructs the secret", shares: []string{" pxs1.a ", "pxs1.b", ""}, combined: []byte("root password")},
+		{name: "invalid share", shares: []string{"pxs1.a", "pxs1.b"}, combineErr: ErrInvalidShare, wantErr: ErrInvalidShare},
+		{name: "too few shares", shares: []string{"pxs1.a", "pxs1.b"}, combineErr: ErrInsufficientShares, wantErr: ErrInsufficientShares},
+		{name: "encryption service failure", shares: []string{"pxs1.a", "pxs1.b"}, combineErr: errors.New("unavailable"), wantErr: ErrDecryptionFailed},
+		{name: "no shares", shares: []string{" "}, wantErr: ErrInvalidMessageRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc := new(mockEncryptionService)
+			svc := NewMessageService(
+				enc, new(mockStorageService), new(mockNotificationService),
+				new(mockPasswordHasher), new(mockURLBuilder), new(mockTurnstileValidator),
+			)
+			enc.On("CombineShares", mock.Anything, []string{"pxs1.a", "pxs1.b"}).Return(tt.combined, tt.combineErr)
+
+			resp, err := svc.CombineShares(context.Background(), ShareCombinationRequest{Shares: tt.shares})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "root password", resp.Content)
+		})
+	}
+}
@@ -14,6 +14,28 @@ type MessageServicePort interface {
 	// RetrieveMessage handles the retrieval and decryption of a stored message
 	RetrieveMessage(ctx context.Context, req domain.MessageRetrievalRequest) (*domain.MessageRetrievalResponse, error)
 	
+	// RetrieveAttachment handles the download and decryption of a message's attachment
+	RetrieveAttachment(ctx context.Context, req domain.MessageRetrievalRequest) (*domain.AttachmentDownloadResponse, error)
+	
 	// CheckMessageAccess checks if a message exists and whether it requires a passphrase
 	CheckMessageAccess(ctx context.Context, messageID string) (*domain.MessageAccessInfo, error)
+	
+	// RevokeMessage deletes a message early when given the sender's revocation token
+	RevokeMessage(ctx context.Context, req domain.MessageRevocationRequest) error
+	
+	// GetMessageStatus reports view counts, expiry and reminder history to the holder of the
+	// sender's token without revealing the message content
+	GetMessageStatus(ctx context.Context, req domain.MessageStatusRequest) (*domain.MessageStatusResponse, error)
+	
+	// CreateSecretRequest opens a request for someone else to send the requester a secret
+	CreateSecretRequest(ctx context.Context, req domain.SecretRequestCreationRequest) (*domain.SecretRequestCreationResponse, error)
+	
+	// GetSecretRequest describes an open secret request to the person fulfilling it
+	GetSecretRequest(ctx context.Context, requestID string) (*domain.SecretRequestInfo, error)
+	
+	// FulfillSecretRequest stores the answer to a secret request and emails its link to the requester
+	FulfillSecretRequest(ctx context.Context, req domain.SecretRequestFulfillmentRequest) error
+	
+	// CombineShares reconstructs a secret that was split across custodians from enough of its shares
+	CombineShares(ctx context.Context, req domain.ShareCombinationRequest) (*domain.ShareCombinationResponse, error)
 }
\ No newline at end of file
@@ -9,11 +9,33 @@ type EncryptionServicePort interface {
 	// GenerateKey generates a new encryption key of the specified length
 	GenerateKey(ctx context.Context, length int32) ([]byte, error)
 	
-	// Encrypt encrypts plaintext using the provided key
-	Encrypt(ctx context.Context, plaintext []string, key []byte) ([]string, error)
+	// Encrypt encrypts plaintext using the provided key, binding each ciphertext to associatedData
+	Encrypt(ctx context.Context, plaintext []string, key []byte, associatedData []byte) ([]string, error)
 	
-	// Decrypt decrypts ciphertext using the provided key
-	Decrypt(ctx context.Context, ciphertext []string, key []byte) ([]string, error)
+	// Decrypt decrypts ciphertext using the provided key and the associatedData it was sealed with
+	Decrypt(ctx context.Context, ciphertext []string, key []byte, associatedData []byte) ([]string, error)
+	
+	// EncryptFile encrypts a file into AES-GCM chunks bound to associatedData
+	EncryptFile(ctx context.Context, data []byte, key []byte, associatedData []byte) ([][]byte, error)
+	
+	// DecryptFile decrypts and reassembles chunks produced by EncryptFile
+	DecryptFile(ctx context.Context, chunks [][]byte, key []byte, associatedData []byte) ([]byte, error)
+	
+	// EncryptToPublicKey seals plaintext to an age or OpenPGP public key and returns it armored
+	EncryptToPublicKey(ctx context.Context, plaintext []byte, publicKey string) (string, error)
+	
+	// DecryptWithPrivateKey opens an armored ciphertext produced by EncryptToPublicKey
+	DecryptWithPrivateKey(ctx context.Context, ciphertext string, privateKey string, passphrase string) ([]byte, error)
+	
+	// SplitSecret splits a secret into Shamir shares, any threshold of which reconstruct it
+	SplitSecret(ctx context.Context, secret []byte, shares int, threshold int) ([]string, error)
+	
+	// CombineShares reconstructs a secret from shares produced by SplitSecret
+	CombineShares(ctx context.Context, shares []string) ([]byte, error)
+	
+	// DerivePassphraseKey mixes the passphrase into key and returns the content key and the salt
+	// it used. An empty salt generates a new one.
+	DerivePassphraseKey(ctx context.Context, key []byte, passphrase string, salt []byte) ([]byte, []byte, error)
 	
 	// GenerateID generates a unique identifier
 	GenerateID(ctx context.Context) (string, error)
@@ -8,6 +8,12 @@ import (
 
 // NotificationServicePort defines the secondary port for notification operations
 type NotificationServicePort interface {
-	// SendMessageNotification sends a notification about a new message
-	SendMessageNotification(ctx context.Context, req domain.MessageNotificationRequest) error
+	// SendReadReceipt tells the original sender that their message was viewed
+	SendReadReceipt(ctx context.Context, req domain.MessageReadReceiptRequest) error
+	// SendPassphraseAlert tells the original sender that wrong passphrases were entered for their message
+	SendPassphraseAlert(ctx context.Context, req domain.MessagePassphraseAlertRequest) error
+	// SendWebhookEvent queues a lifecycle event for the message's webhook
+	SendWebhookEvent(ctx context.Context, event domain.MessageWebhookEvent) error
+	// SendSecretRequestFulfilled emails the requester the link to the secret they asked for
+	SendSecretRequestFulfilled(ctx context.Context, req domain.SecretRequestFulfilledNotification) error
 }
\ No newline at end of file
@@ -11,4 +11,7 @@ type PasswordHasherPort interface {
 	
 	// Verify checks if a password matches the provided hash
 	Verify(ctx context.Context, password, hash string) (bool, error)
+	
+	// NeedsRehash reports whether a hash was made with an outdated algorithm or cost
+	NeedsRehash(hash string) bool
 }
\ No newline at end of file
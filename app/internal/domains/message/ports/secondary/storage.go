@@ -13,4 +13,34 @@ type StorageServicePort interface {
 	
 	// RetrieveMessage retrieves a stored message by ID
 	RetrieveMessage(ctx context.Context, req domain.MessageRetrievalStorageRequest) (*domain.MessageStorageResponse, error)
+	
+	// GetAttachment retrieves a message's encrypted attachment without incrementing view count
+	GetAttachment(ctx context.Context, req domain.MessageRetrievalStorageRequest) (*domain.StoredAttachment, error)
+	
+	// DeleteMessage permanently removes a stored message
+	DeleteMessage(ctx context.Context, req domain.MessageRetrievalStorageRequest) error
+	
+	// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+	ReservePassphraseAttempt(ctx context.Context, req domain.MessageRetrievalStorageRequest) (int, error)
+	
+	// ReleasePassphraseAttempt takes back a reserved attempt once the passphrase turned out right
+	ReleasePassphraseAttempt(ctx context.Context, req domain.MessageRetrievalStorageRequest) error
+	
+	// RecordPassphraseFailure audits a wrong passphrase whose attempt was already reserved
+	RecordPassphraseFailure(ctx context.Context, req domain.MessageRetrievalStorageRequest) error
+	
+	// UpdatePassphraseHash replaces a message's stored passphrase hash, e.g. to upgrade its algorithm
+	UpdatePassphraseHash(ctx context.Context, req domain.MessageRetrievalStorageRequest, hashedPassphrase string) error
+	
+	// GetReminderHistory retrieves the reminders sent for a message by its storage row ID
+	GetReminderHistory(ctx context.Context, storageID int64) ([]domain.ReminderHistoryEntry, error)
+	
+	// StoreSecretRequest stores a request for someone to send the requester a secret
+	StoreSecretRequest(ctx context.Context, req domain.SecretRequestStorageRequest) error
+	
+	// GetSecretRequest retrieves a secret request by ID, whether or not it is still open
+	GetSecretRequest(ctx context.Context, requestID string) (*domain.StoredSecretRequest, error)
+	
+	// FulfillSecretRequest marks an open secret request as answered by the given message
+	FulfillSecretRequest(ctx context.Context, requestID string, messageID string) error
 }
\ No newline at end of file
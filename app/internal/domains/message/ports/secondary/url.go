@@ -20,4 +20,25 @@ type URLBuilderPort interface {
 	// Example:
 	//   https://example.com/decrypt/abc123#key=base64encodedkey
 	BuildDecryptURL(messageID string, encryptionKey []byte) string
-}
\ No newline at end of file
+
+	// BuildRevokeURL constructs the URL the sender uses to delete a message early.
+	// The revocation token is carried in the URL fragment so it stays out of server logs.
+	//
+	// Example:
+	//   https://example.com/revoke/abc123#token
+	BuildRevokeURL(messageID string, revocationToken string) string
+
+	// BuildStatusURL constructs the URL the sender uses to check how a message has been accessed.
+	// Like the revoke URL, the token is carried in the fragment.
+	//
+	// Example:
+	//   https://example.com/status/abc123#token
+	BuildStatusURL(messageID string, revocationToken string) string
+
+	// BuildSecretRequestURL constructs the URL the requester shares with whoever holds the secret.
+	// It carries no key: the secret is encrypted server-side and its link is only emailed to the requester.
+	//
+	// Example:
+	//   https://example.com/request/abc123
+	BuildSecretRequestURL(requestID string) string
+}
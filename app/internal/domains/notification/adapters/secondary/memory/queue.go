@@ -0,0 +1,222 @@
+// Package memory implements the notification queue ports over a channel inside one process, for
+// tests and embedding where the publisher and the consumer run side by side. It is not a
+// queuebackend option: the services run in separate processes. Nothing survives a restart.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+)
+
+// bufferSize is how many notifications wait in a queue before publishing blocks
+const bufferSize = 1024
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Queue{}
+)
+
+// delivery is a notification on the queue together with its failed attempts so far
+type delivery struct {
+	body     []byte
+	attempts int
+}
+
+// DeadLetter is a notification the consumer gave up on
+type DeadLetter struct {
+	Body          []byte
+	Attempts      int
+	FailureReason string
+	FailedAt      time.Time
+}
+
+// Queue implements both the QueuePort and the NotificationPort. A notification that fails is
+// handled again after the retry delay, up to maxAttempts times; after that, or straight away for
+// a notification that can never succeed, it is kept with its failure in DeadLetters.
+type Queue struct {
+	name     string
+	messages chan delivery
+
+	maxAttempts int
+	retryDelay  time.Duration
+
+	mu          sync.Mutex
+	pending     int
+	idle        chan struct{}
+	deadLetters []DeadLetter
+}
+
+// Open returns the queue called name, creating it on first use. Every caller in the process gets
+// the same queue, so a publisher and a consumer opened separately talk to each other.
+func Open(name string) *Queue {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if q, ok := registry[name]; ok {
+		return q
+	}
+	q := &Queue{
+		name:        name,
+		messages:    make(chan delivery, bufferSize),
+		maxAttempts: queuemsg.DefaultMaxAttempts,
+		retryDelay:  queuemsg.DefaultRetryDelay,
+		idle:        make(chan struct{}),
+	}
+	close(q.idle)
+	registry[name] = q
+	return q
+}
+
+// WithRedelivery sets how many times a notification is attempted before it is dead-lettered
+// and how long it waits between attempts. Zero or negative values keep the defaults.
+func (q *Queue) WithRedelivery(maxAttempts int, retryDelay time.Duration) *Queue {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if maxAttempts > 0 {
+		q.maxAttempts = maxAttempts
+	}
+	if retryDelay > 0 {
+		q.retryDelay = retryDelay
+	}
+	return q
+}
+
+// PublishNotification puts a notification on the queue, waiting for room if it is full
+func (q *Queue) PublishNotification(ctx context.Context, req contracts.NotificationRequest) error {
+	body, err := queuemsg.EncodeNotification(req)
+	if err != nil {
+		return err
+	}
+
+	q.track(1)
+	select {
+	case q.messages <- delivery{body: body}:
+	case <-ctx.Done():
+		q.track(-1)
+		return ctx.Err()
+	}
+
+	logging.Debug().Str("recipientEmail", validation.SanitizeEmailForLogging(req.To)).Str("queue", q.name).Msg("Notification message queued in memory")
+	return nil
+}
+
+// Connect does nothing; the queue lives in this process
+func (q *Queue) Connect(ctx context.Context, queueConn contracts.QueueConnection) error {
+	return nil
+}
+
+// StartConsuming handles notifications with concurrency workers until ctx is cancelled
+func (q *Queue) StartConsuming(ctx context.Context, queueConn contracts.QueueConnection, handler contracts.MessageHandler, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	logging.Info().Str("queue", q.name).Int("concurrency", concurrency).Msg("Starting in-memory consumer")
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case d := <-q.messages:
+					q.handle(ctx, d, handler)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	logging.Info().Str("queue", q.name).Msg("In-memory consumer shutting down")
+	return nil
+}
+
+// handle passes one delivery to the handler and retries or dead-letters it if that fails
+func (q *Queue) handle(ctx context.Context, d delivery, handler contracts.MessageHandler) {
+	msg, err := queuemsg.DecodeMessage(d.body)
+	if err == nil {
+		err = handler.HandleMessage(ctx, msg)
+	}
+	if err == nil {
+		q.track(-1)
+		return
+	}
+
+	d.attempts++
+	q.mu.Lock()
+	retry, retryDelay := queuemsg.ShouldRetry(err, d.attempts, q.maxAttempts), q.retryDelay
+	if !retry {
+		q.deadLetters = append(q.deadLetters, DeadLetter{
+			Body:          d.body,
+			Attempts:      d.attempts,
+			FailureReason: queuemsg.FailureReason(err),
+			FailedAt:      time.Now().UTC(),
+		})
+	}
+	q.mu.Unlock()
+
+	if retry {
+		logging.Warn().Err(err).Str("queue", q.name).Int("attempts", d.attempts).Dur("retryDelay", retryDelay).Msg("Message failed, scheduled a retry")
+		time.AfterFunc(retryDelay, func() { q.messages <- d })
+		return
+	}
+	logging.Error().Err(err).Str("queue", q.name).Int("attempts", d.attempts).Bool("permanent", queuemsg.IsPermanentFailure(err)).Msg("Message failed, moved to the dead letters")
+	q.track(-1)
+}
+
+// track adjusts the count of notifications that are queued, being handled or waiting to be retried
+func (q *Queue) track(delta int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pending == 0 && delta > 0 {
+		q.idle = make(chan struct{})
+	}
+	q.pending += delta
+	if q.pending == 0 {
+		close(q.idle)
+	}
+}
+
+// Wait blocks until every published notification has been handled or dead-lettered, or ctx is done
+func (q *Queue) Wait(ctx context.Context) error {
+	q.mu.Lock()
+	idle := q.idle
+	q.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %d notifications still pending: %v", domain.ErrQueueConsumeFailed, q.Pending(), ctx.Err())
+	}
+}
+
+// Pending returns how many notifications have not been handled or dead-lettered yet
+func (q *Queue) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending
+}
+
+// DeadLetters returns the notifications the consumer gave up on
+func (q *Queue) DeadLetters() []DeadLetter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]DeadLetter(nil), q.deadLetters...)
+}
+
+// Close does nothing; the queue stays open for the other users in this process
+func (q *Queue) Close() error {
+	return nil
+}
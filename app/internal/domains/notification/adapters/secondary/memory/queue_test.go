@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuetest"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue_Contract(t *testing.T) {
+	queuetest.Run(t, func(t *testing.T, maxAttempts int, retryDelay time.Duration) queuetest.Backend {
+		name := queueName(t)
+		q := Open(name).WithRedelivery(maxAttempts, retryDelay)
+		return queuetest.Backend{
+			Publisher: q,
+			Consumer:  q,
+			Conn:      contracts.QueueConnection{QueueName: name},
+		}
+	})
+}
+
+// queueName names a queue no earlier run of the test has used; queues live as long as the process
+func queueName(t *testing.T) string {
+	return fmt.Sprintf("%s-%d", t.Name(), time.Now().UnixNano())
+}
+
+func TestOpen_SharesQueueByName(t *testing.T) {
+	assert.Same(t, Open("shared"), Open("shared"))
+	assert.NotSame(t, Open("shared"), Open("other"))
+}
+
+// handlerFunc adapts a function to contracts.MessageHandler
+type handlerFunc func(ctx context.Context, msg contracts.QueueMessage) error
+
+func (f handlerFunc) HandleMessage(ctx context.Context, msg contracts.QueueMessage) error {
+	return f(ctx, msg)
+}
+
+func TestQueue_WaitAndDeadLetters(t *testing.T) {
+	q := Open(queueName(t)).WithRedelivery(2, 10*time.Millisecond)
+	require.NoError(t, q.Wait(context.Background()), "an unused queue is idle")
+
+	require.NoError(t, q.PublishNotification(context.Background(), contracts.NotificationRequest{To: "ok@example.com"}))
+	require.NoError(t, q.PublishNotification(context.Background(), contracts.NotificationRequest{To: "down@example.com"}))
+	assert.Equal(t, 2, q.Pending())
+
+	// Nothing is consuming yet
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Wait(ctx), domain.ErrQueueConsumeFailed)
+
+	consumeCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go q.StartConsuming(consumeCtx, contracts.QueueConnection{}, handlerFunc(func(ctx context.Context, msg contracts.QueueMessage) error {
+		if msg.OtherEmail == "down@example.com" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}), 2)
+
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelWait()
+	require.NoError(t, q.Wait(waitCtx))
+	assert.Equal(t, 0, q.Pending())
+
+	deadLetters := q.DeadLetters()
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 2, deadLetters[0].Attempts)
+	assert.Equal(t, "connection refused", deadLetters[0].FailureReason)
+	assert.False(t, deadLetters[0].FailedAt.IsZero())
+}
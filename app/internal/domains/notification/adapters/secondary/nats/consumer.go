@@ -0,0 +1,182 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// ackWait is how long the server waits for a notification to be settled before redelivering it
+const ackWait = time.Minute
+
+// JetStreamConsumer implements the QueuePort using a durable JetStream consumer. A notification
+// that fails is negatively acknowledged and redelivered by the server after the retry delay, up to
+// maxAttempts times; after that, or straight away for a notification that can never succeed, it
+// is published to the dead-letter subject with the reason in its headers and terminated.
+type JetStreamConsumer struct {
+	connection *natsclient.Conn
+	js         jetstream.JetStream
+	stream     jetstream.Stream
+
+	queueName   string
+	maxAttempts int
+	retryDelay  time.Duration
+}
+
+// NewJetStreamConsumer creates a new JetStream consumer
+func NewJetStreamConsumer() *JetStreamConsumer {
+	return &JetStreamConsumer{
+		maxAttempts: queuemsg.DefaultMaxAttempts,
+		retryDelay:  queuemsg.DefaultRetryDelay,
+	}
+}
+
+// WithRedelivery sets how many times a notification is attempted before it is dead-lettered
+// and how long it waits between attempts. Zero or negative values keep the defaults.
+func (c *JetStreamConsumer) WithRedelivery(maxAttempts int, retryDelay time.Duration) *JetStreamConsumer {
+	if maxAttempts > 0 {
+		c.maxAttempts = maxAttempts
+	}
+	if retryDelay > 0 {
+		c.retryDelay = retryDelay
+	}
+	return c
+}
+
+// Connect connects to NATS and creates or updates the notification stream
+func (c *JetStreamConsumer) Connect(ctx context.Context, queueConn contracts.QueueConnection) error {
+	conn, js, stream, err := connect(ctx, queueConn)
+	if err != nil {
+		return err
+	}
+	c.connection, c.js, c.stream = conn, js, stream
+	return nil
+}
+
+// StartConsuming handles notifications with up to concurrency at a time until ctx is cancelled
+func (c *JetStreamConsumer) StartConsuming(ctx context.Context, queueConn contracts.QueueConnection, handler contracts.MessageHandler, concurrency int) error {
+	if c.connection == nil {
+		if err := c.Connect(ctx, queueConn); err != nil {
+			return err
+		}
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	c.queueName = queueConn.QueueName
+
+	consumer, err := c.stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+		Durable:       consumerName(queueConn.QueueName),
+		FilterSubject: queueConn.QueueName,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       ackWait,
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("subject", queueConn.QueueName).Msg("Failed to create JetStream consumer")
+		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+
+	// The callback runs on a single goroutine; the semaphore hands each message to a worker
+	// and holds back the next one while concurrency handlers are busy
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, concurrency)
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			c.handle(ctx, msg, handler)
+		}()
+	}, jetstream.PullMaxMessages(concurrency))
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to start consuming from JetStream")
+		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+
+	logging.Info().
+		Str("subject", queueConn.QueueName).
+		Int("concurrency", concurrency).
+		Int("maxAttempts", c.maxAttempts).
+		Dur("retryDelay", c.retryDelay).
+		Msg("Starting JetStream consumer")
+
+	<-ctx.Done()
+	consumeCtx.Stop()
+	wg.Wait()
+	logging.Info().Msg("JetStream consumer shutting down")
+	return nil
+}
+
+// handle passes one message to the handler and settles it: acknowledged on success, redelivered
+// later on a transient failure, otherwise dead-lettered
+func (c *JetStreamConsumer) handle(ctx context.Context, msg jetstream.Msg, handler contracts.MessageHandler) {
+	queueMsg, err := queuemsg.DecodeMessage(msg.Data())
+	if err == nil {
+		err = handler.HandleMessage(ctx, queueMsg)
+	}
+	if err == nil {
+		if ackErr := msg.Ack(); ackErr != nil {
+			logging.Error().Err(ackErr).Msg("Failed to acknowledge message")
+		}
+		logging.Debug().Str("to", validation.SanitizeEmailForLogging(queueMsg.OtherEmail)).Msg("Successfully handled message")
+		return
+	}
+
+	attempts := 1
+	if metadata, metaErr := msg.Metadata(); metaErr == nil {
+		attempts = int(metadata.NumDelivered)
+	}
+
+	if queuemsg.ShouldRetry(err, attempts, c.maxAttempts) {
+		logging.Warn().Err(err).Int("attempts", attempts).Dur("retryDelay", c.retryDelay).Msg("Message failed, scheduled a retry")
+		msg.NakWithDelay(c.retryDelay)
+		return
+	}
+
+	if dlqErr := c.deadLetter(ctx, msg, err, attempts); dlqErr != nil {
+		logging.Error().Err(dlqErr).Msg("Failed to dead-letter failed message, redelivering it")
+		msg.NakWithDelay(c.retryDelay)
+		return
+	}
+	logging.Error().Err(err).Int("attempts", attempts).Bool("permanent", queuemsg.IsPermanentFailure(err)).Msg("Message failed, moved to the dead-letter subject")
+	msg.Term()
+}
+
+// deadLetter publishes a copy of msg to the dead-letter subject with the failure in its headers
+func (c *JetStreamConsumer) deadLetter(ctx context.Context, msg jetstream.Msg, handleErr error, attempts int) error {
+	deadLetter := natsclient.NewMsg(DeadLetterSubject(c.queueName))
+	for key, values := range msg.Headers() {
+		deadLetter.Header[key] = append([]string(nil), values...)
+	}
+	deadLetter.Header.Set(queuemsg.HeaderAttempts, strconv.Itoa(attempts))
+	deadLetter.Header.Set(queuemsg.HeaderFailureReason, queuemsg.FailureReason(handleErr))
+	deadLetter.Header.Set(queuemsg.HeaderFailedAt, time.Now().UTC().Format(time.RFC3339))
+	deadLetter.Data = msg.Data()
+
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+	_, err := c.js.PublishMsg(publishCtx, deadLetter)
+	return err
+}
+
+// Close closes the NATS connection
+func (c *JetStreamConsumer) Close() error {
+	if c.connection != nil {
+		c.connection.Close()
+	}
+	logging.Info().Msg("NATS connection closed")
+	return nil
+}
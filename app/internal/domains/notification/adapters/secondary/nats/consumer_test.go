@@ -0,0 +1,129 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuetest"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/nats-io/nats-server/v2/server"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runServer starts an embedded JetStream server that stops when the test ends
+func runServer(t *testing.T) string {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		t.Fatal("NATS server did not start")
+	}
+	t.Cleanup(srv.Shutdown)
+	return srv.ClientURL()
+}
+
+var queues atomic.Int64
+
+// newQueue returns a connection to a queue of its own on url
+func newQueue(url string) contracts.QueueConnection {
+	n := queues.Add(1)
+	return contracts.QueueConnection{
+		Backend:   contracts.QueueBackendNATS,
+		URL:       url,
+		QueueName: fmt.Sprintf("notifications.test%d", n),
+		Stream:    fmt.Sprintf("NOTIFICATIONS_TEST%d", n),
+	}
+}
+
+func TestJetStream_Contract(t *testing.T) {
+	url := runServer(t)
+
+	queuetest.Run(t, func(t *testing.T, maxAttempts int, retryDelay time.Duration) queuetest.Backend {
+		queueConn := newQueue(url)
+		publisher, err := NewNotificationPublisher(context.Background(), queueConn)
+		require.NoError(t, err)
+		consumer := NewJetStreamConsumer().WithRedelivery(maxAttempts, retryDelay)
+		t.Cleanup(func() {
+			consumer.Close()
+			publisher.Close()
+		})
+		return queuetest.Backend{Publisher: publisher, Consumer: consumer, Conn: queueConn}
+	})
+}
+
+func TestJetStream_DeadLettersWithFailureHeaders(t *testing.T) {
+	url := runServer(t)
+	queueConn := newQueue(url)
+
+	publisher, err := NewNotificationPublisher(context.Background(), queueConn)
+	require.NoError(t, err)
+	defer publisher.Close()
+	consumer := NewJetStreamConsumer().WithRedelivery(2, 10*time.Millisecond)
+	defer consumer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go consumer.StartConsuming(ctx, queueConn, handlerFunc(func(ctx context.Context, msg contracts.QueueMessage) error {
+		return fmt.Errorf("%w: connection refused", domain.ErrEmailSendFailed)
+	}), 1)
+
+	require.NoError(t, publisher.PublishNotification(context.Background(), contracts.NotificationRequest{To: "recipient@example.com"}))
+
+	conn, err := natsclient.Connect(url)
+	require.NoError(t, err)
+	defer conn.Close()
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+
+	stream, err := js.Stream(context.Background(), queueConn.Stream)
+	require.NoError(t, err)
+
+	var deadLetter *jetstream.RawStreamMsg
+	require.Eventually(t, func() bool {
+		deadLetter, err = stream.GetLastMsgForSubject(context.Background(), DeadLetterSubject(queueConn.QueueName))
+		return err == nil
+	}, 10*time.Second, 20*time.Millisecond)
+
+	assert.Equal(t, "2", deadLetter.Header.Get(queuemsg.HeaderAttempts))
+	assert.Contains(t, deadLetter.Header.Get(queuemsg.HeaderFailureReason), "connection refused")
+	_, err = time.Parse(time.RFC3339, deadLetter.Header.Get(queuemsg.HeaderFailedAt))
+	assert.NoError(t, err)
+	assert.Equal(t, queuemsg.ContentType, deadLetter.Header.Get("Content-Type"))
+
+	msg, err := queuemsg.DecodeMessage(deadLetter.Data)
+	require.NoError(t, err)
+	assert.Equal(t, "recipient@example.com", msg.OtherEmail)
+
+	// The failed notification is no longer on the queue subject
+	info, err := stream.Info(context.Background(), jetstream.WithSubjectFilter(queueConn.QueueName))
+	require.NoError(t, err)
+	assert.Empty(t, info.State.Subjects)
+}
+
+func TestConsumerName(t *testing.T) {
+	assert.Equal(t, "notifications_email", consumerName("notifications"))
+	assert.Equal(t, "password_exchange_queue_email", consumerName("password.exchange queue"))
+}
+
+// handlerFunc adapts a function to contracts.MessageHandler
+type handlerFunc func(ctx context.Context, msg contracts.QueueMessage) error
+
+func (f handlerFunc) HandleMessage(ctx context.Context, msg contracts.QueueMessage) error {
+	return f(ctx, msg)
+}
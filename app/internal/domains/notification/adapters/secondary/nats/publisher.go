@@ -0,0 +1,70 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// publishTimeout bounds one publish, including the wait for the stream's acknowledgement
+const publishTimeout = 5 * time.Second
+
+// NotificationPublisher implements the NotificationPort using NATS JetStream
+type NotificationPublisher struct {
+	connection *natsclient.Conn
+	js         jetstream.JetStream
+	subject    string
+}
+
+// NewNotificationPublisher connects to NATS and makes sure the stream holding queueConn's queue exists
+func NewNotificationPublisher(ctx context.Context, queueConn contracts.QueueConnection) (*NotificationPublisher, error) {
+	conn, js, _, err := connect(ctx, queueConn)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationPublisher{
+		connection: conn,
+		js:         js,
+		subject:    queueConn.QueueName,
+	}, nil
+}
+
+// PublishNotification publishes a notification and returns once the stream has stored it
+func (p *NotificationPublisher) PublishNotification(ctx context.Context, req contracts.NotificationRequest) error {
+	data, err := queuemsg.EncodeNotification(req)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to marshal notification message")
+		return err
+	}
+
+	msg := natsclient.NewMsg(p.subject)
+	msg.Header.Set("Content-Type", queuemsg.ContentType)
+	msg.Data = data
+
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	if _, err := p.js.PublishMsg(publishCtx, msg); err != nil {
+		logging.Error().Err(err).Str("recipientEmail", validation.SanitizeEmailForLogging(req.To)).Msg("Failed to publish notification message")
+		return fmt.Errorf("%w: failed to publish notification message: %v", domain.ErrQueueConnectionFailed, err)
+	}
+
+	logging.Info().Str("recipientEmail", validation.SanitizeEmailForLogging(req.To)).Str("subject", p.subject).Msg("Notification message published successfully")
+	return nil
+}
+
+// Close closes the NATS connection
+func (p *NotificationPublisher) Close() error {
+	if p.connection != nil {
+		p.connection.Close()
+	}
+	logging.Info().Msg("NATS notification publisher connection closed")
+	return nil
+}
@@ -0,0 +1,67 @@
+// Package nats implements the notification queue ports on NATS JetStream. Notifications are
+// published on a subject named after the queue and kept in a work-queue stream until the email
+// consumer acknowledges them; the ones it gives up on are moved to the <queue>.dlq subject of
+// the same stream.
+package nats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// DefaultStream is the stream used when the queue connection does not name one
+const DefaultStream = "NOTIFICATIONS"
+
+// DeadLetterSubject names the subject that holds the failed notifications from queue
+func DeadLetterSubject(queue string) string { return queue + ".dlq" }
+
+// consumerName names the durable consumer of queue; consumer names may not contain dots,
+// spaces or wildcards
+func consumerName(queue string) string {
+	return strings.NewReplacer(".", "_", " ", "_", "*", "_", ">", "_").Replace(queue) + "_email"
+}
+
+// connect dials the server and creates or updates the stream holding queueConn's queue
+func connect(ctx context.Context, queueConn contracts.QueueConnection) (*natsclient.Conn, jetstream.JetStream, jetstream.Stream, error) {
+	url := queueConn.URL
+	if url == "" {
+		url = natsclient.DefaultURL
+	}
+	streamName := queueConn.Stream
+	if streamName == "" {
+		streamName = DefaultStream
+	}
+
+	conn, err := natsclient.Connect(url, natsclient.Name("password-exchange"))
+	if err != nil {
+		logging.Error().Err(err).Str("url", url).Msg("Failed to connect to NATS")
+		return nil, nil, nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      streamName,
+		Subjects:  []string{queueConn.QueueName, DeadLetterSubject(queueConn.QueueName)},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("stream", streamName).Msg("Failed to create JetStream stream")
+		conn.Close()
+		return nil, nil, nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+
+	logging.Info().Str("url", conn.ConnectedUrlRedacted()).Str("stream", streamName).Msg("Connected to NATS JetStream")
+	return conn, js, stream, nil
+}
@@ -0,0 +1,53 @@
+// Package queue selects the notification queue adapters for the configured backend.
+package queue
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/nats"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/rabbitmq"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
+)
+
+// Publisher is a NotificationPort holding a connection that must be closed
+type Publisher interface {
+	secondary.NotificationPort
+	io.Closer
+}
+
+// NewConsumer returns the queue consumer for queueConn.Backend. Notifications are attempted up
+// to maxAttempts times, retryDelay apart; zero values keep the adapter defaults. The consumer
+// connects when it starts consuming.
+func NewConsumer(queueConn contracts.QueueConnection, maxAttempts int, retryDelay time.Duration) (secondary.QueuePort, error) {
+	switch queueConn.Backend {
+	case contracts.QueueBackendRabbitMQ, "":
+		return rabbitmq.NewRabbitMQConsumer().WithRedelivery(maxAttempts, retryDelay), nil
+	case contracts.QueueBackendNATS:
+		return nats.NewJetStreamConsumer().WithRedelivery(maxAttempts, retryDelay), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedQueueBackend, queueConn.Backend)
+	}
+}
+
+// NewPublisher connects a notification publisher for queueConn.Backend
+func NewPublisher(ctx context.Context, queueConn contracts.QueueConnection) (Publisher, error) {
+	switch queueConn.Backend {
+	case contracts.QueueBackendRabbitMQ, "":
+		return rabbitmq.NewNotificationPublisher(rabbitmq.NotificationConfig{
+			Host:      queueConn.Host,
+			Port:      queueConn.Port,
+			User:      queueConn.User,
+			Password:  queueConn.Password,
+			QueueName: queueConn.QueueName,
+		})
+	case contracts.QueueBackendNATS:
+		return nats.NewNotificationPublisher(ctx, queueConn)
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedQueueBackend, queueConn.Backend)
+	}
+}
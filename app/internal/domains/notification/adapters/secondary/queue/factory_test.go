@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+)
+
+func TestNewConsumer(t *testing.T) {
+	tests := []struct {
+		name     string
+		backend  string
+		wantType string
+		wantErr  error
+	}{
+		{"empty backend defaults to rabbitmq", "", "*rabbitmq.RabbitMQConsumer", nil},
+		{"rabbitmq", contracts.QueueBackendRabbitMQ, "*rabbitmq.RabbitMQConsumer", nil},
+		{"nats", contracts.QueueBackendNATS, "*nats.JetStreamConsumer", nil},
+		{"memory is not a service backend", "memory", "", domain.ErrUnsupportedQueueBackend},
+		{"unknown backend", "kafka", "", domain.ErrUnsupportedQueueBackend},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer, err := NewConsumer(contracts.QueueConnection{Backend: tt.backend, QueueName: "factory-test"}, 0, 0)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewConsumer() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewConsumer() error = %v", err)
+			}
+			if got := fmt.Sprintf("%T", consumer); got != tt.wantType {
+				t.Errorf("NewConsumer() = %s, want %s", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestNewPublisher_UnsupportedBackend(t *testing.T) {
+	for _, backend := range []string{"memory", "kafka"} {
+		if _, err := NewPublisher(context.Background(), contracts.QueueConnection{Backend: backend}); !errors.Is(err, domain.ErrUnsupportedQueueBackend) {
+			t.Errorf("NewPublisher(%q) error = %v, want %v", backend, err, domain.ErrUnsupportedQueueBackend)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package queuemsg holds what every notification queue adapter shares: the protobuf encoding of
+// notifications on the wire and the policy for retrying the ones that fail.
+package queuemsg
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// ContentType is the content type of encoded notifications
+const ContentType = "application/protobuf"
+
+// Headers adapters set on notifications they retry or dead-letter
+const (
+	// HeaderAttempts counts the failed attempts to handle a notification
+	HeaderAttempts = "x-notification-attempts"
+	// HeaderFailureReason is the error of the last failed attempt
+	HeaderFailureReason = "x-failure-reason"
+	// HeaderFailedAt is when the last attempt failed, in RFC 3339
+	HeaderFailedAt = "x-failed-at"
+)
+
+// maxFailureReasonLength caps the failure reason header; SMTP errors can quote whole responses
+const maxFailureReasonLength = 1024
+
+// Redelivery defaults used when a consumer is not configured otherwise
+const (
+	DefaultMaxAttempts = 5
+	DefaultRetryDelay  = 30 * time.Second
+)
+
+// EncodeNotification encodes a notification request as the queue message the email consumer reads
+func EncodeNotification(req contracts.NotificationRequest) ([]byte, error) {
+	data, err := proto.Marshal(&pb.Message{
+		Email:            req.From,
+		FirstName:        req.FromName,
+		OtherFirstName:   req.RecipientName,
+		OtherEmail:       req.To,
+		Content:          req.MessageContent,
+		Url:              req.MessageURL,
+		Hidden:           req.Hidden,
+		NotificationType: req.Type,
+		ReminderNumber:   int32(req.ReminderNumber),
+		WebhookEvent:     req.Event,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification message: %w", err)
+	}
+	return data, nil
+}
+
+// DecodeMessage decodes a queue message body. It fails with ErrEmptyMessageBody or
+// ErrMessageUnmarshalFailed, both of which are permanent.
+func DecodeMessage(body []byte) (contracts.QueueMessage, error) {
+	if len(body) == 0 {
+		return contracts.QueueMessage{}, domain.ErrEmptyMessageBody
+	}
+
+	var pbMsg pb.Message
+	if err := proto.Unmarshal(body, &pbMsg); err != nil {
+		return contracts.QueueMessage{}, fmt.Errorf("%w: %v", domain.ErrMessageUnmarshalFailed, err)
+	}
+
+	queueMsg := contracts.QueueMessage{
+		Email:            pbMsg.Email,
+		FirstName:        pbMsg.FirstName,
+		OtherFirstName:   pbMsg.OtherFirstName,
+		OtherLastName:    pbMsg.OtherLastName,
+		OtherEmail:       pbMsg.OtherEmail,
+		UniqueID:         pbMsg.UniqueId,
+		Content:          pbMsg.Content,
+		URL:              pbMsg.Url,
+		Hidden:           pbMsg.Hidden,
+		Captcha:          pbMsg.Captcha,
+		NotificationType: pbMsg.NotificationType,
+		ViewCount:        int(pbMsg.ViewCount),
+		MaxViewCount:     int(pbMsg.MaxViewCount),
+		ReminderNumber:   int(pbMsg.ReminderNumber),
+		WebhookEvent:     pbMsg.WebhookEvent,
+		WebhookURL:       pbMsg.WebhookUrl,
+		WebhookSecret:    pbMsg.WebhookSecret,
+	}
+	if pbMsg.OccurredAt != "" {
+		if occurredAt, err := time.Parse(time.RFC3339, pbMsg.OccurredAt); err == nil {
+			queueMsg.OccurredAt = occurredAt
+		}
+	}
+	return queueMsg, nil
+}
+
+// IsPermanentFailure reports whether an error would recur on every attempt, so the message is
+// dead-lettered without being retried
+func IsPermanentFailure(err error) bool {
+	return errors.Is(err, domain.ErrEmptyMessageBody) ||
+		errors.Is(err, domain.ErrMessageUnmarshalFailed) ||
+		errors.Is(err, domain.ErrInvalidNotificationRequest) ||
+		errors.Is(err, domain.ErrInvalidEmailAddress) ||
+		errors.Is(err, domain.ErrWebhookRejected)
+}
+
+// ShouldRetry reports whether a message whose latest attempt failed with err should be tried
+// again, given that attempts attempts have failed so far
+func ShouldRetry(err error, attempts, maxAttempts int) bool {
+	return !IsPermanentFailure(err) && attempts < maxAttempts
+}
+
+// FailureReason shortens an error to fit the failure reason header
+func FailureReason(err error) string {
+	reason := err.Error()
+	if len(reason) > maxFailureReasonLength {
+		reason = reason[:maxFailureReasonLength]
+	}
+	return reason
+}
@@ -0,0 +1,95 @@
+package queuemsg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncodeNotification_RoundTrip(t *testing.T) {
+	body, err := EncodeNotification(contracts.NotificationRequest{
+		To:             "recipient@example.com",
+		From:           "sender@example.com",
+		FromName:       "Alice",
+		RecipientName:  "Bob",
+		MessageContent: "Alice sent you a secret",
+		MessageURL:     "https://password.exchange/decrypt/abc/key",
+		Hidden:         "hidden",
+	})
+	require.NoError(t, err)
+
+	msg, err := DecodeMessage(body)
+	require.NoError(t, err)
+	assert.Equal(t, contracts.QueueMessage{
+		Email:          "sender@example.com",
+		FirstName:      "Alice",
+		OtherFirstName: "Bob",
+		OtherEmail:     "recipient@example.com",
+		Content:        "Alice sent you a secret",
+		URL:            "https://password.exchange/decrypt/abc/key",
+		Hidden:         "hidden",
+	}, msg)
+}
+
+func TestEncodeNotification_Reminder(t *testing.T) {
+	body, err := EncodeNotification(contracts.NotificationRequest{
+		To:             "recipient@example.com",
+		Type:           contracts.NotificationTypeReminder,
+		ReminderNumber: 3,
+	})
+	require.NoError(t, err)
+
+	msg, err := DecodeMessage(body)
+	require.NoError(t, err)
+	assert.Equal(t, contracts.NotificationTypeReminder, msg.NotificationType)
+	assert.Equal(t, 3, msg.ReminderNumber)
+}
+
+func TestDecodeMessage(t *testing.T) {
+	body, err := proto.Marshal(&pb.Message{
+		OtherEmail:       "recipient@example.com",
+		UniqueId:         "abc",
+		NotificationType: contracts.NotificationTypeWebhook,
+		ViewCount:        2,
+		MaxViewCount:     5,
+		WebhookEvent:     "message.viewed",
+		OccurredAt:       "2026-10-17T08:00:00Z",
+	})
+	require.NoError(t, err)
+
+	msg, err := DecodeMessage(body)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", msg.UniqueID)
+	assert.Equal(t, contracts.NotificationTypeWebhook, msg.NotificationType)
+	assert.Equal(t, 2, msg.ViewCount)
+	assert.Equal(t, 5, msg.MaxViewCount)
+	assert.Equal(t, "message.viewed", msg.WebhookEvent)
+	assert.Equal(t, time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC), msg.OccurredAt)
+
+	_, err = DecodeMessage(nil)
+	assert.ErrorIs(t, err, domain.ErrEmptyMessageBody)
+
+	_, err = DecodeMessage([]byte("garbage"))
+	assert.ErrorIs(t, err, domain.ErrMessageUnmarshalFailed)
+}
+
+func TestShouldRetry(t *testing.T) {
+	assert.True(t, ShouldRetry(domain.ErrEmailSendFailed, 1, 3))
+	assert.False(t, ShouldRetry(domain.ErrEmailSendFailed, 3, 3))
+	assert.False(t, ShouldRetry(fmt.Errorf("%w: missing recipient", domain.ErrInvalidNotificationRequest), 1, 3))
+	assert.False(t, ShouldRetry(domain.ErrInvalidEmailAddress, 1, 3))
+}
+
+func TestFailureReason_Truncated(t *testing.T) {
+	assert.Equal(t, "smtp down", FailureReason(errors.New("smtp down")))
+	assert.Len(t, FailureReason(errors.New(strings.Repeat("x", 5000))), maxFailureReasonLength)
+}
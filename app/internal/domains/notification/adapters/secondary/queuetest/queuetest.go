@@ -0,0 +1,205 @@
+// Package queuetest is the contract every notification queue backend has to meet. Each adapter
+// package runs it against its own backend, so the email consumer behaves the same whichever
+// queue it is configured with.
+package queuetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// waitFor bounds how long a notification may take to be handled
+	waitFor = 10 * time.Second
+	// settleFor is how long the suite watches for deliveries that should not happen
+	settleFor = 500 * time.Millisecond
+	// retryDelay is short so the retry cases run quickly
+	retryDelay = 100 * time.Millisecond
+)
+
+// Backend is one empty queue of the adapter under test
+type Backend struct {
+	Publisher secondary.NotificationPort
+	Consumer  secondary.QueuePort
+	Conn      contracts.QueueConnection
+}
+
+// Factory opens a new, empty queue whose consumer attempts a notification up to maxAttempts
+// times, retryDelay apart. It registers any cleanup with t.
+type Factory func(t *testing.T, maxAttempts int, retryDelay time.Duration) Backend
+
+// Run runs the contract suite against the backend built by factory
+func Run(t *testing.T, factory Factory) {
+	t.Run("DeliversPublishedNotification", func(t *testing.T) {
+		backend := factory(t, 3, retryDelay)
+		handler := &recordingHandler{}
+		start(t, backend, handler, 1)
+
+		require.NoError(t, backend.Publisher.PublishNotification(context.Background(), contracts.NotificationRequest{
+			To:             "recipient@example.com",
+			From:           "sender@example.com",
+			FromName:       "Alice",
+			RecipientName:  "Bob",
+			MessageContent: "Alice sent you a secret",
+			MessageURL:     "https://password.exchange/decrypt/abc/key",
+			Hidden:         "hidden",
+		}))
+
+		handler.waitForCalls(t, 1)
+		msg := handler.messages()[0]
+		assert.Equal(t, "recipient@example.com", msg.OtherEmail)
+		assert.Equal(t, "sender@example.com", msg.Email)
+		assert.Equal(t, "Alice", msg.FirstName)
+		assert.Equal(t, "Bob", msg.OtherFirstName)
+		assert.Equal(t, "Alice sent you a secret", msg.Content)
+		assert.Equal(t, "https://password.exchange/decrypt/abc/key", msg.URL)
+		assert.Equal(t, "hidden", msg.Hidden)
+	})
+
+	t.Run("DeliversEachNotificationOnce", func(t *testing.T) {
+		const notifications = 25
+		backend := factory(t, 3, retryDelay)
+		handler := &recordingHandler{}
+		start(t, backend, handler, 5)
+
+		for i := 0; i < notifications; i++ {
+			require.NoError(t, backend.Publisher.PublishNotification(context.Background(), contracts.NotificationRequest{
+				To: fmt.Sprintf("recipient%d@example.com", i),
+			}))
+		}
+
+		handler.waitForCalls(t, notifications)
+		time.Sleep(settleFor)
+		recipients := map[string]int{}
+		for _, msg := range handler.messages() {
+			recipients[msg.OtherEmail]++
+		}
+		assert.Len(t, recipients, notifications)
+		for recipient, calls := range recipients {
+			assert.Equal(t, 1, calls, recipient)
+		}
+	})
+
+	t.Run("RetriesFailedNotification", func(t *testing.T) {
+		backend := factory(t, 3, retryDelay)
+		handler := &recordingHandler{fail: func(call int) error {
+			if call == 1 {
+				return fmt.Errorf("%w: connection refused", domain.ErrEmailSendFailed)
+			}
+			return nil
+		}}
+		start(t, backend, handler, 1)
+
+		require.NoError(t, backend.Publisher.PublishNotification(context.Background(), contracts.NotificationRequest{To: "recipient@example.com"}))
+
+		handler.waitForCalls(t, 2)
+		time.Sleep(settleFor)
+		assert.Equal(t, 2, handler.calls(), "a notification that succeeded is not delivered again")
+	})
+
+	t.Run("StopsAfterMaxAttempts", func(t *testing.T) {
+		backend := factory(t, 3, retryDelay)
+		handler := &recordingHandler{fail: func(call int) error { return domain.ErrEmailSendFailed }}
+		start(t, backend, handler, 1)
+
+		require.NoError(t, backend.Publisher.PublishNotification(context.Background(), contracts.NotificationRequest{To: "recipient@example.com"}))
+
+		handler.waitForCalls(t, 3)
+		time.Sleep(settleFor + 2*retryDelay)
+		assert.Equal(t, 3, handler.calls())
+	})
+
+	t.Run("DoesNotRetryPermanentFailure", func(t *testing.T) {
+		backend := factory(t, 3, retryDelay)
+		handler := &recordingHandler{fail: func(call int) error {
+			return fmt.Errorf("%w: missing domain", domain.ErrInvalidEmailAddress)
+		}}
+		start(t, backend, handler, 1)
+
+		require.NoError(t, backend.Publisher.PublishNotification(context.Background(), contracts.NotificationRequest{To: "recipient"}))
+
+		handler.waitForCalls(t, 1)
+		time.Sleep(settleFor + 2*retryDelay)
+		assert.Equal(t, 1, handler.calls())
+	})
+
+	t.Run("StopsWhenContextCancelled", func(t *testing.T) {
+		backend := factory(t, 3, retryDelay)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- backend.Consumer.StartConsuming(ctx, backend.Conn, &recordingHandler{}, 2) }()
+
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(waitFor):
+			t.Fatal("StartConsuming did not return after its context was cancelled")
+		}
+	})
+}
+
+// start consumes backend's queue until the test ends
+func start(t *testing.T, backend Backend, handler contracts.MessageHandler, concurrency int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- backend.Consumer.StartConsuming(ctx, backend.Conn, handler, concurrency) }()
+
+	t.Cleanup(func() {
+		cancel()
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(waitFor):
+			t.Error("StartConsuming did not return after its context was cancelled")
+		}
+	})
+}
+
+// recordingHandler records the messages it is given and fails the calls fail returns an error for
+type recordingHandler struct {
+	mu       sync.Mutex
+	received []contracts.QueueMessage
+	fail     func(call int) error
+}
+
+func (h *recordingHandler) HandleMessage(ctx context.Context, msg contracts.QueueMessage) error {
+	h.mu.Lock()
+	h.received = append(h.received, msg)
+	call := len(h.received)
+	h.mu.Unlock()
+
+	if h.fail != nil {
+		return h.fail(call)
+	}
+	return nil
+}
+
+func (h *recordingHandler) calls() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.received)
+}
+
+func (h *recordingHandler) messages() []contracts.QueueMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]contracts.QueueMessage(nil), h.received...)
+}
+
+func (h *recordingHandler) waitForCalls(t *testing.T, calls int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return h.calls() >= calls }, waitFor, 10*time.Millisecond,
+		"expected %d calls to the handler", calls)
+}
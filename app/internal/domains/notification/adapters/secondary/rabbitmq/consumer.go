@@ -4,25 +4,54 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
-	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
 	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
-	"github.com/golang/protobuf/proto"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-// RabbitMQConsumer implements the QueuePort using RabbitMQ
+// RabbitMQConsumer implements the QueuePort using RabbitMQ. A notification that fails is
+// published to the retry queue and handled again after the retry delay, up to maxAttempts
+// times; after that, or straight away for a notification that can never succeed, it is
+// published to the dead-letter exchange with the reason in its headers.
 type RabbitMQConsumer struct {
 	connection *amqp.Connection
 	channel    *amqp.Channel
+
+	queueName   string
+	maxAttempts int
+	retryDelay  time.Duration
+
+	// publishMu serializes republishing, which waits for the confirm on the publish channel
+	publishMu      sync.Mutex
+	publishChannel *amqp.Channel
+	publisher      publisher
 }
 
 // NewRabbitMQConsumer creates a new RabbitMQ consumer
 func NewRabbitMQConsumer() *RabbitMQConsumer {
-	return &RabbitMQConsumer{}
+	return &RabbitMQConsumer{
+		maxAttempts: queuemsg.DefaultMaxAttempts,
+		retryDelay:  queuemsg.DefaultRetryDelay,
+	}
+}
+
+// WithRedelivery sets how many times a notification is attempted before it is dead-lettered
+// and how long it waits between attempts. Zero or negative values keep the defaults.
+func (r *RabbitMQConsumer) WithRedelivery(maxAttempts int, retryDelay time.Duration) *RabbitMQConsumer {
+	if maxAttempts > 0 {
+		r.maxAttempts = maxAttempts
+	}
+	if retryDelay > 0 {
+		r.retryDelay = retryDelay
+	}
+	return r
 }
 
 // Connect establishes a connection to RabbitMQ
@@ -42,8 +71,24 @@ func (r *RabbitMQConsumer) Connect(ctx context.Context, queueConn contracts.Queu
 		return fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
 	}
 
+	// Retries and dead letters are published on their own channel, in confirm mode, so a
+	// delivery is only acknowledged once its copy is safely queued
+	publishCh, err := conn.Channel()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to open RabbitMQ publish channel")
+		conn.Close()
+		return fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+	if err := publishCh.Confirm(false); err != nil {
+		logging.Error().Err(err).Msg("Failed to enable publisher confirms")
+		conn.Close()
+		return fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+
 	r.connection = conn
 	r.channel = ch
+	r.publishChannel = publishCh
+	r.publisher = &confirmingPublisher{channel: publishCh}
 
 	logging.Info().Str("host", queueConn.Host).Int("port", queueConn.Port).Msg("Connected to RabbitMQ")
 	return nil
@@ -70,6 +115,11 @@ func (r *RabbitMQConsumer) StartConsuming(ctx context.Context, queueConn contrac
 		logging.Error().Err(err).Str("queue", queueConn.QueueName).Msg("Failed to declare queue")
 		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
 	}
+	if err := declareDeadLetterTopology(r.channel, queueConn.QueueName); err != nil {
+		logging.Error().Err(err).Str("queue", queueConn.QueueName).Msg("Failed to declare dead-letter topology")
+		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+	r.queueName = queueConn.QueueName
 
 	// Set QoS
 	err = r.channel.Qos(
@@ -97,7 +147,12 @@ func (r *RabbitMQConsumer) StartConsuming(ctx context.Context, queueConn contrac
 		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
 	}
 
-	logging.Info().Str("queue", queueConn.QueueName).Int("concurrency", concurrency).Msg("Starting RabbitMQ consumer")
+	logging.Info().
+		Str("queue", queueConn.QueueName).
+		Int("concurrency", concurrency).
+		Int("maxAttempts", r.maxAttempts).
+		Dur("retryDelay", r.retryDelay).
+		Msg("Starting RabbitMQ consumer")
 
 	// Start worker goroutines
 	for i := 0; i < concurrency; i++ {
@@ -126,58 +181,76 @@ func (r *RabbitMQConsumer) messageWorker(ctx context.Context, msgs <-chan amqp.D
 				return
 			}
 
-			success := r.handleMessage(ctx, msg, handler, workerID)
-			if success {
-				msg.Ack(false)
-			} else {
-				msg.Nack(false, true) // Requeue the message
-			}
+			err := r.handleMessage(ctx, msg, handler, workerID)
+			r.settle(ctx, msg, err, workerID)
 		}
 	}
 }
 
-// handleMessage processes a single message
-func (r *RabbitMQConsumer) handleMessage(ctx context.Context, delivery amqp.Delivery, handler domain.MessageHandler, workerID int) bool {
-	if delivery.Body == nil {
-		logging.Error().Int("workerId", workerID).Msg("Received message with empty body")
-		return false
+// settle acknowledges a handled delivery. A failed one is first republished to the retry queue,
+// or to the dead-letter exchange once it has used up its attempts or can never succeed; if that
+// publish fails the delivery is requeued as it is.
+func (r *RabbitMQConsumer) settle(ctx context.Context, delivery amqp.Delivery, handleErr error, workerID int) {
+	if handleErr == nil {
+		delivery.Ack(false)
+		return
+	}
+
+	attempts := deliveryAttempts(delivery.Headers) + 1
+	headers := copyHeaders(delivery.Headers)
+	headers[HeaderAttempts] = int64(attempts)
+	headers[HeaderFailureReason] = queuemsg.FailureReason(handleErr)
+	headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+	msg := republishing(delivery, headers)
+
+	exchange, routingKey := DeadLetterExchange(r.queueName), r.queueName
+	permanent := queuemsg.IsPermanentFailure(handleErr)
+	if queuemsg.ShouldRetry(handleErr, attempts, r.maxAttempts) {
+		exchange, routingKey = "", RetryQueue(r.queueName)
+		msg.Expiration = strconv.FormatInt(r.retryDelay.Milliseconds(), 10)
 	}
 
-	// Unmarshal protobuf message
-	var pbMsg pb.Message
-	err := proto.Unmarshal(delivery.Body, &pbMsg)
+	r.publishMu.Lock()
+	err := r.publisher.publish(ctx, exchange, routingKey, msg)
+	r.publishMu.Unlock()
 	if err != nil {
-		logging.Error().Err(err).Int("workerId", workerID).Msg("Failed to unmarshal message")
-		return false
+		logging.Error().Err(err).Int("workerId", workerID).Str("routingKey", routingKey).Msg("Failed to republish failed message, requeueing it")
+		delivery.Nack(false, true)
+		return
 	}
 
-	// Convert to domain message
-	queueMsg := domain.QueueMessage{
-		Email:          pbMsg.Email,
-		FirstName:      pbMsg.FirstName,
-		OtherFirstName: pbMsg.OtherFirstName,
-		OtherLastName:  pbMsg.OtherLastName,
-		OtherEmail:     pbMsg.OtherEmail,
-		UniqueID:       pbMsg.UniqueId,
-		Content:        pbMsg.Content,
-		URL:            pbMsg.Url,
-		Hidden:         pbMsg.Hidden,
-		Captcha:        pbMsg.Captcha,
+	if exchange == "" {
+		logging.Warn().Err(handleErr).Int("workerId", workerID).Int("attempts", attempts).Dur("retryDelay", r.retryDelay).Msg("Message failed, scheduled a retry")
+	} else {
+		logging.Error().Err(handleErr).Int("workerId", workerID).Int("attempts", attempts).Bool("permanent", permanent).Msg("Message failed, moved to the dead-letter queue")
+	}
+	delivery.Ack(false)
+}
+
+// handleMessage processes a single message
+func (r *RabbitMQConsumer) handleMessage(ctx context.Context, delivery amqp.Delivery, handler domain.MessageHandler, workerID int) error {
+	queueMsg, err := queuemsg.DecodeMessage(delivery.Body)
+	if err != nil {
+		logging.Error().Err(err).Int("workerId", workerID).Msg("Failed to decode message")
+		return err
 	}
 
 	// Handle the message
 	err = handler.HandleMessage(ctx, queueMsg)
 	if err != nil {
 		logging.Error().Err(err).Int("workerId", workerID).Str("to", validation.SanitizeEmailForLogging(queueMsg.OtherEmail)).Msg("Failed to handle message")
-		return false
+		return err
 	}
 
 	logging.Debug().Int("workerId", workerID).Str("to", validation.SanitizeEmailForLogging(queueMsg.OtherEmail)).Msg("Successfully handled message")
-	return true
+	return nil
 }
 
 // Close closes the RabbitMQ connection
 func (r *RabbitMQConsumer) Close() error {
+	if r.publishChannel != nil {
+		r.publishChannel.Close()
+	}
 	if r.channel != nil {
 		r.channel.Close()
 	}
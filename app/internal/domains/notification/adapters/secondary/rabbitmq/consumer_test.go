@@ -3,6 +3,7 @@ package rabbitmq
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
 	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
@@ -67,10 +68,10 @@ func TestHandleMessage_ValidProtobufMessage(t *testing.T) {
 	mockHandler.On("HandleMessage", mock.Anything, expectedMsg).Return(nil)
 
 	// Act
-	success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+	handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 	// Assert
-	assert.True(t, success)
+	assert.NoError(t, handleErr)
 	mockHandler.AssertExpectations(t)
 }
 
@@ -84,10 +85,10 @@ func TestHandleMessage_EmptyBody(t *testing.T) {
 	}
 
 	// Act
-	success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+	handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 	// Assert
-	assert.False(t, success)
+	assert.ErrorIs(t, handleErr, domain.ErrEmptyMessageBody)
 	mockHandler.AssertNotCalled(t, "HandleMessage")
 }
 
@@ -102,10 +103,10 @@ func TestHandleMessage_InvalidProtobufData(t *testing.T) {
 	}
 
 	// Act
-	success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+	handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 	// Assert
-	assert.False(t, success)
+	assert.ErrorIs(t, handleErr, domain.ErrMessageUnmarshalFailed)
 	mockHandler.AssertNotCalled(t, "HandleMessage")
 }
 
@@ -138,10 +139,10 @@ func TestHandleMessage_HandlerError(t *testing.T) {
 	mockHandler.On("HandleMessage", mock.Anything, expectedMsg).Return(assert.AnError)
 
 	// Act
-	success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+	handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 	// Assert
-	assert.False(t, success)
+	assert.ErrorIs(t, handleErr, assert.AnError)
 	mockHandler.AssertExpectations(t)
 }
 
@@ -179,6 +180,46 @@ func TestHandleMessage_ProtobufFieldMapping(t *testing.T) {
 				Captcha:        "cap456",
 			},
 		},
+		{
+			name: "Read receipt",
+			pbMsg: &pb.Message{
+				Email:            "sender@example.com",
+				UniqueId:         "abc123",
+				NotificationType: "read_receipt",
+				ViewCount:        2,
+				MaxViewCount:     5,
+			},
+			expected: domain.QueueMessage{
+				Email:            "sender@example.com",
+				UniqueID:         "abc123",
+				NotificationType: "read_receipt",
+				ViewCount:        2,
+				MaxViewCount:     5,
+			},
+		},
+		{
+			name: "Webhook event",
+			pbMsg: &pb.Message{
+				UniqueId:         "abc123",
+				NotificationType: "webhook",
+				ViewCount:        5,
+				MaxViewCount:     5,
+				WebhookEvent:     "message.exhausted",
+				WebhookUrl:       "https://hooks.example.com/events",
+				WebhookSecret:    "per-message-secret",
+				OccurredAt:       "2030-01-02T03:04:05Z",
+			},
+			expected: domain.QueueMessage{
+				UniqueID:         "abc123",
+				NotificationType: "webhook",
+				ViewCount:        5,
+				MaxViewCount:     5,
+				WebhookEvent:     "message.exhausted",
+				WebhookURL:       "https://hooks.example.com/events",
+				WebhookSecret:    "per-message-secret",
+				OccurredAt:       time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
 		{
 			name: "Minimal fields",
 			pbMsg: &pb.Message{
@@ -235,10 +276,10 @@ func TestHandleMessage_ProtobufFieldMapping(t *testing.T) {
 			mockHandler.On("HandleMessage", mock.Anything, tc.expected).Return(nil)
 
 			// Act
-			success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+			handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 			// Assert
-			assert.True(t, success)
+			assert.NoError(t, handleErr)
 			mockHandler.AssertExpectations(t)
 		})
 	}
@@ -274,10 +315,10 @@ func TestHandleMessage_ProtobufBinaryData(t *testing.T) {
 	mockHandler.On("HandleMessage", mock.Anything, expectedMsg).Return(nil)
 
 	// Act
-	success := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
+	handleErr := consumer.handleMessage(context.Background(), delivery, mockHandler, 1)
 
 	// Assert
-	assert.True(t, success)
+	assert.NoError(t, handleErr)
 	mockHandler.AssertExpectations(t)
 }
 
@@ -0,0 +1,76 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuetest"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRabbitMQ_Contract runs the queue contract against the broker at
+// PASSWORDEXCHANGE_TEST_RABHOST, logging in as guest unless PASSWORDEXCHANGE_TEST_RABUSER and
+// PASSWORDEXCHANGE_TEST_RABPASS are set
+func TestRabbitMQ_Contract(t *testing.T) {
+	host := os.Getenv("PASSWORDEXCHANGE_TEST_RABHOST")
+	if host == "" {
+		t.Skip("PASSWORDEXCHANGE_TEST_RABHOST is not set")
+	}
+	port, _ := strconv.Atoi(os.Getenv("PASSWORDEXCHANGE_TEST_RABPORT"))
+	if port == 0 {
+		port = 5672
+	}
+	user, password := os.Getenv("PASSWORDEXCHANGE_TEST_RABUSER"), os.Getenv("PASSWORDEXCHANGE_TEST_RABPASS")
+	if user == "" {
+		user, password = "guest", "guest"
+	}
+
+	queuetest.Run(t, func(t *testing.T, maxAttempts int, retryDelay time.Duration) queuetest.Backend {
+		queueConn := contracts.QueueConnection{
+			Backend:   contracts.QueueBackendRabbitMQ,
+			Host:      host,
+			Port:      port,
+			User:      user,
+			Password:  password,
+			QueueName: fmt.Sprintf("queuetest-%d", time.Now().UnixNano()),
+		}
+		publisher, err := NewNotificationPublisher(NotificationConfig{
+			Host:      queueConn.Host,
+			Port:      queueConn.Port,
+			User:      queueConn.User,
+			Password:  queueConn.Password,
+			QueueName: queueConn.QueueName,
+		})
+		require.NoError(t, err)
+		consumer := NewRabbitMQConsumer().WithRedelivery(maxAttempts, retryDelay)
+
+		t.Cleanup(func() {
+			consumer.Close()
+			deleteQueues(queueConn)
+			publisher.Close()
+		})
+		return queuetest.Backend{Publisher: publisher, Consumer: consumer, Conn: queueConn}
+	})
+}
+
+// deleteQueues removes the queues and exchange a contract case declared
+func deleteQueues(queueConn contracts.QueueConnection) {
+	conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%d/", queueConn.User, queueConn.Password, queueConn.Host, queueConn.Port))
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	ch, err := conn.Channel()
+	if err != nil {
+		return
+	}
+	for _, queue := range []string{queueConn.QueueName, RetryQueue(queueConn.QueueName), DeadLetterQueue(queueConn.QueueName)} {
+		ch.QueueDelete(queue, false, false, false)
+	}
+	ch.ExchangeDelete(DeadLetterExchange(queueConn.QueueName), false, false)
+}
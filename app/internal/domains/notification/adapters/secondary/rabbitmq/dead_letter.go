@@ -0,0 +1,303 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	"github.com/golang/protobuf/proto"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers the consumer sets on deliveries it retries or dead-letters
+const (
+	HeaderAttempts      = queuemsg.HeaderAttempts
+	HeaderFailureReason = queuemsg.HeaderFailureReason
+	HeaderFailedAt      = queuemsg.HeaderFailedAt
+)
+
+// confirmTimeout bounds one republish, including the wait for the broker's confirm
+const confirmTimeout = 5 * time.Second
+
+// DeadLetterExchange names the exchange failed notifications from queue are published to. It is
+// a direct exchange routing on the queue name, so other queues can be bound to it for alerting.
+func DeadLetterExchange(queue string) string { return queue + ".dlx" }
+
+// DeadLetterQueue names the queue that holds the failed notifications from queue
+func DeadLetterQueue(queue string) string { return queue + ".dlq" }
+
+// RetryQueue names the queue a failed notification waits in before it is handled again. Each
+// retry carries its own expiration; once it runs out RabbitMQ dead-letters the delivery back
+// onto queue through the default exchange.
+func RetryQueue(queue string) string { return queue + ".retry" }
+
+// declareDeadLetterTopology declares the dead-letter exchange and queue and the retry queue for
+// queue. The main queue is left as it is, so publishers that declare it keep working.
+func declareDeadLetterTopology(ch *amqp.Channel, queue string) error {
+	if err := ch.ExchangeDeclare(
+		DeadLetterExchange(queue), // name
+		amqp.ExchangeDirect,       // kind
+		true,                      // durable
+		false,                     // auto-delete
+		false,                     // internal
+		false,                     // no-wait
+		nil,                       // arguments
+	); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange: %w", err)
+	}
+	if _, err := ch.QueueDeclare(DeadLetterQueue(queue), true, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue: %w", err)
+	}
+	if err := ch.QueueBind(DeadLetterQueue(queue), queue, DeadLetterExchange(queue), false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue: %w", err)
+	}
+	if _, err := ch.QueueDeclare(RetryQueue(queue), true, false, false, false, amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	}); err != nil {
+		return fmt.Errorf("failed to declare retry queue: %w", err)
+	}
+	return nil
+}
+
+// publisher publishes a message and returns once the broker has confirmed it
+type publisher interface {
+	publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error
+}
+
+// confirmingPublisher publishes on a channel in confirm mode
+type confirmingPublisher struct {
+	channel *amqp.Channel
+}
+
+func (p *confirmingPublisher) publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	publishCtx, cancel := context.WithTimeout(ctx, confirmTimeout)
+	defer cancel()
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(publishCtx, exchange, routingKey, false, false, msg)
+	if err != nil {
+		return err
+	}
+	acked, err := confirmation.WaitContext(publishCtx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("broker rejected the message")
+	}
+	return nil
+}
+
+// republishing copies a delivery into a persistent message with the given headers
+func republishing(delivery amqp.Delivery, headers amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:       headers,
+		ContentType:   delivery.ContentType,
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: delivery.CorrelationId,
+		MessageId:     delivery.MessageId,
+		Timestamp:     delivery.Timestamp,
+		Type:          delivery.Type,
+		Body:          delivery.Body,
+	}
+}
+
+// copyHeaders returns a copy of headers that can be changed without touching the delivery
+func copyHeaders(headers amqp.Table) amqp.Table {
+	copied := amqp.Table{}
+	for key, value := range headers {
+		copied[key] = value
+	}
+	return copied
+}
+
+// deliveryAttempts reads how many attempts to handle a delivery have failed so far
+func deliveryAttempts(headers amqp.Table) int {
+	switch value := headers[HeaderAttempts].(type) {
+	case int:
+		return value
+	case int32:
+		return int(value)
+	case int64:
+		return int(value)
+	case string:
+		attempts, _ := strconv.Atoi(value)
+		return attempts
+	default:
+		return 0
+	}
+}
+
+// DeadLetter describes a notification waiting in the dead-letter queue
+type DeadLetter struct {
+	UniqueID         string
+	NotificationType string
+	// Recipient is sanitized for display
+	Recipient     string
+	Attempts      int
+	FailureReason string
+	FailedAt      time.Time
+	Size          int
+}
+
+// DeadLetterAdmin lists, replays and purges the dead-letter queue of the notification queue
+type DeadLetterAdmin struct {
+	queueName  string
+	connection *amqp.Connection
+	channel    *amqp.Channel
+	publisher  publisher
+}
+
+// NewDeadLetterAdmin connects to RabbitMQ and declares the dead-letter topology of queueConn's
+// queue, so it can be used before the consumer has ever run
+func NewDeadLetterAdmin(queueConn contracts.QueueConnection) (*DeadLetterAdmin, error) {
+	conn, err := amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%d/", queueConn.User, queueConn.Password, queueConn.Host, queueConn.Port))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+	if err := declareDeadLetterTopology(ch, queueConn.QueueName); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%w: %v", domain.ErrQueueConnectionFailed, err)
+	}
+
+	return &DeadLetterAdmin{
+		queueName:  queueConn.QueueName,
+		connection: conn,
+		channel:    ch,
+		publisher:  &confirmingPublisher{channel: ch},
+	}, nil
+}
+
+// List returns up to limit dead letters from the head of the queue without removing them
+func (a *DeadLetterAdmin) List(ctx context.Context, limit int) ([]DeadLetter, error) {
+	var deadLetters []DeadLetter
+	var lastTag uint64
+	for len(deadLetters) < limit {
+		delivery, ok, err := a.channel.Get(DeadLetterQueue(a.queueName), false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+		}
+		if !ok {
+			break
+		}
+		lastTag = delivery.DeliveryTag
+		deadLetters = append(deadLetters, describeDeadLetter(delivery))
+	}
+
+	// Hand every message back; they return to the head of the queue
+	if lastTag != 0 {
+		if err := a.channel.Nack(lastTag, true, true); err != nil {
+			return nil, fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+		}
+	}
+	return deadLetters, nil
+}
+
+// Replay moves up to limit dead letters back onto the notification queue with their attempt
+// count reset; zero replays every message that was in the queue when the replay started, so
+// notifications that fail again are not picked up twice.
+func (a *DeadLetterAdmin) Replay(ctx context.Context, limit int) (int, error) {
+	queue, err := a.channel.QueueDeclarePassive(DeadLetterQueue(a.queueName), true, false, false, false, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+	if limit <= 0 || limit > queue.Messages {
+		limit = queue.Messages
+	}
+
+	replayed := 0
+	for replayed < limit {
+		if err := ctx.Err(); err != nil {
+			return replayed, err
+		}
+		delivery, ok, err := a.channel.Get(DeadLetterQueue(a.queueName), false)
+		if err != nil {
+			return replayed, fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+		}
+		if !ok {
+			break
+		}
+		if err := a.replay(ctx, delivery); err != nil {
+			delivery.Nack(false, true)
+			return replayed, err
+		}
+		replayed++
+	}
+
+	logging.Info().Str("queue", a.queueName).Int("replayed", replayed).Msg("Replayed dead-lettered notifications")
+	return replayed, nil
+}
+
+// replay publishes one dead letter to the notification queue and removes it from the dead-letter queue
+func (a *DeadLetterAdmin) replay(ctx context.Context, delivery amqp.Delivery) error {
+	headers := copyHeaders(delivery.Headers)
+	delete(headers, HeaderAttempts)
+	delete(headers, HeaderFailureReason)
+	delete(headers, HeaderFailedAt)
+
+	if err := a.publisher.publish(ctx, "", a.queueName, republishing(delivery, headers)); err != nil {
+		return fmt.Errorf("%w: failed to replay notification: %v", domain.ErrQueueConsumeFailed, err)
+	}
+	if err := delivery.Ack(false); err != nil {
+		// The notification is already back on the queue, so at worst it is replayed twice
+		return fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+	return nil
+}
+
+// Purge deletes every dead letter and returns how many there were
+func (a *DeadLetterAdmin) Purge(ctx context.Context) (int, error) {
+	purged, err := a.channel.QueuePurge(DeadLetterQueue(a.queueName), false)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", domain.ErrQueueConsumeFailed, err)
+	}
+	logging.Info().Str("queue", a.queueName).Int("purged", purged).Msg("Purged dead-lettered notifications")
+	return purged, nil
+}
+
+// Close closes the RabbitMQ connection
+func (a *DeadLetterAdmin) Close() error {
+	if a.connection != nil {
+		return a.connection.Close()
+	}
+	return nil
+}
+
+// describeDeadLetter summarizes a dead letter without exposing its content or link
+func describeDeadLetter(delivery amqp.Delivery) DeadLetter {
+	deadLetter := DeadLetter{
+		Attempts: deliveryAttempts(delivery.Headers),
+		Size:     len(delivery.Body),
+	}
+	if reason, ok := delivery.Headers[HeaderFailureReason].(string); ok {
+		deadLetter.FailureReason = reason
+	}
+	if failedAt, ok := delivery.Headers[HeaderFailedAt].(string); ok {
+		deadLetter.FailedAt, _ = time.Parse(time.RFC3339, failedAt)
+	}
+
+	var pbMsg pb.Message
+	if err := proto.Unmarshal(delivery.Body, &pbMsg); err == nil {
+		deadLetter.UniqueID = pbMsg.UniqueId
+		deadLetter.NotificationType = pbMsg.NotificationType
+		deadLetter.Recipient = validation.SanitizeEmailForLogging(pbMsg.OtherEmail)
+	}
+	return deadLetter
+}
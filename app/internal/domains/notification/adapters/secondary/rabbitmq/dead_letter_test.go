@@ -0,0 +1,213 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	pb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"github.com/golang/protobuf/proto"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingAcknowledger records how a delivery was settled
+type recordingAcknowledger struct {
+	acked   bool
+	nacked  bool
+	requeue bool
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acked = true
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple bool, requeue bool) error {
+	a.nacked, a.requeue = true, requeue
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	return a.Nack(tag, false, requeue)
+}
+
+// published is a message passed to recordingPublisher
+type published struct {
+	exchange   string
+	routingKey string
+	msg        amqp.Publishing
+}
+
+// recordingPublisher records published messages, failing with err when it is set
+type recordingPublisher struct {
+	messages []published
+	err      error
+}
+
+func (p *recordingPublisher) publish(ctx context.Context, exchange, routingKey string, msg amqp.Publishing) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.messages = append(p.messages, published{exchange: exchange, routingKey: routingKey, msg: msg})
+	return nil
+}
+
+func newTestConsumer(pub publisher) *RabbitMQConsumer {
+	consumer := NewRabbitMQConsumer().WithRedelivery(3, 10*time.Second)
+	consumer.queueName = "notifications"
+	consumer.publisher = pub
+	return consumer
+}
+
+func TestSettle_AcksHandledMessage(t *testing.T) {
+	pub := &recordingPublisher{}
+	ack := &recordingAcknowledger{}
+
+	newTestConsumer(pub).settle(context.Background(), amqp.Delivery{Acknowledger: ack, Body: []byte("body")}, nil, 1)
+
+	assert.True(t, ack.acked)
+	assert.Empty(t, pub.messages)
+}
+
+func TestSettle_RetriesTransientFailure(t *testing.T) {
+	pub := &recordingPublisher{}
+	ack := &recordingAcknowledger{}
+	delivery := amqp.Delivery{
+		Acknowledger: ack,
+		ContentType:  "application/protobuf",
+		Headers:      amqp.Table{HeaderAttempts: int64(1), "trace": "abc"},
+		Body:         []byte("body"),
+	}
+
+	newTestConsumer(pub).settle(context.Background(), delivery, fmt.Errorf("%w: dial tcp: connection refused", domain.ErrEmailSendFailed), 1)
+
+	require.Len(t, pub.messages, 1)
+	retry := pub.messages[0]
+	assert.Equal(t, "", retry.exchange)
+	assert.Equal(t, "notifications.retry", retry.routingKey)
+	assert.Equal(t, "10000", retry.msg.Expiration)
+	assert.Equal(t, int64(2), retry.msg.Headers[HeaderAttempts])
+	assert.Contains(t, retry.msg.Headers[HeaderFailureReason], "connection refused")
+	assert.NotEmpty(t, retry.msg.Headers[HeaderFailedAt])
+	assert.Equal(t, "abc", retry.msg.Headers["trace"])
+	assert.Equal(t, amqp.Persistent, retry.msg.DeliveryMode)
+	assert.Equal(t, []byte("body"), retry.msg.Body)
+	assert.Equal(t, int64(1), delivery.Headers[HeaderAttempts], "the delivery's own headers are not changed")
+	assert.True(t, ack.acked)
+}
+
+func TestSettle_DeadLettersAfterMaxAttempts(t *testing.T) {
+	pub := &recordingPublisher{}
+	ack := &recordingAcknowledger{}
+	delivery := amqp.Delivery{Acknowledger: ack, Headers: amqp.Table{HeaderAttempts: int32(2)}, Body: []byte("body")}
+
+	newTestConsumer(pub).settle(context.Background(), delivery, domain.ErrEmailSendFailed, 1)
+
+	require.Len(t, pub.messages, 1)
+	assert.Equal(t, "notifications.dlx", pub.messages[0].exchange)
+	assert.Equal(t, "notifications", pub.messages[0].routingKey)
+	assert.Empty(t, pub.messages[0].msg.Expiration)
+	assert.Equal(t, int64(3), pub.messages[0].msg.Headers[HeaderAttempts])
+	assert.True(t, ack.acked)
+}
+
+func TestSettle_DeadLettersPermanentFailureImmediately(t *testing.T) {
+	for _, err := range []error{
+		domain.ErrEmptyMessageBody,
+		fmt.Errorf("%w: proto: cannot parse invalid wire-format data", domain.ErrMessageUnmarshalFailed),
+		fmt.Errorf("%w: missing recipient", domain.ErrInvalidNotificationRequest),
+		fmt.Errorf("%w: endpoint returned status 410", domain.ErrWebhookRejected),
+	} {
+		t.Run(err.Error(), func(t *testing.T) {
+			pub := &recordingPublisher{}
+			ack := &recordingAcknowledger{}
+
+			newTestConsumer(pub).settle(context.Background(), amqp.Delivery{Acknowledger: ack, Body: []byte("body")}, err, 1)
+
+			require.Len(t, pub.messages, 1)
+			assert.Equal(t, "notifications.dlx", pub.messages[0].exchange)
+			assert.Equal(t, int64(1), pub.messages[0].msg.Headers[HeaderAttempts])
+			assert.True(t, ack.acked)
+		})
+	}
+}
+
+func TestSettle_RequeuesWhenRepublishFails(t *testing.T) {
+	pub := &recordingPublisher{err: errors.New("channel closed")}
+	ack := &recordingAcknowledger{}
+
+	newTestConsumer(pub).settle(context.Background(), amqp.Delivery{Acknowledger: ack, Body: []byte("body")}, domain.ErrEmailSendFailed, 1)
+
+	assert.False(t, ack.acked)
+	assert.True(t, ack.nacked)
+	assert.True(t, ack.requeue)
+}
+
+func TestDeliveryAttempts(t *testing.T) {
+	assert.Equal(t, 0, deliveryAttempts(nil))
+	assert.Equal(t, 2, deliveryAttempts(amqp.Table{HeaderAttempts: int32(2)}))
+	assert.Equal(t, 3, deliveryAttempts(amqp.Table{HeaderAttempts: int64(3)}))
+	assert.Equal(t, 4, deliveryAttempts(amqp.Table{HeaderAttempts: "4"}))
+	assert.Equal(t, 0, deliveryAttempts(amqp.Table{HeaderAttempts: true}))
+}
+
+func TestDescribeDeadLetter(t *testing.T) {
+	body, err := proto.Marshal(&pb.Message{
+		OtherEmail:       "recipient@example.com",
+		UniqueId:         "unique123",
+		Url:              "https://password.exchange/decrypt/unique123/key",
+		NotificationType: "initial",
+	})
+	require.NoError(t, err)
+
+	deadLetter := describeDeadLetter(amqp.Delivery{
+		Headers: amqp.Table{
+			HeaderAttempts:      int64(5),
+			HeaderFailureReason: "failed to send email",
+			HeaderFailedAt:      "2026-10-17T08:00:00Z",
+		},
+		Body: body,
+	})
+
+	assert.Equal(t, "unique123", deadLetter.UniqueID)
+	assert.Equal(t, "initial", deadLetter.NotificationType)
+	assert.NotContains(t, deadLetter.Recipient, "recipient@")
+	assert.Equal(t, 5, deadLetter.Attempts)
+	assert.Equal(t, "failed to send email", deadLetter.FailureReason)
+	assert.Equal(t, time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC), deadLetter.FailedAt)
+	assert.Equal(t, len(body), deadLetter.Size)
+
+	// A poison message is still listed
+	assert.Equal(t, DeadLetter{Size: 7}, describeDeadLetter(amqp.Delivery{Body: []byte("garbage")}))
+}
+
+func TestDeadLetterAdmin_ReplayResetsFailureHeaders(t *testing.T) {
+	pub := &recordingPublisher{}
+	ack := &recordingAcknowledger{}
+	admin := &DeadLetterAdmin{queueName: "notifications", publisher: pub}
+
+	err := admin.replay(context.Background(), amqp.Delivery{
+		Acknowledger: ack,
+		Headers:      amqp.Table{HeaderAttempts: int64(5), HeaderFailureReason: "smtp down", HeaderFailedAt: "2026-10-17T08:00:00Z", "trace": "abc"},
+		Body:         []byte("body"),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pub.messages, 1)
+	assert.Equal(t, "", pub.messages[0].exchange)
+	assert.Equal(t, "notifications", pub.messages[0].routingKey)
+	assert.Equal(t, amqp.Table{"trace": "abc"}, pub.messages[0].msg.Headers)
+	assert.True(t, ack.acked)
+
+	// A dead letter that cannot be republished stays where it is
+	pub.err = errors.New("channel closed")
+	ack = &recordingAcknowledger{}
+	err = admin.replay(context.Background(), amqp.Delivery{Acknowledger: ack, Body: []byte("body")})
+	assert.ErrorIs(t, err, domain.ErrQueueConsumeFailed)
+	assert.False(t, ack.acked)
+}
@@ -5,12 +5,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
-	messagepb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
 	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
 	amqp "github.com/rabbitmq/amqp091-go"
-	"google.golang.org/protobuf/proto"
 )
 
 // NotificationPublisher implements the NotificationPort using RabbitMQ
@@ -74,22 +73,10 @@ func (p *NotificationPublisher) PublishNotification(ctx context.Context, req con
 		return fmt.Errorf("failed to declare queue: %w", err)
 	}
 
-	// Create protobuf message
-	pbMsg := &messagepb.Message{
-		Email:          req.From,
-		FirstName:      req.FromName,
-		OtherFirstName: req.RecipientName,
-		OtherEmail:     req.To,
-		Content:        req.MessageContent,
-		Url:            req.MessageURL,
-		Hidden:         req.Hidden,
-	}
-
-	// Marshal the message
-	data, err := proto.Marshal(pbMsg)
+	data, err := queuemsg.EncodeNotification(req)
 	if err != nil {
 		logging.Error().Err(err).Msg("Failed to marshal notification message")
-		return fmt.Errorf("failed to marshal notification message: %w", err)
+		return err
 	}
 
 	// Create context with timeout
@@ -104,7 +91,7 @@ func (p *NotificationPublisher) PublishNotification(ctx context.Context, req con
 		false,  // immediate
 		amqp.Publishing{
 			DeliveryMode: amqp.Persistent,
-			ContentType:  "application/protobuf",
+			ContentType:  queuemsg.ContentType,
 			Body:         data,
 		})
 
@@ -66,6 +66,21 @@ func (c *SharedConfigAdapter) GetReminderNotificationSubject() string {
 	return "Reminder: You have an unviewed encrypted message (Reminder #%d)"
 }
 
+// GetReadReceiptSubject returns the subject template for read receipt emails.
+func (c *SharedConfigAdapter) GetReadReceiptSubject() string {
+	return "Your encrypted message was viewed (%d of %d)"
+}
+
+// GetRequestFulfilledSubject returns the subject line for request fulfilled emails.
+func (c *SharedConfigAdapter) GetRequestFulfilledSubject() string {
+	return "Someone answered your secret request"
+}
+
+// GetPassphraseAlertSubject returns the subject line for passphrase alert emails.
+func (c *SharedConfigAdapter) GetPassphraseAlertSubject() string {
+	return "A wrong passphrase was entered for your encrypted message"
+}
+
 // GetReminderNotificationBodyTemplate returns the body template for reminder notifications.
 func (c *SharedConfigAdapter) GetReminderNotificationBodyTemplate() string {
 	return ""
@@ -81,6 +96,40 @@ func (c *SharedConfigAdapter) GetReminderMessageContent() string {
 	return "Please check your original email for the secure decrypt link. For security reasons, the decrypt link cannot be included in reminder emails. If you cannot find the original email, please contact the sender to resend the message."
 }
 
+// GetReadReceiptEmailTemplate returns the path to the read receipt email template.
+func (c *SharedConfigAdapter) GetReadReceiptEmailTemplate() string {
+	return "/templates/read_receipt_email_template.html"
+}
+
+// GetRequestFulfilledEmailTemplate returns the path to the request fulfilled email template.
+func (c *SharedConfigAdapter) GetRequestFulfilledEmailTemplate() string {
+	return "/templates/request_fulfilled_email_template.html"
+}
+
+// GetPassphraseAlertEmailTemplate returns the path to the passphrase alert email template.
+func (c *SharedConfigAdapter) GetPassphraseAlertEmailTemplate() string {
+	return "/templates/passphrase_alert_email_template.html"
+}
+
+// GetEmailTextTemplate returns the path to the plain-text initial email template.
+func (c *SharedConfigAdapter) GetEmailTextTemplate() string {
+	return "/templates/email_template.txt"
+}
+
+// GetReminderEmailTextTemplate returns the path to the plain-text reminder email template.
+func (c *SharedConfigAdapter) GetReminderEmailTextTemplate() string {
+	return "/templates/reminder_email_template.txt"
+}
+
+// GetListUnsubscribe returns the List-Unsubscribe target from configuration.
+// Falls back to a mailto: link to the server email address.
+func (c *SharedConfigAdapter) GetListUnsubscribe() string {
+	if c.config.EmailListUnsubscribe != "" {
+		return c.config.EmailListUnsubscribe
+	}
+	return "mailto:" + c.GetServerEmail() + "?subject=unsubscribe"
+}
+
 // Validation methods
 
 // ValidatePasswordExchangeURL validates the password exchange URL configuration.
@@ -354,7 +354,24 @@ func (t *testConfigAdapter) GetReminderNotificationBodyTemplate() string { retur
 func (t *testConfigAdapter) GetReminderEmailTemplate() string {
 	return "/templates/reminder_email_template.html"
 }
-func (t *testConfigAdapter) GetReminderMessageContent() string  { return "test content" }
+func (t *testConfigAdapter) GetReminderMessageContent() string { return "test content" }
+func (t *testConfigAdapter) GetReadReceiptSubject() string     { return "Viewed %d of %d" }
+func (t *testConfigAdapter) GetReadReceiptEmailTemplate() string {
+	return "/templates/read_receipt_email_template.html"
+}
+func (t *testConfigAdapter) GetRequestFulfilledSubject() string { return "Request answered" }
+func (t *testConfigAdapter) GetRequestFulfilledEmailTemplate() string {
+	return "/templates/request_fulfilled_email_template.html"
+}
+func (t *testConfigAdapter) GetPassphraseAlertSubject() string { return "Wrong passphrase" }
+func (t *testConfigAdapter) GetPassphraseAlertEmailTemplate() string {
+	return "/templates/passphrase_alert_email_template.html"
+}
+func (t *testConfigAdapter) GetEmailTextTemplate() string { return "/templates/email_template.txt" }
+func (t *testConfigAdapter) GetReminderEmailTextTemplate() string {
+	return "/templates/reminder_email_template.txt"
+}
+func (t *testConfigAdapter) GetListUnsubscribe() string         { return "" }
 func (t *testConfigAdapter) ValidatePasswordExchangeURL() error { return nil }
 func (t *testConfigAdapter) ValidateServerEmail() error         { return nil }
 
@@ -0,0 +1,106 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/emersion/go-msgauth/dkim"
+)
+
+// dkimHeaderKeys are the headers covered by the signature; buildMessage writes all of them
+// except List-Unsubscribe when it is not configured, which DKIM treats as signing its absence
+var dkimHeaderKeys = []string{
+	"From", "To", "Subject", "Date", "Message-ID", "List-Unsubscribe", "MIME-Version", "Content-Type",
+}
+
+// dkimSigner adds a DKIM-Signature header to outgoing messages
+type dkimSigner struct {
+	options *dkim.SignOptions
+}
+
+// newDKIMSigner loads the signing key of emailConn. It returns nil when no key is configured.
+func newDKIMSigner(emailConn contracts.EmailConnection) (*dkimSigner, error) {
+	if emailConn.DKIMKey == "" {
+		return nil, nil
+	}
+	if emailConn.DKIMSelector == "" {
+		return nil, fmt.Errorf("%w: a DKIM key needs a selector", domain.ErrInvalidSMTPConfig)
+	}
+
+	signingDomain := emailConn.DKIMDomain
+	if signingDomain == "" {
+		if at := strings.LastIndex(emailConn.From, "@"); at >= 0 {
+			signingDomain = emailConn.From[at+1:]
+		}
+	}
+	if signingDomain == "" {
+		return nil, fmt.Errorf("%w: no DKIM domain configured and none in the sender address", domain.ErrInvalidSMTPConfig)
+	}
+
+	key, err := loadDKIMKey(emailConn.DKIMKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidSMTPConfig, err)
+	}
+
+	return &dkimSigner{options: &dkim.SignOptions{
+		Domain:                 signingDomain,
+		Selector:               emailConn.DKIMSelector,
+		Signer:                 key,
+		HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+		BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+		HeaderKeys:             dkimHeaderKeys,
+	}}, nil
+}
+
+// sign returns msg with a DKIM-Signature header prepended
+func (d *dkimSigner) sign(msg []byte) ([]byte, error) {
+	var signed bytes.Buffer
+	if err := dkim.Sign(&signed, bytes.NewReader(msg), d.options); err != nil {
+		return nil, fmt.Errorf("failed to sign message with DKIM: %w", err)
+	}
+	return signed.Bytes(), nil
+}
+
+// loadDKIMKey parses a PEM-encoded RSA or Ed25519 private key, read from the file at keyConfig
+// unless keyConfig is the PEM itself
+func loadDKIMKey(keyConfig string) (crypto.Signer, error) {
+	keyPEM := []byte(keyConfig)
+	if !strings.HasPrefix(strings.TrimSpace(keyConfig), "-----BEGIN") {
+		content, err := os.ReadFile(keyConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DKIM key: %w", err)
+		}
+		keyPEM = content
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("DKIM key is not PEM-encoded")
+	}
+
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DKIM key: %w", err)
+	}
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported DKIM key type %T", key)
+	}
+}
@@ -0,0 +1,144 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ed25519DKIMKey returns a PEM-encoded Ed25519 key and the DNS TXT record publishing its public half
+func ed25519DKIMKey(t *testing.T) (string, string) {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return string(keyPEM), "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(public)
+}
+
+// verifyDKIM checks the signatures on msg against the TXT record of mail._domainkey.password.exchange
+func verifyDKIM(t *testing.T, msg []byte, record string) []*dkim.Verification {
+	t.Helper()
+	verifications, err := dkim.VerifyWithOptions(bytes.NewReader(msg), &dkim.VerifyOptions{
+		LookupTXT: func(name string) ([]string, error) {
+			assert.Equal(t, "mail._domainkey.password.exchange", name)
+			return []string{record}, nil
+		},
+	})
+	require.NoError(t, err)
+	return verifications
+}
+
+func TestDKIMSigner_SignsBuiltMessages(t *testing.T) {
+	keyPEM, record := ed25519DKIMKey(t)
+	signer, err := newDKIMSigner(contracts.EmailConnection{
+		From:         "server@password.exchange",
+		DKIMKey:      keyPEM,
+		DKIMSelector: "mail",
+	})
+	require.NoError(t, err)
+
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+	msg, _, err := sender.buildMessage("Password Exchange", "server@password.exchange", "recipient@example.com",
+		"Encrypted Message", emailBody{html: []byte("<p>hello</p>"), text: []byte("hello")})
+	require.NoError(t, err)
+
+	signed, err := signer.sign(msg)
+	require.NoError(t, err)
+
+	verifications := verifyDKIM(t, signed, record)
+	require.Len(t, verifications, 1)
+	assert.NoError(t, verifications[0].Err)
+	assert.Equal(t, "password.exchange", verifications[0].Domain)
+	assert.Contains(t, verifications[0].HeaderKeys, "Message-ID")
+
+	// Tampering with a signed header breaks the signature
+	tampered := bytes.Replace(signed, []byte("Subject: Encrypted Message"), []byte("Subject: Encrypted Massage"), 1)
+	verifications = verifyDKIM(t, tampered, record)
+	require.Len(t, verifications, 1)
+	assert.Error(t, verifications[0].Err)
+}
+
+func TestNewDKIMSigner(t *testing.T) {
+	keyPEM, _ := ed25519DKIMKey(t)
+
+	t.Run("disabled without a key", func(t *testing.T) {
+		signer, err := newDKIMSigner(contracts.EmailConnection{From: "server@password.exchange"})
+		require.NoError(t, err)
+		assert.Nil(t, signer)
+	})
+
+	t.Run("loads the key from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "dkim.pem")
+		require.NoError(t, os.WriteFile(path, []byte(keyPEM), 0o600))
+
+		signer, err := newDKIMSigner(contracts.EmailConnection{
+			From:         "server@password.exchange",
+			DKIMKey:      path,
+			DKIMSelector: "mail",
+			DKIMDomain:   "mail.password.exchange",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "mail.password.exchange", signer.options.Domain)
+	})
+
+	for name, emailConn := range map[string]contracts.EmailConnection{
+		"missing selector":  {From: "server@password.exchange", DKIMKey: keyPEM},
+		"missing key file":  {From: "server@password.exchange", DKIMKey: "/nonexistent/dkim.pem", DKIMSelector: "mail"},
+		"not a PEM key":     {From: "server@password.exchange", DKIMKey: "-----BEGIN garbage", DKIMSelector: "mail"},
+		"no signing domain": {From: "server", DKIMKey: keyPEM, DKIMSelector: "mail"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newDKIMSigner(emailConn)
+			assert.ErrorIs(t, err, domain.ErrInvalidSMTPConfig)
+		})
+	}
+}
+
+func TestSMTPSender_SendNotification_SignsAndReusesConnection(t *testing.T) {
+	server := startFakeSMTPServer(t, nil, false)
+	keyPEM, record := ed25519DKIMKey(t)
+
+	emailConn := server.emailConnection(contracts.EmailTLSNone)
+	emailConn.DKIMKey = keyPEM
+	emailConn.DKIMSelector = "mail"
+	sender, err := NewSMTPSender(emailConn, &mockConfigPortSecure{}, &mockLoggerPortSecure{}, &mockValidationPortSecure{})
+	require.NoError(t, err)
+
+	for _, to := range []string{"first@example.com", "second@example.com"} {
+		response, err := sender.SendNotification(context.Background(), contracts.NotificationRequest{
+			To:             to,
+			From:           "server@password.exchange",
+			FromName:       "Password Exchange",
+			Subject:        "Reminder Subject 1",
+			Type:           contracts.NotificationTypeReminder,
+			ReminderNumber: 1,
+		})
+		require.NoError(t, err)
+		assert.Regexp(t, `^<[0-9a-f]{32}@password\.exchange>$`, response.MessageID)
+	}
+	require.NoError(t, sender.Close())
+
+	connections, _, messages := server.snapshot()
+	assert.Equal(t, 1, connections)
+	require.Len(t, messages, 2)
+	for _, msg := range messages {
+		verifications := verifyDKIM(t, []byte(msg), record)
+		require.Len(t, verifications, 1)
+		assert.NoError(t, verifications[0].Err)
+	}
+}
@@ -0,0 +1,119 @@
+package smtp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+)
+
+// Content types of the message parts; both are sent quoted-printable so that long template
+// lines stay within the SMTP line limit
+const (
+	contentTypeText = `text/plain; charset="UTF-8"`
+	contentTypeHTML = `text/html; charset="UTF-8"`
+)
+
+// emailBody holds the rendered parts of a notification email
+type emailBody struct {
+	html []byte
+	// text is the plain-text alternative; nil sends the HTML part on its own
+	text []byte
+}
+
+// buildMessage assembles a complete RFC 5322 message: the validated address and subject headers,
+// Date, Message-ID, List-Unsubscribe when configured, and the body as multipart/alternative with
+// the plain-text part first so that clients preferring HTML pick the last one. It returns the
+// message and its Message-ID.
+func (s *SMTPSender) buildMessage(fromName, fromEmail, to, subject string, body emailBody) ([]byte, string, error) {
+	addressHeaders, err := s.buildSafeEmailHeaders(fromName, fromEmail, to, subject)
+	if err != nil {
+		return nil, "", err
+	}
+
+	messageID, err := newMessageID(fromEmail)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var msg bytes.Buffer
+	msg.WriteString(addressHeaders)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: %s\r\n", messageID)
+	if unsubscribe := s.config.GetListUnsubscribe(); unsubscribe != "" {
+		if err := validation.ValidateEmailHeaderValue(unsubscribe); err != nil {
+			return nil, "", fmt.Errorf("invalid list unsubscribe: %w", err)
+		}
+		fmt.Fprintf(&msg, "List-Unsubscribe: <%s>\r\n", validation.SanitizeEmailHeaderValue(unsubscribe))
+	}
+	msg.WriteString("MIME-Version: 1.0\r\n")
+
+	if body.text == nil {
+		fmt.Fprintf(&msg, "Content-Type: %s\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n", contentTypeHTML)
+		if err := writeQuotedPrintable(&msg, body.html); err != nil {
+			return nil, "", err
+		}
+		return msg.Bytes(), messageID, nil
+	}
+
+	var parts bytes.Buffer
+	mw := multipart.NewWriter(&parts)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{contentTypeText, body.text},
+		{contentTypeHTML, body.html},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create message part: %w", err)
+		}
+		if err := writeQuotedPrintable(w, part.content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", mw.Boundary())
+	msg.Write(parts.Bytes())
+	return msg.Bytes(), messageID, nil
+}
+
+// writeQuotedPrintable writes content to w in quoted-printable encoding
+func writeQuotedPrintable(w io.Writer, content []byte) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write(content); err != nil {
+		return fmt.Errorf("failed to encode message part: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("failed to encode message part: %w", err)
+	}
+	return nil
+}
+
+// newMessageID returns a random Message-ID in the domain of the sender address
+func newMessageID(fromEmail string) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+	domain := "localhost"
+	if at := strings.LastIndex(fromEmail, "@"); at >= 0 && at < len(fromEmail)-1 {
+		domain = validation.SanitizeEmailHeaderValue(fromEmail[at+1:])
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domain), nil
+}
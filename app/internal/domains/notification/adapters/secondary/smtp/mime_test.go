@@ -0,0 +1,96 @@
+package smtp
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSMTPSender_buildMessage_MultipartAlternative(t *testing.T) {
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+
+	html := "<p>" + strings.Repeat("long line ", 200) + "</p>"
+	raw, messageID, err := sender.buildMessage("Password Exchange", "server@password.exchange", "recipient@example.com",
+		"Encrypted Message from Password Exchange from Zoë", emailBody{html: []byte(html), text: []byte("Hi Zoë")})
+	require.NoError(t, err)
+
+	for _, line := range strings.Split(string(raw), "\r\n") {
+		assert.LessOrEqual(t, len(line), 998, "SMTP limits lines to 998 characters")
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+
+	assert.Equal(t, messageID, msg.Header.Get("Message-ID"))
+	assert.Regexp(t, `^<[0-9a-f]{32}@password\.exchange>$`, messageID)
+	date, err := msg.Header.Date()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), date, time.Minute)
+	assert.Equal(t, "<mailto:test@example.com?subject=unsubscribe>", msg.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Encrypted Message from Password Exchange from Zoë", subject)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	// The reader decodes quoted-printable parts
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var contentTypes, contents []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		contents = append(contents, string(content))
+	}
+	assert.Equal(t, []string{contentTypeText, contentTypeHTML}, contentTypes, "the preferred HTML part comes last")
+	assert.Equal(t, []string{"Hi Zoë", html}, contents)
+}
+
+func TestSMTPSender_buildMessage_HTMLOnly(t *testing.T) {
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+
+	raw, _, err := sender.buildMessage("Password Exchange", "server@password.exchange", "recipient@example.com",
+		"Your encrypted message was viewed", emailBody{html: []byte("<p>Viewed</p>")})
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	assert.Equal(t, contentTypeHTML, msg.Header.Get("Content-Type"))
+	assert.Equal(t, "quoted-printable", msg.Header.Get("Content-Transfer-Encoding"))
+}
+
+func TestSMTPSender_buildMessage_RejectsHeaderInjection(t *testing.T) {
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+
+	_, _, err := sender.buildMessage("Password Exchange", "server@password.exchange",
+		"recipient@example.com\r\nBcc: attacker@evil.com", "Subject", emailBody{html: []byte("<p>hi</p>")})
+	assert.ErrorContains(t, err, "invalid to email")
+}
+
+func TestNewMessageID(t *testing.T) {
+	first, err := newMessageID("server@password.exchange")
+	require.NoError(t, err)
+	second, err := newMessageID("server@password.exchange")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, second)
+
+	noDomain, err := newMessageID("server")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(noDomain, "@localhost>"))
+}
@@ -6,10 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"html/template"
-	"net/smtp"
+	"mime"
 	"os"
 	"regexp"
 	"strings"
+	texttemplate "text/template"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
@@ -124,21 +125,40 @@ type SMTPSender struct {
 	config     secondary.ConfigPort
 	logger     secondary.LoggerPort
 	validation secondary.ValidationPort
+	transport  *transport
+	dkim       *dkimSigner
 }
 
-// NewSMTPSender creates a new SMTP email sender
+// NewSMTPSender creates a new SMTP email sender. It fails with ErrInvalidSMTPConfig when the
+// TLS mode is unknown or the CA bundle or DKIM key cannot be loaded.
 func NewSMTPSender(
 	emailConn contracts.EmailConnection,
 	config secondary.ConfigPort,
 	logger secondary.LoggerPort,
 	validation secondary.ValidationPort,
-) *SMTPSender {
+) (*SMTPSender, error) {
+	smtpTransport, err := newTransport(emailConn)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := newDKIMSigner(emailConn)
+	if err != nil {
+		return nil, err
+	}
 	return &SMTPSender{
 		emailConn:  emailConn,
 		config:     config,
 		logger:     logger,
 		validation: validation,
-	}
+		transport:  smtpTransport,
+		dkim:       signer,
+	}, nil
+}
+
+// Close ends the SMTP sessions kept open between sends
+func (s *SMTPSender) Close() error {
+	s.transport.close()
+	return nil
 }
 
 // getSafeTemplateFunctions returns the package-level safe template FuncMap.
@@ -246,9 +266,29 @@ func (s *SMTPSender) validateTemplateContent(templateContent string) error {
 // parseTemplate parses a template that can be either a file path or inline template content
 // Uses restricted set of safe template functions to prevent injection attacks
 func (s *SMTPSender) parseTemplate(templateConfig string) (*template.Template, error) {
-	// Define safe template functions only
-	safeFuncs := s.getSafeTemplateFunctions()
+	templateContent, err := s.loadTemplate(templateConfig)
+	if err != nil {
+		return nil, err
+	}
 
+	// Parse template with safe functions
+	return template.New("email").Funcs(s.getSafeTemplateFunctions()).Parse(templateContent)
+}
+
+// parseTextTemplate parses a plain-text template the same way parseTemplate parses HTML ones.
+// text/template does no escaping, which is what a text/plain part needs.
+func (s *SMTPSender) parseTextTemplate(templateConfig string) (*texttemplate.Template, error) {
+	templateContent, err := s.loadTemplate(templateConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return texttemplate.New("email").Funcs(texttemplate.FuncMap(s.getSafeTemplateFunctions())).Parse(templateContent)
+}
+
+// loadTemplate returns the content of a template file, or templateConfig itself when it is
+// inline content, after validating it
+func (s *SMTPSender) loadTemplate(templateConfig string) (string, error) {
 	var templateContent string
 
 	// Check if templateConfig looks like a file path - attempt to read directly.
@@ -261,7 +301,7 @@ func (s *SMTPSender) parseTemplate(templateConfig string) (*template.Template, e
 			// File doesn't exist, treat as inline template
 			templateContent = templateConfig
 		} else {
-			return nil, fmt.Errorf("failed to read template file: %w", err)
+			return "", fmt.Errorf("failed to read template file: %w", err)
 		}
 	} else {
 		templateContent = templateConfig
@@ -269,14 +309,44 @@ func (s *SMTPSender) parseTemplate(templateConfig string) (*template.Template, e
 
 	// Validate template content before parsing
 	if err := s.validateTemplateContent(templateContent); err != nil {
-		return nil, fmt.Errorf("template validation failed: %w", err)
+		return "", fmt.Errorf("template validation failed: %w", err)
 	}
 
-	// Parse template with safe functions
-	return template.New("email").Funcs(safeFuncs).Parse(templateContent)
+	return templateContent, nil
+}
+
+// templateForType returns the configured template for a notification type,
+// falling back to the initial notification template for unknown or empty types.
+func (s *SMTPSender) templateForType(notificationType string) string {
+	switch notificationType {
+	case contracts.NotificationTypeReadReceipt:
+		return s.config.GetReadReceiptEmailTemplate()
+	case contracts.NotificationTypeRequestFulfilled:
+		return s.config.GetRequestFulfilledEmailTemplate()
+	case contracts.NotificationTypePassphraseAlert:
+		return s.config.GetPassphraseAlertEmailTemplate()
+	case contracts.NotificationTypeReminder:
+		return s.config.GetReminderEmailTemplate()
+	default:
+		return s.config.GetEmailTemplate()
+	}
+}
+
+// textTemplateForType returns the configured plain-text template for a notification type, or
+// an empty string for types that are sent as HTML only.
+func (s *SMTPSender) textTemplateForType(notificationType string) string {
+	switch notificationType {
+	case contracts.NotificationTypeInitial, "":
+		return s.config.GetEmailTextTemplate()
+	case contracts.NotificationTypeReminder:
+		return s.config.GetReminderEmailTextTemplate()
+	default:
+		return ""
+	}
 }
 
-// buildSafeEmailHeaders constructs email headers with CRLF injection protection
+// buildSafeEmailHeaders constructs the From, To and Subject headers with CRLF injection
+// protection. Non-ASCII names and subjects are encoded as RFC 2047 encoded-words.
 func (s *SMTPSender) buildSafeEmailHeaders(fromName, fromEmail, to, subject string) (string, error) {
 	// Validate and sanitize all header values to prevent CRLF injection
 	if err := validation.ValidateEmailHeaderValue(fromName); err != nil {
@@ -299,9 +369,9 @@ func (s *SMTPSender) buildSafeEmailHeaders(fromName, fromEmail, to, subject stri
 	safeSubject := validation.SanitizeEmailHeaderValue(subject)
 
 	// Construct email headers with proper CRLF line endings
-	mimeHeaders := "MIME-version: 1.0;\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n\r\n"
-	emailHeaders := fmt.Sprintf("From: %s <%s>\r\nTo: %s\r\nSubject: %s\r\n%s",
-		safeFromName, safeFromEmail, safeTo, safeSubject, mimeHeaders)
+	emailHeaders := fmt.Sprintf("From: %s <%s>\r\nTo: %s\r\nSubject: %s\r\n",
+		mime.QEncoding.Encode("UTF-8", safeFromName), safeFromEmail, safeTo,
+		mime.QEncoding.Encode("UTF-8", safeSubject))
 
 	return emailHeaders, nil
 }
@@ -316,11 +386,8 @@ func (s *SMTPSender) SendNotification(
 		Str("subject", req.Subject).
 		Msg("Sending email via SMTP")
 
-	// Create SMTP authentication
-	auth := smtp.PlainAuth("", s.emailConn.User, s.emailConn.Password, s.emailConn.Host)
-
 	// Parse email template using injected config (supports both file paths and inline templates)
-	templateConfig := s.config.GetEmailTemplate()
+	templateConfig := s.templateForType(req.Type)
 	tmpl, err := s.parseTemplate(templateConfig)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to parse email template")
@@ -329,32 +396,59 @@ func (s *SMTPSender) SendNotification(
 
 	// Prepare template data
 	templateData := contracts.NotificationTemplateData{
-		Message:       template.HTML(req.MessageContent),
-		SenderName:    req.SenderName,
-		RecipientName: req.RecipientName,
-		MessageURL:    req.MessageURL,
-	}
-
-	// Build email headers with CRLF injection protection
-	emailHeaders, err := s.buildSafeEmailHeaders(req.FromName, req.From, req.To, req.Subject)
-	if err != nil {
-		s.logger.Error().Err(err).Msg("Failed to build safe email headers")
-		return nil, fmt.Errorf("%w: %v", domain.ErrEmailSendFailed, err)
+		Message:        template.HTML(req.MessageContent),
+		SenderName:     req.SenderName,
+		RecipientName:  req.RecipientName,
+		MessageURL:     req.MessageURL,
+		ViewCount:      req.ViewCount,
+		MaxViewCount:   req.MaxViewCount,
+		Description:    req.Description,
+		ReminderNumber: req.ReminderNumber,
+		Event:          req.Event,
 	}
 
 	// Render template
-	body := []byte(emailHeaders)
-	buf := bytes.NewBuffer(body)
-
-	err = tmpl.Execute(buf, templateData)
+	var body emailBody
+	var htmlBuf bytes.Buffer
+	err = tmpl.Execute(&htmlBuf, templateData)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to execute email template")
 		return nil, fmt.Errorf("%w: %v", domain.ErrTemplateRenderFailed, err)
 	}
+	body.html = htmlBuf.Bytes()
+
+	// Render the plain-text alternative when the type has one
+	if textConfig := s.textTemplateForType(req.Type); textConfig != "" {
+		textTmpl, err := s.parseTextTemplate(textConfig)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to parse text email template")
+			return nil, fmt.Errorf("%w: %v", domain.ErrTemplateNotFound, err)
+		}
+		var textBuf bytes.Buffer
+		if err := textTmpl.Execute(&textBuf, templateData); err != nil {
+			s.logger.Error().Err(err).Msg("Failed to execute text email template")
+			return nil, fmt.Errorf("%w: %v", domain.ErrTemplateRenderFailed, err)
+		}
+		body.text = textBuf.Bytes()
+	}
+
+	// Build the message with CRLF injection protected headers
+	msg, messageID, err := s.buildMessage(req.FromName, req.From, req.To, req.Subject, body)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to build email message")
+		return nil, fmt.Errorf("%w: %v", domain.ErrEmailSendFailed, err)
+	}
 
-	// Send email
-	smtpAddr := fmt.Sprintf("%s:%d", s.emailConn.Host, s.emailConn.Port)
-	err = smtp.SendMail(smtpAddr, auth, s.emailConn.From, []string{req.To}, buf.Bytes())
+	if s.dkim != nil {
+		msg, err = s.dkim.sign(msg)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("Failed to sign email")
+			return nil, fmt.Errorf("%w: %v", domain.ErrEmailSendFailed, err)
+		}
+	}
+
+	// Send email, over a connection left open by an earlier send when there is one
+	err = s.transport.send(ctx, s.emailConn.From, []string{req.To}, msg)
 	if err != nil {
 		s.logger.Error().Err(err).
 			Str("smtpHost", s.emailConn.Host).
@@ -364,9 +458,6 @@ func (s *SMTPSender) SendNotification(
 		return nil, fmt.Errorf("%w: %v", domain.ErrEmailSendFailed, err)
 	}
 
-	// Generate a simple message ID
-	messageID := fmt.Sprintf("smtp-%d-%s", len(req.To)+len(req.Subject), req.To[:min(5, len(req.To))])
-
 	response := &contracts.NotificationResponse{
 		Success:   true,
 		MessageID: messageID,
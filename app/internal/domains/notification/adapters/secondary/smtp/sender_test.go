@@ -604,8 +604,29 @@ func (m *mockConfigPortSecure) GetEmailTemplate() string {
 func (m *mockConfigPortSecure) GetReminderNotificationBodyTemplate() string {
 	return "Reminder body template"
 }
-func (m *mockConfigPortSecure) GetReminderEmailTemplate() string   { return "Reminder email template" }
-func (m *mockConfigPortSecure) GetReminderMessageContent() string  { return "Reminder message content" }
+func (m *mockConfigPortSecure) GetReminderEmailTemplate() string  { return "Reminder email template" }
+func (m *mockConfigPortSecure) GetReminderMessageContent() string { return "Reminder message content" }
+func (m *mockConfigPortSecure) GetReadReceiptSubject() string     { return "Viewed %d of %d" }
+func (m *mockConfigPortSecure) GetReadReceiptEmailTemplate() string {
+	return "Viewed {{.ViewCount}} of {{.MaxViewCount}}"
+}
+func (m *mockConfigPortSecure) GetRequestFulfilledSubject() string { return "Request answered" }
+func (m *mockConfigPortSecure) GetRequestFulfilledEmailTemplate() string {
+	return "Answered by {{.SenderName}}: {{.MessageURL}}"
+}
+func (m *mockConfigPortSecure) GetPassphraseAlertSubject() string { return "Wrong passphrase" }
+func (m *mockConfigPortSecure) GetPassphraseAlertEmailTemplate() string {
+	return "Passphrase alert: {{.Event}}"
+}
+func (m *mockConfigPortSecure) GetEmailTextTemplate() string {
+	return "Hi {{.RecipientName}}, open {{.MessageURL}}"
+}
+func (m *mockConfigPortSecure) GetReminderEmailTextTemplate() string {
+	return "Reminder #{{.ReminderNumber}}"
+}
+func (m *mockConfigPortSecure) GetListUnsubscribe() string {
+	return "mailto:test@example.com?subject=unsubscribe"
+}
 func (m *mockConfigPortSecure) ValidatePasswordExchangeURL() error { return nil }
 func (m *mockConfigPortSecure) ValidateServerEmail() error         { return nil }
 func (m *mockConfigPortSecure) ValidateTemplateFormats() error     { return nil }
@@ -753,8 +774,6 @@ func TestSMTPSender_buildSafeEmailHeaders(t *testing.T) {
 					"From:",
 					"To:",
 					"Subject:",
-					"MIME-version: 1.0",
-					"Content-Type: text/html",
 				}
 
 				for _, pattern := range expectedPatterns {
@@ -838,3 +857,165 @@ func TestSMTPSender_RenderEmailTemplate(t *testing.T) {
 		assert.Contains(t, output, "#ZgotmplZ", "html/template must replace dangerous URI with #ZgotmplZ")
 	})
 }
+
+func TestSMTPSender_templateForType(t *testing.T) {
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+
+	assert.Equal(t, "Viewed {{.ViewCount}} of {{.MaxViewCount}}",
+		sender.templateForType(contracts.NotificationTypeReadReceipt))
+	assert.Equal(t, "Answered by {{.SenderName}}: {{.MessageURL}}",
+		sender.templateForType(contracts.NotificationTypeRequestFulfilled))
+	assert.Equal(t, (&mockConfigPortSecure{}).GetEmailTemplate(),
+		sender.templateForType(contracts.NotificationTypeInitial))
+	assert.Equal(t, (&mockConfigPortSecure{}).GetEmailTemplate(), sender.templateForType(""),
+		"messages queued before notification types existed must keep the initial template")
+}
+
+func TestSMTPSender_textTemplateForType(t *testing.T) {
+	sender := &SMTPSender{config: &mockConfigPortSecure{}}
+
+	assert.Equal(t, "Reminder email template", sender.templateForType(contracts.NotificationTypeReminder))
+	assert.Equal(t, "Hi {{.RecipientName}}, open {{.MessageURL}}", sender.textTemplateForType(""))
+	assert.Equal(t, "Hi {{.RecipientName}}, open {{.MessageURL}}",
+		sender.textTemplateForType(contracts.NotificationTypeInitial))
+	assert.Equal(t, "Reminder #{{.ReminderNumber}}", sender.textTemplateForType(contracts.NotificationTypeReminder))
+	assert.Empty(t, sender.textTemplateForType(contracts.NotificationTypeReadReceipt),
+		"types without a text template are sent as HTML only")
+}
+
+func TestSMTPSender_RenderReminderTemplates(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "runtime.Caller must succeed")
+	templatesDir := filepath.Join(filepath.Dir(thisFile), "../../../../../../templates")
+
+	sender := &SMTPSender{}
+	data := contracts.NotificationTemplateData{
+		Message:        template.HTML("Please check your original email for the secure decrypt link."),
+		ReminderNumber: 2,
+	}
+
+	t.Run("html", func(t *testing.T) {
+		tmpl, err := sender.parseTemplate(filepath.Join(templatesDir, "reminder_email_template.html"))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, data))
+		assert.Contains(t, buf.String(), "Reminder #2")
+		assert.Contains(t, buf.String(), "Please check your original email")
+		assert.NotContains(t, buf.String(), "View Your Secure Message", "reminders carry no decrypt link")
+	})
+
+	t.Run("text", func(t *testing.T) {
+		tmpl, err := sender.parseTextTemplate(filepath.Join(templatesDir, "reminder_email_template.txt"))
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, data))
+		assert.Contains(t, buf.String(), "Reminder #2")
+		assert.Contains(t, buf.String(), "Please check your original email")
+	})
+}
+
+func TestSMTPSender_RenderEmailTextTemplate(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "runtime.Caller must succeed")
+	templatePath := filepath.Join(filepath.Dir(thisFile), "../../../../../../templates/email_template.txt")
+
+	sender := &SMTPSender{}
+	tmpl, err := sender.parseTextTemplate(templatePath)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, contracts.NotificationTemplateData{
+		RecipientName: "Jane & Co",
+		SenderName:    "John",
+		MessageURL:    "https://password.exchange/decrypt/abc/key",
+		Message:       template.HTML(`Please click this link <a href="https://password.exchange/decrypt/abc/key">here</a>`),
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Hi Jane & Co,", "plain text must not be HTML-escaped")
+	assert.Contains(t, output, "John has shared an encrypted message")
+	assert.Contains(t, output, "https://password.exchange/decrypt/abc/key")
+	assert.NotContains(t, output, "<a href", "the HTML message snippet belongs to the HTML part only")
+}
+
+func TestSMTPSender_RenderReadReceiptTemplate(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "runtime.Caller must succeed")
+	templatePath := filepath.Join(filepath.Dir(thisFile), "../../../../../../templates/read_receipt_email_template.html")
+
+	sender := &SMTPSender{}
+	tmpl, err := sender.parseTemplate(templatePath)
+	require.NoError(t, err)
+
+	t.Run("shows view count", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := tmpl.Execute(&buf, contracts.NotificationTemplateData{ViewCount: 1, MaxViewCount: 3})
+		require.NoError(t, err)
+
+		output := buf.String()
+		assert.Contains(t, output, "Viewed 1 of 3")
+		assert.NotContains(t, output, "permanently deleted")
+	})
+
+	t.Run("notes deletion at the view limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := tmpl.Execute(&buf, contracts.NotificationTemplateData{ViewCount: 3, MaxViewCount: 3})
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), "permanently deleted")
+	})
+}
+
+func TestSMTPSender_RenderRequestFulfilledTemplate(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "runtime.Caller must succeed")
+	templatePath := filepath.Join(filepath.Dir(thisFile), "../../../../../../templates/request_fulfilled_email_template.html")
+
+	sender := &SMTPSender{}
+	tmpl, err := sender.parseTemplate(templatePath)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, contracts.NotificationTemplateData{
+		SenderName:    "Bob",
+		RecipientName: "Alice",
+		MessageURL:    "https://password.exchange/decrypt/abc/key",
+		Description:   "<b>VPN</b> credentials",
+	})
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "Hi Alice")
+	assert.Contains(t, output, "Bob")
+	assert.Contains(t, output, "https://password.exchange/decrypt/abc/key")
+	assert.Contains(t, output, "&lt;b&gt;VPN&lt;/b&gt; credentials", "the requester's description must be escaped")
+}
+
+func TestSMTPSender_RenderPassphraseAlertTemplate(t *testing.T) {
+	_, thisFile, _, ok := runtime.Caller(0)
+	require.True(t, ok, "runtime.Caller must succeed")
+	templatePath := filepath.Join(filepath.Dir(thisFile), "../../../../../../templates/passphrase_alert_email_template.html")
+
+	sender := &SMTPSender{}
+	tmpl, err := sender.parseTemplate(templatePath)
+	require.NoError(t, err)
+
+	tests := []struct {
+		event string
+		want  string
+	}{
+		{event: "message.passphrase_failed", want: "A wrong passphrase was entered for your secure message"},
+		{event: "message.locked", want: "Your secure message was locked"},
+		{event: "message.destroyed", want: "Your secure message was destroyed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tmpl.Execute(&buf, contracts.NotificationTemplateData{Event: tt.event}))
+			assert.Contains(t, buf.String(), tt.want)
+		})
+	}
+}
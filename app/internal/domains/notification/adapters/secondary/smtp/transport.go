@@ -0,0 +1,259 @@
+package smtp
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+)
+
+const (
+	// dialTimeout bounds connecting to the server, including the TLS handshake
+	dialTimeout = 30 * time.Second
+	// sendTimeout bounds the SMTP conversation for one message
+	sendTimeout = 2 * time.Minute
+	// maxIdleConnections caps the connections kept open between sends
+	maxIdleConnections = 4
+	// idleTimeout is how long an unused connection is kept; servers commonly drop idle
+	// clients after a few minutes
+	idleTimeout = 30 * time.Second
+)
+
+// errSTARTTLSUnavailable is returned in EmailTLSStartTLS mode when the server does not offer STARTTLS
+var errSTARTTLSUnavailable = errors.New("server does not offer STARTTLS")
+
+// errAUTHUnavailable is returned when credentials are configured but the server does not offer AUTH
+var errAUTHUnavailable = fmt.Errorf("%w: server does not offer AUTH", domain.ErrSMTPAuthFailed)
+
+// transport sends messages over SMTP connections secured according to the configured TLS mode.
+// Connections are kept open after a successful send so that a batch of notifications, such as a
+// reminder run, shares them instead of dialing once per message.
+type transport struct {
+	conn      contracts.EmailConnection
+	addr      string
+	mode      string
+	tlsConfig *tls.Config
+
+	mu     sync.Mutex
+	idle   []*smtpConn
+	closed bool
+}
+
+// smtpConn is an open SMTP session and the connection under it
+type smtpConn struct {
+	client   *smtp.Client
+	netConn  net.Conn
+	lastUsed time.Time
+}
+
+// newTransport validates the TLS settings of emailConn and loads its CA bundle
+func newTransport(emailConn contracts.EmailConnection) (*transport, error) {
+	mode := emailConn.TLSMode
+	if mode == "" {
+		mode = contracts.EmailTLSOpportunistic
+	}
+	switch mode {
+	case contracts.EmailTLSOpportunistic, contracts.EmailTLSStartTLS, contracts.EmailTLSImplicit, contracts.EmailTLSNone:
+	default:
+		return nil, fmt.Errorf("%w: unknown TLS mode %q", domain.ErrInvalidSMTPConfig, mode)
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         emailConn.Host,
+		InsecureSkipVerify: emailConn.TLSSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+	if emailConn.TLSCAFile != "" {
+		pem, err := os.ReadFile(emailConn.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to read CA file: %v", domain.ErrInvalidSMTPConfig, err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates found in CA file %s", domain.ErrInvalidSMTPConfig, emailConn.TLSCAFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+
+	return &transport{
+		conn:      emailConn,
+		addr:      net.JoinHostPort(emailConn.Host, strconv.Itoa(emailConn.Port)),
+		mode:      mode,
+		tlsConfig: tlsConfig,
+	}, nil
+}
+
+// send delivers msg from the envelope sender to the recipients, reusing an idle connection when
+// one is still alive
+func (t *transport) send(ctx context.Context, from string, to []string, msg []byte) error {
+	c, err := t.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.netConn.SetDeadline(time.Now().Add(sendTimeout)); err != nil {
+		c.close()
+		return err
+	}
+	if err := deliver(c.client, from, to, msg); err != nil {
+		// The session state is unknown after a failure, so the connection is not reused
+		c.close()
+		return err
+	}
+
+	t.put(c)
+	return nil
+}
+
+// deliver runs one mail transaction on an open session
+func deliver(client *smtp.Client, from string, to []string, msg []byte) error {
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// get returns an idle connection that still answers RSET, or dials a new one
+func (t *transport) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		t.mu.Lock()
+		if len(t.idle) == 0 {
+			t.mu.Unlock()
+			return t.dial(ctx)
+		}
+		c := t.idle[len(t.idle)-1]
+		t.idle = t.idle[:len(t.idle)-1]
+		t.mu.Unlock()
+
+		if time.Since(c.lastUsed) > idleTimeout {
+			c.quit()
+			continue
+		}
+		if err := c.netConn.SetDeadline(time.Now().Add(dialTimeout)); err == nil {
+			if err := c.client.Reset(); err == nil {
+				return c, nil
+			}
+		}
+		// The server closed the connection while it was idle
+		c.close()
+	}
+}
+
+// put keeps a connection for the next send, or closes it when enough are kept already
+func (t *transport) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+	t.mu.Lock()
+	if t.closed || len(t.idle) >= maxIdleConnections {
+		t.mu.Unlock()
+		c.quit()
+		return
+	}
+	t.idle = append(t.idle, c)
+	t.mu.Unlock()
+}
+
+// dial opens a session, secures it according to the TLS mode and authenticates when credentials
+// are configured
+func (t *transport) dial(ctx context.Context) (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var netConn net.Conn
+	var err error
+	if t.mode == contracts.EmailTLSImplicit {
+		netConn, err = (&tls.Dialer{NetDialer: dialer, Config: t.tlsConfig}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		netConn, err = dialer.DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := netConn.SetDeadline(time.Now().Add(dialTimeout)); err != nil {
+		netConn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(netConn, t.conn.Host)
+	if err != nil {
+		netConn.Close()
+		return nil, err
+	}
+	c := &smtpConn{client: client, netConn: netConn}
+
+	if t.mode == contracts.EmailTLSOpportunistic || t.mode == contracts.EmailTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(t.tlsConfig); err != nil {
+				c.close()
+				return nil, err
+			}
+		} else if t.mode == contracts.EmailTLSStartTLS {
+			c.close()
+			return nil, errSTARTTLSUnavailable
+		}
+	}
+
+	if t.conn.User != "" {
+		// Configured credentials are never skipped: sending unauthenticated would bypass the
+		// relay policy the operator asked for
+		if ok, _ := client.Extension("AUTH"); !ok {
+			c.close()
+			return nil, errAUTHUnavailable
+		}
+		// PlainAuth refuses to send credentials over an unencrypted connection to another host
+		if err := client.Auth(smtp.PlainAuth("", t.conn.User, t.conn.Password, t.conn.Host)); err != nil {
+			c.close()
+			return nil, fmt.Errorf("%w: %v", domain.ErrSMTPAuthFailed, err)
+		}
+	}
+	return c, nil
+}
+
+// close closes the idle connections; connections in use are closed when their send finishes
+func (t *transport) close() {
+	t.mu.Lock()
+	idle := t.idle
+	t.idle = nil
+	t.closed = true
+	t.mu.Unlock()
+
+	for _, c := range idle {
+		c.quit()
+	}
+}
+
+// quit ends the session politely
+func (c *smtpConn) quit() {
+	c.netConn.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := c.client.Quit(); err != nil {
+		c.client.Close()
+	}
+}
+
+// close drops the connection without QUIT
+func (c *smtpConn) close() {
+	c.client.Close()
+}
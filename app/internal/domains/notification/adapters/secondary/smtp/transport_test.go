@@ -0,0 +1,285 @@
+package smtp
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts SMTP sessions on a local port and records what it receives. It speaks
+// just enough of the protocol for net/smtp.
+type fakeSMTPServer struct {
+	listener net.Listener
+	// tlsConfig enables STARTTLS, or implicit TLS when implicit is set
+	tlsConfig *tls.Config
+	implicit  bool
+
+	mu          sync.Mutex
+	connections int
+	commands    []string
+	messages    []string
+}
+
+// startFakeSMTPServer listens on 127.0.0.1 until the test ends
+func startFakeSMTPServer(t *testing.T, tlsConfig *tls.Config, implicit bool) *fakeSMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	if implicit {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	s := &fakeSMTPServer{listener: listener, tlsConfig: tlsConfig, implicit: implicit}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+// emailConnection returns a connection to the server with the given TLS mode
+func (s *fakeSMTPServer) emailConnection(tlsMode string) contracts.EmailConnection {
+	host, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	portNumber, _ := strconv.Atoi(port)
+	return contracts.EmailConnection{
+		Host:    host,
+		Port:    portNumber,
+		From:    "server@password.exchange",
+		TLSMode: tlsMode,
+	}
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.Fields(strings.TrimSpace(line) + " x")[0])
+		s.mu.Lock()
+		s.commands = append(s.commands, command)
+		s.mu.Unlock()
+
+		switch command {
+		case "EHLO":
+			reply("250-localhost")
+			if s.tlsConfig != nil && !s.implicit {
+				if _, secure := conn.(*tls.Conn); !secure {
+					reply("250-STARTTLS")
+				}
+			}
+			reply("250 8BITMIME")
+		case "STARTTLS":
+			reply("220 Ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, data.String())
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// snapshot returns the connections accepted, commands received and messages delivered so far
+func (s *fakeSMTPServer) snapshot() (int, []string, []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections, append([]string(nil), s.commands...), append([]string(nil), s.messages...)
+}
+
+// selfSignedTLS returns a server TLS config for 127.0.0.1 and the PEM of its certificate
+func selfSignedTLS(t *testing.T) (*tls.Config, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// writeCAFile writes a PEM bundle to a temporary file and returns its path
+func writeCAFile(t *testing.T, certPEM []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, certPEM, 0o600))
+	return path
+}
+
+func TestNewTransport_RejectsUnknownTLSMode(t *testing.T) {
+	_, err := newTransport(contracts.EmailConnection{Host: "mail.example.com", Port: 465, TLSMode: "ssl"})
+	assert.ErrorIs(t, err, domain.ErrInvalidSMTPConfig)
+
+	_, err = newTransport(contracts.EmailConnection{Host: "mail.example.com", TLSCAFile: "/nonexistent/ca.pem"})
+	assert.ErrorIs(t, err, domain.ErrInvalidSMTPConfig)
+}
+
+func TestTransport_ReusesConnectionAcrossSends(t *testing.T) {
+	server := startFakeSMTPServer(t, nil, false)
+	smtpTransport, err := newTransport(server.emailConnection(contracts.EmailTLSNone))
+	require.NoError(t, err)
+
+	for _, to := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+		require.NoError(t, smtpTransport.send(context.Background(), "server@password.exchange", []string{to}, []byte("Subject: hi\r\n\r\nhello\r\n")))
+	}
+	smtpTransport.close()
+
+	require.Eventually(t, func() bool {
+		_, commands, _ := server.snapshot()
+		return len(commands) > 0 && commands[len(commands)-1] == "QUIT"
+	}, 5*time.Second, 10*time.Millisecond, "closing the transport ends the session")
+
+	connections, commands, messages := server.snapshot()
+	assert.Equal(t, 1, connections, "the batch shares one connection")
+	assert.Len(t, messages, 3)
+	assert.Equal(t, 2, strings.Count(strings.Join(commands, " "), "RSET"), "each reuse resets the session first")
+}
+
+func TestTransport_RedialsWhenIdleConnectionWasDropped(t *testing.T) {
+	server := startFakeSMTPServer(t, nil, false)
+	smtpTransport, err := newTransport(server.emailConnection(contracts.EmailTLSNone))
+	require.NoError(t, err)
+	defer smtpTransport.close()
+
+	require.NoError(t, smtpTransport.send(context.Background(), "server@password.exchange", []string{"first@example.com"}, []byte("\r\nhello\r\n")))
+
+	// The server hangs up on the idle connection
+	smtpTransport.mu.Lock()
+	smtpTransport.idle[0].netConn.Close()
+	smtpTransport.mu.Unlock()
+
+	require.NoError(t, smtpTransport.send(context.Background(), "server@password.exchange", []string{"second@example.com"}, []byte("\r\nhello\r\n")))
+	connections, _, messages := server.snapshot()
+	assert.Equal(t, 2, connections)
+	assert.Len(t, messages, 2)
+}
+
+func TestTransport_TLSModes(t *testing.T) {
+	serverTLS, certPEM := selfSignedTLS(t)
+	caFile := writeCAFile(t, certPEM)
+	msg := []byte("Subject: hi\r\n\r\nhello\r\n")
+
+	send := func(t *testing.T, emailConn contracts.EmailConnection) error {
+		smtpTransport, err := newTransport(emailConn)
+		require.NoError(t, err)
+		defer smtpTransport.close()
+		return smtpTransport.send(context.Background(), emailConn.From, []string{"recipient@example.com"}, msg)
+	}
+
+	t.Run("implicit TLS verifies the certificate", func(t *testing.T) {
+		server := startFakeSMTPServer(t, serverTLS, true)
+		emailConn := server.emailConnection(contracts.EmailTLSImplicit)
+		assert.Error(t, send(t, emailConn), "a self-signed certificate is not trusted by default")
+
+		emailConn.TLSCAFile = caFile
+		require.NoError(t, send(t, emailConn))
+		_, _, messages := server.snapshot()
+		assert.Len(t, messages, 1)
+	})
+
+	t.Run("implicit TLS can skip verification", func(t *testing.T) {
+		server := startFakeSMTPServer(t, serverTLS, true)
+		emailConn := server.emailConnection(contracts.EmailTLSImplicit)
+		emailConn.TLSSkipVerify = true
+		require.NoError(t, send(t, emailConn))
+	})
+
+	t.Run("starttls upgrades the connection", func(t *testing.T) {
+		server := startFakeSMTPServer(t, serverTLS, false)
+		emailConn := server.emailConnection(contracts.EmailTLSStartTLS)
+		emailConn.TLSCAFile = caFile
+		require.NoError(t, send(t, emailConn))
+		_, commands, _ := server.snapshot()
+		assert.Contains(t, commands, "STARTTLS")
+	})
+
+	t.Run("starttls is required when configured", func(t *testing.T) {
+		server := startFakeSMTPServer(t, nil, false)
+		assert.ErrorIs(t, send(t, server.emailConnection(contracts.EmailTLSStartTLS)), errSTARTTLSUnavailable)
+	})
+
+	t.Run("configured credentials fail without AUTH", func(t *testing.T) {
+		server := startFakeSMTPServer(t, nil, false)
+		emailConn := server.emailConnection(contracts.EmailTLSNone)
+		emailConn.User = "user"
+		emailConn.Password = "secret"
+		assert.ErrorIs(t, send(t, emailConn), domain.ErrSMTPAuthFailed)
+		_, _, messages := server.snapshot()
+		assert.Empty(t, messages, "nothing is sent unauthenticated")
+	})
+
+	t.Run("opportunistic falls back to plain text", func(t *testing.T) {
+		server := startFakeSMTPServer(t, nil, false)
+		require.NoError(t, send(t, server.emailConnection("")))
+		_, commands, _ := server.snapshot()
+		assert.NotContains(t, commands, "STARTTLS")
+	})
+}
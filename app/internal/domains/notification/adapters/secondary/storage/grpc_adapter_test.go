@@ -31,11 +31,46 @@ func (m *MockStorageService) GetMessage(ctx context.Context, uniqueID string) (*
 	return args.Get(0).(*storageDomain.Message), args.Error(1)
 }
 
-func (m *MockStorageService) CleanupExpiredMessages(ctx context.Context) error {
-	args := m.Called(ctx)
+func (m *MockStorageService) GetAttachment(ctx context.Context, uniqueID string) (*storageDomain.Attachment, error) {
+	args := m.Called(ctx, uniqueID)
+	return args.Get(0).(*storageDomain.Attachment), args.Error(1)
+}
+
+func (m *MockStorageService) DeleteMessage(ctx context.Context, uniqueID string) error {
+	args := m.Called(ctx, uniqueID)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) ReservePassphraseAttempt(ctx context.Context, uniqueID string) (int, error) {
+	args := m.Called(ctx, uniqueID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorageService) ReleasePassphraseAttempt(ctx context.Context, uniqueID string) error {
+	args := m.Called(ctx, uniqueID)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) RecordPassphraseFailure(ctx context.Context, uniqueID string) error {
+	args := m.Called(ctx, uniqueID)
 	return args.Error(0)
 }
 
+func (m *MockStorageService) UpdatePassphraseHash(ctx context.Context, uniqueID, hashedPassphrase string) error {
+	args := m.Called(ctx, uniqueID, hashedPassphrase)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) CleanupExpiredMessages(ctx context.Context, batchSize int) (storageDomain.CleanupResult, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Get(0).(storageDomain.CleanupResult), args.Error(1)
+}
+
+func (m *MockStorageService) RelayOutbox(ctx context.Context, batchSize int) (storageDomain.OutboxRelayResult, error) {
+	args := m.Called(ctx, batchSize)
+	return args.Get(0).(storageDomain.OutboxRelayResult), args.Error(1)
+}
+
 func (m *MockStorageService) HealthCheck(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -56,6 +91,21 @@ func (m *MockStorageService) GetReminderHistory(ctx context.Context, messageID i
 	return args.Get(0).([]*storageDomain.ReminderLogEntry), args.Error(1)
 }
 
+func (m *MockStorageService) StoreSecretRequest(ctx context.Context, request *storageDomain.SecretRequest) error {
+	args := m.Called(ctx, request)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) GetSecretRequest(ctx context.Context, uniqueID string) (*storageDomain.SecretRequest, error) {
+	args := m.Called(ctx, uniqueID)
+	return args.Get(0).(*storageDomain.SecretRequest), args.Error(1)
+}
+
+func (m *MockStorageService) FulfillSecretRequest(ctx context.Context, uniqueID, messageUniqueID string) error {
+	args := m.Called(ctx, uniqueID, messageUniqueID)
+	return args.Error(0)
+}
+
 func TestGetUnviewedMessagesForReminders_Success(t *testing.T) {
 	// Arrange
 	mockStorage := &MockStorageService{}
@@ -38,12 +38,20 @@ func NewViperConfigAdapter() secondary.ConfigPort {
 func getDefaultEmailConfig() config.EmailConfig {
 	return config.EmailConfig{
 		Templates: config.EmailTemplates{
-			Initial:  "/templates/email_template.html",
-			Reminder: "/templates/reminder_email_template.html",
+			Initial:          "/templates/email_template.html",
+			Reminder:         "/templates/reminder_email_template.html",
+			ReadReceipt:      "/templates/read_receipt_email_template.html",
+			RequestFulfilled: "/templates/request_fulfilled_email_template.html",
+			PassphraseAlert:  "/templates/passphrase_alert_email_template.html",
+			InitialText:      "/templates/email_template.txt",
+			ReminderText:     "/templates/reminder_email_template.txt",
 		},
 		Subjects: config.EmailSubjects{
-			Initial:  "Encrypted Message from Password Exchange from %s",
-			Reminder: "Reminder: You have an unviewed encrypted message (Reminder #%d)",
+			Initial:          "Encrypted Message from Password Exchange from %s",
+			Reminder:         "Reminder: You have an unviewed encrypted message (Reminder #%d)",
+			ReadReceipt:      "Your encrypted message was viewed (%d of %d)",
+			RequestFulfilled: "Someone answered your secret request",
+			PassphraseAlert:  "A wrong passphrase was entered for your encrypted message",
 		},
 		Body: config.EmailBody{
 			Reminder: "Please check your original email for the secure decrypt link. For security reasons, the decrypt link cannot be included in reminder emails. If you cannot find the original email, please contact the sender to resend the message.",
@@ -65,12 +73,36 @@ func setDefaults(cfg *config.EmailConfig) {
 	if cfg.Templates.Reminder == "" {
 		cfg.Templates.Reminder = defaults.Templates.Reminder
 	}
+	if cfg.Templates.ReadReceipt == "" {
+		cfg.Templates.ReadReceipt = defaults.Templates.ReadReceipt
+	}
+	if cfg.Templates.RequestFulfilled == "" {
+		cfg.Templates.RequestFulfilled = defaults.Templates.RequestFulfilled
+	}
+	if cfg.Templates.PassphraseAlert == "" {
+		cfg.Templates.PassphraseAlert = defaults.Templates.PassphraseAlert
+	}
+	if cfg.Templates.InitialText == "" {
+		cfg.Templates.InitialText = defaults.Templates.InitialText
+	}
+	if cfg.Templates.ReminderText == "" {
+		cfg.Templates.ReminderText = defaults.Templates.ReminderText
+	}
 	if cfg.Subjects.Initial == "" {
 		cfg.Subjects.Initial = defaults.Subjects.Initial
 	}
 	if cfg.Subjects.Reminder == "" {
 		cfg.Subjects.Reminder = defaults.Subjects.Reminder
 	}
+	if cfg.Subjects.ReadReceipt == "" {
+		cfg.Subjects.ReadReceipt = defaults.Subjects.ReadReceipt
+	}
+	if cfg.Subjects.RequestFulfilled == "" {
+		cfg.Subjects.RequestFulfilled = defaults.Subjects.RequestFulfilled
+	}
+	if cfg.Subjects.PassphraseAlert == "" {
+		cfg.Subjects.PassphraseAlert = defaults.Subjects.PassphraseAlert
+	}
 	if cfg.Body.Reminder == "" {
 		cfg.Body.Reminder = defaults.Body.Reminder
 	}
@@ -83,6 +115,9 @@ func setDefaults(cfg *config.EmailConfig) {
 	if cfg.URL == "" {
 		cfg.URL = defaults.URL
 	}
+	if cfg.ListUnsubscribe == "" {
+		cfg.ListUnsubscribe = "mailto:" + cfg.Sender.Email + "?subject=unsubscribe"
+	}
 }
 
 func (v *ViperConfigAdapter) GetEmailTemplate() string {
@@ -109,6 +144,18 @@ func (v *ViperConfigAdapter) GetReminderNotificationSubject() string {
 	return v.emailConfig.Subjects.Reminder
 }
 
+func (v *ViperConfigAdapter) GetReadReceiptSubject() string {
+	return v.emailConfig.Subjects.ReadReceipt
+}
+
+func (v *ViperConfigAdapter) GetRequestFulfilledSubject() string {
+	return v.emailConfig.Subjects.RequestFulfilled
+}
+
+func (v *ViperConfigAdapter) GetPassphraseAlertSubject() string {
+	return v.emailConfig.Subjects.PassphraseAlert
+}
+
 func (v *ViperConfigAdapter) GetReminderNotificationBodyTemplate() string {
 	return v.emailConfig.Templates.Reminder
 }
@@ -121,6 +168,30 @@ func (v *ViperConfigAdapter) GetReminderMessageContent() string {
 	return v.emailConfig.Body.Reminder
 }
 
+func (v *ViperConfigAdapter) GetReadReceiptEmailTemplate() string {
+	return v.emailConfig.Templates.ReadReceipt
+}
+
+func (v *ViperConfigAdapter) GetRequestFulfilledEmailTemplate() string {
+	return v.emailConfig.Templates.RequestFulfilled
+}
+
+func (v *ViperConfigAdapter) GetPassphraseAlertEmailTemplate() string {
+	return v.emailConfig.Templates.PassphraseAlert
+}
+
+func (v *ViperConfigAdapter) GetEmailTextTemplate() string {
+	return v.emailConfig.Templates.InitialText
+}
+
+func (v *ViperConfigAdapter) GetReminderEmailTextTemplate() string {
+	return v.emailConfig.Templates.ReminderText
+}
+
+func (v *ViperConfigAdapter) GetListUnsubscribe() string {
+	return v.emailConfig.ListUnsubscribe
+}
+
 // Validation methods
 
 // ValidatePasswordExchangeURL validates the password exchange URL configuration.
@@ -55,6 +55,43 @@ func TestNewViperConfigAdapter_Defaults(t *testing.T) {
 	)
 }
 
+func TestNewViperConfigAdapter_ReadReceiptDefaults(t *testing.T) {
+	setupTestViper(t, "")
+
+	adapter := NewViperConfigAdapter()
+
+	assert.Equal(t, "/templates/read_receipt_email_template.html", adapter.GetReadReceiptEmailTemplate())
+	assert.Equal(t, "Your encrypted message was viewed (%d of %d)", adapter.GetReadReceiptSubject())
+}
+
+func TestNewViperConfigAdapter_RequestFulfilledDefaults(t *testing.T) {
+	setupTestViper(t, "")
+
+	adapter := NewViperConfigAdapter()
+
+	assert.Equal(t, "/templates/request_fulfilled_email_template.html", adapter.GetRequestFulfilledEmailTemplate())
+	assert.Equal(t, "Someone answered your secret request", adapter.GetRequestFulfilledSubject())
+}
+
+func TestNewViperConfigAdapter_PassphraseAlertDefaults(t *testing.T) {
+	setupTestViper(t, "")
+
+	adapter := NewViperConfigAdapter()
+
+	assert.Equal(t, "/templates/passphrase_alert_email_template.html", adapter.GetPassphraseAlertEmailTemplate())
+	assert.Equal(t, "A wrong passphrase was entered for your encrypted message", adapter.GetPassphraseAlertSubject())
+}
+
+func TestNewViperConfigAdapter_TextTemplateDefaults(t *testing.T) {
+	setupTestViper(t, "")
+
+	adapter := NewViperConfigAdapter()
+
+	assert.Equal(t, "/templates/email_template.txt", adapter.GetEmailTextTemplate())
+	assert.Equal(t, "/templates/reminder_email_template.txt", adapter.GetReminderEmailTextTemplate())
+	assert.Equal(t, "mailto:server@password.exchange?subject=unsubscribe", adapter.GetListUnsubscribe())
+}
+
 func TestNewViperConfigAdapter_WithCustomConfig(t *testing.T) {
 	configContent := `
 email:
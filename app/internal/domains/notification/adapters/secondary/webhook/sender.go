@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
+)
+
+// RequestTimeout bounds each delivery attempt, including reading the response
+const RequestTimeout = 10 * time.Second
+
+// errBlockedAddress is returned by the guarded dialer for addresses webhooks may not reach
+var errBlockedAddress = errors.New("webhook address is not publicly routable")
+
+// carrierGradeNAT is 100.64.0.0/10, which net.IP.IsPrivate does not cover
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// HTTPSender implements the WebhookPort over HTTP. Deliveries to untrusted hosts go
+// through a client that refuses to connect to private, loopback and link-local addresses,
+// so per-message webhooks cannot be used to probe the internal network.
+type HTTPSender struct {
+	guardedClient *http.Client
+	trustedClient *http.Client
+	logger        secondary.LoggerPort
+}
+
+// NewHTTPSender creates a new webhook sender
+func NewHTTPSender(logger secondary.LoggerPort) *HTTPSender {
+	return &HTTPSender{
+		guardedClient: newClient(&net.Dialer{Timeout: RequestTimeout, Control: guardAddress}),
+		trustedClient: newClient(&net.Dialer{Timeout: RequestTimeout}),
+		logger:        logger,
+	}
+}
+
+// newClient builds a client that ignores proxy settings and never follows redirects,
+// since a redirect could point a vetted URL somewhere else
+func newClient(dialer *net.Dialer) *http.Client {
+	return &http.Client{
+		Timeout: RequestTimeout,
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: RequestTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Deliver posts the delivery body. 408, 429 and 5xx responses and network errors are
+// transient; other non-2xx responses and blocked addresses wrap domain.ErrWebhookRejected.
+func (s *HTTPSender) Deliver(ctx context.Context, delivery contracts.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrWebhookRejected, err)
+	}
+	for name, value := range delivery.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("User-Agent", "password-exchange-webhook/1")
+
+	client := s.guardedClient
+	if delivery.AllowPrivateNetwork {
+		client = s.trustedClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, errBlockedAddress) {
+			return fmt.Errorf("%w: %v", domain.ErrWebhookRejected, err)
+		}
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		s.logger.Debug().Int("status", resp.StatusCode).Msg("Webhook delivered")
+		return nil
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= 500:
+		return fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("%w: endpoint returned status %d", domain.ErrWebhookRejected, resp.StatusCode)
+	}
+}
+
+// guardAddress is a net.Dialer Control hook that runs after DNS resolution, so a hostname
+// cannot be rebound to an internal address between validation and connection
+func guardAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || isBlockedIP(ip) {
+		return fmt.Errorf("%w: %s", errBlockedAddress, host)
+	}
+	return nil
+}
+
+// isBlockedIP reports whether ip is loopback, private, link-local, unspecified, multicast or CGNAT
+func isBlockedIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		carrierGradeNAT.Contains(ip)
+}
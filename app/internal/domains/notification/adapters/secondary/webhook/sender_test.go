@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/logger"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_DeliversToTrustedHost(t *testing.T) {
+	var gotBody, gotSignature, gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotSignature = r.Header.Get(domain.WebhookSignatureHeader)
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(logger.NewAdapter())
+	err := sender.Deliver(context.Background(), contracts.WebhookDelivery{
+		URL:  server.URL,
+		Body: []byte(`{"event":"message.viewed"}`),
+		Headers: map[string]string{
+			"Content-Type":                "application/json",
+			domain.WebhookSignatureHeader: "sha256=abc",
+		},
+		AllowPrivateNetwork: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"event":"message.viewed"}`, gotBody)
+	assert.Equal(t, "sha256=abc", gotSignature)
+	assert.Equal(t, "application/json", gotContentType)
+}
+
+func TestHTTPSender_BlocksPrivateAddressesForUntrustedHosts(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(logger.NewAdapter())
+	err := sender.Deliver(context.Background(), contracts.WebhookDelivery{URL: server.URL, Body: []byte("{}")})
+
+	assert.ErrorIs(t, err, domain.ErrWebhookRejected)
+	assert.False(t, called)
+}
+
+func TestHTTPSender_ClassifiesResponses(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantErr      bool
+		wantRejected bool
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "accepted", status: http.StatusAccepted},
+		{name: "not found is rejected", status: http.StatusNotFound, wantErr: true, wantRejected: true},
+		{name: "redirect is rejected", status: http.StatusFound, wantErr: true, wantRejected: true},
+		{name: "rate limited is retried", status: http.StatusTooManyRequests, wantErr: true},
+		{name: "timeout is retried", status: http.StatusRequestTimeout, wantErr: true},
+		{name: "server error is retried", status: http.StatusBadGateway, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.status == http.StatusFound {
+					w.Header().Set("Location", "http://169.254.169.254/")
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			sender := NewHTTPSender(logger.NewAdapter())
+			err := sender.Deliver(context.Background(), contracts.WebhookDelivery{
+				URL:                 server.URL,
+				Body:                []byte("{}"),
+				AllowPrivateNetwork: true,
+			})
+
+			if !tc.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tc.wantRejected, errors.Is(err, domain.ErrWebhookRejected))
+		})
+	}
+}
+
+func TestIsBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"224.0.0.1", true},
+		{"93.184.216.34", false},
+		{"2606:4700::1111", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ip, func(t *testing.T) {
+			assert.Equal(t, tc.blocked, isBlockedIP(net.ParseIP(tc.ip)))
+		})
+	}
+}
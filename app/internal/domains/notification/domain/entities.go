@@ -17,6 +17,7 @@ type (
 	ReminderRequest          = contracts.ReminderRequest
 	MessageHandler           = contracts.MessageHandler
 	LogEvent                 = contracts.LogEvent
+	WebhookDelivery          = contracts.WebhookDelivery
 )
 
 // ReminderConfig holds configuration for reminder processing.
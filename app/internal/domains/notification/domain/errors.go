@@ -27,9 +27,18 @@ var (
 	// ErrSMTPAuthFailed indicates SMTP authentication failed
 	ErrSMTPAuthFailed = errors.New("SMTP authentication failed")
 
+	// ErrInvalidSMTPConfig indicates the SMTP transport security or DKIM settings cannot be used
+	ErrInvalidSMTPConfig = errors.New("invalid SMTP configuration")
+
 	// ErrMessageUnmarshalFailed indicates message unmarshaling failed
 	ErrMessageUnmarshalFailed = errors.New("failed to unmarshal queue message")
 
 	// ErrEmptyMessageBody indicates the message body is empty
 	ErrEmptyMessageBody = errors.New("message body is empty")
+
+	// ErrWebhookRejected indicates a webhook endpoint refused an event or cannot be reached safely; it is not retried
+	ErrWebhookRejected = errors.New("webhook rejected")
+
+	// ErrUnsupportedQueueBackend indicates the configured queue backend is not known
+	ErrUnsupportedQueueBackend = errors.New("unsupported queue backend")
 )
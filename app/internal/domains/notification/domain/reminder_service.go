@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
 )
 
@@ -47,7 +49,9 @@ const (
 // CircuitBreaker implements the circuit breaker pattern for error recovery.
 // It prevents cascading failures by temporarily blocking requests when error rates are high.
 // States: Closed (normal), Open (blocking), HalfOpen (testing recovery)
+// It is safe for concurrent use, since queue workers share breakers.
 type CircuitBreaker struct {
+	mu              sync.Mutex
 	failureCount    int                  // Number of consecutive failures
 	lastFailureTime time.Time            // Time of the last failure
 	state           CircuitBreakerState  // Current state of the circuit breaker
@@ -273,6 +277,8 @@ func (r *ReminderService) ProcessMessageReminder(ctx context.Context, reminderRe
 		Subject:        fmt.Sprintf(r.config.GetReminderNotificationSubject(), reminderRequest.ReminderNumber),
 		MessageURL:     reminderRequest.DecryptionURL,
 		MessageContent: r.config.GetReminderMessageContent(),
+		Type:           contracts.NotificationTypeReminder,
+		ReminderNumber: reminderRequest.ReminderNumber,
 	}
 
 	if r.notificationPublisher != nil {
@@ -313,12 +319,23 @@ func (r *ReminderService) ProcessMessageReminder(ctx context.Context, reminderRe
 // Implements resilience pattern: starts with 100ms delay, doubles each retry, max 5s delay.
 // Integrates with circuit breaker to prevent repeated attempts when system is failing.
 func (r *ReminderService) retryWithBackoff(ctx context.Context, operation func() error, operationName string) error {
+	return retryWithBackoff(ctx, r.circuitBreaker, r.logger, operation, operationName)
+}
+
+// retryWithBackoff runs operation through circuitBreaker, retrying failures with exponential backoff
+func retryWithBackoff(
+	ctx context.Context,
+	circuitBreaker *CircuitBreaker,
+	logger secondary.LoggerPort,
+	operation func() error,
+	operationName string,
+) error {
 	var lastErr error
 
 	for attempt := 0; attempt < MaxRetries; attempt++ {
 		// Check circuit breaker before attempting operation
-		if err := r.circuitBreaker.CanExecute(); err != nil {
-			r.logger.Warn().
+		if err := circuitBreaker.CanExecute(); err != nil {
+			logger.Warn().
 				Err(err).
 				Str("operation", operationName).
 				Int("attempt", attempt+1).
@@ -330,9 +347,9 @@ func (r *ReminderService) retryWithBackoff(ctx context.Context, operation func()
 		err := operation()
 		if err == nil {
 			// Success - record success and return
-			r.circuitBreaker.RecordSuccess()
+			circuitBreaker.RecordSuccess()
 			if attempt > 0 {
-				r.logger.Info().
+				logger.Info().
 					Str("operation", operationName).
 					Int("successfulAttempt", attempt+1).
 					Msg("Operation succeeded after retry")
@@ -341,7 +358,7 @@ func (r *ReminderService) retryWithBackoff(ctx context.Context, operation func()
 		}
 
 		lastErr = err
-		r.circuitBreaker.RecordFailure()
+		circuitBreaker.RecordFailure()
 
 		// Don't retry on last attempt
 		if attempt == MaxRetries-1 {
@@ -354,7 +371,7 @@ func (r *ReminderService) retryWithBackoff(ctx context.Context, operation func()
 			delay = MaxRetryDelay
 		}
 
-		r.logger.Warn().
+		logger.Warn().
 			Err(err).
 			Str("operation", operationName).
 			Int("attempt", attempt+1).
@@ -369,7 +386,7 @@ func (r *ReminderService) retryWithBackoff(ctx context.Context, operation func()
 		}
 	}
 
-	r.logger.Error().
+	logger.Error().
 		Err(lastErr).
 		Str("operation", operationName).
 		Int("maxRetries", MaxRetries).
@@ -397,6 +414,9 @@ func (r *ReminderService) validateReminderConfig(reminderConfig ReminderConfig)
 
 // CanExecute checks if the circuit breaker allows execution
 func (circuitBreaker *CircuitBreaker) CanExecute() error {
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.mu.Unlock()
+
 	switch circuitBreaker.state {
 	case CircuitBreakerClosed:
 		return nil
@@ -424,6 +444,9 @@ func (circuitBreaker *CircuitBreaker) CanExecute() error {
 
 // RecordSuccess records a successful operation
 func (circuitBreaker *CircuitBreaker) RecordSuccess() {
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.mu.Unlock()
+
 	oldState := circuitBreaker.state
 	circuitBreaker.failureCount = 0
 	circuitBreaker.state = CircuitBreakerClosed
@@ -439,6 +462,9 @@ func (circuitBreaker *CircuitBreaker) RecordSuccess() {
 
 // RecordFailure records a failed operation
 func (circuitBreaker *CircuitBreaker) RecordFailure() {
+	circuitBreaker.mu.Lock()
+	defer circuitBreaker.mu.Unlock()
+
 	circuitBreaker.failureCount++
 	circuitBreaker.lastFailureTime = time.Now()
 
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -367,8 +368,10 @@ func TestProcessReminders_SuccessfulProcessing_ReturnsSuccess(t *testing.T) {
 	mockStorageRepo.On("GetReminderHistory", ctx, 123).Return([]*ReminderLogEntry{}, nil)
 	mockStorageRepo.On("LogReminderSent", ctx, 123, "test@example.com").Return(nil)
 
-	// Mock email sending
-	mockNotificationPublisher.On("PublishNotification", ctx, mock.AnythingOfType("NotificationRequest")).Return(nil)
+	// Mock email sending; the first reminder is published as reminder #1 for the reminder template
+	mockNotificationPublisher.On("PublishNotification", ctx, mock.MatchedBy(func(req NotificationRequest) bool {
+		return req.Type == contracts.NotificationTypeReminder && req.ReminderNumber == 1
+	})).Return(nil)
 
 	// Act
 	err := service.ProcessReminders(ctx, config)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
 )
 
@@ -14,6 +15,7 @@ type NotificationService struct {
 	queueConsumer    secondary.QueuePort
 	templateRenderer secondary.TemplatePort
 	reminderService  *ReminderService
+	webhookService   *WebhookService
 	logger           secondary.LoggerPort
 	validation       secondary.ValidationPort
 	config           secondary.ConfigPort
@@ -42,7 +44,8 @@ func NewNotificationService(
 	}
 }
 
-// NewNotificationServiceWithReminder creates a new notification service with an existing reminder service
+// NewNotificationServiceWithReminder creates a new notification service with existing reminder
+// and webhook services. A nil webhookService drops webhook events.
 func NewNotificationServiceWithReminder(
 	emailSender secondary.EmailPort,
 	queueConsumer secondary.QueuePort,
@@ -51,12 +54,14 @@ func NewNotificationServiceWithReminder(
 	logger secondary.LoggerPort,
 	validation secondary.ValidationPort,
 	config secondary.ConfigPort,
+	webhookService *WebhookService,
 ) *NotificationService {
 	return &NotificationService{
 		emailSender:      emailSender,
 		queueConsumer:    queueConsumer,
 		templateRenderer: templateRenderer,
 		reminderService:  reminderService,
+		webhookService:   webhookService,
 		logger:           logger,
 		validation:       validation,
 		config:           config,
@@ -108,6 +113,10 @@ func (s *NotificationService) StartMessageProcessing(
 
 // HandleMessage implements the MessageHandler interface
 func (s *NotificationService) HandleMessage(ctx context.Context, msg QueueMessage) error {
+	if msg.NotificationType == contracts.NotificationTypeWebhook {
+		return s.handleWebhookMessage(ctx, msg)
+	}
+
 	s.logger.Debug().
 		Str("to", s.validation.SanitizeEmailForLogging(msg.OtherEmail)).
 		Str("from", msg.FirstName).
@@ -132,8 +141,31 @@ func (s *NotificationService) HandleMessage(ctx context.Context, msg QueueMessag
 	return nil
 }
 
+// handleWebhookMessage hands a lifecycle event to the webhook service
+func (s *NotificationService) handleWebhookMessage(ctx context.Context, msg QueueMessage) error {
+	if s.webhookService == nil {
+		s.logger.Warn().
+			Str("messageId", msg.UniqueID).
+			Str("event", msg.WebhookEvent).
+			Msg("Webhooks are not configured, dropping webhook event")
+		return nil
+	}
+	return s.webhookService.HandleWebhookEvent(ctx, msg)
+}
+
 // createNotificationRequest converts a queue message to a notification request
 func (s *NotificationService) createNotificationRequest(msg QueueMessage) NotificationRequest {
+	switch msg.NotificationType {
+	case contracts.NotificationTypeReadReceipt:
+		return s.createReadReceiptRequest(msg)
+	case contracts.NotificationTypeRequestFulfilled:
+		return s.createRequestFulfilledRequest(msg)
+	case contracts.NotificationTypeReminder:
+		return s.createReminderRequest(msg)
+	case contracts.NotificationTypePassphraseAlert:
+		return s.createPassphraseAlertRequest(msg)
+	}
+
 	subject := fmt.Sprintf(s.config.GetInitialNotificationSubject(), msg.FirstName)
 
 	return NotificationRequest{
@@ -149,6 +181,68 @@ func (s *NotificationService) createNotificationRequest(msg QueueMessage) Notifi
 	}
 }
 
+// createReadReceiptRequest builds the "your secret was viewed" email, which goes back
+// to the original sender rather than to the recipient.
+func (s *NotificationService) createReadReceiptRequest(msg QueueMessage) NotificationRequest {
+	return NotificationRequest{
+		To:            msg.Email,
+		From:          s.config.GetServerEmail(),
+		FromName:      s.config.GetServerName(),
+		Subject:       fmt.Sprintf(s.config.GetReadReceiptSubject(), msg.ViewCount, msg.MaxViewCount),
+		SenderName:    msg.FirstName,
+		RecipientName: msg.OtherFirstName,
+		Type:          contracts.NotificationTypeReadReceipt,
+		ViewCount:     msg.ViewCount,
+		MaxViewCount:  msg.MaxViewCount,
+	}
+}
+
+// createRequestFulfilledRequest builds the email telling a requester that someone answered
+// their secret request. The link is the decrypt URL of the message created for them.
+func (s *NotificationService) createRequestFulfilledRequest(msg QueueMessage) NotificationRequest {
+	return NotificationRequest{
+		To:            msg.OtherEmail,
+		From:          s.config.GetServerEmail(),
+		FromName:      s.config.GetServerName(),
+		Subject:       s.config.GetRequestFulfilledSubject(),
+		SenderName:    msg.FirstName,
+		RecipientName: msg.OtherFirstName,
+		MessageURL:    msg.URL,
+		Type:          contracts.NotificationTypeRequestFulfilled,
+		Description:   msg.Hidden,
+	}
+}
+
+// createPassphraseAlertRequest builds the email warning a sender about wrong passphrases entered
+// for their message. Like read receipts it goes back to the sender.
+func (s *NotificationService) createPassphraseAlertRequest(msg QueueMessage) NotificationRequest {
+	return NotificationRequest{
+		To:           msg.Email,
+		From:         s.config.GetServerEmail(),
+		FromName:     s.config.GetServerName(),
+		Subject:      s.config.GetPassphraseAlertSubject(),
+		Type:         contracts.NotificationTypePassphraseAlert,
+		ViewCount:    msg.ViewCount,
+		MaxViewCount: msg.MaxViewCount,
+		Event:        msg.WebhookEvent,
+	}
+}
+
+// createReminderRequest builds a reminder for a recipient who has not opened their message.
+// Reminders carry no names; see ReminderService.ProcessMessageReminder.
+func (s *NotificationService) createReminderRequest(msg QueueMessage) NotificationRequest {
+	return NotificationRequest{
+		To:             msg.OtherEmail,
+		From:           s.config.GetServerEmail(),
+		FromName:       s.config.GetServerName(),
+		Subject:        fmt.Sprintf(s.config.GetReminderNotificationSubject(), msg.ReminderNumber),
+		MessageContent: msg.Content,
+		MessageURL:     msg.URL,
+		Type:           contracts.NotificationTypeReminder,
+		ReminderNumber: msg.ReminderNumber,
+	}
+}
+
 // validateNotificationRequest validates the notification request
 func (s *NotificationService) validateNotificationRequest(req NotificationRequest) error {
 	if strings.TrimSpace(req.To) == "" {
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -157,6 +158,51 @@ func (m *MockConfigPort) GetReminderMessageContent() string {
 	return args.String(0)
 }
 
+func (m *MockConfigPort) GetReadReceiptSubject() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetReadReceiptEmailTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetRequestFulfilledSubject() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetRequestFulfilledEmailTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetPassphraseAlertSubject() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetPassphraseAlertEmailTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetEmailTextTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetReminderEmailTextTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MockConfigPort) GetListUnsubscribe() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 func (m *MockConfigPort) ValidatePasswordExchangeURL() error {
 	args := m.Called()
 	return args.Error(0)
@@ -591,6 +637,126 @@ func TestCreateNotificationRequest(t *testing.T) {
 	assert.Equal(t, "password123", notificationReq.Hidden)
 }
 
+func TestCreateNotificationRequest_ReadReceipt(t *testing.T) {
+	// Read receipts go back to the original sender, not to the message recipient
+	mockConfig := &MockConfigPort{}
+	mockConfig.On("GetServerEmail").Return("server@password.exchange")
+	mockConfig.On("GetServerName").Return("Password Exchange")
+	mockConfig.On("GetReadReceiptSubject").Return("Your encrypted message was viewed (%d of %d)")
+
+	service := &NotificationService{
+		config: mockConfig,
+	}
+	queueMsg := QueueMessage{
+		Email:            "john@example.com",
+		OtherEmail:       "jane@example.com",
+		UniqueID:         "msg-123",
+		NotificationType: contracts.NotificationTypeReadReceipt,
+		ViewCount:        2,
+		MaxViewCount:     5,
+	}
+
+	notificationReq := service.createNotificationRequest(queueMsg)
+
+	assert.Equal(t, "john@example.com", notificationReq.To)
+	assert.Equal(t, "server@password.exchange", notificationReq.From)
+	assert.Equal(t, "Your encrypted message was viewed (2 of 5)", notificationReq.Subject)
+	assert.Equal(t, contracts.NotificationTypeReadReceipt, notificationReq.Type)
+	assert.Equal(t, 2, notificationReq.ViewCount)
+	assert.Equal(t, 5, notificationReq.MaxViewCount)
+	assert.Empty(t, notificationReq.MessageURL)
+	mockConfig.AssertNotCalled(t, "GetInitialNotificationSubject")
+}
+
+func TestCreateNotificationRequest_RequestFulfilled(t *testing.T) {
+	// The requester gets the link to the secret someone sent in answer to their request
+	mockConfig := &MockConfigPort{}
+	mockConfig.On("GetServerEmail").Return("server@password.exchange")
+	mockConfig.On("GetServerName").Return("Password Exchange")
+	mockConfig.On("GetRequestFulfilledSubject").Return("Someone answered your secret request")
+
+	service := &NotificationService{
+		config: mockConfig,
+	}
+	queueMsg := QueueMessage{
+		FirstName:        "Bob",
+		OtherFirstName:   "Alice",
+		OtherEmail:       "alice@example.com",
+		URL:              "https://example.com/decrypt/msg-123/key",
+		Hidden:           "Staging database password",
+		NotificationType: contracts.NotificationTypeRequestFulfilled,
+	}
+
+	notificationReq := service.createNotificationRequest(queueMsg)
+
+	assert.Equal(t, "alice@example.com", notificationReq.To)
+	assert.Equal(t, "Someone answered your secret request", notificationReq.Subject)
+	assert.Equal(t, contracts.NotificationTypeRequestFulfilled, notificationReq.Type)
+	assert.Equal(t, "Bob", notificationReq.SenderName)
+	assert.Equal(t, "Alice", notificationReq.RecipientName)
+	assert.Equal(t, "https://example.com/decrypt/msg-123/key", notificationReq.MessageURL)
+	assert.Equal(t, "Staging database password", notificationReq.Description)
+	assert.Empty(t, notificationReq.MessageContent)
+	mockConfig.AssertNotCalled(t, "GetInitialNotificationSubject")
+}
+
+func TestCreateNotificationRequest_PassphraseAlert(t *testing.T) {
+	// Like a read receipt, the alert goes back to the sender and names what happened
+	mockConfig := &MockConfigPort{}
+	mockConfig.On("GetServerEmail").Return("server@password.exchange")
+	mockConfig.On("GetServerName").Return("Password Exchange")
+	mockConfig.On("GetPassphraseAlertSubject").Return("A wrong passphrase was entered for your encrypted message")
+
+	service := &NotificationService{
+		config: mockConfig,
+	}
+	queueMsg := QueueMessage{
+		Email:            "john@example.com",
+		UniqueID:         "msg-123",
+		NotificationType: contracts.NotificationTypePassphraseAlert,
+		WebhookEvent:     "message.locked",
+		ViewCount:        1,
+		MaxViewCount:     3,
+	}
+
+	notificationReq := service.createNotificationRequest(queueMsg)
+
+	assert.Equal(t, "john@example.com", notificationReq.To)
+	assert.Equal(t, "A wrong passphrase was entered for your encrypted message", notificationReq.Subject)
+	assert.Equal(t, contracts.NotificationTypePassphraseAlert, notificationReq.Type)
+	assert.Equal(t, "message.locked", notificationReq.Event)
+	assert.Empty(t, notificationReq.MessageURL)
+	mockConfig.AssertNotCalled(t, "GetInitialNotificationSubject")
+}
+
+func TestCreateNotificationRequest_Reminder(t *testing.T) {
+	// Reminders are sent with the reminder subject and template, numbered as the reminder service counted them
+	mockConfig := &MockConfigPort{}
+	mockConfig.On("GetServerEmail").Return("server@password.exchange")
+	mockConfig.On("GetServerName").Return("Password Exchange")
+	mockConfig.On("GetReminderNotificationSubject").Return("Reminder: You have an unviewed encrypted message (Reminder #%d)")
+
+	service := &NotificationService{
+		config: mockConfig,
+	}
+	queueMsg := QueueMessage{
+		OtherEmail:       "jane@example.com",
+		Content:          "Please check your original email for the secure decrypt link.",
+		NotificationType: contracts.NotificationTypeReminder,
+		ReminderNumber:   2,
+	}
+
+	notificationReq := service.createNotificationRequest(queueMsg)
+
+	assert.Equal(t, "jane@example.com", notificationReq.To)
+	assert.Equal(t, "Reminder: You have an unviewed encrypted message (Reminder #2)", notificationReq.Subject)
+	assert.Equal(t, contracts.NotificationTypeReminder, notificationReq.Type)
+	assert.Equal(t, 2, notificationReq.ReminderNumber)
+	assert.Equal(t, "Please check your original email for the secure decrypt link.", notificationReq.MessageContent)
+	assert.Empty(t, notificationReq.SenderName)
+	mockConfig.AssertNotCalled(t, "GetInitialNotificationSubject")
+}
+
 // Test HandleMessage success
 func TestHandleMessage_Success(t *testing.T) {
 	// Arrange
@@ -712,3 +878,64 @@ func TestHandleMessage_SendNotificationFails_ReturnsError(t *testing.T) {
 	assert.Contains(t, err.Error(), "email sending failed")
 	mockEmailSender.AssertExpectations(t)
 }
+
+func TestHandleMessage_WebhookRoutedToWebhookService(t *testing.T) {
+	mockEmailSender := &MockNotificationSender{}
+	mockLogger := &MockLoggerPort{}
+	setupLenientLoggerMock(mockLogger)
+
+	mockWebhookSender := &MockWebhookSender{}
+	mockWebhookSender.On("Deliver", mock.Anything, mock.MatchedBy(func(delivery WebhookDelivery) bool {
+		return delivery.URL == "https://hooks.example.com/events"
+	})).Return(nil)
+	webhookService := NewWebhookService(mockWebhookSender, WebhookConfig{}, mockLogger)
+
+	service := NewNotificationServiceWithReminder(
+		mockEmailSender,
+		&MockQueueConsumer{},
+		&MockTemplateRenderer{},
+		nil,
+		mockLogger,
+		&MockValidationPort{},
+		&MockConfigPort{},
+		webhookService,
+	)
+
+	err := service.HandleMessage(context.Background(), QueueMessage{
+		UniqueID:         "msg-1",
+		NotificationType: contracts.NotificationTypeWebhook,
+		WebhookEvent:     "message.viewed",
+		WebhookURL:       "https://hooks.example.com/events",
+		WebhookSecret:    "per-message-secret",
+	})
+
+	assert.NoError(t, err)
+	mockWebhookSender.AssertExpectations(t)
+	mockEmailSender.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything)
+}
+
+func TestHandleMessage_WebhookWithoutWebhookServiceIsDropped(t *testing.T) {
+	mockEmailSender := &MockNotificationSender{}
+	mockLogger := &MockLoggerPort{}
+	setupLenientLoggerMock(mockLogger)
+
+	service := NewNotificationServiceWithReminder(
+		mockEmailSender,
+		&MockQueueConsumer{},
+		&MockTemplateRenderer{},
+		nil,
+		mockLogger,
+		&MockValidationPort{},
+		&MockConfigPort{},
+		nil,
+	)
+
+	err := service.HandleMessage(context.Background(), QueueMessage{
+		UniqueID:         "msg-1",
+		NotificationType: contracts.NotificationTypeWebhook,
+		WebhookEvent:     "message.viewed",
+	})
+
+	assert.NoError(t, err)
+	mockEmailSender.AssertNotCalled(t, "SendNotification", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/secondary"
+)
+
+// Webhook request headers. Receivers verify the signature by computing
+// HMAC-SHA256(secret, timestamp + "." + body) and comparing it to the hex digest.
+const (
+	WebhookSignatureHeader = "X-Password-Exchange-Signature"
+	WebhookTimestampHeader = "X-Password-Exchange-Timestamp"
+	WebhookEventHeader     = "X-Password-Exchange-Event"
+)
+
+// WebhookConfig holds the operator-level webhook settings
+type WebhookConfig struct {
+	URL    string // Global webhook that receives every event; optional
+	Secret string // Signs events sent to the global webhook
+	// TrustedHosts may be reached by per-message webhooks even on private networks
+	TrustedHosts []string
+}
+
+// WebhookPayload is the JSON body posted to webhook endpoints. It never carries message content.
+type WebhookPayload struct {
+	Event        string    `json:"event"`
+	MessageID    string    `json:"messageId"`
+	ViewCount    int       `json:"viewCount"`
+	MaxViewCount int       `json:"maxViewCount"`
+	OccurredAt   time.Time `json:"occurredAt"`
+}
+
+// WebhookService delivers signed lifecycle events to per-message and global webhooks
+type WebhookService struct {
+	sender          secondary.WebhookPort
+	config          WebhookConfig
+	logger          secondary.LoggerPort
+	mu              sync.Mutex
+	circuitBreakers map[string]*CircuitBreaker
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(sender secondary.WebhookPort, config WebhookConfig, logger secondary.LoggerPort) *WebhookService {
+	return &WebhookService{
+		sender:          sender,
+		config:          config,
+		logger:          logger,
+		circuitBreakers: make(map[string]*CircuitBreaker),
+	}
+}
+
+// HandleWebhookEvent signs and delivers the event in msg. Events the endpoint rejects are
+// logged and dropped; transient failures are returned so the queue redelivers them.
+func (w *WebhookService) HandleWebhookEvent(ctx context.Context, msg QueueMessage) error {
+	target, secret, trusted := w.resolveTarget(msg)
+	if target == "" {
+		w.logger.Debug().
+			Str("messageId", msg.UniqueID).
+			Str("event", msg.WebhookEvent).
+			Msg("No webhook configured for event")
+		return nil
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil {
+		w.logger.Warn().Err(err).Str("messageId", msg.UniqueID).Msg("Dropping webhook event with invalid URL")
+		return nil
+	}
+
+	occurredAt := msg.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+	body, err := json.Marshal(WebhookPayload{
+		Event:        msg.WebhookEvent,
+		MessageID:    msg.UniqueID,
+		ViewCount:    msg.ViewCount,
+		MaxViewCount: msg.MaxViewCount,
+		OccurredAt:   occurredAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	delivery := WebhookDelivery{
+		URL:  target,
+		Body: body,
+		Headers: map[string]string{
+			"Content-Type":         "application/json",
+			WebhookEventHeader:     msg.WebhookEvent,
+			WebhookTimestampHeader: timestamp,
+			WebhookSignatureHeader: "sha256=" + SignWebhookPayload(secret, timestamp, body),
+		},
+		AllowPrivateNetwork: trusted,
+	}
+
+	err = retryWithBackoff(ctx, w.circuitBreaker(parsed.Hostname()), w.logger, func() error {
+		err := w.sender.Deliver(ctx, delivery)
+		if errors.Is(err, ErrWebhookRejected) {
+			w.logger.Warn().
+				Err(err).
+				Str("messageId", msg.UniqueID).
+				Str("event", msg.WebhookEvent).
+				Str("host", parsed.Hostname()).
+				Msg("Webhook rejected event, dropping it")
+			return nil
+		}
+		return err
+	}, "deliver_webhook")
+	if err != nil {
+		return err
+	}
+
+	w.logger.Debug().
+		Str("messageId", msg.UniqueID).
+		Str("event", msg.WebhookEvent).
+		Str("host", parsed.Hostname()).
+		Msg("Webhook event processed")
+	return nil
+}
+
+// resolveTarget picks the message's own webhook over the global one. Per-message webhooks
+// are only allowed onto private networks when their host is explicitly trusted.
+func (w *WebhookService) resolveTarget(msg QueueMessage) (target, secret string, trusted bool) {
+	if msg.WebhookURL != "" {
+		return msg.WebhookURL, msg.WebhookSecret, w.isTrustedHost(msg.WebhookURL)
+	}
+	return w.config.URL, w.config.Secret, true
+}
+
+// isTrustedHost reports whether rawURL's host is in the configured trusted hosts
+func (w *WebhookService) isTrustedHost(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, trusted := range w.config.TrustedHosts {
+		if host != "" && host == strings.ToLower(strings.TrimSpace(trusted)) {
+			return true
+		}
+	}
+	return false
+}
+
+// circuitBreaker returns the breaker for host, so one failing endpoint does not block the rest
+func (w *WebhookService) circuitBreaker(host string) *CircuitBreaker {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	circuitBreaker, ok := w.circuitBreakers[host]
+	if !ok {
+		circuitBreaker = &CircuitBreaker{state: CircuitBreakerClosed, logger: w.logger}
+		w.circuitBreakers[host] = circuitBreaker
+	}
+	return circuitBreaker
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of timestamp + "." + body.
+// Including the timestamp lets receivers reject replayed deliveries.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockWebhookSender is a mock implementation of WebhookPort
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Deliver(ctx context.Context, delivery WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func newTestWebhookService(config WebhookConfig) (*WebhookService, *MockWebhookSender) {
+	sender := new(MockWebhookSender)
+	mockLogger := new(MockLoggerPort)
+	setupLenientLoggerMock(mockLogger)
+	return NewWebhookService(sender, config, mockLogger), sender
+}
+
+func webhookQueueMessage(url, secret string) QueueMessage {
+	return QueueMessage{
+		UniqueID:         "msg-1",
+		NotificationType: contracts.NotificationTypeWebhook,
+		ViewCount:        3,
+		MaxViewCount:     3,
+		WebhookEvent:     "message.exhausted",
+		WebhookURL:       url,
+		WebhookSecret:    secret,
+		OccurredAt:       time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
+
+func TestWebhookService_SignsPayload(t *testing.T) {
+	service, sender := newTestWebhookService(WebhookConfig{})
+
+	var delivered WebhookDelivery
+	sender.On("Deliver", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { delivered = args.Get(1).(WebhookDelivery) }).
+		Return(nil)
+
+	err := service.HandleWebhookEvent(context.Background(), webhookQueueMessage("https://hooks.example.com/events", "per-message-secret"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://hooks.example.com/events", delivered.URL)
+	assert.False(t, delivered.AllowPrivateNetwork)
+	assert.Equal(t, "message.exhausted", delivered.Headers[WebhookEventHeader])
+
+	timestamp := delivered.Headers[WebhookTimestampHeader]
+	require.NotEmpty(t, timestamp)
+	assert.Equal(t,
+		"sha256="+SignWebhookPayload("per-message-secret", timestamp, delivered.Body),
+		delivered.Headers[WebhookSignatureHeader],
+	)
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(delivered.Body, &payload))
+	assert.Equal(t, WebhookPayload{
+		Event:        "message.exhausted",
+		MessageID:    "msg-1",
+		ViewCount:    3,
+		MaxViewCount: 3,
+		OccurredAt:   time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, payload)
+}
+
+func TestSignWebhookPayload_KnownVector(t *testing.T) {
+	// HMAC-SHA256("secret", "1700000000.{}")
+	assert.Equal(t,
+		"b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163",
+		SignWebhookPayload("secret", "1700000000", []byte("{}")),
+	)
+}
+
+func TestWebhookService_ResolvesTarget(t *testing.T) {
+	config := WebhookConfig{
+		URL:          "https://global.example.com/hook",
+		Secret:       "global-secret",
+		TrustedHosts: []string{"Internal.Example.Com"},
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		wantURL     string
+		wantSecret  string
+		wantPrivate bool
+	}{
+		{name: "global webhook is trusted", wantURL: config.URL, wantSecret: "global-secret", wantPrivate: true},
+		{name: "per-message webhook overrides global", url: "https://hooks.example.com/events", wantURL: "https://hooks.example.com/events", wantSecret: "per-message-secret"},
+		{name: "trusted per-message host", url: "https://internal.example.com/events", wantURL: "https://internal.example.com/events", wantSecret: "per-message-secret", wantPrivate: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service, sender := newTestWebhookService(config)
+			var delivered WebhookDelivery
+			sender.On("Deliver", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { delivered = args.Get(1).(WebhookDelivery) }).
+				Return(nil)
+
+			secret := ""
+			if tc.url != "" {
+				secret = "per-message-secret"
+			}
+			require.NoError(t, service.HandleWebhookEvent(context.Background(), webhookQueueMessage(tc.url, secret)))
+
+			assert.Equal(t, tc.wantURL, delivered.URL)
+			assert.Equal(t, tc.wantPrivate, delivered.AllowPrivateNetwork)
+			timestamp := delivered.Headers[WebhookTimestampHeader]
+			assert.Equal(t, "sha256="+SignWebhookPayload(tc.wantSecret, timestamp, delivered.Body), delivered.Headers[WebhookSignatureHeader])
+		})
+	}
+}
+
+func TestWebhookService_NoTargetIsDropped(t *testing.T) {
+	service, sender := newTestWebhookService(WebhookConfig{})
+
+	err := service.HandleWebhookEvent(context.Background(), webhookQueueMessage("", ""))
+
+	assert.NoError(t, err)
+	sender.AssertNotCalled(t, "Deliver", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_RejectedEventIsNotRetried(t *testing.T) {
+	service, sender := newTestWebhookService(WebhookConfig{})
+	sender.On("Deliver", mock.Anything, mock.Anything).Return(errors.Join(ErrWebhookRejected, errors.New("status 404")))
+
+	err := service.HandleWebhookEvent(context.Background(), webhookQueueMessage("https://hooks.example.com/events", "per-message-secret"))
+
+	assert.NoError(t, err)
+	sender.AssertNumberOfCalls(t, "Deliver", 1)
+}
+
+func TestWebhookService_TransientFailureIsRetriedThenReturned(t *testing.T) {
+	service, sender := newTestWebhookService(WebhookConfig{})
+	sender.On("Deliver", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+	err := service.HandleWebhookEvent(context.Background(), webhookQueueMessage("https://hooks.example.com/events", "per-message-secret"))
+
+	assert.ErrorIs(t, err, ErrMaxRetriesExceeded)
+	sender.AssertNumberOfCalls(t, "Deliver", MaxRetries)
+}
+
+func TestWebhookService_CircuitBreakerPerHost(t *testing.T) {
+	service, _ := newTestWebhookService(WebhookConfig{})
+
+	assert.Same(t, service.circuitBreaker("a.example.com"), service.circuitBreaker("a.example.com"))
+	assert.NotSame(t, service.circuitBreaker("a.example.com"), service.circuitBreaker("b.example.com"))
+}
@@ -24,8 +24,33 @@ type NotificationRequest struct {
 	RecipientName  string
 	MessageURL     string
 	Hidden         string
+	// Type selects the email template; empty means NotificationTypeInitial.
+	Type         string
+	ViewCount    int
+	MaxViewCount int
+	// Description is the requester's note on a fulfilled secret request.
+	Description string
+	// ReminderNumber counts the reminders sent for a message, starting at 1.
+	ReminderNumber int
+	// Event is the lifecycle event a passphrase alert reports, e.g. "message.locked".
+	Event string
 }
 
+// Notification types carried on queue messages. Messages published before the
+// type field existed have no type and are treated as initial notifications.
+const (
+	NotificationTypeInitial     = "initial"
+	NotificationTypeReadReceipt = "read_receipt"
+	NotificationTypeWebhook     = "webhook"
+	// NotificationTypeReminder nudges a recipient who has not opened their message yet.
+	NotificationTypeReminder = "reminder"
+	// NotificationTypeRequestFulfilled tells a requester that their secret request was answered.
+	NotificationTypeRequestFulfilled = "request_fulfilled"
+	// NotificationTypePassphraseAlert tells a sender that a wrong passphrase was entered for their
+	// message, or that wrong passphrases got it locked or destroyed.
+	NotificationTypePassphraseAlert = "passphrase_alert"
+)
+
 // NotificationResponse represents the result of a notification send operation.
 // It provides feedback about whether the notification was successfully sent,
 // along with any relevant tracking information or error details.
@@ -39,21 +64,38 @@ type NotificationResponse struct {
 // to a message queue system (e.g., RabbitMQ). This struct encapsulates all
 // connection parameters required for queue operations.
 type QueueConnection struct {
+	// Backend is one of the QueueBackend constants; empty means QueueBackendRabbitMQ.
+	Backend   string
 	Host      string
 	Port      int
 	User      string
 	Password  string
 	QueueName string
+	// URL is the server URL for QueueBackendNATS, e.g. nats://localhost:4222.
+	URL string
+	// Stream is the JetStream stream holding the queue for QueueBackendNATS.
+	Stream string
 }
 
+// Supported values for QueueConnection.Backend
+const (
+	QueueBackendRabbitMQ = "rabbitmq"
+	QueueBackendNATS     = "nats"
+)
+
 // NotificationTemplateData represents the data structure passed to email templates
 // for rendering. This struct provides the dynamic content that will be inserted
 // into email template placeholders.
 type NotificationTemplateData struct {
-	Message       template.HTML
-	SenderName    string
-	RecipientName string
-	MessageURL    string
+	Message        template.HTML
+	SenderName     string
+	RecipientName  string
+	MessageURL     string
+	ViewCount      int
+	MaxViewCount   int
+	Description    string
+	ReminderNumber int
+	Event          string
 }
 
 // UnviewedMessage represents a message that has been sent but not yet viewed by
@@ -92,6 +134,29 @@ type QueueMessage struct {
 	URL            string
 	Hidden         string
 	Captcha        string
+	// NotificationType is one of the NotificationType constants; empty means initial.
+	NotificationType string
+	ViewCount        int
+	MaxViewCount     int
+	// ReminderNumber is set on NotificationTypeReminder messages.
+	ReminderNumber int
+	// WebhookEvent, WebhookURL, WebhookSecret and OccurredAt describe a lifecycle event
+	// for NotificationTypeWebhook messages. An empty WebhookURL means the global webhook.
+	// NotificationTypePassphraseAlert messages carry their event in WebhookEvent too.
+	WebhookEvent  string
+	WebhookURL    string
+	WebhookSecret string
+	OccurredAt    time.Time
+}
+
+// WebhookDelivery represents a signed HTTP POST to a webhook endpoint.
+// AllowPrivateNetwork is only set for operator-configured or trusted hosts;
+// otherwise the sender refuses to connect to private and loopback addresses.
+type WebhookDelivery struct {
+	URL                 string
+	Body                []byte
+	Headers             map[string]string
+	AllowPrivateNetwork bool
 }
 
 // MessageHandler defines the interface for processing messages received from the notification queue.
@@ -201,8 +266,31 @@ type EmailConnection struct {
 	User     string
 	Password string
 	From     string
+	// TLSMode is one of the EmailTLS constants; empty means EmailTLSOpportunistic.
+	TLSMode string
+	// TLSSkipVerify accepts any server certificate. TLSCAFile is a PEM bundle of
+	// CAs trusted in addition to the system roots.
+	TLSSkipVerify bool
+	TLSCAFile     string
+	// DKIMKey is a PEM-encoded RSA or Ed25519 private key, or the path to one. Messages
+	// are signed for DKIMDomain, or the domain of From when empty, under DKIMSelector.
+	DKIMKey      string
+	DKIMSelector string
+	DKIMDomain   string
 }
 
+// Supported values for EmailConnection.TLSMode
+const (
+	// EmailTLSOpportunistic upgrades with STARTTLS when the server offers it.
+	EmailTLSOpportunistic = "opportunistic"
+	// EmailTLSStartTLS refuses servers that do not offer STARTTLS.
+	EmailTLSStartTLS = "starttls"
+	// EmailTLSImplicit speaks TLS from the first byte, as relays on port 465 expect.
+	EmailTLSImplicit = "implicit"
+	// EmailTLSNone never encrypts; only suitable for a relay on the same host.
+	EmailTLSNone = "none"
+)
+
 // ReminderRequest represents a request to send a reminder notification for an unviewed message.
 // This struct contains all the information needed to construct a reminder email,
 // including message identification, recipient details, and timing information.
@@ -47,6 +47,27 @@ type ConfigPort interface {
 	//   - The subject template string (e.g., "Reminder: You have an unviewed encrypted message (Reminder #%d)")
 	GetReminderNotificationSubject() string
 
+	// GetReadReceiptSubject returns the subject template for read receipt emails sent to
+	// the original sender after a recipient views their message.
+	//
+	// Returns:
+	//   - The subject template string (e.g., "Your encrypted message was viewed (%d of %d)")
+	GetReadReceiptSubject() string
+
+	// GetRequestFulfilledSubject returns the subject line for emails telling a requester
+	// that someone answered their secret request.
+	//
+	// Returns:
+	//   - The subject string (e.g., "Someone answered your secret request")
+	GetRequestFulfilledSubject() string
+
+	// GetPassphraseAlertSubject returns the subject line for emails telling a sender that wrong
+	// passphrases were entered for their message.
+	//
+	// Returns:
+	//   - The subject string (e.g., "A wrong passphrase was entered for your encrypted message")
+	GetPassphraseAlertSubject() string
+
 	// === Email Template Configuration ===
 	// Template content and file paths for notification emails
 
@@ -79,6 +100,48 @@ type ConfigPort interface {
 	//   - The reminder message content string
 	GetReminderMessageContent() string
 
+	// GetReadReceiptEmailTemplate returns the path to the read receipt email template file.
+	// This can be either a file path to a template file or an inline template string.
+	//
+	// Returns:
+	//   - The file path to the read receipt template (e.g., "/templates/read_receipt_email_template.html")
+	GetReadReceiptEmailTemplate() string
+
+	// GetRequestFulfilledEmailTemplate returns the path to the request fulfilled email template file.
+	// This can be either a file path to a template file or an inline template string.
+	//
+	// Returns:
+	//   - The file path to the template (e.g., "/templates/request_fulfilled_email_template.html")
+	GetRequestFulfilledEmailTemplate() string
+
+	// GetPassphraseAlertEmailTemplate returns the path to the passphrase alert email template file.
+	// This can be either a file path to a template file or an inline template string.
+	//
+	// Returns:
+	//   - The file path to the template (e.g., "/templates/passphrase_alert_email_template.html")
+	GetPassphraseAlertEmailTemplate() string
+
+	// GetEmailTextTemplate returns the plain-text counterpart of GetEmailTemplate, sent as the
+	// text/plain alternative of initial notification emails. Like the HTML templates it can be
+	// a file path or an inline text/template string.
+	//
+	// Returns:
+	//   - The file path to the text template (e.g., "/templates/email_template.txt")
+	GetEmailTextTemplate() string
+
+	// GetReminderEmailTextTemplate returns the plain-text counterpart of GetReminderEmailTemplate.
+	//
+	// Returns:
+	//   - The file path to the text template (e.g., "/templates/reminder_email_template.txt")
+	GetReminderEmailTextTemplate() string
+
+	// GetListUnsubscribe returns the target of the List-Unsubscribe header on outgoing emails,
+	// a mailto: or https: URI without the angle brackets. An empty string omits the header.
+	//
+	// Returns:
+	//   - The unsubscribe URI (e.g., "mailto:server@password.exchange?subject=unsubscribe")
+	GetListUnsubscribe() string
+
 	// === Configuration Validation ===
 	// Methods for validating configuration values
 
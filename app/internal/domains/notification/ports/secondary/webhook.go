@@ -0,0 +1,14 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+)
+
+// WebhookPort defines the secondary port for delivering webhook events over HTTP
+type WebhookPort interface {
+	// Deliver posts the payload and returns an error wrapping domain.ErrWebhookRejected
+	// when the endpoint refuses it in a way that retrying will not fix
+	Deliver(ctx context.Context, delivery contracts.WebhookDelivery) error
+}
@@ -0,0 +1,84 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+)
+
+// stubService returns a fixed cleanup result; only CleanupExpiredMessages is implemented
+type stubService struct {
+	primary.StorageServicePort
+	result domain.CleanupResult
+	err    error
+
+	mu         sync.Mutex
+	batchSizes []int
+}
+
+func (s *stubService) CleanupExpiredMessages(ctx context.Context, batchSize int) (domain.CleanupResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batchSizes = append(s.batchSizes, batchSize)
+	return s.result, s.err
+}
+
+func (s *stubService) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.batchSizes)
+}
+
+func TestInstrumentedService_CountsPurgedRows(t *testing.T) {
+	metrics := NewMetrics(prometheus.NewRegistry())
+	stub := &stubService{result: domain.CleanupResult{MessagesPurged: 7, SecretRequestsPurged: 2}}
+	service := NewInstrumentedService(stub, metrics)
+
+	result, err := service.CleanupExpiredMessages(context.Background(), 100)
+	require.NoError(t, err)
+	assert.Equal(t, stub.result, result)
+	assert.Equal(t, []int{100}, stub.batchSizes)
+
+	// Rows deleted before a failure are still counted
+	stub.result, stub.err = domain.CleanupResult{MessagesPurged: 3}, errors.New("connection reset")
+	_, err = service.CleanupExpiredMessages(context.Background(), 100)
+	require.Error(t, err)
+
+	assert.Equal(t, 10.0, testutil.ToFloat64(metrics.MessagesPurged))
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.SecretRequestsPurged))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Runs.WithLabelValues("success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Runs.WithLabelValues("error")))
+}
+
+func TestSweeper_RunsUntilCancelled(t *testing.T) {
+	stub := &stubService{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewSweeper(stub, 10*time.Millisecond, 250).Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return stub.calls() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sweeper did not stop after cancel")
+	}
+	assert.Equal(t, 250, stub.batchSizes[0])
+}
+
+func TestNewSweeper_DefaultInterval(t *testing.T) {
+	assert.Equal(t, DefaultInterval, NewSweeper(&stubService{}, 0, 0).interval)
+}
@@ -0,0 +1,84 @@
+// Package cleanup purges expired messages on a timer and counts what every cleanup removed.
+package cleanup
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+)
+
+// Metrics counts the rows removed by expired message cleanups
+type Metrics struct {
+	MessagesPurged       prometheus.Counter
+	SecretRequestsPurged prometheus.Counter
+	Runs                 *prometheus.CounterVec
+}
+
+// NewMetrics creates and registers the cleanup metrics
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	metrics := &Metrics{
+		MessagesPurged: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "passwordexchange_expired_messages_purged_total",
+				Help: "Total number of expired messages deleted by cleanup",
+			},
+		),
+		SecretRequestsPurged: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: "passwordexchange_expired_secret_requests_purged_total",
+				Help: "Total number of expired secret requests deleted by cleanup",
+			},
+		),
+		Runs: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "passwordexchange_expiry_cleanup_runs_total",
+				Help: "Total number of expired message cleanups by result",
+			},
+			[]string{"result"},
+		),
+	}
+
+	registerer.MustRegister(
+		metrics.MessagesPurged,
+		metrics.SecretRequestsPurged,
+		metrics.Runs,
+	)
+
+	return metrics
+}
+
+// Observe records one cleanup. Rows purged before a failure are counted too, since they are gone.
+func (m *Metrics) Observe(result domain.CleanupResult, err error) {
+	m.MessagesPurged.Add(float64(result.MessagesPurged))
+	m.SecretRequestsPurged.Add(float64(result.SecretRequestsPurged))
+	if err != nil {
+		m.Runs.WithLabelValues("error").Inc()
+		return
+	}
+	m.Runs.WithLabelValues("success").Inc()
+}
+
+// InstrumentedService decorates a StorageServicePort so every cleanup, whether started by the
+// sweeper, the gRPC API or the cleanup command, is counted in Metrics
+type InstrumentedService struct {
+	primary.StorageServicePort
+	metrics *Metrics
+}
+
+// NewInstrumentedService wraps service with cleanup metrics
+func NewInstrumentedService(service primary.StorageServicePort, metrics *Metrics) *InstrumentedService {
+	return &InstrumentedService{
+		StorageServicePort: service,
+		metrics:            metrics,
+	}
+}
+
+// CleanupExpiredMessages runs the cleanup and records what it removed
+func (s *InstrumentedService) CleanupExpiredMessages(ctx context.Context, batchSize int) (domain.CleanupResult, error) {
+	result, err := s.StorageServicePort.CleanupExpiredMessages(ctx, batchSize)
+	s.metrics.Observe(result, err)
+	return result, err
+}
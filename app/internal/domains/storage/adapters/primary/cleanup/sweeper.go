@@ -0,0 +1,57 @@
+package cleanup
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// DefaultInterval is how often the sweeper runs unless configured otherwise
+const DefaultInterval = time.Hour
+
+// Sweeper periodically deletes messages past their own expires_at, which may be anywhere from
+// minutes to 90 days after they were created
+type Sweeper struct {
+	service   primary.StorageServicePort
+	interval  time.Duration
+	batchSize int
+}
+
+// NewSweeper creates a sweeper. A zero or negative interval uses DefaultInterval; a zero or
+// negative batch size uses the storage service's default.
+func NewSweeper(service primary.StorageServicePort, interval time.Duration, batchSize int) *Sweeper {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Sweeper{
+		service:   service,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run sweeps once immediately and then every interval until ctx is cancelled
+func (s *Sweeper) Run(ctx context.Context) {
+	logging.Info().Dur("interval", s.interval).Int("batchSize", s.batchSize).Msg("Starting expired message sweeper")
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			logging.Info().Msg("Stopping expired message sweeper")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep runs one cleanup; failures are logged and retried on the next tick
+func (s *Sweeper) sweep(ctx context.Context) {
+	if _, err := s.service.CleanupExpiredMessages(ctx, s.batchSize); err != nil && ctx.Err() == nil {
+		logging.Error().Err(err).Msg("Failed to cleanup expired messages")
+	}
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -21,6 +23,17 @@ import (
 // maxExpirationDuration is the maximum allowed expiration time (90 days).
 const maxExpirationDuration = 2160 * time.Hour
 
+// maxMessageBytes raises gRPC's 4 MiB default so a message and its encrypted
+// attachment chunks can be stored or fetched in a single call.
+const maxMessageBytes = 16 << 20
+
+// Metadata keys the message service sets to the client a call is made for. Must match the
+// message service's storage client.
+const (
+	clientIPMetadataKey        = "x-client-ip"
+	clientUserAgentMetadataKey = "x-client-user-agent"
+)
+
 // GRPCServer adapts the storage service to gRPC protocol
 type GRPCServer struct {
 	database.UnimplementedDbServiceServer
@@ -53,12 +66,21 @@ func (s *GRPCServer) Insert(ctx context.Context, request *database.InsertRequest
 	}
 
 	message := &domain.Message{
-		Content:        request.GetContent(),
-		UniqueID:       request.GetUuid(),
-		Passphrase:     request.GetPassphrase(),
-		RecipientEmail: request.GetRecipientEmail(),
-		MaxViewCount:   int(request.GetMaxViewCount()),
-		ExpiresAt:      expiresAt,
+		Content:             request.GetContent(),
+		UniqueID:            request.GetUuid(),
+		Passphrase:          request.GetPassphrase(),
+		RecipientEmail:      request.GetRecipientEmail(),
+		MaxViewCount:        int(request.GetMaxViewCount()),
+		ExpiresAt:           expiresAt,
+		ClientEncrypted:     request.GetClientEncrypted(),
+		Attachment:          fromPBAttachment(request.GetAttachment()),
+		RevocationTokenHash: request.GetRevocationTokenHash(),
+		SenderEmail:         request.GetSenderEmail(),
+		WebhookURL:          request.GetWebhookUrl(),
+		WebhookSecret:       request.GetWebhookSecret(),
+		PublicKeyEncrypted:  request.GetPublicKeyEncrypted(),
+		PassphraseKeySalt:   request.GetPassphraseKeySalt(),
+		Notification:        request.GetNotification(),
 	}
 
 	err = s.storageService.StoreMessage(ctx, message)
@@ -89,6 +111,32 @@ func parseExpiresAt(s string) (*time.Time, error) {
 	return &t, nil
 }
 
+// fromPBAttachment converts a protobuf attachment into the domain type, returning nil when absent.
+func fromPBAttachment(a *database.Attachment) *domain.Attachment {
+	if a == nil {
+		return nil
+	}
+	return &domain.Attachment{
+		Filename:    a.GetFilename(),
+		ContentType: a.GetContentType(),
+		SizeBytes:   a.GetSizeBytes(),
+		Chunks:      a.GetChunks(),
+	}
+}
+
+// toPBAttachment converts a domain attachment into its protobuf form, returning nil when absent.
+func toPBAttachment(a *domain.Attachment) *database.Attachment {
+	if a == nil {
+		return nil
+	}
+	return &database.Attachment{
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		SizeBytes:   a.SizeBytes,
+		Chunks:      a.Chunks,
+	}
+}
+
 // formatTime formats a *time.Time as RFC3339, returning empty string for nil.
 func formatTime(t *time.Time) string {
 	if t == nil {
@@ -106,11 +154,21 @@ func (s *GRPCServer) Select(ctx context.Context, request *database.SelectRequest
 	}
 
 	response := &database.SelectResponse{
-		Content:      message.Content,
-		Passphrase:   message.Passphrase,
-		ViewCount:    int32(message.ViewCount),
-		MaxViewCount: int32(message.MaxViewCount),
-		ExpiresAt:    formatTime(message.ExpiresAt),
+		Content:                  message.Content,
+		Passphrase:               message.Passphrase,
+		ViewCount:                int32(message.ViewCount),
+		MaxViewCount:             int32(message.MaxViewCount),
+		ExpiresAt:                formatTime(message.ExpiresAt),
+		ClientEncrypted:          message.ClientEncrypted,
+		Attachment:               toPBAttachment(message.Attachment),
+		RevocationTokenHash:      message.RevocationTokenHash,
+		SenderEmail:              message.SenderEmail,
+		Id:                       message.ID,
+		WebhookUrl:               message.WebhookURL,
+		WebhookSecret:            message.WebhookSecret,
+		PublicKeyEncrypted:       message.PublicKeyEncrypted,
+		PassphraseKeySalt:        message.PassphraseKeySalt,
+		FailedPassphraseAttempts: int32(message.FailedPassphraseAttempts),
 	}
 
 	logging.Info().
@@ -135,11 +193,21 @@ func (s *GRPCServer) GetMessage(
 	}
 
 	response := &database.SelectResponse{
-		Content:      message.Content,
-		Passphrase:   message.Passphrase,
-		ViewCount:    int32(message.ViewCount),
-		MaxViewCount: int32(message.MaxViewCount),
-		ExpiresAt:    formatTime(message.ExpiresAt),
+		Content:                  message.Content,
+		Passphrase:               message.Passphrase,
+		ViewCount:                int32(message.ViewCount),
+		MaxViewCount:             int32(message.MaxViewCount),
+		ExpiresAt:                formatTime(message.ExpiresAt),
+		ClientEncrypted:          message.ClientEncrypted,
+		Attachment:               toPBAttachment(message.Attachment),
+		RevocationTokenHash:      message.RevocationTokenHash,
+		SenderEmail:              message.SenderEmail,
+		Id:                       message.ID,
+		WebhookUrl:               message.WebhookURL,
+		WebhookSecret:            message.WebhookSecret,
+		PublicKeyEncrypted:       message.PublicKeyEncrypted,
+		PassphraseKeySalt:        message.PassphraseKeySalt,
+		FailedPassphraseAttempts: int32(message.FailedPassphraseAttempts),
 	}
 
 	logging.Info().
@@ -149,6 +217,93 @@ func (s *GRPCServer) GetMessage(
 	return response, nil
 }
 
+// GetAttachment handles gRPC requests for a message's encrypted attachment without incrementing view count
+func (s *GRPCServer) GetAttachment(
+	ctx context.Context,
+	request *database.SelectRequest,
+) (*database.Attachment, error) {
+	attachment, err := s.storageService.GetAttachment(ctx, request.GetUuid())
+	if err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to get attachment via gRPC")
+		return nil, err
+	}
+
+	logging.Info().
+		Str("uuid", request.GetUuid()).
+		Int("chunkCount", len(attachment.Chunks)).
+		Msg("Attachment retrieved successfully via gRPC")
+	return toPBAttachment(attachment), nil
+}
+
+// DeleteMessage handles gRPC requests to permanently remove a message
+func (s *GRPCServer) DeleteMessage(ctx context.Context, request *database.SelectRequest) (*emptypb.Empty, error) {
+	if err := s.storageService.DeleteMessage(ctx, request.GetUuid()); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to delete message via gRPC")
+		return nil, err
+	}
+
+	logging.Info().Str("uuid", request.GetUuid()).Msg("Message deleted successfully via gRPC")
+	return &emptypb.Empty{}, nil
+}
+
+// ReservePassphraseAttempt handles gRPC requests to count a passphrase attempt against a message
+func (s *GRPCServer) ReservePassphraseAttempt(
+	ctx context.Context,
+	request *database.SelectRequest,
+) (*database.FailedPassphraseAttemptResponse, error) {
+	failedAttempts, err := s.storageService.ReservePassphraseAttempt(ctx, request.GetUuid())
+	if err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to reserve passphrase attempt via gRPC")
+		return nil, err
+	}
+
+	logging.Info().
+		Str("uuid", request.GetUuid()).
+		Int("failedAttempts", failedAttempts).
+		Msg("Passphrase attempt reserved successfully via gRPC")
+	return &database.FailedPassphraseAttemptResponse{FailedAttempts: int32(failedAttempts)}, nil
+}
+
+// ReleasePassphraseAttempt handles gRPC requests to take back a reserved passphrase attempt
+func (s *GRPCServer) ReleasePassphraseAttempt(
+	ctx context.Context,
+	request *database.SelectRequest,
+) (*emptypb.Empty, error) {
+	if err := s.storageService.ReleasePassphraseAttempt(ctx, request.GetUuid()); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to release passphrase attempt via gRPC")
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RecordPassphraseFailure handles gRPC requests to audit a wrong passphrase
+func (s *GRPCServer) RecordPassphraseFailure(
+	ctx context.Context,
+	request *database.SelectRequest,
+) (*emptypb.Empty, error) {
+	if err := s.storageService.RecordPassphraseFailure(ctx, request.GetUuid()); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to record passphrase failure via gRPC")
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// UpdatePassphraseHash handles gRPC requests to replace a message's stored passphrase hash
+func (s *GRPCServer) UpdatePassphraseHash(
+	ctx context.Context,
+	request *database.UpdatePassphraseHashRequest,
+) (*emptypb.Empty, error) {
+	if err := s.storageService.UpdatePassphraseHash(ctx, request.GetUuid(), request.GetPassphrase()); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to update passphrase hash via gRPC")
+		return nil, err
+	}
+
+	logging.Info().Str("uuid", request.GetUuid()).Msg("Passphrase hash updated successfully via gRPC")
+	return &emptypb.Empty{}, nil
+}
+
 // GetUnviewedMessagesForReminders handles gRPC requests for unviewed messages eligible for reminders
 func (s *GRPCServer) GetUnviewedMessagesForReminders(
 	ctx context.Context,
@@ -230,22 +385,130 @@ func (s *GRPCServer) GetReminderHistory(
 	return &database.GetReminderHistoryResponse{Entries: entries}, nil
 }
 
-// runExpiredMessageCleanup runs a background loop that periodically deletes expired messages.
-// It exits when ctx is cancelled (i.e., when the server shuts down).
-func (s *GRPCServer) runExpiredMessageCleanup(ctx context.Context) {
-	ticker := time.NewTicker(1 * time.Hour)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			if err := s.storageService.CleanupExpiredMessages(context.Background()); err != nil {
-				logging.Error().Err(err).Msg("Failed to cleanup expired messages")
-			}
+// InsertSecretRequest handles gRPC requests to store a new secret request
+func (s *GRPCServer) InsertSecretRequest(ctx context.Context, request *database.SecretRequest) (*emptypb.Empty, error) {
+	expiresAt, err := parseExpiresAt(request.GetExpiresAt())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid expires_at: %v", err)
+	}
+	if expiresAt != nil {
+		remaining := time.Until(*expiresAt)
+		if remaining <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		if remaining > maxExpirationDuration {
+			return nil, status.Errorf(codes.InvalidArgument, "expires_at exceeds maximum allowed expiration")
 		}
 	}
+
+	secretRequest := &domain.SecretRequest{
+		UniqueID:       request.GetUuid(),
+		RequesterName:  request.GetRequesterName(),
+		RequesterEmail: request.GetRequesterEmail(),
+		Description:    request.GetDescription(),
+		ExpiresAt:      expiresAt,
+	}
+
+	if err := s.storageService.StoreSecretRequest(ctx, secretRequest); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to insert secret request via gRPC")
+		return nil, err
+	}
+
+	logging.Info().
+		Str("uuid", request.GetUuid()).
+		Str("requesterEmail", validation.SanitizeEmailForLogging(request.GetRequesterEmail())).
+		Msg("Secret request inserted successfully via gRPC")
+	return &emptypb.Empty{}, nil
+}
+
+// GetSecretRequest handles gRPC requests for a secret request. A missing request is reported
+// as codes.NotFound so clients can tell it apart from storage failures.
+func (s *GRPCServer) GetSecretRequest(ctx context.Context, request *database.SelectRequest) (*database.SecretRequest, error) {
+	secretRequest, err := s.storageService.GetSecretRequest(ctx, request.GetUuid())
+	if err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to get secret request via gRPC")
+		if errors.Is(err, domain.ErrSecretRequestNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+
+	logging.Info().Str("uuid", request.GetUuid()).Msg("Secret request retrieved successfully via gRPC")
+	return &database.SecretRequest{
+		Uuid:           secretRequest.UniqueID,
+		RequesterName:  secretRequest.RequesterName,
+		RequesterEmail: secretRequest.RequesterEmail,
+		Description:    secretRequest.Description,
+		CreatedAt:      formatTime(&secretRequest.CreatedAt),
+		ExpiresAt:      formatTime(secretRequest.ExpiresAt),
+		FulfilledAt:    formatTime(secretRequest.FulfilledAt),
+		MessageUuid:    secretRequest.MessageUniqueID,
+	}, nil
+}
+
+// FulfillSecretRequest handles gRPC requests to mark a secret request fulfilled. A request
+// that is already fulfilled or expired is reported as codes.FailedPrecondition.
+func (s *GRPCServer) FulfillSecretRequest(
+	ctx context.Context,
+	request *database.FulfillSecretRequestRequest,
+) (*emptypb.Empty, error) {
+	if err := s.storageService.FulfillSecretRequest(ctx, request.GetUuid(), request.GetMessageUuid()); err != nil {
+		logging.Error().Err(err).Str("uuid", request.GetUuid()).Msg("Failed to fulfill secret request via gRPC")
+		if errors.Is(err, domain.ErrSecretRequestUnavailable) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, err
+	}
+
+	logging.Info().Str("uuid", request.GetUuid()).Msg("Secret request fulfilled successfully via gRPC")
+	return &emptypb.Empty{}, nil
+}
+
+// CleanupExpiredMessages handles gRPC requests to purge expired messages and secret requests now,
+// e.g. from a scheduled job instead of waiting for the built-in sweeper
+func (s *GRPCServer) CleanupExpiredMessages(
+	ctx context.Context,
+	request *database.CleanupExpiredMessagesRequest,
+) (*database.CleanupExpiredMessagesResponse, error) {
+	if request.GetBatchSize() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "batch_size cannot be negative")
+	}
+
+	result, err := s.storageService.CleanupExpiredMessages(ctx, int(request.GetBatchSize()))
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to cleanup expired messages via gRPC")
+		return nil, err
+	}
+
+	logging.Info().Int("messagesPurged", result.MessagesPurged).Msg("Expired messages cleaned up via gRPC")
+	return &database.CleanupExpiredMessagesResponse{
+		MessagesPurged:       int32(result.MessagesPurged),
+		SecretRequestsPurged: int32(result.SecretRequestsPurged),
+	}, nil
+}
+
+// auditClientInterceptor attributes the call to the client named in its metadata, for the audit log
+func auditClientInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = domain.WithAuditClient(ctx, domain.AuditClient{
+			IP:        firstMetadataValue(md, clientIPMetadataKey),
+			UserAgent: firstMetadataValue(md, clientUserAgentMetadataKey),
+		})
+	}
+	return handler(ctx, req)
+}
+
+// firstMetadataValue returns the first value of a metadata key, or ""
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
 }
 
 // Start starts the gRPC server
@@ -256,15 +519,14 @@ func (s *GRPCServer) Start() error {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxMessageBytes),
+		grpc.MaxSendMsgSize(maxMessageBytes),
+		grpc.UnaryInterceptor(auditClientInterceptor),
+	)
 	database.RegisterDbServiceServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
-	// Run expired message cleanup in the background; cancel it when Start returns.
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go s.runExpiredMessageCleanup(ctx)
-
 	logging.Info().Str("address", s.address).Msg("Starting gRPC storage server")
 
 	if err := grpcServer.Serve(lis); err != nil {
@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
 	database "github.com/Anthony-Bible/password-exchange/app/pkg/pb/database"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -109,3 +112,35 @@ func TestParseExpiresAt_ValidRFC3339(t *testing.T) {
 		t.Errorf("expected %v, got %v", expected, *result)
 	}
 }
+
+func TestCleanupExpiredMessages_NegativeBatchSize(t *testing.T) {
+	s := newServerForTest()
+
+	_, err := s.CleanupExpiredMessages(context.Background(), &database.CleanupExpiredMessagesRequest{BatchSize: -1})
+
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("expected gRPC status error, got %v", err)
+	}
+	if st.Code() != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", st.Code())
+	}
+}
+
+func TestAuditClientInterceptor(t *testing.T) {
+	md := metadata.Pairs(clientIPMetadataKey, "203.0.113.7", clientUserAgentMetadataKey, "curl/8.0")
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+
+	var got domain.AuditClient
+	_, err := auditClientInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		got = domain.AuditClientFromContext(ctx)
+		return nil, nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != (domain.AuditClient{IP: "203.0.113.7", UserAgent: "curl/8.0"}) {
+		t.Errorf("unexpected audit client: %+v", got)
+	}
+}
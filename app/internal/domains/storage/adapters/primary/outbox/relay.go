@@ -0,0 +1,58 @@
+// Package outbox runs the relay that publishes notifications written to the storage outbox.
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// DefaultInterval is how often the relay looks for pending notifications unless configured otherwise
+const DefaultInterval = 5 * time.Second
+
+// Relay periodically publishes the notifications queued in the outbox, so a notification
+// stored while the queue was down goes out once it is back
+type Relay struct {
+	service   primary.StorageServicePort
+	interval  time.Duration
+	batchSize int
+}
+
+// NewRelay creates a relay. A zero or negative interval uses DefaultInterval; a zero or negative
+// batch size uses the storage service's default.
+func NewRelay(service primary.StorageServicePort, interval time.Duration, batchSize int) *Relay {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Relay{
+		service:   service,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run relays once immediately and then every interval until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	logging.Info().Dur("interval", r.interval).Int("batchSize", r.batchSize).Msg("Starting notification outbox relay")
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.relay(ctx)
+		select {
+		case <-ctx.Done():
+			logging.Info().Msg("Stopping notification outbox relay")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relay runs one pass; failures are logged and retried on the next tick
+func (r *Relay) relay(ctx context.Context) {
+	if _, err := r.service.RelayOutbox(ctx, r.batchSize); err != nil && ctx.Err() == nil {
+		logging.Error().Err(err).Msg("Failed to relay notification outbox")
+	}
+}
@@ -0,0 +1,58 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/ports/primary"
+)
+
+// stubService records relay calls; only RelayOutbox is implemented
+type stubService struct {
+	primary.StorageServicePort
+
+	mu         sync.Mutex
+	batchSizes []int
+}
+
+func (s *stubService) RelayOutbox(ctx context.Context, batchSize int) (domain.OutboxRelayResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batchSizes = append(s.batchSizes, batchSize)
+	return domain.OutboxRelayResult{}, nil
+}
+
+func (s *stubService) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.batchSizes)
+}
+
+func TestRelay_RunsUntilCancelled(t *testing.T) {
+	stub := &stubService{}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewRelay(stub, 10*time.Millisecond, 50).Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return stub.calls() >= 3 }, time.Second, 5*time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after cancel")
+	}
+	assert.Equal(t, 50, stub.batchSizes[0])
+}
+
+func TestNewRelay_DefaultInterval(t *testing.T) {
+	assert.Equal(t, DefaultInterval, NewRelay(&stubService{}, 0, 0).interval)
+}
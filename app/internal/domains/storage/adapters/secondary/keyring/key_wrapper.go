@@ -0,0 +1,135 @@
+// Package keyring wraps data-encryption keys under master keys read from a local keyring file.
+package keyring
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// ErrInvalidKeyring is returned when a keyring file or its keys are malformed
+var ErrInvalidKeyring = errors.New("invalid keyring")
+
+// masterKeyLength is the size of an AES-256 master key
+const masterKeyLength = 32
+
+// File is the keyring file format. Keys are base64-encoded 32-byte AES keys; Current names the
+// one new data keys are wrapped under. Retired keys stay listed until every message wrapped
+// under them has been rotated or has expired.
+//
+//	{"current": "2026-10", "keys": {"2026-10": "<base64>", "2026-01": "<base64>"}}
+type File struct {
+	Current string            `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// KeyWrapper implements the domain.KeyWrapper port with AES-256-GCM under keyring master keys
+type KeyWrapper struct {
+	current string
+	keys    map[string]cipher.AEAD
+}
+
+// Load reads a keyring file and creates a KeyWrapper from it
+func Load(path string) (*KeyWrapper, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyring, err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		logging.Warn().Str("path", path).Str("mode", info.Mode().Perm().String()).Msg("Keyring file is readable by other users")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyring, err)
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeyring, err)
+	}
+
+	keys := make(map[string][]byte, len(file.Keys))
+	for keyID, encoded := range file.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidKeyring, keyID, err)
+		}
+		keys[keyID] = key
+	}
+	return NewKeyWrapper(file.Current, keys)
+}
+
+// NewKeyWrapper creates a KeyWrapper from raw master keys, wrapping new data keys under current
+func NewKeyWrapper(current string, keys map[string][]byte) (*KeyWrapper, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: current key %q is not in the keyring", ErrInvalidKeyring, current)
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for keyID, key := range keys {
+		if keyID == "" || len(keyID) > 255 {
+			return nil, fmt.Errorf("%w: key IDs must be 1-255 bytes", ErrInvalidKeyring)
+		}
+		if len(key) != masterKeyLength {
+			return nil, fmt.Errorf("%w: key %q must be %d bytes, got %d", ErrInvalidKeyring, keyID, masterKeyLength, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidKeyring, keyID, err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %q: %v", ErrInvalidKeyring, keyID, err)
+		}
+		aeads[keyID] = gcm
+	}
+
+	logging.Info().Str("currentKeyID", current).Int("keyCount", len(aeads)).Msg("Loaded at-rest keyring")
+	return &KeyWrapper{
+		current: current,
+		keys:    aeads,
+	}, nil
+}
+
+// CurrentKeyID names the master key that WrapKey uses
+func (k *KeyWrapper) CurrentKeyID() string {
+	return k.current
+}
+
+// WrapKey encrypts a data key under the current master key, returning nonce || ciphertext.
+// The key ID is authenticated so a wrapped key cannot be attributed to another master key.
+func (k *KeyWrapper) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	gcm := k.keys[k.current]
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrKeyWrapping, err)
+	}
+	return gcm.Seal(nonce, nonce, dataKey, []byte(k.current)), nil
+}
+
+// UnwrapKey decrypts a data key wrapped under the named master key
+func (k *KeyWrapper) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	gcm, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnknownMasterKey, keyID)
+	}
+	if len(wrappedKey) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("%w: wrapped key truncated", domain.ErrKeyWrapping)
+	}
+
+	nonce, ciphertext := wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():]
+	dataKey, err := gcm.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrKeyWrapping, err)
+	}
+	return dataKey, nil
+}
@@ -0,0 +1,76 @@
+package keyring
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func writeKeyring(t *testing.T, file File) string {
+	t.Helper()
+	data, err := json.Marshal(file)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestKeyWrapper_WrapAndUnwrap(t *testing.T) {
+	ctx := context.Background()
+	oldKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	newKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	before, err := Load(writeKeyring(t, File{Current: "2026-01", Keys: map[string]string{"2026-01": oldKey}}))
+	require.NoError(t, err)
+	dataKey := bytes.Repeat([]byte{9}, 32)
+	wrapped, err := before.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+
+	// After rotation the old key still unwraps what it wrapped
+	after, err := Load(writeKeyring(t, File{Current: "2026-10", Keys: map[string]string{"2026-01": oldKey, "2026-10": newKey}}))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10", after.CurrentKeyID())
+
+	unwrapped, err := after.UnwrapKey(ctx, "2026-01", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	// The key ID is authenticated
+	_, err = after.UnwrapKey(ctx, "2026-10", wrapped)
+	assert.ErrorIs(t, err, domain.ErrKeyWrapping)
+
+	_, err = after.UnwrapKey(ctx, "2025-06", wrapped)
+	assert.ErrorIs(t, err, domain.ErrUnknownMasterKey)
+}
+
+func TestLoad_InvalidKeyring(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+
+	tests := []struct {
+		name string
+		file File
+	}{
+		{"current key missing", File{Current: "2026-10", Keys: map[string]string{"2026-01": validKey}}},
+		{"short key", File{Current: "2026-10", Keys: map[string]string{"2026-10": base64.StdEncoding.EncodeToString([]byte("short"))}}},
+		{"not base64", File{Current: "2026-10", Keys: map[string]string{"2026-10": "!!!"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeKeyring(t, tt.file))
+			assert.ErrorIs(t, err, ErrInvalidKeyring)
+		})
+	}
+
+	_, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, ErrInvalidKeyring)
+}
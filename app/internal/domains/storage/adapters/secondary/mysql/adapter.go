@@ -16,13 +16,25 @@ import (
 const defaultMessageTTL = 7 * 24 * time.Hour
 
 // selectMessageQuery is the standard SELECT statement used to retrieve a message row.
-const selectMessageQuery = "SELECT message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at FROM messages WHERE uniqueid = ?"
+// Attachment metadata is joined in so callers can tell a message carries a file without loading its chunks.
+const selectMessageQuery = "SELECT m.messageid, m.message, m.uniqueid, m.other_lastname, m.other_email, m.view_count, m.max_view_count, m.expires_at, m.client_encrypted, m.revocation_token_hash, m.email, m.webhook_url, m.webhook_secret, m.public_key_encrypted, m.passphrase_key_salt, m.failed_passphrase_attempts, a.filename, a.content_type, a.size_bytes FROM messages m LEFT JOIN message_attachments a ON a.message_id = m.messageid WHERE m.uniqueid = ?"
 
-// scanMessageRow scans a single message row into a domain.Message, handling the nullable expires_at field.
+// selectAttachmentQuery retrieves the attachment metadata row for a message.
+const selectAttachmentQuery = "SELECT a.id, a.filename, a.content_type, a.size_bytes FROM message_attachments a JOIN messages m ON m.messageid = a.message_id WHERE m.uniqueid = ?"
+
+// selectSecretRequestQuery retrieves a secret request row by its unique ID.
+const selectSecretRequestQuery = "SELECT id, uniqueid, requester_name, requester_email, description, created_at, expires_at, fulfilled_at, message_uniqueid FROM secret_requests WHERE uniqueid = ?"
+
+// scanMessageRow scans a single message row into a domain.Message, handling the nullable expires_at
+// and attachment fields.
 func scanMessageRow(row *sql.Row) (*domain.Message, error) {
 	var message domain.Message
 	var expiresAt sql.NullTime
+	var revocationTokenHash, senderEmail, webhookURL, webhookSecret, passphraseKeySalt sql.NullString
+	var attachmentFilename, attachmentContentType sql.NullString
+	var attachmentSize sql.NullInt64
 	err := row.Scan(
+		&message.ID,
 		&message.Content,
 		&message.UniqueID,
 		&message.Passphrase,
@@ -30,6 +42,17 @@ func scanMessageRow(row *sql.Row) (*domain.Message, error) {
 		&message.ViewCount,
 		&message.MaxViewCount,
 		&expiresAt,
+		&message.ClientEncrypted,
+		&revocationTokenHash,
+		&senderEmail,
+		&webhookURL,
+		&webhookSecret,
+		&message.PublicKeyEncrypted,
+		&passphraseKeySalt,
+		&message.FailedPassphraseAttempts,
+		&attachmentFilename,
+		&attachmentContentType,
+		&attachmentSize,
 	)
 	if err != nil {
 		return nil, err
@@ -37,9 +60,26 @@ func scanMessageRow(row *sql.Row) (*domain.Message, error) {
 	if expiresAt.Valid {
 		message.ExpiresAt = &expiresAt.Time
 	}
+	message.RevocationTokenHash = revocationTokenHash.String
+	message.SenderEmail = senderEmail.String
+	message.WebhookURL = webhookURL.String
+	message.WebhookSecret = webhookSecret.String
+	message.PassphraseKeySalt = passphraseKeySalt.String
+	if attachmentSize.Valid {
+		message.Attachment = &domain.Attachment{
+			Filename:    attachmentFilename.String,
+			ContentType: attachmentContentType.String,
+			SizeBytes:   attachmentSize.Int64,
+		}
+	}
 	return &message, nil
 }
 
+// nullableString stores empty optional values as NULL
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
 // MySQLAdapter implements the MessageRepository interface for MySQL
 type MySQLAdapter struct {
 	db     *sql.DB
@@ -90,7 +130,10 @@ func (m *MySQLAdapter) InsertMessage(message *domain.Message) error {
 	} else {
 		expiresAt = time.Now().Add(defaultMessageTTL)
 	}
-	query := "INSERT INTO messages (message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at) VALUES (?, ?, ?, ?, 0, ?, ?)"
+	query := "INSERT INTO messages (message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at, client_encrypted, revocation_token_hash, email, webhook_url, webhook_secret, public_key_encrypted, passphrase_key_salt) VALUES (?, ?, ?, ?, 0, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	if message.Attachment != nil || len(message.Notification) > 0 {
+		return m.insertMessageInTransaction(query, message, expiresAt)
+	}
 	_, err := m.db.Exec(
 		query,
 		message.Content,
@@ -99,6 +142,13 @@ func (m *MySQLAdapter) InsertMessage(message *domain.Message) error {
 		message.RecipientEmail,
 		message.MaxViewCount,
 		expiresAt,
+		message.ClientEncrypted,
+		nullableString(message.RevocationTokenHash),
+		nullableString(message.SenderEmail),
+		nullableString(message.WebhookURL),
+		nullableString(message.WebhookSecret),
+		message.PublicKeyEncrypted,
+		nullableString(message.PassphraseKeySalt),
 	)
 	if err != nil {
 		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert message")
@@ -113,6 +163,104 @@ func (m *MySQLAdapter) InsertMessage(message *domain.Message) error {
 	return nil
 }
 
+// insertMessageInTransaction stores a message with its attachment chunks and outbox notification
+// in a single transaction, so a message is never visible with a partially written file and its
+// notification is queued exactly when the message is stored.
+func (m *MySQLAdapter) insertMessageInTransaction(query string, message *domain.Message, expiresAt time.Time) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to begin transaction")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer tx.Rollback() // Will be ignored if transaction is committed
+
+	result, err := tx.Exec(
+		query,
+		message.Content,
+		message.UniqueID,
+		message.Passphrase,
+		message.RecipientEmail,
+		message.MaxViewCount,
+		expiresAt,
+		message.ClientEncrypted,
+		nullableString(message.RevocationTokenHash),
+		nullableString(message.SenderEmail),
+		nullableString(message.WebhookURL),
+		nullableString(message.WebhookSecret),
+		message.PublicKeyEncrypted,
+		nullableString(message.PassphraseKeySalt),
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	messageID, err := result.LastInsertId()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to get inserted message ID")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	if attachment := message.Attachment; attachment != nil {
+		result, err = tx.Exec(
+			"INSERT INTO message_attachments (message_id, filename, content_type, size_bytes) VALUES (?, ?, ?, ?)",
+			messageID,
+			attachment.Filename,
+			attachment.ContentType,
+			attachment.SizeBytes,
+		)
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert attachment")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		attachmentID, err := result.LastInsertId()
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to get inserted attachment ID")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+
+		for index, chunk := range attachment.Chunks {
+			_, err = tx.Exec(
+				"INSERT INTO message_attachment_chunks (attachment_id, chunk_index, data) VALUES (?, ?, ?)",
+				attachmentID,
+				index,
+				chunk,
+			)
+			if err != nil {
+				logging.Error().Err(err).Str("uniqueID", message.UniqueID).Int("chunkIndex", index).Msg("Failed to insert attachment chunk")
+				return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+			}
+		}
+	}
+
+	if len(message.Notification) > 0 {
+		if err = insertOutboxEntry(tx, messageID, message.Notification); err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to queue notification")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to commit transaction")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logInsertedMessage(message)
+	return nil
+}
+
+// logInsertedMessage logs a message stored in a transaction, with its attachment size if any
+func logInsertedMessage(message *domain.Message) {
+	event := logging.Info().
+		Str("uniqueID", message.UniqueID).
+		Int("maxViewCount", message.MaxViewCount).
+		Bool("notificationQueued", len(message.Notification) > 0).
+		Str("recipientEmail", validation.SanitizeEmailForLogging(message.RecipientEmail))
+	if message.Attachment != nil {
+		event = event.Int64("attachmentBytes", message.Attachment.SizeBytes).Int("chunkCount", len(message.Attachment.Chunks))
+	}
+	event.Msg("Message stored successfully")
+}
+
 // SelectMessageByUniqueID retrieves a message by its unique identifier
 func (m *MySQLAdapter) SelectMessageByUniqueID(uniqueID string) (*domain.Message, error) {
 	if m.db == nil {
@@ -229,26 +377,297 @@ func (m *MySQLAdapter) IncrementViewCountAndGet(uniqueID string) (*domain.Messag
 	return message, nil
 }
 
-// DeleteExpiredMessages removes messages that have exceeded their TTL
-func (m *MySQLAdapter) DeleteExpiredMessages() error {
+// GetAttachment retrieves a message's attachment and all of its chunks without incrementing the view count
+func (m *MySQLAdapter) GetAttachment(uniqueID string) (*domain.Attachment, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	var attachmentID int64
+	var attachment domain.Attachment
+	err := m.db.QueryRow(selectAttachmentQuery, uniqueID).Scan(
+		&attachmentID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Attachment not found")
+			return nil, domain.ErrAttachmentNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select attachment")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rows, err := m.db.Query(
+		"SELECT data FROM message_attachment_chunks WHERE attachment_id = ? ORDER BY chunk_index",
+		attachmentID,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to query attachment chunks")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var chunk []byte
+		if err := rows.Scan(&chunk); err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to scan attachment chunk")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		attachment.Chunks = append(attachment.Chunks, chunk)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Error iterating over attachment chunks")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("chunkCount", len(attachment.Chunks)).Msg("Attachment retrieved successfully")
+	return &attachment, nil
+}
+
+// DeleteMessage permanently removes a message; its attachment and reminder log are removed by cascade
+func (m *MySQLAdapter) DeleteMessage(uniqueID string) error {
 	if m.db == nil {
 		if err := m.Connect(); err != nil {
 			return err
 		}
 	}
 
-	query := "DELETE FROM messages WHERE expires_at < NOW()"
-	result, err := m.db.Exec(query)
+	result, err := m.db.Exec("DELETE FROM messages WHERE uniqueid = ?", uniqueID)
 	if err != nil {
-		logging.Error().Err(err).Msg("Failed to delete expired messages")
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete message")
 		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	logging.Info().Int64("rowsDeleted", rowsAffected).Msg("Expired messages cleaned up")
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for deletion")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message deleted successfully")
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (m *MySQLAdapter) ReservePassphraseAttempt(uniqueID string) (int, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to begin transaction")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer tx.Rollback() // Will be ignored if transaction is committed
+
+	result, err := tx.Exec("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts + 1 WHERE uniqueid = ?", uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to reserve passphrase attempt")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase attempt")
+		return 0, domain.ErrMessageNotFound
+	}
+
+	var failedAttempts int
+	if err := tx.QueryRow("SELECT failed_passphrase_attempts FROM messages WHERE uniqueid = ?", uniqueID).Scan(&failedAttempts); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to read failed passphrase attempts")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to commit transaction")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Int("failedAttempts", failedAttempts).Msg("Passphrase attempt reserved")
+	return failedAttempts, nil
+}
+
+// ReleasePassphraseAttempt takes back an attempt counted by ReservePassphraseAttempt once the
+// passphrase turned out right. The count never drops below zero.
+func (m *MySQLAdapter) ReleasePassphraseAttempt(uniqueID string) error {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return err
+		}
+	}
+
+	_, err := m.db.Exec("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts - 1 WHERE uniqueid = ? AND failed_passphrase_attempts > 0", uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to release passphrase attempt")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase attempt released")
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash
+func (m *MySQLAdapter) UpdatePassphraseHash(uniqueID, hashedPassphrase string) error {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := m.db.Exec("UPDATE messages SET other_lastname = ? WHERE uniqueid = ?", hashedPassphrase, uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update passphrase hash")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase hash update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase hash updated")
 	return nil
 }
 
+// SelectMessageContents returns up to limit messages with an ID above afterID, in ID order.
+// Only the ID, unique ID and content are filled in.
+func (m *MySQLAdapter) SelectMessageContents(afterID int64, limit int) ([]*domain.Message, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := m.db.Query("SELECT messageid, uniqueid, message FROM messages WHERE messageid > ? ORDER BY messageid LIMIT ?", afterID, limit)
+	if err != nil {
+		logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to select message contents")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		if err := rows.Scan(&message.ID, &message.UniqueID, &message.Content); err != nil {
+			logging.Error().Err(err).Msg("Failed to scan message content")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return messages, nil
+}
+
+// UpdateMessageContent replaces a message's content if it still equals currentContent,
+// returning ErrMessageNotFound when the message is gone or its content has changed
+func (m *MySQLAdapter) UpdateMessageContent(uniqueID, currentContent, newContent string) error {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := m.db.Exec("UPDATE messages SET message = ? WHERE uniqueid = ? AND message = ?", newContent, uniqueID, currentContent)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update message content")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found or changed for content update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Message content updated")
+	return nil
+}
+
+// DeleteExpiredMessages removes messages that have exceeded their TTL
+func (m *MySQLAdapter) DeleteExpiredMessages(limit int) (int, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := m.db.Exec("DELETE FROM messages WHERE expires_at < NOW() LIMIT ?", limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to delete expired messages")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to get rows affected")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	logging.Info().Int64("rowsDeleted", rowsAffected).Msg("Expired messages cleaned up")
+	return int(rowsAffected), nil
+}
+
+// SelectExpiredMessages returns the messages DeleteExpiredMessages is about to remove,
+// with just the fields needed to report their expiry
+func (m *MySQLAdapter) SelectExpiredMessages(limit int) ([]*domain.Message, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := m.db.Query("SELECT uniqueid, view_count, max_view_count, webhook_url, webhook_secret FROM messages WHERE expires_at < NOW() LIMIT ?", limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to select expired messages")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		var webhookURL, webhookSecret sql.NullString
+		if err := rows.Scan(&message.UniqueID, &message.ViewCount, &message.MaxViewCount, &webhookURL, &webhookSecret); err != nil {
+			logging.Error().Err(err).Msg("Failed to scan expired message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		message.WebhookURL = webhookURL.String
+		message.WebhookSecret = webhookSecret.String
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return messages, nil
+}
+
 // GetUnviewedMessagesForReminders retrieves messages that are unviewed and eligible for reminder emails
 func (m *MySQLAdapter) GetUnviewedMessagesForReminders(
 	olderThanHours, maxReminders, reminderIntervalHours int,
@@ -367,6 +786,141 @@ func (m *MySQLAdapter) GetReminderHistory(messageID int) ([]*domain.ReminderLogE
 	return history, nil
 }
 
+// InsertSecretRequest stores a new secret request
+func (m *MySQLAdapter) InsertSecretRequest(request *domain.SecretRequest) error {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return err
+		}
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	} else {
+		expiresAt = time.Now().Add(defaultMessageTTL)
+	}
+
+	_, err := m.db.Exec(
+		"INSERT INTO secret_requests (uniqueid, requester_name, requester_email, description, expires_at) VALUES (?, ?, ?, ?, ?)",
+		request.UniqueID,
+		request.RequesterName,
+		request.RequesterEmail,
+		nullableString(request.Description),
+		expiresAt,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", request.UniqueID).Msg("Failed to insert secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Str("uniqueID", request.UniqueID).
+		Str("requesterEmail", validation.SanitizeEmailForLogging(request.RequesterEmail)).
+		Msg("Secret request stored successfully")
+	return nil
+}
+
+// GetSecretRequest retrieves a secret request by its unique identifier
+func (m *MySQLAdapter) GetSecretRequest(uniqueID string) (*domain.SecretRequest, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	var request domain.SecretRequest
+	var description, messageUniqueID sql.NullString
+	var createdAt, expiresAt, fulfilledAt sql.NullTime
+	err := m.db.QueryRow(selectSecretRequestQuery, uniqueID).Scan(
+		&request.ID,
+		&request.UniqueID,
+		&request.RequesterName,
+		&request.RequesterEmail,
+		&description,
+		&createdAt,
+		&expiresAt,
+		&fulfilledAt,
+		&messageUniqueID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Secret request not found")
+			return nil, domain.ErrSecretRequestNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select secret request")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	request.Description = description.String
+	request.MessageUniqueID = messageUniqueID.String
+	request.CreatedAt = createdAt.Time
+	if expiresAt.Valid {
+		request.ExpiresAt = &expiresAt.Time
+	}
+	if fulfilledAt.Valid {
+		request.FulfilledAt = &fulfilledAt.Time
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Secret request retrieved successfully")
+	return &request, nil
+}
+
+// FulfillSecretRequest records the message that fulfilled a secret request. The update only
+// matches open, unexpired requests, so concurrent submissions cannot both fulfill it.
+func (m *MySQLAdapter) FulfillSecretRequest(uniqueID, messageUniqueID string) error {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := m.db.Exec(
+		"UPDATE secret_requests SET fulfilled_at = NOW(), message_uniqueid = ? WHERE uniqueid = ? AND fulfilled_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())",
+		messageUniqueID,
+		uniqueID,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to fulfill secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("No open secret request to fulfill")
+		return domain.ErrSecretRequestUnavailable
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Str("messageUniqueID", messageUniqueID).Msg("Secret request fulfilled successfully")
+	return nil
+}
+
+// DeleteExpiredSecretRequests removes secret requests that have exceeded their TTL
+func (m *MySQLAdapter) DeleteExpiredSecretRequests() (int, error) {
+	if m.db == nil {
+		if err := m.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := m.db.Exec("DELETE FROM secret_requests WHERE expires_at < NOW()")
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to delete expired secret requests")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to get rows affected")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	logging.Info().Int64("rowsDeleted", rowsAffected).Msg("Expired secret requests cleaned up")
+	return int(rowsAffected), nil
+}
+
 // Close closes the database connection
 func (m *MySQLAdapter) Close() error {
 	if m.db != nil {
@@ -1,6 +1,9 @@
 package mysql
 
 import (
+	"database/sql"
+	"errors"
+	"regexp"
 	"testing"
 	"time"
 
@@ -8,6 +11,12 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 )
 
+// messageColumns are the columns returned by selectMessageQuery
+var messageColumns = []string{
+	"messageid", "message", "uniqueid", "other_lastname", "other_email", "view_count", "max_view_count",
+	"expires_at", "client_encrypted", "revocation_token_hash", "email", "webhook_url", "webhook_secret", "public_key_encrypted", "passphrase_key_salt", "failed_passphrase_attempts", "filename", "content_type", "size_bytes",
+}
+
 func TestMySQLAdapter_InsertMessage_WithRecipientEmail(t *testing.T) {
 	// Test that recipient email is properly stored in other_email field and expires_at is set
 	db, mock, err := sqlmock.New()
@@ -28,8 +37,8 @@ func TestMySQLAdapter_InsertMessage_WithRecipientEmail(t *testing.T) {
 	}
 
 	// Expected SQL should store recipient email in other_email field and include expires_at
-	mock.ExpectExec(`INSERT INTO messages \(message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at\) VALUES \(\?, \?, \?, \?, 0, \?, \?\)`).
-		WithArgs(message.Content, message.UniqueID, message.Passphrase, message.RecipientEmail, message.MaxViewCount, sqlmock.AnyArg()).
+	mock.ExpectExec(`INSERT INTO messages \(message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at, client_encrypted, revocation_token_hash, email, webhook_url, webhook_secret, public_key_encrypted, passphrase_key_salt\) VALUES \(\?, \?, \?, \?, 0, \?, \?, \?, \?, \?, \?, \?, \?, \?\)`).
+		WithArgs(message.Content, message.UniqueID, message.Passphrase, message.RecipientEmail, message.MaxViewCount, sqlmock.AnyArg(), false, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, false, sql.NullString{}).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Act
@@ -64,8 +73,8 @@ func TestMySQLAdapter_InsertMessage_WithCustomExpiresAt(t *testing.T) {
 	}
 
 	// The INSERT should use the exact customExpiry value, not AnyArg()
-	mock.ExpectExec(`INSERT INTO messages \(message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at\) VALUES \(\?, \?, \?, \?, 0, \?, \?\)`).
-		WithArgs(message.Content, message.UniqueID, message.Passphrase, message.RecipientEmail, message.MaxViewCount, customExpiry).
+	mock.ExpectExec(`INSERT INTO messages \(message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at, client_encrypted, revocation_token_hash, email, webhook_url, webhook_secret, public_key_encrypted, passphrase_key_salt\) VALUES \(\?, \?, \?, \?, 0, \?, \?, \?, \?, \?, \?, \?, \?, \?\)`).
+		WithArgs(message.Content, message.UniqueID, message.Passphrase, message.RecipientEmail, message.MaxViewCount, customExpiry, false, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, false, sql.NullString{}).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	// Act
@@ -92,10 +101,10 @@ func TestMySQLAdapter_SelectMessageByUniqueID_WithExpiresAt(t *testing.T) {
 
 	expectedExpiry := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
 
-	rows := sqlmock.NewRows([]string{"message", "uniqueid", "other_lastname", "other_email", "view_count", "max_view_count", "expires_at"}).
-		AddRow("encrypted-content", "test-uuid-123", "test-passphrase", "test@example.com", 0, 3, expectedExpiry)
+	rows := sqlmock.NewRows(messageColumns).
+		AddRow(42, "encrypted-content", "test-uuid-123", "test-passphrase", "test@example.com", 0, 3, expectedExpiry, false, nil, nil, nil, nil, false, nil, 0, nil, nil, nil)
 
-	mock.ExpectQuery(`SELECT message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at FROM messages WHERE uniqueid = \?`).
+	mock.ExpectQuery(regexp.QuoteMeta(selectMessageQuery)).
 		WithArgs("test-uuid-123").
 		WillReturnRows(rows)
 
@@ -106,6 +115,10 @@ func TestMySQLAdapter_SelectMessageByUniqueID_WithExpiresAt(t *testing.T) {
 		t.Errorf("SelectMessageByUniqueID() error = %v", err)
 	}
 
+	if message.ID != 42 {
+		t.Errorf("Expected ID 42, got %d", message.ID)
+	}
+
 	if message.ExpiresAt == nil {
 		t.Errorf("Expected ExpiresAt to be set, got nil")
 	} else if !message.ExpiresAt.Equal(expectedExpiry) {
@@ -129,10 +142,10 @@ func TestMySQLAdapter_GetMessage_WithExpiresAt(t *testing.T) {
 
 	expectedExpiry := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
 
-	rows := sqlmock.NewRows([]string{"message", "uniqueid", "other_lastname", "other_email", "view_count", "max_view_count", "expires_at"}).
-		AddRow("encrypted-content", "test-uuid-123", "", "test@example.com", 0, 5, expectedExpiry)
+	rows := sqlmock.NewRows(messageColumns).
+		AddRow(42, "encrypted-content", "test-uuid-123", "", "test@example.com", 0, 5, expectedExpiry, true, nil, nil, nil, nil, false, nil, 0, nil, nil, nil)
 
-	mock.ExpectQuery(`SELECT message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at FROM messages WHERE uniqueid = \?`).
+	mock.ExpectQuery(regexp.QuoteMeta(selectMessageQuery)).
 		WithArgs("test-uuid-123").
 		WillReturnRows(rows)
 
@@ -149,6 +162,10 @@ func TestMySQLAdapter_GetMessage_WithExpiresAt(t *testing.T) {
 		t.Errorf("Expected ExpiresAt %v, got %v", expectedExpiry, *message.ExpiresAt)
 	}
 
+	if !message.ClientEncrypted {
+		t.Errorf("Expected ClientEncrypted to be true")
+	}
+
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("SQL expectations were not met: %v", err)
 	}
@@ -164,15 +181,19 @@ func TestMySQLAdapter_DeleteExpiredMessages_UsesExpiresAt(t *testing.T) {
 
 	adapter := &MySQLAdapter{db: db}
 
-	mock.ExpectExec(`DELETE FROM messages WHERE expires_at < NOW\(\)`).
+	mock.ExpectExec(`DELETE FROM messages WHERE expires_at < NOW\(\) LIMIT \?`).
+		WithArgs(500).
 		WillReturnResult(sqlmock.NewResult(0, 2))
 
 	// Act
-	err = adapter.DeleteExpiredMessages()
+	deleted, err := adapter.DeleteExpiredMessages(500)
 	// Assert
 	if err != nil {
 		t.Errorf("DeleteExpiredMessages() error = %v", err)
 	}
+	if deleted != 2 {
+		t.Errorf("DeleteExpiredMessages() deleted = %d, want 2", deleted)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("SQL expectations were not met: %v", err)
@@ -383,3 +404,384 @@ func TestMySQLAdapter_GetReminderHistory(t *testing.T) {
 		t.Errorf("SQL expectations were not met: %v", err)
 	}
 }
+
+func TestMySQLAdapter_InsertMessage_WithAttachment(t *testing.T) {
+	// Test that the message, attachment row and every chunk are written in one transaction
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	message := &domain.Message{
+		UniqueID:     "test-uuid-123",
+		Content:      "encrypted-content",
+		MaxViewCount: 3,
+		Attachment: &domain.Attachment{
+			Filename:    "encrypted-name",
+			ContentType: "encrypted-type",
+			SizeBytes:   70000,
+			Chunks:      [][]byte{[]byte("chunk-0"), []byte("chunk-1")},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO messages`).
+		WithArgs(message.Content, message.UniqueID, "", "", message.MaxViewCount, sqlmock.AnyArg(), false, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, false, sql.NullString{}).
+		WillReturnResult(sqlmock.NewResult(42, 1))
+	mock.ExpectExec(`INSERT INTO message_attachments \(message_id, filename, content_type, size_bytes\)`).
+		WithArgs(int64(42), "encrypted-name", "encrypted-type", int64(70000)).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(`INSERT INTO message_attachment_chunks`).
+		WithArgs(int64(7), 0, []byte("chunk-0")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO message_attachment_chunks`).
+		WithArgs(int64(7), 1, []byte("chunk-1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Act
+	err = adapter.InsertMessage(message)
+	// Assert
+	if err != nil {
+		t.Errorf("InsertMessage() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_InsertMessage_WithAttachmentRollsBackOnChunkFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	message := &domain.Message{
+		UniqueID:     "test-uuid-123",
+		Content:      "encrypted-content",
+		MaxViewCount: 3,
+		Attachment: &domain.Attachment{
+			Filename:    "encrypted-name",
+			ContentType: "encrypted-type",
+			SizeBytes:   10,
+			Chunks:      [][]byte{[]byte("chunk-0")},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO messages`).WillReturnResult(sqlmock.NewResult(42, 1))
+	mock.ExpectExec(`INSERT INTO message_attachments`).WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectExec(`INSERT INTO message_attachment_chunks`).WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	// Act
+	err = adapter.InsertMessage(message)
+	// Assert
+	if err == nil {
+		t.Errorf("Expected InsertMessage() to fail when a chunk insert fails")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_GetMessage_WithAttachmentMetadata(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	rows := sqlmock.NewRows(messageColumns).
+		AddRow(42, "encrypted-content", "test-uuid-123", "", "", 0, 5, nil, false, nil, nil, nil, nil, false, nil, 0, "encrypted-name", "encrypted-type", 1234)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectMessageQuery)).
+		WithArgs("test-uuid-123").
+		WillReturnRows(rows)
+
+	// Act
+	message, err := adapter.GetMessage("test-uuid-123")
+	// Assert
+	if err != nil {
+		t.Fatalf("GetMessage() error = %v", err)
+	}
+
+	if message.Attachment == nil {
+		t.Fatalf("Expected Attachment to be set, got nil")
+	}
+	if message.Attachment.Filename != "encrypted-name" || message.Attachment.SizeBytes != 1234 {
+		t.Errorf("Unexpected attachment metadata: %+v", message.Attachment)
+	}
+	if message.Attachment.Chunks != nil {
+		t.Errorf("Expected chunks not to be loaded by GetMessage")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_GetAttachment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAttachmentQuery)).
+		WithArgs("test-uuid-123").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "filename", "content_type", "size_bytes"}).
+			AddRow(7, "encrypted-name", "encrypted-type", 70000))
+	mock.ExpectQuery(`SELECT data FROM message_attachment_chunks WHERE attachment_id = \? ORDER BY chunk_index`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"data"}).
+			AddRow([]byte("chunk-0")).
+			AddRow([]byte("chunk-1")))
+
+	// Act
+	attachment, err := adapter.GetAttachment("test-uuid-123")
+	// Assert
+	if err != nil {
+		t.Fatalf("GetAttachment() error = %v", err)
+	}
+
+	if len(attachment.Chunks) != 2 || string(attachment.Chunks[1]) != "chunk-1" {
+		t.Errorf("Unexpected chunks: %q", attachment.Chunks)
+	}
+	if attachment.SizeBytes != 70000 {
+		t.Errorf("Expected SizeBytes 70000, got %d", attachment.SizeBytes)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_GetAttachment_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAttachmentQuery)).
+		WithArgs("test-uuid-123").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "filename", "content_type", "size_bytes"}))
+
+	// Act
+	_, err = adapter.GetAttachment("test-uuid-123")
+	// Assert
+	if err != domain.ErrAttachmentNotFound {
+		t.Errorf("Expected ErrAttachmentNotFound, got %v", err)
+	}
+}
+
+func TestMySQLAdapter_DeleteMessage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM messages WHERE uniqueid = ?")).
+		WithArgs("test-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM messages WHERE uniqueid = ?")).
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := adapter.DeleteMessage("test-uuid"); err != nil {
+		t.Errorf("DeleteMessage() error = %v", err)
+	}
+	if err := adapter.DeleteMessage("missing"); !errors.Is(err, domain.ErrMessageNotFound) {
+		t.Errorf("DeleteMessage() error = %v, want %v", err, domain.ErrMessageNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_ReservePassphraseAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts + 1 WHERE uniqueid = ?")).
+		WithArgs("test-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT failed_passphrase_attempts FROM messages WHERE uniqueid = ?")).
+		WithArgs("test-uuid").
+		WillReturnRows(sqlmock.NewRows([]string{"failed_passphrase_attempts"}).AddRow(3))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts + 1 WHERE uniqueid = ?")).
+		WithArgs("missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	failedAttempts, err := adapter.ReservePassphraseAttempt("test-uuid")
+	if err != nil {
+		t.Errorf("ReservePassphraseAttempt() error = %v", err)
+	}
+	if failedAttempts != 3 {
+		t.Errorf("ReservePassphraseAttempt() = %d, want 3", failedAttempts)
+	}
+	if _, err := adapter.ReservePassphraseAttempt("missing"); !errors.Is(err, domain.ErrMessageNotFound) {
+		t.Errorf("ReservePassphraseAttempt() error = %v, want %v", err, domain.ErrMessageNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_ReleasePassphraseAttempt(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	// The count never drops below zero, so a release without a reservation changes nothing
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts - 1 WHERE uniqueid = ? AND failed_passphrase_attempts > 0")).
+		WithArgs("test-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts - 1")).
+		WithArgs("broken").
+		WillReturnError(errors.New("connection reset"))
+
+	if err := adapter.ReleasePassphraseAttempt("test-uuid"); err != nil {
+		t.Errorf("ReleasePassphraseAttempt() error = %v", err)
+	}
+	if err := adapter.ReleasePassphraseAttempt("broken"); !errors.Is(err, domain.ErrDatabaseOperation) {
+		t.Errorf("ReleasePassphraseAttempt() error = %v, want %v", err, domain.ErrDatabaseOperation)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_UpdatePassphraseHash(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	query := regexp.QuoteMeta("UPDATE messages SET other_lastname = ? WHERE uniqueid = ?")
+	mock.ExpectExec(query).
+		WithArgs("new-hash", "test-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("new-hash", "missing").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := adapter.UpdatePassphraseHash("test-uuid", "new-hash"); err != nil {
+		t.Errorf("UpdatePassphraseHash() error = %v", err)
+	}
+	if err := adapter.UpdatePassphraseHash("missing", "new-hash"); !errors.Is(err, domain.ErrMessageNotFound) {
+		t.Errorf("UpdatePassphraseHash() error = %v, want %v", err, domain.ErrMessageNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_MessageContents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT messageid, uniqueid, message FROM messages WHERE messageid > ? ORDER BY messageid LIMIT ?")).
+		WithArgs(int64(10), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"messageid", "uniqueid", "message"}).
+			AddRow(11, "first", "first-content").
+			AddRow(14, "second", "second-content"))
+	// The update only matches if the content is unchanged since it was read
+	query := regexp.QuoteMeta("UPDATE messages SET message = ? WHERE uniqueid = ? AND message = ?")
+	mock.ExpectExec(query).
+		WithArgs("rewrapped", "first", "first-content").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).
+		WithArgs("rewrapped", "second", "second-content").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	messages, err := adapter.SelectMessageContents(10, 2)
+	if err != nil {
+		t.Fatalf("SelectMessageContents() error = %v", err)
+	}
+	if len(messages) != 2 || messages[1].ID != 14 || messages[1].Content != "second-content" {
+		t.Errorf("SelectMessageContents() = %+v", messages)
+	}
+	if err := adapter.UpdateMessageContent("first", "first-content", "rewrapped"); err != nil {
+		t.Errorf("UpdateMessageContent() error = %v", err)
+	}
+	if err := adapter.UpdateMessageContent("second", "second-content", "rewrapped"); !errors.Is(err, domain.ErrMessageNotFound) {
+		t.Errorf("UpdateMessageContent() error = %v, want %v", err, domain.ErrMessageNotFound)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_FulfillSecretRequest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	// The update only matches open requests, so a second answer affects no rows
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE secret_requests SET fulfilled_at = NOW(), message_uniqueid = ? WHERE uniqueid = ?")).
+		WithArgs("message-uuid", "request-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE secret_requests SET fulfilled_at = NOW(), message_uniqueid = ? WHERE uniqueid = ?")).
+		WithArgs("other-message-uuid", "request-uuid").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := adapter.FulfillSecretRequest("request-uuid", "message-uuid"); err != nil {
+		t.Errorf("FulfillSecretRequest() error = %v", err)
+	}
+	if err := adapter.FulfillSecretRequest("request-uuid", "other-message-uuid"); !errors.Is(err, domain.ErrSecretRequestUnavailable) {
+		t.Errorf("FulfillSecretRequest() error = %v, want %v", err, domain.ErrSecretRequestUnavailable)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
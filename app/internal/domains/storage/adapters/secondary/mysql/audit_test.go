@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestMySQLAdapter_AppendAuditEvent_Conflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+	event := &domain.AuditEvent{Sequence: 7, EventType: domain.AuditEventViewed, MessageID: "message-1", OccurredAt: time.Now(), PrevHash: "prev", Hash: "hash"}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs(int64(7), "message.viewed", "message-1", "", "", event.OccurredAt, "prev", "hash").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '7' for key 'PRIMARY'"})
+
+	err = adapter.AppendAuditEvent(event)
+	if !errors.Is(err, domain.ErrAuditConflict) {
+		t.Errorf("AppendAuditEvent() error = %v, want ErrAuditConflict", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestMySQLAdapter_LastAuditEvent_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &MySQLAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAuditEventColumns + " ORDER BY sequence DESC LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"sequence", "event_type", "message_uniqueid", "client_ip_hash", "user_agent_hash", "occurred_at", "prev_hash", "hash"}))
+
+	event, err := adapter.LastAuditEvent()
+	if err != nil {
+		t.Errorf("LastAuditEvent() error = %v", err)
+	}
+	if event != nil {
+		t.Errorf("LastAuditEvent() = %+v, want nil", event)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package nats publishes the storage service's notification outbox to NATS JetStream.
+package nats
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// publishTimeout bounds one publish, including the wait for the stream's acknowledgement
+const publishTimeout = 5 * time.Second
+
+// defaultStream is the stream used when the configuration does not name one; it matches the
+// email consumer's default
+const defaultStream = "NOTIFICATIONS"
+
+// OutboxPublisher implements domain.OutboxPublisher. It connects on first use and again after
+// a failed publish, so the relay keeps retrying through a NATS outage, and waits for the stream
+// to acknowledge each entry so it is only marked delivered once it is stored.
+type OutboxPublisher struct {
+	url       string
+	stream    string
+	queueName string
+
+	mu         sync.Mutex
+	connection *natsclient.Conn
+	js         jetstream.JetStream
+}
+
+// OutboxPublisherConfig holds NATS connection configuration
+type OutboxPublisherConfig struct {
+	URL       string
+	Stream    string
+	QueueName string
+}
+
+// NewOutboxPublisher creates an outbox publisher for the subject the email consumer reads. It
+// does not connect until the first publish.
+func NewOutboxPublisher(config OutboxPublisherConfig) *OutboxPublisher {
+	if config.URL == "" {
+		config.URL = natsclient.DefaultURL
+	}
+	if config.Stream == "" {
+		config.Stream = defaultStream
+	}
+	return &OutboxPublisher{
+		url:       config.URL,
+		stream:    config.Stream,
+		queueName: config.QueueName,
+	}
+}
+
+// PublishOutboxEntry publishes a payload and waits for the stream to store it
+func (p *OutboxPublisher) PublishOutboxEntry(ctx context.Context, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	if err := p.connect(publishCtx); err != nil {
+		return err
+	}
+
+	msg := natsclient.NewMsg(p.queueName)
+	msg.Header.Set("Content-Type", "application/protobuf")
+	msg.Data = payload
+	if _, err := p.js.PublishMsg(publishCtx, msg); err != nil {
+		p.disconnect()
+		return fmt.Errorf("failed to publish outbox entry: %w", err)
+	}
+	return nil
+}
+
+// connect dials NATS and makes sure the stream exists, unless a connection is already open. The
+// stream is configured exactly as the email consumer configures it, so either can create it.
+func (p *OutboxPublisher) connect(ctx context.Context) error {
+	if p.connection != nil && p.connection.IsConnected() {
+		return nil
+	}
+	p.disconnect()
+
+	conn, err := natsclient.Connect(p.url, natsclient.Name("password-exchange"))
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open JetStream: %w", err)
+	}
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      p.stream,
+		Subjects:  []string{p.queueName, p.queueName + ".dlq"},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	}); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to create stream: %w", err)
+	}
+
+	logging.Info().Str("subject", p.queueName).Str("stream", p.stream).Msg("Outbox publisher connected to NATS")
+	p.connection = conn
+	p.js = js
+	return nil
+}
+
+// disconnect drops the current connection so the next publish dials again
+func (p *OutboxPublisher) disconnect() {
+	if p.connection != nil {
+		p.connection.Close()
+	}
+	p.connection = nil
+	p.js = nil
+}
+
+// Close closes the NATS connection
+func (p *OutboxPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disconnect()
+	return nil
+}
@@ -0,0 +1,107 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	notificationNATS "github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/nats"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	storageQueue "github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/queue"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receivedMessages passes every message it is given to a channel
+type receivedMessages chan contracts.QueueMessage
+
+func (r receivedMessages) HandleMessage(ctx context.Context, msg contracts.QueueMessage) error {
+	r <- msg
+	return nil
+}
+
+// startJetStream runs an embedded NATS server with JetStream until the test ends
+func startJetStream(t *testing.T) *server.Server {
+	t.Helper()
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  t.TempDir(),
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	require.NoError(t, err)
+	go srv.Start()
+	require.True(t, srv.ReadyForConnections(10*time.Second))
+	t.Cleanup(srv.Shutdown)
+	return srv
+}
+
+// consume delivers the messages on the notifications subject to the returned channel until the test ends
+func consume(t *testing.T, srv *server.Server) receivedMessages {
+	t.Helper()
+	consumer := notificationNATS.NewJetStreamConsumer()
+	t.Cleanup(func() { consumer.Close() })
+	received := make(receivedMessages, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go consumer.StartConsuming(ctx, contracts.QueueConnection{
+		Backend:   contracts.QueueBackendNATS,
+		URL:       srv.ClientURL(),
+		QueueName: "notifications",
+	}, received, 1)
+	return received
+}
+
+func TestOutboxPublisher_ReachesEmailConsumer(t *testing.T) {
+	srv := startJetStream(t)
+
+	publisher := NewOutboxPublisher(OutboxPublisherConfig{URL: srv.ClientURL(), QueueName: "notifications"})
+	defer publisher.Close()
+
+	payload, err := queuemsg.EncodeNotification(contracts.NotificationRequest{To: "recipient@example.com"})
+	require.NoError(t, err)
+	require.NoError(t, publisher.PublishOutboxEntry(context.Background(), payload))
+
+	// The consumer finds the stream the publisher created and accepts its configuration
+	received := consume(t, srv)
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, "recipient@example.com", msg.OtherEmail)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the outbox entry was not delivered")
+	}
+
+	// With the server gone the publish fails and the relay retries the entry later
+	srv.Shutdown()
+	assert.Error(t, publisher.PublishOutboxEntry(context.Background(), payload))
+}
+
+func TestExpiryNotifier_ReachesWebhookConsumer(t *testing.T) {
+	srv := startJetStream(t)
+
+	publisher := NewOutboxPublisher(OutboxPublisherConfig{URL: srv.ClientURL(), QueueName: "notifications"})
+	defer publisher.Close()
+
+	// message.expired events take the same route as the outbox
+	notifier := storageQueue.NewExpiryNotifier(publisher, "")
+	require.NoError(t, notifier.NotifyExpired(context.Background(), &domain.Message{
+		UniqueID:   "message-1",
+		WebhookURL: "https://hooks.example.com/px",
+	}))
+
+	received := consume(t, srv)
+	select {
+	case msg := <-received:
+		assert.Equal(t, contracts.NotificationTypeWebhook, msg.NotificationType)
+		assert.Equal(t, "message.expired", msg.WebhookEvent)
+		assert.Equal(t, "message-1", msg.UniqueID)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the expiry event was not delivered")
+	}
+}
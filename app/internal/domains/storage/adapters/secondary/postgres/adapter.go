@@ -0,0 +1,895 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// defaultMessageTTL is the default time-to-live for messages stored in PostgreSQL.
+// Must match the message domain's DefaultMessageTTL.
+const defaultMessageTTL = 7 * 24 * time.Hour
+
+// insertMessageQuery stores a message and returns its generated id so attachments can reference it.
+const insertMessageQuery = "INSERT INTO messages (message, uniqueid, other_lastname, other_email, view_count, max_view_count, expires_at, client_encrypted, revocation_token_hash, email, webhook_url, webhook_secret, public_key_encrypted, passphrase_key_salt) VALUES ($1, $2, $3, $4, 0, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING messageid"
+
+// selectMessageQuery is the standard SELECT statement used to retrieve a message row.
+// Attachment metadata is joined in so callers can tell a message carries a file without loading its chunks.
+const selectMessageQuery = "SELECT m.messageid, m.message, m.uniqueid, m.other_lastname, m.other_email, m.view_count, m.max_view_count, m.expires_at, m.client_encrypted, m.revocation_token_hash, m.email, m.webhook_url, m.webhook_secret, m.public_key_encrypted, m.passphrase_key_salt, m.failed_passphrase_attempts, a.filename, a.content_type, a.size_bytes FROM messages m LEFT JOIN message_attachments a ON a.message_id = m.messageid WHERE m.uniqueid = $1"
+
+// selectAttachmentQuery retrieves the attachment metadata row for a message.
+const selectAttachmentQuery = "SELECT a.id, a.filename, a.content_type, a.size_bytes FROM message_attachments a JOIN messages m ON m.messageid = a.message_id WHERE m.uniqueid = $1"
+
+// selectSecretRequestQuery retrieves a secret request row by its unique ID.
+const selectSecretRequestQuery = "SELECT id, uniqueid, requester_name, requester_email, description, created_at, expires_at, fulfilled_at, message_uniqueid FROM secret_requests WHERE uniqueid = $1"
+
+// scanMessageRow scans a single message row into a domain.Message, handling the nullable expires_at
+// and attachment fields.
+func scanMessageRow(row *sql.Row) (*domain.Message, error) {
+	var message domain.Message
+	var expiresAt sql.NullTime
+	var revocationTokenHash, senderEmail, webhookURL, webhookSecret, passphraseKeySalt sql.NullString
+	var attachmentFilename, attachmentContentType sql.NullString
+	var attachmentSize sql.NullInt64
+	err := row.Scan(
+		&message.ID,
+		&message.Content,
+		&message.UniqueID,
+		&message.Passphrase,
+		&message.RecipientEmail,
+		&message.ViewCount,
+		&message.MaxViewCount,
+		&expiresAt,
+		&message.ClientEncrypted,
+		&revocationTokenHash,
+		&senderEmail,
+		&webhookURL,
+		&webhookSecret,
+		&message.PublicKeyEncrypted,
+		&passphraseKeySalt,
+		&message.FailedPassphraseAttempts,
+		&attachmentFilename,
+		&attachmentContentType,
+		&attachmentSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt.Valid {
+		message.ExpiresAt = &expiresAt.Time
+	}
+	message.RevocationTokenHash = revocationTokenHash.String
+	message.SenderEmail = senderEmail.String
+	message.WebhookURL = webhookURL.String
+	message.WebhookSecret = webhookSecret.String
+	message.PassphraseKeySalt = passphraseKeySalt.String
+	if attachmentSize.Valid {
+		message.Attachment = &domain.Attachment{
+			Filename:    attachmentFilename.String,
+			ContentType: attachmentContentType.String,
+			SizeBytes:   attachmentSize.Int64,
+		}
+	}
+	return &message, nil
+}
+
+// nullableString stores empty optional values as NULL
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// PostgresAdapter implements the MessageRepository interface for PostgreSQL
+type PostgresAdapter struct {
+	db     *sql.DB
+	config domain.DatabaseConfig
+}
+
+// NewPostgresAdapter creates a new PostgreSQL database adapter
+func NewPostgresAdapter(config domain.DatabaseConfig) domain.MessageRepository {
+	return &PostgresAdapter{
+		config: config,
+	}
+}
+
+// ConnectionString builds a pgx connection URL from the database configuration.
+// Host may include a port; TLS settings follow the libpq defaults and PG* environment variables.
+func ConnectionString(config domain.DatabaseConfig) string {
+	connURL := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(config.User, config.Password),
+		Host:   config.Host,
+		Path:   "/" + config.Name,
+	}
+	return connURL.String()
+}
+
+// Connect establishes a connection to the PostgreSQL database
+func (p *PostgresAdapter) Connect() error {
+	db, err := sql.Open("pgx", ConnectionString(p.config))
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to open PostgreSQL connection")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseConnection, err)
+	}
+
+	// Test the connection
+	if err := db.Ping(); err != nil {
+		db.Close() // Close connection if ping fails to prevent leak
+		logging.Error().Err(err).Msg("Failed to ping PostgreSQL database")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseConnection, err)
+	}
+
+	p.db = db
+	return nil
+}
+
+// InsertMessage stores a new encrypted message in the database
+func (p *PostgresAdapter) InsertMessage(message *domain.Message) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	var expiresAt time.Time
+	if message.ExpiresAt != nil {
+		expiresAt = *message.ExpiresAt
+	} else {
+		expiresAt = time.Now().Add(defaultMessageTTL)
+	}
+	if message.Attachment != nil || len(message.Notification) > 0 {
+		return p.insertMessageInTransaction(message, expiresAt)
+	}
+
+	var messageID int64
+	err := p.db.QueryRow(
+		insertMessageQuery,
+		message.Content,
+		message.UniqueID,
+		message.Passphrase,
+		message.RecipientEmail,
+		message.MaxViewCount,
+		expiresAt,
+		message.ClientEncrypted,
+		nullableString(message.RevocationTokenHash),
+		nullableString(message.SenderEmail),
+		nullableString(message.WebhookURL),
+		nullableString(message.WebhookSecret),
+		message.PublicKeyEncrypted,
+		nullableString(message.PassphraseKeySalt),
+	).Scan(&messageID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Str("uniqueID", message.UniqueID).
+		Int("maxViewCount", message.MaxViewCount).
+		Str("recipientEmail", validation.SanitizeEmailForLogging(message.RecipientEmail)).
+		Msg("Message stored successfully")
+	return nil
+}
+
+// insertMessageInTransaction stores a message with its attachment chunks and outbox notification
+// in a single transaction, so a message is never visible with a partially written file and its
+// notification is queued exactly when the message is stored.
+func (p *PostgresAdapter) insertMessageInTransaction(message *domain.Message, expiresAt time.Time) error {
+	tx, err := p.db.Begin()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to begin transaction")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer tx.Rollback() // Will be ignored if transaction is committed
+
+	var messageID int64
+	err = tx.QueryRow(
+		insertMessageQuery,
+		message.Content,
+		message.UniqueID,
+		message.Passphrase,
+		message.RecipientEmail,
+		message.MaxViewCount,
+		expiresAt,
+		message.ClientEncrypted,
+		nullableString(message.RevocationTokenHash),
+		nullableString(message.SenderEmail),
+		nullableString(message.WebhookURL),
+		nullableString(message.WebhookSecret),
+		message.PublicKeyEncrypted,
+		nullableString(message.PassphraseKeySalt),
+	).Scan(&messageID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	if attachment := message.Attachment; attachment != nil {
+		var attachmentID int64
+		err = tx.QueryRow(
+			"INSERT INTO message_attachments (message_id, filename, content_type, size_bytes) VALUES ($1, $2, $3, $4) RETURNING id",
+			messageID,
+			attachment.Filename,
+			attachment.ContentType,
+			attachment.SizeBytes,
+		).Scan(&attachmentID)
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert attachment")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+
+		for index, chunk := range attachment.Chunks {
+			_, err = tx.Exec(
+				"INSERT INTO message_attachment_chunks (attachment_id, chunk_index, data) VALUES ($1, $2, $3)",
+				attachmentID,
+				index,
+				chunk,
+			)
+			if err != nil {
+				logging.Error().Err(err).Str("uniqueID", message.UniqueID).Int("chunkIndex", index).Msg("Failed to insert attachment chunk")
+				return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+			}
+		}
+	}
+
+	if len(message.Notification) > 0 {
+		if err = insertOutboxEntry(tx, messageID, message.Notification); err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to queue notification")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to commit transaction")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logInsertedMessage(message)
+	return nil
+}
+
+// logInsertedMessage logs a message stored in a transaction, with its attachment size if any
+func logInsertedMessage(message *domain.Message) {
+	event := logging.Info().
+		Str("uniqueID", message.UniqueID).
+		Int("maxViewCount", message.MaxViewCount).
+		Bool("notificationQueued", len(message.Notification) > 0).
+		Str("recipientEmail", validation.SanitizeEmailForLogging(message.RecipientEmail))
+	if message.Attachment != nil {
+		event = event.Int64("attachmentBytes", message.Attachment.SizeBytes).Int("chunkCount", len(message.Attachment.Chunks))
+	}
+	event.Msg("Message stored successfully")
+}
+
+// SelectMessageByUniqueID retrieves a message by its unique identifier
+func (p *PostgresAdapter) SelectMessageByUniqueID(uniqueID string) (*domain.Message, error) {
+	return p.GetMessage(uniqueID)
+}
+
+// GetMessage retrieves a message by its unique identifier without incrementing the view count
+func (p *PostgresAdapter) GetMessage(uniqueID string) (*domain.Message, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	message, err := scanMessageRow(p.db.QueryRow(selectMessageQuery, uniqueID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found")
+			return nil, domain.ErrMessageNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select message")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message retrieved successfully")
+	return message, nil
+}
+
+// IncrementViewCountAndGet atomically increments the view count and returns the message.
+// The UPDATE takes a row lock, so concurrent viewers are serialized and each sees its own count;
+// the message is deleted in the same transaction once it reaches its max view count.
+func (p *PostgresAdapter) IncrementViewCountAndGet(uniqueID string) (*domain.Message, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to begin transaction")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer tx.Rollback() // Will be ignored if transaction is committed
+
+	result, err := tx.Exec("UPDATE messages SET view_count = view_count + 1 WHERE uniqueid = $1", uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to increment view count")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for view count increment")
+		return nil, domain.ErrMessageNotFound
+	}
+
+	message, err := scanMessageRow(tx.QueryRow(selectMessageQuery, uniqueID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found after increment")
+			return nil, domain.ErrMessageNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select message after increment")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	if message.ViewCount >= message.MaxViewCount {
+		_, err = tx.Exec("DELETE FROM messages WHERE uniqueid = $1", uniqueID)
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete message after reaching view limit")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		logging.Info().
+			Str("uniqueID", uniqueID).
+			Int("viewCount", message.ViewCount).
+			Int("maxViewCount", message.MaxViewCount).
+			Msg("Message deleted after reaching view limit")
+	}
+
+	if err = tx.Commit(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to commit transaction")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("viewCount", message.ViewCount).Msg("View count incremented successfully")
+	return message, nil
+}
+
+// GetAttachment retrieves a message's attachment and all of its chunks without incrementing the view count
+func (p *PostgresAdapter) GetAttachment(uniqueID string) (*domain.Attachment, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	var attachmentID int64
+	var attachment domain.Attachment
+	err := p.db.QueryRow(selectAttachmentQuery, uniqueID).Scan(
+		&attachmentID,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Attachment not found")
+			return nil, domain.ErrAttachmentNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select attachment")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rows, err := p.db.Query(
+		"SELECT data FROM message_attachment_chunks WHERE attachment_id = $1 ORDER BY chunk_index",
+		attachmentID,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to query attachment chunks")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var chunk []byte
+		if err := rows.Scan(&chunk); err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to scan attachment chunk")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		attachment.Chunks = append(attachment.Chunks, chunk)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Error iterating over attachment chunks")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("chunkCount", len(attachment.Chunks)).Msg("Attachment retrieved successfully")
+	return &attachment, nil
+}
+
+// DeleteMessage permanently removes a message; its attachment and reminder log are removed by cascade
+func (p *PostgresAdapter) DeleteMessage(uniqueID string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := p.db.Exec("DELETE FROM messages WHERE uniqueid = $1", uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for deletion")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message deleted successfully")
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (p *PostgresAdapter) ReservePassphraseAttempt(uniqueID string) (int, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	var failedAttempts int
+	err := p.db.QueryRow(
+		"UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts + 1 WHERE uniqueid = $1 RETURNING failed_passphrase_attempts",
+		uniqueID,
+	).Scan(&failedAttempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase attempt")
+			return 0, domain.ErrMessageNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to reserve passphrase attempt")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Int("failedAttempts", failedAttempts).Msg("Passphrase attempt reserved")
+	return failedAttempts, nil
+}
+
+// ReleasePassphraseAttempt takes back an attempt counted by ReservePassphraseAttempt once the
+// passphrase turned out right. The count never drops below zero.
+func (p *PostgresAdapter) ReleasePassphraseAttempt(uniqueID string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	_, err := p.db.Exec("UPDATE messages SET failed_passphrase_attempts = failed_passphrase_attempts - 1 WHERE uniqueid = $1 AND failed_passphrase_attempts > 0", uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to release passphrase attempt")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase attempt released")
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash
+func (p *PostgresAdapter) UpdatePassphraseHash(uniqueID, hashedPassphrase string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := p.db.Exec("UPDATE messages SET other_lastname = $1 WHERE uniqueid = $2", hashedPassphrase, uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update passphrase hash")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase hash update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase hash updated")
+	return nil
+}
+
+// SelectMessageContents returns up to limit messages with an ID above afterID, in ID order.
+// Only the ID, unique ID and content are filled in.
+func (p *PostgresAdapter) SelectMessageContents(afterID int64, limit int) ([]*domain.Message, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := p.db.Query("SELECT messageid, uniqueid, message FROM messages WHERE messageid > $1 ORDER BY messageid LIMIT $2", afterID, limit)
+	if err != nil {
+		logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to select message contents")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		if err := rows.Scan(&message.ID, &message.UniqueID, &message.Content); err != nil {
+			logging.Error().Err(err).Msg("Failed to scan message content")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return messages, nil
+}
+
+// UpdateMessageContent replaces a message's content if it still equals currentContent,
+// returning ErrMessageNotFound when the message is gone or its content has changed
+func (p *PostgresAdapter) UpdateMessageContent(uniqueID, currentContent, newContent string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := p.db.Exec("UPDATE messages SET message = $1 WHERE uniqueid = $2 AND message = $3", newContent, uniqueID, currentContent)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update message content")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found or changed for content update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Message content updated")
+	return nil
+}
+
+// DeleteExpiredMessages removes messages that have exceeded their TTL
+func (p *PostgresAdapter) DeleteExpiredMessages(limit int) (int, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := p.db.Exec("DELETE FROM messages WHERE messageid IN (SELECT messageid FROM messages WHERE expires_at < NOW() LIMIT $1)", limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to delete expired messages")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to get rows affected")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	logging.Info().Int64("rowsDeleted", rowsAffected).Msg("Expired messages cleaned up")
+	return int(rowsAffected), nil
+}
+
+// SelectExpiredMessages returns the messages DeleteExpiredMessages is about to remove,
+// with just the fields needed to report their expiry
+func (p *PostgresAdapter) SelectExpiredMessages(limit int) ([]*domain.Message, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := p.db.Query("SELECT uniqueid, view_count, max_view_count, webhook_url, webhook_secret FROM messages WHERE expires_at < NOW() LIMIT $1", limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to select expired messages")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		var message domain.Message
+		var webhookURL, webhookSecret sql.NullString
+		if err := rows.Scan(&message.UniqueID, &message.ViewCount, &message.MaxViewCount, &webhookURL, &webhookSecret); err != nil {
+			logging.Error().Err(err).Msg("Failed to scan expired message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		message.WebhookURL = webhookURL.String
+		message.WebhookSecret = webhookSecret.String
+		messages = append(messages, &message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return messages, nil
+}
+
+// GetUnviewedMessagesForReminders retrieves messages that are unviewed and eligible for reminder emails
+func (p *PostgresAdapter) GetUnviewedMessagesForReminders(
+	olderThanHours, maxReminders, reminderIntervalHours int,
+) ([]*domain.UnviewedMessage, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `SELECT m.messageid, m.uniqueid, m.other_email, m.created,
+         EXTRACT(DAY FROM NOW() - m.created)::INTEGER AS days_old
+  FROM messages m
+  LEFT JOIN email_reminders er ON m.messageid = er.message_id
+  WHERE m.view_count = 0
+    AND m.created < NOW() - make_interval(hours => $1)
+    AND m.other_email IS NOT NULL
+    AND m.other_email != ''
+    AND (er.reminder_count IS NULL OR er.reminder_count < $2)
+    AND (er.last_reminder_sent IS NULL OR er.last_reminder_sent < NOW() - make_interval(hours => $3))`
+
+	rows, err := p.db.Query(query, olderThanHours, maxReminders, reminderIntervalHours)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to query unviewed messages for reminders")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var messages []*domain.UnviewedMessage
+	for rows.Next() {
+		var msg domain.UnviewedMessage
+		err := rows.Scan(&msg.MessageID, &msg.UniqueID, &msg.RecipientEmail, &msg.Created, &msg.DaysOld)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to scan unviewed message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+
+		messages = append(messages, &msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.Error().Err(err).Msg("Error iterating over unviewed messages")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Int("count", len(messages)).Msg("Retrieved unviewed messages for reminders")
+	return messages, nil
+}
+
+// LogReminderSent records that a reminder email was sent for a message
+func (p *PostgresAdapter) LogReminderSent(messageID int, emailAddress string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	query := `INSERT INTO email_reminders (message_id, email_address, reminder_count, last_reminder_sent)
+		VALUES ($1, $2, 1, NOW())
+		ON CONFLICT (message_id) DO UPDATE SET
+		reminder_count = email_reminders.reminder_count + 1,
+		last_reminder_sent = NOW()`
+
+	_, err := p.db.Exec(query, messageID, emailAddress)
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Int("messageID", messageID).
+			Str("emailAddress", validation.SanitizeEmailForLogging(emailAddress)).
+			Msg("Failed to log reminder sent")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Int("messageID", messageID).
+		Str("emailAddress", validation.SanitizeEmailForLogging(emailAddress)).
+		Msg("Reminder sent logged successfully")
+	return nil
+}
+
+// GetReminderHistory retrieves the reminder history for a specific message
+func (p *PostgresAdapter) GetReminderHistory(messageID int) ([]*domain.ReminderLogEntry, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `SELECT message_id, email_address, reminder_count, last_reminder_sent
+		FROM email_reminders WHERE message_id = $1`
+
+	rows, err := p.db.Query(query, messageID)
+	if err != nil {
+		logging.Error().Err(err).Int("messageID", messageID).Msg("Failed to query reminder history")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var history []*domain.ReminderLogEntry
+	for rows.Next() {
+		var entry domain.ReminderLogEntry
+		err := rows.Scan(&entry.MessageID, &entry.EmailAddress, &entry.ReminderCount, &entry.LastReminderSent)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to scan reminder history entry")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		history = append(history, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.Error().Err(err).Msg("Error iterating over reminder history")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Int("messageID", messageID).Int("count", len(history)).Msg("Retrieved reminder history")
+	return history, nil
+}
+
+// InsertSecretRequest stores a new secret request
+func (p *PostgresAdapter) InsertSecretRequest(request *domain.SecretRequest) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	} else {
+		expiresAt = time.Now().Add(defaultMessageTTL)
+	}
+
+	_, err := p.db.Exec(
+		"INSERT INTO secret_requests (uniqueid, requester_name, requester_email, description, expires_at) VALUES ($1, $2, $3, $4, $5)",
+		request.UniqueID,
+		request.RequesterName,
+		request.RequesterEmail,
+		nullableString(request.Description),
+		expiresAt,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", request.UniqueID).Msg("Failed to insert secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Str("uniqueID", request.UniqueID).
+		Str("requesterEmail", validation.SanitizeEmailForLogging(request.RequesterEmail)).
+		Msg("Secret request stored successfully")
+	return nil
+}
+
+// GetSecretRequest retrieves a secret request by its unique identifier
+func (p *PostgresAdapter) GetSecretRequest(uniqueID string) (*domain.SecretRequest, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	var request domain.SecretRequest
+	var description, messageUniqueID sql.NullString
+	var createdAt, expiresAt, fulfilledAt sql.NullTime
+	err := p.db.QueryRow(selectSecretRequestQuery, uniqueID).Scan(
+		&request.ID,
+		&request.UniqueID,
+		&request.RequesterName,
+		&request.RequesterEmail,
+		&description,
+		&createdAt,
+		&expiresAt,
+		&fulfilledAt,
+		&messageUniqueID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Secret request not found")
+			return nil, domain.ErrSecretRequestNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select secret request")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	request.Description = description.String
+	request.MessageUniqueID = messageUniqueID.String
+	request.CreatedAt = createdAt.Time
+	if expiresAt.Valid {
+		request.ExpiresAt = &expiresAt.Time
+	}
+	if fulfilledAt.Valid {
+		request.FulfilledAt = &fulfilledAt.Time
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Secret request retrieved successfully")
+	return &request, nil
+}
+
+// FulfillSecretRequest records the message that fulfilled a secret request. The update only
+// matches open, unexpired requests, so concurrent submissions cannot both fulfill it.
+func (p *PostgresAdapter) FulfillSecretRequest(uniqueID, messageUniqueID string) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := p.db.Exec(
+		"UPDATE secret_requests SET fulfilled_at = NOW(), message_uniqueid = $1 WHERE uniqueid = $2 AND fulfilled_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())",
+		messageUniqueID,
+		uniqueID,
+	)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to fulfill secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("No open secret request to fulfill")
+		return domain.ErrSecretRequestUnavailable
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Str("messageUniqueID", messageUniqueID).Msg("Secret request fulfilled successfully")
+	return nil
+}
+
+// DeleteExpiredSecretRequests removes secret requests that have exceeded their TTL
+func (p *PostgresAdapter) DeleteExpiredSecretRequests() (int, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	result, err := p.db.Exec("DELETE FROM secret_requests WHERE expires_at < NOW()")
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to delete expired secret requests")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to get rows affected")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	logging.Info().Int64("rowsDeleted", rowsAffected).Msg("Expired secret requests cleaned up")
+	return int(rowsAffected), nil
+}
+
+// Close closes the database connection
+func (p *PostgresAdapter) Close() error {
+	if p.db != nil {
+		if err := p.db.Close(); err != nil {
+			logging.Error().Err(err).Msg("Failed to close database connection")
+			return err
+		}
+		p.db = nil
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode is PostgreSQL's unique_violation SQLSTATE
+const uniqueViolationCode = "23505"
+
+// selectAuditEventColumns lists the audit_events columns in AuditEvent field order
+const selectAuditEventColumns = "SELECT sequence, event_type, message_uniqueid, client_ip_hash, user_agent_hash, occurred_at, prev_hash, hash FROM audit_events"
+
+// LastAuditEvent returns the audit entry with the highest sequence, or nil when there is none
+func (p *PostgresAdapter) LastAuditEvent() (*domain.AuditEvent, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	event, err := scanAuditEvent(p.db.QueryRow(selectAuditEventColumns + " ORDER BY sequence DESC LIMIT 1"))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to read last audit event")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return event, nil
+}
+
+// AppendAuditEvent inserts an audit entry. The sequence is the primary key, so two writers
+// chaining onto the same entry cannot both succeed.
+func (p *PostgresAdapter) AppendAuditEvent(event *domain.AuditEvent) error {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return err
+		}
+	}
+
+	_, err := p.db.Exec(
+		"INSERT INTO audit_events (sequence, event_type, message_uniqueid, client_ip_hash, user_agent_hash, occurred_at, prev_hash, hash) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+		event.Sequence, string(event.EventType), event.MessageID, event.ClientIPHash, event.UserAgentHash, event.OccurredAt, event.PrevHash, event.Hash,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return fmt.Errorf("%w: %d", domain.ErrAuditConflict, event.Sequence)
+	}
+	if err != nil {
+		logging.Error().Err(err).Int64("sequence", event.Sequence).Msg("Failed to append audit event")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// SelectAuditEvents returns up to limit audit entries after afterSequence, in sequence order
+func (p *PostgresAdapter) SelectAuditEvents(afterSequence int64, limit int) ([]*domain.AuditEvent, error) {
+	if p.db == nil {
+		if err := p.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := p.db.Query(selectAuditEventColumns+" WHERE sequence > $1 ORDER BY sequence LIMIT $2", afterSequence, limit)
+	if err != nil {
+		logging.Error().Err(err).Int64("afterSequence", afterSequence).Msg("Failed to select audit events")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to scan audit event")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return events, nil
+}
+
+// scanAuditEvent reads a row selected with selectAuditEventColumns
+func scanAuditEvent(row interface{ Scan(...interface{}) error }) (*domain.AuditEvent, error) {
+	var event domain.AuditEvent
+	var eventType string
+	if err := row.Scan(&event.Sequence, &eventType, &event.MessageID, &event.ClientIPHash, &event.UserAgentHash, &event.OccurredAt, &event.PrevHash, &event.Hash); err != nil {
+		return nil, err
+	}
+	event.EventType = domain.AuditEventType(eventType)
+	return &event, nil
+}
@@ -0,0 +1,209 @@
+package postgres
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// auditEventColumns are the columns returned by selectAuditEventColumns
+var auditEventColumns = []string{"sequence", "event_type", "message_uniqueid", "client_ip_hash", "user_agent_hash", "occurred_at", "prev_hash", "hash"}
+
+func TestPostgresAdapter_LastAuditEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+	occurred := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAuditEventColumns + " ORDER BY sequence DESC LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows(auditEventColumns).
+			AddRow(int64(9), "message.viewed", "message-1", "ip-hash", "agent-hash", occurred, "prev", "hash"))
+
+	event, err := adapter.LastAuditEvent()
+	if err != nil {
+		t.Fatalf("LastAuditEvent() error = %v", err)
+	}
+	if event == nil {
+		t.Fatal("LastAuditEvent() = nil, want the latest event")
+	}
+	if event.Sequence != 9 || event.EventType != domain.AuditEventViewed || event.MessageID != "message-1" {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if event.ClientIPHash != "ip-hash" || event.UserAgentHash != "agent-hash" || !event.OccurredAt.Equal(occurred) {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+	if event.PrevHash != "prev" || event.Hash != "hash" {
+		t.Errorf("Unexpected hash chain: prev = %q, hash = %q", event.PrevHash, event.Hash)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_LastAuditEvent_Empty(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAuditEventColumns + " ORDER BY sequence DESC LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows(auditEventColumns))
+
+	event, err := adapter.LastAuditEvent()
+	if err != nil {
+		t.Errorf("LastAuditEvent() error = %v", err)
+	}
+	if event != nil {
+		t.Errorf("LastAuditEvent() = %+v, want nil", event)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_AppendAuditEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+	event := &domain.AuditEvent{Sequence: 7, EventType: domain.AuditEventViewed, MessageID: "message-1", OccurredAt: time.Now(), PrevHash: "prev", Hash: "hash"}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events (sequence, event_type, message_uniqueid, client_ip_hash, user_agent_hash, occurred_at, prev_hash, hash) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)")).
+		WithArgs(int64(7), "message.viewed", "message-1", "", "", event.OccurredAt, "prev", "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := adapter.AppendAuditEvent(event); err != nil {
+		t.Errorf("AppendAuditEvent() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_AppendAuditEvent_Conflict(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+	event := &domain.AuditEvent{Sequence: 7, EventType: domain.AuditEventViewed, MessageID: "message-1", OccurredAt: time.Now(), PrevHash: "prev", Hash: "hash"}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WithArgs(int64(7), "message.viewed", "message-1", "", "", event.OccurredAt, "prev", "hash").
+		WillReturnError(&pgconn.PgError{Code: uniqueViolationCode, Message: `duplicate key value violates unique constraint "audit_events_pkey"`})
+
+	err = adapter.AppendAuditEvent(event)
+	if !errors.Is(err, domain.ErrAuditConflict) {
+		t.Errorf("AppendAuditEvent() error = %v, want ErrAuditConflict", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_AppendAuditEvent_OtherError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_events")).
+		WillReturnError(&pgconn.PgError{Code: "23502", Message: "null value in column \"hash\""})
+
+	err = adapter.AppendAuditEvent(&domain.AuditEvent{Sequence: 7, EventType: domain.AuditEventViewed})
+	if errors.Is(err, domain.ErrAuditConflict) || !errors.Is(err, domain.ErrDatabaseOperation) {
+		t.Errorf("AppendAuditEvent() error = %v, want ErrDatabaseOperation", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_SelectAuditEvents(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+	occurred := time.Now()
+
+	// Only entries after the given sequence are selected, in ascending order and up to the limit
+	mock.ExpectQuery(regexp.QuoteMeta(selectAuditEventColumns+" WHERE sequence > $1 ORDER BY sequence LIMIT $2")).
+		WithArgs(int64(4), 2).
+		WillReturnRows(sqlmock.NewRows(auditEventColumns).
+			AddRow(int64(5), "message.created", "message-1", "", "", occurred, "hash-4", "hash-5").
+			AddRow(int64(6), "message.viewed", "message-1", "", "", occurred, "hash-5", "hash-6"))
+
+	events, err := adapter.SelectAuditEvents(4, 2)
+	if err != nil {
+		t.Fatalf("SelectAuditEvents() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	for i, want := range []int64{5, 6} {
+		if events[i].Sequence != want {
+			t.Errorf("events[%d].Sequence = %d, want %d", i, events[i].Sequence, want)
+		}
+	}
+	if events[1].EventType != domain.AuditEventViewed || events[1].PrevHash != events[0].Hash {
+		t.Errorf("Unexpected event: %+v", events[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_SelectAuditEvents_QueryError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectAuditEventColumns+" WHERE sequence > $1")).
+		WithArgs(int64(0), 100).
+		WillReturnError(errors.New("connection reset"))
+
+	events, err := adapter.SelectAuditEvents(0, 100)
+	if !errors.Is(err, domain.ErrDatabaseOperation) {
+		t.Errorf("SelectAuditEvents() error = %v, want ErrDatabaseOperation", err)
+	}
+	if events != nil {
+		t.Errorf("SelectAuditEvents() = %v, want nil", events)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresAdapter_InsertMessage_WithNotification(t *testing.T) {
+	// Test that the notification is queued in the same transaction as the message
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	message := &domain.Message{
+		UniqueID:     "test-uuid-123",
+		Content:      "encrypted-content",
+		MaxViewCount: 3,
+		Notification: []byte("notification"),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(insertMessageQuery)).
+		WithArgs(message.Content, message.UniqueID, "", "", message.MaxViewCount, sqlmock.AnyArg(), false, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, false, sql.NullString{}).
+		WillReturnRows(sqlmock.NewRows([]string{"messageid"}).AddRow(42))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notification_outbox (message_id, payload) VALUES ($1, $2)")).
+		WithArgs(int64(42), []byte("notification")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// Act
+	err = adapter.InsertMessage(message)
+	// Assert
+	if err != nil {
+		t.Errorf("InsertMessage() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_InsertMessage_RollsBackWhenOutboxInsertFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO messages`).WillReturnRows(sqlmock.NewRows([]string{"messageid"}).AddRow(42))
+	mock.ExpectExec(`INSERT INTO notification_outbox`).WillReturnError(sqlmock.ErrCancelled)
+	mock.ExpectRollback()
+
+	// Act
+	err = adapter.InsertMessage(&domain.Message{UniqueID: "test-uuid-123", Content: "encrypted-content", Notification: []byte("notification")})
+	// Assert
+	if err == nil {
+		t.Errorf("Expected InsertMessage() to fail when the outbox insert fails")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_ClaimOutboxEntries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+	created := time.Now()
+
+	// Due entries are locked, skipping those held by another relay, and leased in the same transaction
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT o.id, m.uniqueid, o.payload, o.attempts, o.created_at\s+FROM notification_outbox o.*WHERE o.delivered_at IS NULL AND o.dead_lettered_at IS NULL AND o.next_attempt_at <= NOW\(\).*FOR UPDATE OF o SKIP LOCKED`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uniqueid", "payload", "attempts", "created_at"}).
+			AddRow(int64(3), "message-1", []byte("payload-1"), 0, created).
+			AddRow(int64(5), "message-2", []byte("payload-2"), 2, created))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notification_outbox SET next_attempt_at = NOW() + make_interval(secs => $1) WHERE id IN ($2, $3)")).
+		WithArgs(60, int64(3), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEntries() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].ID != 3 || entries[0].MessageID != "message-1" {
+		t.Errorf("Unexpected entry: %+v", entries[0])
+	}
+	if entries[1].MessageID != "message-2" || string(entries[1].Payload) != "payload-2" || entries[1].Attempts != 2 {
+		t.Errorf("Unexpected entry: %+v", entries[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_ClaimOutboxEntries_NoneDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	// Nothing is leased when no entry is due
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM notification_outbox o`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uniqueid", "payload", "attempts", "created_at"}))
+	mock.ExpectRollback()
+
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimOutboxEntries() error = %v", err)
+	}
+	if entries != nil {
+		t.Errorf("ClaimOutboxEntries() = %v, want nil", entries)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_ClaimOutboxEntries_LeaseFails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	// Entries that could not be leased are not returned, so no other relay can double-send them
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FROM notification_outbox o`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uniqueid", "payload", "attempts", "created_at"}).
+			AddRow(int64(3), "message-1", []byte("payload-1"), 0, time.Now()))
+	mock.ExpectExec(`UPDATE notification_outbox SET next_attempt_at`).
+		WithArgs(60, int64(3)).
+		WillReturnError(errors.New("deadlock detected"))
+	mock.ExpectRollback()
+
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	if !errors.Is(err, domain.ErrDatabaseOperation) {
+		t.Errorf("ClaimOutboxEntries() error = %v, want ErrDatabaseOperation", err)
+	}
+	if entries != nil {
+		t.Errorf("ClaimOutboxEntries() = %v, want nil", entries)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_MarkOutboxEntryDelivered(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	// The payload holds the message link, so it is cleared once delivered
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notification_outbox SET delivered_at = NOW(), payload = NULL WHERE id = $1")).
+		WithArgs(int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := adapter.MarkOutboxEntryDelivered(3); err != nil {
+		t.Errorf("MarkOutboxEntryDelivered() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_MarkOutboxEntryFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	// The attempt is counted and the next one is pushed back by the backoff
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notification_outbox SET attempts = attempts + 1, next_attempt_at = NOW() + make_interval(secs => $1), last_error = $2 WHERE id = $3")).
+		WithArgs(120, "broker unavailable", int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := adapter.MarkOutboxEntryFailed(3, 2*time.Minute, "broker unavailable"); err != nil {
+		t.Errorf("MarkOutboxEntryFailed() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_MarkOutboxEntryFailed_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectExec(`UPDATE notification_outbox SET attempts = attempts \+ 1`).
+		WillReturnError(errors.New("connection reset"))
+
+	err = adapter.MarkOutboxEntryFailed(3, time.Minute, "broker unavailable")
+	if !errors.Is(err, domain.ErrDatabaseOperation) {
+		t.Errorf("MarkOutboxEntryFailed() error = %v, want ErrDatabaseOperation", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_DeadLetterOutboxEntry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	// The entry keeps its payload but is no longer claimed
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notification_outbox SET dead_lettered_at = NOW(), last_error = $1 WHERE id = $2")).
+		WithArgs("unknown master key", int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := adapter.DeadLetterOutboxEntry(3, "unknown master key"); err != nil {
+		t.Errorf("DeadLetterOutboxEntry() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_SelectOutboxPayloads(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Error creating mock database: %v", err)
+	}
+	defer db.Close()
+
+	adapter := &PostgresAdapter{db: db}
+
+	mock.ExpectQuery(`SELECT o.id, m.uniqueid, o.payload\s+FROM notification_outbox o.*WHERE o.id > \S+ AND o.delivered_at IS NULL AND o.dead_lettered_at IS NULL\s+ORDER BY o.id`).
+		WithArgs(int64(2), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "uniqueid", "payload"}).
+			AddRow(int64(3), "message-1", []byte("payload-1")))
+
+	entries, err := adapter.SelectOutboxPayloads(2, 10)
+	if err != nil {
+		t.Fatalf("SelectOutboxPayloads() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != 3 || entries[0].MessageID != "message-1" || string(entries[0].Payload) != "payload-1" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("SQL expectations were not met: %v", err)
+	}
+}
+
+func TestPostgresAdapter_UpdateOutboxPayload(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{"unchanged entry is updated", 1, nil},
+		{"delivered or changed entry is left alone", 0, domain.ErrOutboxEntryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("Error creating mock database: %v", err)
+			}
+			defer db.Close()
+
+			adapter := &PostgresAdapter{db: db}
+
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE notification_outbox SET payload = $1 WHERE id = $2 AND payload = $3 AND delivered_at IS NULL AND dead_lettered_at IS NULL")).
+				WithArgs([]byte("rewrapped"), int64(3), []byte("sealed")).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err = adapter.UpdateOutboxPayload(3, []byte("sealed"), []byte("rewrapped"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateOutboxPayload() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("SQL expectations were not met: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package queue reports storage events to the notification queue over whichever backend the
+// configured outbox publisher speaks.
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	messagepb "github.com/Anthony-Bible/password-exchange/app/pkg/pb/message"
+	"google.golang.org/protobuf/proto"
+)
+
+// Values understood by the notification consumer; they must match the notification
+// domain's contracts.NotificationTypeWebhook and the message domain's event names.
+const (
+	notificationTypeWebhook = "webhook"
+	webhookEventExpired     = "message.expired"
+)
+
+// ExpiryNotifier implements domain.ExpiryNotifier by queueing a message.expired webhook
+// event for the notification service. Events are published through the outbox publisher, so
+// they reach the queue of every backend the outbox can be relayed to.
+type ExpiryNotifier struct {
+	publisher  domain.OutboxPublisher
+	webhookURL string
+}
+
+// NewExpiryNotifier returns an expiry notifier publishing through publisher. webhookURL is the
+// globally configured webhook; messages without their own webhook are skipped when it is empty.
+func NewExpiryNotifier(publisher domain.OutboxPublisher, webhookURL string) *ExpiryNotifier {
+	return &ExpiryNotifier{
+		publisher:  publisher,
+		webhookURL: webhookURL,
+	}
+}
+
+// NotifyExpired queues a message.expired event for the message
+func (n *ExpiryNotifier) NotifyExpired(ctx context.Context, message *domain.Message) error {
+	if message.WebhookURL == "" && n.webhookURL == "" {
+		return nil
+	}
+
+	pbMsg := &messagepb.Message{
+		UniqueId:         message.UniqueID,
+		NotificationType: notificationTypeWebhook,
+		ViewCount:        int32(message.ViewCount),
+		MaxViewCount:     int32(message.MaxViewCount),
+		WebhookEvent:     webhookEventExpired,
+		WebhookUrl:       message.WebhookURL,
+		WebhookSecret:    message.WebhookSecret,
+		OccurredAt:       time.Now().UTC().Format(time.RFC3339),
+	}
+
+	data, err := proto.Marshal(pbMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal expiry event: %w", err)
+	}
+
+	if err := n.publisher.PublishOutboxEntry(ctx, data); err != nil {
+		return fmt.Errorf("failed to publish expiry event: %w", err)
+	}
+
+	logging.Debug().Str("uniqueID", message.UniqueID).Msg("Expiry event published")
+	return nil
+}
@@ -0,0 +1,72 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/adapters/secondary/queuemsg"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/notification/ports/contracts"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher keeps every payload it is asked to publish
+type recordingPublisher struct {
+	payloads [][]byte
+	err      error
+}
+
+func (p *recordingPublisher) PublishOutboxEntry(ctx context.Context, payload []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.payloads = append(p.payloads, payload)
+	return nil
+}
+
+func TestExpiryNotifier_PublishesWebhookEvent(t *testing.T) {
+	publisher := &recordingPublisher{}
+	notifier := NewExpiryNotifier(publisher, "")
+
+	err := notifier.NotifyExpired(context.Background(), &domain.Message{
+		UniqueID:      "message-1",
+		ViewCount:     1,
+		MaxViewCount:  3,
+		WebhookURL:    "https://hooks.example.com/px",
+		WebhookSecret: "secret",
+	})
+	require.NoError(t, err)
+	require.Len(t, publisher.payloads, 1)
+
+	// The email consumer reads the event as a webhook notification
+	msg, err := queuemsg.DecodeMessage(publisher.payloads[0])
+	require.NoError(t, err)
+	assert.Equal(t, contracts.NotificationTypeWebhook, msg.NotificationType)
+	assert.Equal(t, "message.expired", msg.WebhookEvent)
+	assert.Equal(t, "message-1", msg.UniqueID)
+	assert.Equal(t, "https://hooks.example.com/px", msg.WebhookURL)
+	assert.Equal(t, "secret", msg.WebhookSecret)
+	assert.Equal(t, 1, msg.ViewCount)
+	assert.Equal(t, 3, msg.MaxViewCount)
+	assert.False(t, msg.OccurredAt.IsZero())
+}
+
+func TestExpiryNotifier_SkipsMessagesWithoutWebhook(t *testing.T) {
+	publisher := &recordingPublisher{}
+
+	require.NoError(t, NewExpiryNotifier(publisher, "").NotifyExpired(context.Background(), &domain.Message{UniqueID: "message-1"}))
+	assert.Empty(t, publisher.payloads)
+
+	// With a global webhook every expired message is reported
+	require.NoError(t, NewExpiryNotifier(publisher, "https://hooks.example.com/global").NotifyExpired(context.Background(), &domain.Message{UniqueID: "message-1"}))
+	assert.Len(t, publisher.payloads, 1)
+}
+
+func TestExpiryNotifier_ReturnsPublishError(t *testing.T) {
+	publisher := &recordingPublisher{err: errors.New("queue unavailable")}
+
+	err := NewExpiryNotifier(publisher, "https://hooks.example.com/global").NotifyExpired(context.Background(), &domain.Message{UniqueID: "message-1"})
+	assert.ErrorContains(t, err, "queue unavailable")
+}
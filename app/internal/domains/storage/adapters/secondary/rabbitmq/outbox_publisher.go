@@ -0,0 +1,138 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// publishTimeout bounds one publish, including the wait for the broker's confirm
+const publishTimeout = 5 * time.Second
+
+// OutboxPublisher implements domain.OutboxPublisher. It connects on first use and again after
+// the connection is lost, so the relay keeps retrying through a RabbitMQ outage, and waits for
+// a publisher confirm so an entry is only marked delivered once the broker has it.
+type OutboxPublisher struct {
+	url       string
+	queueName string
+
+	mu         sync.Mutex
+	connection *amqp.Connection
+	channel    *amqp.Channel
+}
+
+// OutboxPublisherConfig holds RabbitMQ connection configuration
+type OutboxPublisherConfig struct {
+	Host      string
+	Port      int
+	User      string
+	Password  string
+	QueueName string
+}
+
+// NewOutboxPublisher creates an outbox publisher for the queue the email consumer reads. It
+// does not connect until the first publish.
+func NewOutboxPublisher(config OutboxPublisherConfig) *OutboxPublisher {
+	return &OutboxPublisher{
+		url:       fmt.Sprintf("amqp://%s:%s@%s:%d/", config.User, config.Password, config.Host, config.Port),
+		queueName: config.QueueName,
+	}
+}
+
+// PublishOutboxEntry publishes a payload as a persistent message and waits for the broker to confirm it
+func (p *OutboxPublisher) PublishOutboxEntry(ctx context.Context, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.connect(); err != nil {
+		return err
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(publishCtx,
+		"",          // exchange
+		p.queueName, // routing key
+		false,       // mandatory
+		false,       // immediate
+		amqp.Publishing{
+			DeliveryMode: amqp.Persistent,
+			ContentType:  "application/protobuf",
+			Body:         payload,
+		})
+	if err != nil {
+		p.disconnect()
+		return fmt.Errorf("failed to publish outbox entry: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(publishCtx)
+	if err != nil {
+		p.disconnect()
+		return fmt.Errorf("failed to confirm outbox entry: %w", err)
+	}
+	if !acked {
+		return errors.New("broker rejected outbox entry")
+	}
+	return nil
+}
+
+// connect opens a confirming channel and declares the queue, unless a channel is already open
+func (p *OutboxPublisher) connect() error {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return nil
+	}
+	p.disconnect()
+
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open RabbitMQ channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+	if _, err := ch.QueueDeclare(
+		p.queueName, // name
+		true,        // durable
+		false,       // delete when unused
+		false,       // exclusive
+		false,       // no-wait
+		nil,         // arguments
+	); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	logging.Info().Str("queue", p.queueName).Msg("Outbox publisher connected to RabbitMQ")
+	p.connection = conn
+	p.channel = ch
+	return nil
+}
+
+// disconnect drops the current connection so the next publish dials again
+func (p *OutboxPublisher) disconnect() {
+	if p.connection != nil {
+		p.connection.Close()
+	}
+	p.connection = nil
+	p.channel = nil
+}
+
+// Close closes the RabbitMQ connection
+func (p *OutboxPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.disconnect()
+	return nil
+}
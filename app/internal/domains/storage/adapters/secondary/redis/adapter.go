@@ -0,0 +1,1111 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// defaultMessageTTL is the default time-to-live for messages stored in Redis.
+// Must match the message domain's DefaultMessageTTL.
+const defaultMessageTTL = 7 * 24 * time.Hour
+
+// expiryGracePeriod is how long keys outlive the message's ExpiresAt. Until then the cleanup job
+// can still read an expired message to report and audit it; the TTL only frees the memory if the
+// job does not run.
+const expiryGracePeriod = 24 * time.Hour
+
+// defaultPort is appended to DatabaseConfig.Host when it does not name a port
+const defaultPort = "6379"
+
+// keyPrefix namespaces every key written by the adapter
+const keyPrefix = "passwordexchange:"
+
+// Hash fields of a stored message
+const (
+	fieldID               = "id"
+	fieldContent          = "content"
+	fieldPassphrase       = "passphrase"
+	fieldRecipientEmail   = "recipient_email"
+	fieldViewCount        = "view_count"
+	fieldMaxViewCount     = "max_view_count"
+	fieldCreated          = "created"
+	fieldExpiresAt        = "expires_at"
+	fieldClientEncrypted  = "client_encrypted"
+	fieldRevocationHash   = "revocation_token_hash"
+	fieldSenderEmail      = "sender_email"
+	fieldWebhookURL       = "webhook_url"
+	fieldWebhookSecret    = "webhook_secret"
+	fieldPublicKeySealed  = "public_key_encrypted"
+	fieldPassphraseSalt   = "passphrase_key_salt"
+	fieldFailedAttempts   = "failed_passphrase_attempts"
+	fieldAttachmentName   = "attachment_filename"
+	fieldAttachmentType   = "attachment_content_type"
+	fieldAttachmentSize   = "attachment_size_bytes"
+	fieldReminderEmail    = "email_address"
+	fieldReminderCount    = "reminder_count"
+	fieldLastReminderSent = "last_reminder_sent"
+)
+
+// Hash fields of a stored secret request, alongside fieldID, fieldCreated and fieldExpiresAt
+const (
+	fieldRequesterName   = "requester_name"
+	fieldRequesterEmail  = "requester_email"
+	fieldDescription     = "description"
+	fieldFulfilledAt     = "fulfilled_at"
+	fieldMessageUniqueID = "message_unique_id"
+)
+
+// Keys that are not derived from a message
+const (
+	// idSequenceKey hands out numeric message IDs, which reminders are keyed by
+	idSequenceKey = keyPrefix + "messages:next_id"
+	// createdIndexKey is a sorted set of unique IDs scored by creation time, used to find reminder candidates
+	createdIndexKey = keyPrefix + "messages:created"
+	// expiresIndexKey is a sorted set of unique IDs scored by expiry time, used to find expired messages
+	expiresIndexKey = keyPrefix + "messages:expires"
+	// secretRequestSequenceKey hands out numeric secret request IDs
+	secretRequestSequenceKey = keyPrefix + "secret_requests:next_id"
+)
+
+// messageKey holds the message hash
+func messageKey(uniqueID string) string { return keyPrefix + "message:" + uniqueID }
+
+// attachmentKey holds the attachment's encrypted chunks as a list
+func attachmentKey(uniqueID string) string { return keyPrefix + "message:" + uniqueID + ":attachment" }
+
+// messageIDKey maps a numeric message ID back to its unique ID
+func messageIDKey(messageID int) string { return keyPrefix + "message_id:" + strconv.Itoa(messageID) }
+
+// reminderKey holds the reminder log hash for a message
+func reminderKey(messageID int) string { return keyPrefix + "reminder:" + strconv.Itoa(messageID) }
+
+// secretRequestKey holds the secret request hash
+func secretRequestKey(uniqueID string) string { return keyPrefix + "secret_request:" + uniqueID }
+
+// incrementViewCountScript increments the view count and returns the message hash in one step.
+// Once the view limit is reached the message and everything keyed off it are deleted, so
+// concurrent readers can never see more views than allowed. A message past its expiry counts as
+// gone, even before the cleanup job deletes it.
+//
+// KEYS[1] message hash, KEYS[2] attachment chunks, KEYS[3] created index, KEYS[4] expiry index
+// ARGV[1] unique ID, ARGV[2] key prefix, ARGV[3] current Unix time
+var incrementViewCountScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return false
+end
+local expiresAt = redis.call('ZSCORE', KEYS[4], ARGV[1])
+if expiresAt and tonumber(expiresAt) <= tonumber(ARGV[3]) then
+  return false
+end
+local views = redis.call('HINCRBY', KEYS[1], 'view_count', 1)
+local fields = redis.call('HGETALL', KEYS[1])
+local maxViews = tonumber(redis.call('HGET', KEYS[1], 'max_view_count'))
+if views >= maxViews then
+  local id = redis.call('HGET', KEYS[1], 'id')
+  redis.call('DEL', KEYS[1], KEYS[2], ARGV[2] .. 'message_id:' .. id, ARGV[2] .. 'reminder:' .. id)
+  redis.call('ZREM', KEYS[3], ARGV[1])
+  redis.call('ZREM', KEYS[4], ARGV[1])
+end
+return fields
+`)
+
+// deleteMessageScript removes a message and everything keyed off it, returning 0 when it does not
+// exist. Index entries are removed either way, so none outlive a message whose keys expired.
+//
+// KEYS[1] message hash, KEYS[2] attachment chunks, KEYS[3] created index, KEYS[4] expiry index
+// ARGV[1] unique ID, ARGV[2] key prefix
+var deleteMessageScript = goredis.NewScript(`
+redis.call('ZREM', KEYS[3], ARGV[1])
+redis.call('ZREM', KEYS[4], ARGV[1])
+local id = redis.call('HGET', KEYS[1], 'id')
+if not id then
+  return 0
+end
+redis.call('DEL', KEYS[1], KEYS[2], ARGV[2] .. 'message_id:' .. id, ARGV[2] .. 'reminder:' .. id)
+return 1
+`)
+
+// reserveAttemptScript increments the failed passphrase attempts of an existing message,
+// returning nil when it does not exist so no stray hash is created.
+//
+// KEYS[1] message hash
+var reserveAttemptScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return false
+end
+return redis.call('HINCRBY', KEYS[1], 'failed_passphrase_attempts', 1)
+`)
+
+// releaseAttemptScript decrements the failed passphrase attempts of an existing message, never
+// below zero and without creating a stray hash.
+//
+// KEYS[1] message hash
+var releaseAttemptScript = goredis.NewScript(`
+if tonumber(redis.call('HGET', KEYS[1], 'failed_passphrase_attempts') or '0') > 0 then
+  redis.call('HINCRBY', KEYS[1], 'failed_passphrase_attempts', -1)
+end
+return 1
+`)
+
+// updatePassphraseScript replaces the passphrase hash of an existing message, returning 0 when
+// it does not exist so no stray hash is created.
+//
+// KEYS[1] message hash; ARGV[1] new passphrase hash
+var updatePassphraseScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'passphrase', ARGV[1])
+return 1
+`)
+
+// updateContentScript replaces the content of an existing message if it is unchanged, returning 0
+// when the message is gone or its content differs.
+//
+// KEYS[1] message hash; ARGV[1] current content, ARGV[2] new content
+var updateContentScript = goredis.NewScript(`
+if redis.call('HGET', KEYS[1], 'content') ~= ARGV[1] then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'content', ARGV[2])
+return 1
+`)
+
+// logReminderScript upserts the reminder log and gives it the message's remaining TTL.
+//
+// KEYS[1] reminder hash, KEYS[2] message ID mapping
+// ARGV[1] email address, ARGV[2] send time
+var logReminderScript = goredis.NewScript(`
+redis.call('HSETNX', KEYS[1], 'email_address', ARGV[1])
+redis.call('HINCRBY', KEYS[1], 'reminder_count', 1)
+redis.call('HSET', KEYS[1], 'last_reminder_sent', ARGV[2])
+local ttl = redis.call('PTTL', KEYS[2])
+if ttl > 0 then
+  redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 1
+`)
+
+// fulfillSecretRequestScript records the fulfilling message unless the request is gone or
+// already fulfilled, returning 0 in either case.
+//
+// KEYS[1] secret request hash
+// ARGV[1] message unique ID, ARGV[2] fulfillment time
+var fulfillSecretRequestScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 or redis.call('HEXISTS', KEYS[1], 'fulfilled_at') == 1 then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'fulfilled_at', ARGV[2], 'message_unique_id', ARGV[1])
+return 1
+`)
+
+// RedisAdapter implements the MessageRepository interface for Redis. Messages past their ExpiresAt
+// are no longer served and are deleted by the cleanup job, which reports them like the SQL
+// backends do; their keys also expire expiryGracePeriod later in case the job never runs.
+type RedisAdapter struct {
+	client *goredis.Client
+	config domain.DatabaseConfig
+}
+
+// NewRedisAdapter creates a new Redis database adapter
+func NewRedisAdapter(config domain.DatabaseConfig) domain.MessageRepository {
+	return &RedisAdapter{
+		config: config,
+	}
+}
+
+// Options builds client options from the database configuration. Host may include a port and
+// defaults to 6379; Name, when set, selects the numbered Redis database.
+func Options(config domain.DatabaseConfig) (*goredis.Options, error) {
+	addr := config.Host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultPort)
+	}
+
+	db := 0
+	if config.Name != "" {
+		var err error
+		if db, err = strconv.Atoi(config.Name); err != nil {
+			return nil, fmt.Errorf("%w: redis database must be a number, got %q", domain.ErrDatabaseConnection, config.Name)
+		}
+	}
+
+	return &goredis.Options{
+		Addr:     addr,
+		Username: config.User,
+		Password: config.Password,
+		DB:       db,
+	}, nil
+}
+
+// Connect establishes connection to Redis
+func (r *RedisAdapter) Connect() error {
+	options, err := Options(r.config)
+	if err != nil {
+		logging.Error().Err(err).Msg("Invalid Redis configuration")
+		return err
+	}
+
+	client := goredis.NewClient(options)
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		client.Close() // Close client if ping fails to prevent leak
+		logging.Error().Err(err).Str("addr", options.Addr).Msg("Failed to ping Redis")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseConnection, err)
+	}
+
+	r.client = client
+	return nil
+}
+
+// InsertMessage stores a new encrypted message, indexed by its expiry
+func (r *RedisAdapter) InsertMessage(message *domain.Message) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(defaultMessageTTL)
+	if message.ExpiresAt != nil {
+		expiresAt = message.ExpiresAt.UTC()
+	}
+
+	keysExpireAt := expiresAt.Add(expiryGracePeriod)
+
+	id, err := r.client.Incr(ctx, idSequenceKey).Result()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to allocate message ID")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	var outboxID int64
+	if len(message.Notification) > 0 {
+		if outboxID, err = r.client.Incr(ctx, outboxSequenceKey).Result(); err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to allocate outbox entry ID")
+			return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+	}
+
+	fields := map[string]interface{}{
+		fieldID:              id,
+		fieldContent:         message.Content,
+		fieldPassphrase:      message.Passphrase,
+		fieldRecipientEmail:  message.RecipientEmail,
+		fieldViewCount:       0,
+		fieldMaxViewCount:    message.MaxViewCount,
+		fieldCreated:         now.Format(time.RFC3339Nano),
+		fieldExpiresAt:       expiresAt.Format(time.RFC3339Nano),
+		fieldClientEncrypted: strconv.FormatBool(message.ClientEncrypted),
+	}
+	if message.RevocationTokenHash != "" {
+		fields[fieldRevocationHash] = message.RevocationTokenHash
+	}
+	if message.SenderEmail != "" {
+		fields[fieldSenderEmail] = message.SenderEmail
+	}
+	if message.WebhookURL != "" {
+		fields[fieldWebhookURL] = message.WebhookURL
+		fields[fieldWebhookSecret] = message.WebhookSecret
+	}
+	if message.PublicKeyEncrypted {
+		fields[fieldPublicKeySealed] = strconv.FormatBool(true)
+	}
+	if message.PassphraseKeySalt != "" {
+		fields[fieldPassphraseSalt] = message.PassphraseKeySalt
+	}
+	if message.Attachment != nil {
+		fields[fieldAttachmentName] = message.Attachment.Filename
+		fields[fieldAttachmentType] = message.Attachment.ContentType
+		fields[fieldAttachmentSize] = message.Attachment.SizeBytes
+	}
+
+	// MULTI/EXEC so a message is never visible without its attachment, expiry or queued notification
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, messageKey(message.UniqueID), fields)
+		pipe.ExpireAt(ctx, messageKey(message.UniqueID), keysExpireAt)
+		if message.Attachment != nil {
+			chunks := make([]interface{}, len(message.Attachment.Chunks))
+			for i, chunk := range message.Attachment.Chunks {
+				chunks[i] = chunk
+			}
+			pipe.RPush(ctx, attachmentKey(message.UniqueID), chunks...)
+			pipe.ExpireAt(ctx, attachmentKey(message.UniqueID), keysExpireAt)
+		}
+		pipe.Set(ctx, messageIDKey(int(id)), message.UniqueID, 0)
+		pipe.ExpireAt(ctx, messageIDKey(int(id)), keysExpireAt)
+		pipe.ZAdd(ctx, createdIndexKey, goredis.Z{Score: float64(now.Unix()), Member: message.UniqueID})
+		pipe.ZAdd(ctx, expiresIndexKey, goredis.Z{Score: float64(expiresAt.Unix()), Member: message.UniqueID})
+		if outboxID != 0 {
+			queueOutboxEntry(ctx, pipe, outboxID, message.UniqueID, message.Notification, now, expiresAt)
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to insert message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Str("uniqueID", message.UniqueID).
+		Int("maxViewCount", message.MaxViewCount).
+		Str("recipientEmail", validation.SanitizeEmailForLogging(message.RecipientEmail)).
+		Msg("Message stored successfully")
+	return nil
+}
+
+// SelectMessageByUniqueID retrieves a message by its unique identifier
+func (r *RedisAdapter) SelectMessageByUniqueID(uniqueID string) (*domain.Message, error) {
+	return r.GetMessage(uniqueID)
+}
+
+// GetMessage retrieves a message by its unique identifier without incrementing the view count
+func (r *RedisAdapter) GetMessage(uniqueID string) (*domain.Message, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := r.client.HGetAll(context.Background(), messageKey(uniqueID)).Result()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select message")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if len(fields) == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found")
+		return nil, domain.ErrMessageNotFound
+	}
+
+	message, err := messageFromHash(uniqueID, fields)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to decode message")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if expired(message, time.Now()) {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message expired, awaiting cleanup")
+		return nil, domain.ErrMessageNotFound
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message retrieved successfully")
+	return message, nil
+}
+
+// IncrementViewCountAndGet atomically increments the view count and returns the message.
+// The message is deleted by the same script once it reaches its max view count.
+func (r *RedisAdapter) IncrementViewCountAndGet(uniqueID string) (*domain.Message, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := incrementViewCountScript.Run(
+		context.Background(),
+		r.client,
+		[]string{messageKey(uniqueID), attachmentKey(uniqueID), createdIndexKey, expiresIndexKey},
+		uniqueID,
+		keyPrefix,
+		time.Now().Unix(),
+	).StringSlice()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for view count increment")
+			return nil, domain.ErrMessageNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to increment view count")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	fields := make(map[string]string, len(result)/2)
+	for i := 0; i+1 < len(result); i += 2 {
+		fields[result[i]] = result[i+1]
+	}
+	message, err := messageFromHash(uniqueID, fields)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to decode message after increment")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	if message.ViewCount >= message.MaxViewCount {
+		logging.Info().
+			Str("uniqueID", uniqueID).
+			Int("viewCount", message.ViewCount).
+			Int("maxViewCount", message.MaxViewCount).
+			Msg("Message deleted after reaching view limit")
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("viewCount", message.ViewCount).Msg("View count incremented successfully")
+	return message, nil
+}
+
+// GetAttachment retrieves a message's attachment and all of its chunks without incrementing the view count
+func (r *RedisAdapter) GetAttachment(uniqueID string) (*domain.Attachment, error) {
+	message, err := r.GetMessage(uniqueID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMessageNotFound) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	if message.Attachment == nil {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Attachment not found")
+		return nil, domain.ErrAttachmentNotFound
+	}
+
+	chunks, err := r.client.LRange(context.Background(), attachmentKey(uniqueID), 0, -1).Result()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to query attachment chunks")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	attachment := message.Attachment
+	for _, chunk := range chunks {
+		attachment.Chunks = append(attachment.Chunks, []byte(chunk))
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("chunkCount", len(attachment.Chunks)).Msg("Attachment retrieved successfully")
+	return attachment, nil
+}
+
+// DeleteMessage permanently removes a message together with its attachment and reminder log
+func (r *RedisAdapter) DeleteMessage(uniqueID string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := r.deleteMessage(context.Background(), uniqueID)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete message")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if deleted == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for deletion")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message deleted successfully")
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (r *RedisAdapter) ReservePassphraseAttempt(uniqueID string) (int, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	failedAttempts, err := reserveAttemptScript.Run(
+		context.Background(),
+		r.client,
+		[]string{messageKey(uniqueID)},
+	).Int()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase attempt")
+			return 0, domain.ErrMessageNotFound
+		}
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to reserve passphrase attempt")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Int("failedAttempts", failedAttempts).Msg("Passphrase attempt reserved")
+	return failedAttempts, nil
+}
+
+// ReleasePassphraseAttempt takes back an attempt counted by ReservePassphraseAttempt once the
+// passphrase turned out right. The count never drops below zero.
+func (r *RedisAdapter) ReleasePassphraseAttempt(uniqueID string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	if err := releaseAttemptScript.Run(context.Background(), r.client, []string{messageKey(uniqueID)}).Err(); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to release passphrase attempt")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase attempt released")
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash
+func (r *RedisAdapter) UpdatePassphraseHash(uniqueID, hashedPassphrase string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	updated, err := updatePassphraseScript.Run(
+		context.Background(),
+		r.client,
+		[]string{messageKey(uniqueID)},
+		hashedPassphrase,
+	).Int()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update passphrase hash")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if updated == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found for passphrase hash update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase hash updated")
+	return nil
+}
+
+// SelectMessageContents returns up to limit messages with an ID above afterID, in ID order.
+// Only the ID, unique ID and content are filled in. IDs are walked up to the last one handed out,
+// skipping messages that have expired or been deleted.
+func (r *RedisAdapter) SelectMessageContents(afterID int64, limit int) ([]*domain.Message, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+	ctx := context.Background()
+
+	lastID, err := r.client.Get(ctx, idSequenceKey).Int64()
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		logging.Error().Err(err).Msg("Failed to read message ID sequence")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var messages []*domain.Message
+	for start := afterID + 1; start <= lastID && len(messages) < limit; start += int64(limit) {
+		end := min(start+int64(limit)-1, lastID)
+
+		uniqueIDCmds := make([]*goredis.StringCmd, 0, end-start+1)
+		_, err := r.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			for id := start; id <= end; id++ {
+				uniqueIDCmds = append(uniqueIDCmds, pipe.Get(ctx, messageIDKey(int(id))))
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to read message IDs")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+
+		for i, cmd := range uniqueIDCmds {
+			uniqueID, err := cmd.Result()
+			if errors.Is(err, goredis.Nil) {
+				continue
+			}
+			content, err := r.client.HGet(ctx, messageKey(uniqueID), fieldContent).Result()
+			if errors.Is(err, goredis.Nil) {
+				continue
+			}
+			if err != nil {
+				logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to read message content")
+				return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+			}
+
+			messages = append(messages, &domain.Message{ID: start + int64(i), UniqueID: uniqueID, Content: content})
+			if len(messages) == limit {
+				break
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// UpdateMessageContent replaces a message's content if it still equals currentContent,
+// returning ErrMessageNotFound when the message is gone or its content has changed
+func (r *RedisAdapter) UpdateMessageContent(uniqueID, currentContent, newContent string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	updated, err := updateContentScript.Run(
+		context.Background(),
+		r.client,
+		[]string{messageKey(uniqueID)},
+		currentContent,
+		newContent,
+	).Int()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update message content")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if updated == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Message not found or changed for content update")
+		return domain.ErrMessageNotFound
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Message content updated")
+	return nil
+}
+
+// SelectExpiredMessages returns up to limit messages DeleteExpiredMessages is about to remove,
+// so they can be reported before they are gone. Messages whose keys already expired are skipped;
+// there is nothing left to report for them.
+func (r *RedisAdapter) SelectExpiredMessages(limit int) ([]*domain.Message, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+	ctx := context.Background()
+
+	uniqueIDs, err := r.expiredUniqueIDs(ctx, limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to query expired messages")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	cmds := make([]*goredis.MapStringStringCmd, len(uniqueIDs))
+	_, err = r.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for i, uniqueID := range uniqueIDs {
+			cmds[i] = pipe.HGetAll(ctx, messageKey(uniqueID))
+		}
+		return nil
+	})
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to read expired messages")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var messages []*domain.Message
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			continue
+		}
+		message, err := messageFromHash(uniqueIDs[i], cmd.Val())
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueIDs[i]).Msg("Failed to decode expired message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		messages = append(messages, message)
+	}
+
+	logging.Info().Int("count", len(messages)).Msg("Retrieved expired messages")
+	return messages, nil
+}
+
+// DeleteExpiredMessages removes up to limit messages past their expiry, together with their
+// attachments, reminder logs and index entries. Entries whose keys already expired count as
+// purged too.
+func (r *RedisAdapter) DeleteExpiredMessages(limit int) (int, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return 0, err
+		}
+	}
+	ctx := context.Background()
+
+	uniqueIDs, err := r.expiredUniqueIDs(ctx, limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to query expired messages")
+		return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	for _, uniqueID := range uniqueIDs {
+		if _, err := r.deleteMessage(ctx, uniqueID); err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete expired message")
+			return 0, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+	}
+
+	logging.Info().Int("rowsDeleted", len(uniqueIDs)).Msg("Expired messages cleaned up")
+	return len(uniqueIDs), nil
+}
+
+// expiredUniqueIDs reads up to limit unique IDs from the expiry index whose expiry has passed
+func (r *RedisAdapter) expiredUniqueIDs(ctx context.Context, limit int) ([]string, error) {
+	return r.client.ZRangeByScore(ctx, expiresIndexKey, &goredis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+}
+
+// deleteMessage runs deleteMessageScript for a message, returning 0 when it did not exist
+func (r *RedisAdapter) deleteMessage(ctx context.Context, uniqueID string) (int, error) {
+	return deleteMessageScript.Run(
+		ctx,
+		r.client,
+		[]string{messageKey(uniqueID), attachmentKey(uniqueID), createdIndexKey, expiresIndexKey},
+		uniqueID,
+		keyPrefix,
+	).Int()
+}
+
+// GetUnviewedMessagesForReminders retrieves messages that are unviewed and eligible for reminder emails
+func (r *RedisAdapter) GetUnviewedMessagesForReminders(
+	olderThanHours, maxReminders, reminderIntervalHours int,
+) ([]*domain.UnviewedMessage, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	cutoff := now.Add(-time.Duration(olderThanHours) * time.Hour).Unix()
+	uniqueIDs, err := r.client.ZRangeByScore(ctx, createdIndexKey, &goredis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(cutoff, 10),
+	}).Result()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to query unviewed messages for reminders")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var messages []*domain.UnviewedMessage
+	for _, uniqueID := range uniqueIDs {
+		fields, err := r.client.HGetAll(ctx, messageKey(uniqueID)).Result()
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to read unviewed message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		// The keys expired without the cleanup job deleting the message, so nothing else prunes the entry
+		if len(fields) == 0 {
+			if err := r.client.ZRem(ctx, createdIndexKey, uniqueID).Err(); err != nil {
+				logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to prune message index")
+			}
+			continue
+		}
+		message, err := messageFromHash(uniqueID, fields)
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to decode unviewed message")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		if message.ViewCount != 0 || message.RecipientEmail == "" || expired(message, now) {
+			continue
+		}
+
+		history, err := r.GetReminderHistory(int(message.ID))
+		if err != nil {
+			return nil, err
+		}
+		if len(history) > 0 {
+			entry := history[0]
+			if entry.ReminderCount >= maxReminders {
+				continue
+			}
+			if !entry.LastReminderSent.Before(now.Add(-time.Duration(reminderIntervalHours) * time.Hour)) {
+				continue
+			}
+		}
+
+		messages = append(messages, &domain.UnviewedMessage{
+			MessageID:      int(message.ID),
+			UniqueID:       uniqueID,
+			RecipientEmail: message.RecipientEmail,
+			Created:        message.CreatedAt,
+			DaysOld:        int(now.Sub(message.CreatedAt).Hours() / 24),
+		})
+	}
+
+	logging.Info().Int("count", len(messages)).Msg("Retrieved unviewed messages for reminders")
+	return messages, nil
+}
+
+// LogReminderSent records that a reminder email was sent for a message
+func (r *RedisAdapter) LogReminderSent(messageID int, emailAddress string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	err := logReminderScript.Run(
+		context.Background(),
+		r.client,
+		[]string{reminderKey(messageID), messageIDKey(messageID)},
+		emailAddress,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	).Err()
+	if err != nil {
+		logging.Error().
+			Err(err).
+			Int("messageID", messageID).
+			Str("emailAddress", validation.SanitizeEmailForLogging(emailAddress)).
+			Msg("Failed to log reminder sent")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Int("messageID", messageID).
+		Str("emailAddress", validation.SanitizeEmailForLogging(emailAddress)).
+		Msg("Reminder sent logged successfully")
+	return nil
+}
+
+// GetReminderHistory retrieves the reminder history for a specific message
+func (r *RedisAdapter) GetReminderHistory(messageID int) ([]*domain.ReminderLogEntry, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := r.client.HGetAll(context.Background(), reminderKey(messageID)).Result()
+	if err != nil {
+		logging.Error().Err(err).Int("messageID", messageID).Msg("Failed to query reminder history")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var history []*domain.ReminderLogEntry
+	if len(fields) > 0 {
+		entry := &domain.ReminderLogEntry{
+			MessageID:    messageID,
+			EmailAddress: fields[fieldReminderEmail],
+		}
+		if entry.ReminderCount, err = strconv.Atoi(fields[fieldReminderCount]); err != nil {
+			logging.Error().Err(err).Int("messageID", messageID).Msg("Failed to decode reminder count")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		if entry.LastReminderSent, err = time.Parse(time.RFC3339Nano, fields[fieldLastReminderSent]); err != nil {
+			logging.Error().Err(err).Int("messageID", messageID).Msg("Failed to decode reminder time")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		history = append(history, entry)
+	}
+
+	logging.Info().Int("messageID", messageID).Int("count", len(history)).Msg("Retrieved reminder history")
+	return history, nil
+}
+
+// InsertSecretRequest stores a new secret request with a TTL matching its expiry
+func (r *RedisAdapter) InsertSecretRequest(request *domain.SecretRequest) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	expiresAt := now.Add(defaultMessageTTL)
+	if request.ExpiresAt != nil {
+		expiresAt = request.ExpiresAt.UTC()
+	}
+
+	id, err := r.client.Incr(ctx, secretRequestSequenceKey).Result()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", request.UniqueID).Msg("Failed to allocate secret request ID")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	fields := map[string]interface{}{
+		fieldID:             id,
+		fieldRequesterName:  request.RequesterName,
+		fieldRequesterEmail: request.RequesterEmail,
+		fieldDescription:    request.Description,
+		fieldCreated:        now.Format(time.RFC3339Nano),
+		fieldExpiresAt:      expiresAt.Format(time.RFC3339Nano),
+	}
+
+	// MULTI/EXEC so a request is never visible without its expiry
+	_, err = r.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, secretRequestKey(request.UniqueID), fields)
+		pipe.ExpireAt(ctx, secretRequestKey(request.UniqueID), expiresAt)
+		return nil
+	})
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", request.UniqueID).Msg("Failed to insert secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().
+		Str("uniqueID", request.UniqueID).
+		Str("requesterEmail", validation.SanitizeEmailForLogging(request.RequesterEmail)).
+		Msg("Secret request stored successfully")
+	return nil
+}
+
+// GetSecretRequest retrieves a secret request by its unique identifier
+func (r *RedisAdapter) GetSecretRequest(uniqueID string) (*domain.SecretRequest, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	fields, err := r.client.HGetAll(context.Background(), secretRequestKey(uniqueID)).Result()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to select secret request")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if len(fields) == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("Secret request not found")
+		return nil, domain.ErrSecretRequestNotFound
+	}
+
+	request, err := secretRequestFromHash(uniqueID, fields)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to decode secret request")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Secret request retrieved successfully")
+	return request, nil
+}
+
+// FulfillSecretRequest records the message that fulfilled a secret request. The check and
+// update run in one script, so concurrent submissions cannot both fulfill it.
+func (r *RedisAdapter) FulfillSecretRequest(uniqueID, messageUniqueID string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	fulfilled, err := fulfillSecretRequestScript.Run(
+		context.Background(),
+		r.client,
+		[]string{secretRequestKey(uniqueID)},
+		messageUniqueID,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	).Int()
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Msg("Failed to fulfill secret request")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if fulfilled == 0 {
+		logging.Debug().Str("uniqueID", uniqueID).Msg("No open secret request to fulfill")
+		return domain.ErrSecretRequestUnavailable
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Str("messageUniqueID", messageUniqueID).Msg("Secret request fulfilled successfully")
+	return nil
+}
+
+// DeleteExpiredSecretRequests does nothing; Redis removes secret requests when their TTL passes
+func (r *RedisAdapter) DeleteExpiredSecretRequests() (int, error) {
+	return 0, nil
+}
+
+// Close closes the Redis client
+func (r *RedisAdapter) Close() error {
+	if r.client != nil {
+		if err := r.client.Close(); err != nil {
+			logging.Error().Err(err).Msg("Failed to close Redis connection")
+			return err
+		}
+		r.client = nil
+	}
+	return nil
+}
+
+// expired reports whether a message is past its expiry and only awaits the cleanup job
+func expired(message *domain.Message, now time.Time) bool {
+	return message.ExpiresAt != nil && !now.Before(*message.ExpiresAt)
+}
+
+// messageFromHash decodes a message hash into a domain.Message
+func messageFromHash(uniqueID string, fields map[string]string) (*domain.Message, error) {
+	message := &domain.Message{
+		UniqueID:            uniqueID,
+		Content:             fields[fieldContent],
+		Passphrase:          fields[fieldPassphrase],
+		RecipientEmail:      fields[fieldRecipientEmail],
+		RevocationTokenHash: fields[fieldRevocationHash],
+		SenderEmail:         fields[fieldSenderEmail],
+		WebhookURL:          fields[fieldWebhookURL],
+		WebhookSecret:       fields[fieldWebhookSecret],
+		PassphraseKeySalt:   fields[fieldPassphraseSalt],
+	}
+
+	var err error
+	if message.ID, err = strconv.ParseInt(fields[fieldID], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldID, err)
+	}
+	if message.ViewCount, err = strconv.Atoi(fields[fieldViewCount]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldViewCount, err)
+	}
+	if message.MaxViewCount, err = strconv.Atoi(fields[fieldMaxViewCount]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldMaxViewCount, err)
+	}
+	if message.CreatedAt, err = time.Parse(time.RFC3339Nano, fields[fieldCreated]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldCreated, err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339Nano, fields[fieldExpiresAt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldExpiresAt, err)
+	}
+	message.ExpiresAt = &expiresAt
+	if message.ClientEncrypted, err = strconv.ParseBool(fields[fieldClientEncrypted]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldClientEncrypted, err)
+	}
+	// The counter only exists once a wrong passphrase has been submitted
+	if failed, ok := fields[fieldFailedAttempts]; ok {
+		if message.FailedPassphraseAttempts, err = strconv.Atoi(failed); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fieldFailedAttempts, err)
+		}
+	}
+	// Messages stored before public-key encryption existed have no such field
+	if sealed, ok := fields[fieldPublicKeySealed]; ok {
+		if message.PublicKeyEncrypted, err = strconv.ParseBool(sealed); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fieldPublicKeySealed, err)
+		}
+	}
+
+	if size, ok := fields[fieldAttachmentSize]; ok {
+		attachment := &domain.Attachment{
+			Filename:    fields[fieldAttachmentName],
+			ContentType: fields[fieldAttachmentType],
+		}
+		if attachment.SizeBytes, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fieldAttachmentSize, err)
+		}
+		message.Attachment = attachment
+	}
+
+	return message, nil
+}
+
+// secretRequestFromHash decodes a secret request hash into a domain.SecretRequest
+func secretRequestFromHash(uniqueID string, fields map[string]string) (*domain.SecretRequest, error) {
+	request := &domain.SecretRequest{
+		UniqueID:        uniqueID,
+		RequesterName:   fields[fieldRequesterName],
+		RequesterEmail:  fields[fieldRequesterEmail],
+		Description:     fields[fieldDescription],
+		MessageUniqueID: fields[fieldMessageUniqueID],
+	}
+
+	var err error
+	if request.ID, err = strconv.ParseInt(fields[fieldID], 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldID, err)
+	}
+	if request.CreatedAt, err = time.Parse(time.RFC3339Nano, fields[fieldCreated]); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldCreated, err)
+	}
+	expiresAt, err := time.Parse(time.RFC3339Nano, fields[fieldExpiresAt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", fieldExpiresAt, err)
+	}
+	request.ExpiresAt = &expiresAt
+	if value, ok := fields[fieldFulfilledAt]; ok {
+		fulfilledAt, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fieldFulfilledAt, err)
+		}
+		request.FulfilledAt = &fulfilledAt
+	}
+
+	return request, nil
+}
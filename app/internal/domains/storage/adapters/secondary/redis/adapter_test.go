@@ -0,0 +1,473 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAdapter returns a connected adapter backed by an in-process Redis server
+func newTestAdapter(t *testing.T) (*RedisAdapter, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	adapter := NewRedisAdapter(domain.DatabaseConfig{
+		Driver: domain.DriverRedis,
+		Host:   server.Addr(),
+	}).(*RedisAdapter)
+	require.NoError(t, adapter.Connect())
+	t.Cleanup(func() { adapter.Close() })
+	return adapter, server
+}
+
+func TestOptions(t *testing.T) {
+	options, err := Options(domain.DatabaseConfig{Host: "redis", Password: "secret", Name: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, "redis:6379", options.Addr)
+	assert.Equal(t, "secret", options.Password)
+	assert.Equal(t, 2, options.DB)
+
+	options, err = Options(domain.DatabaseConfig{Host: "redis:6380"})
+	require.NoError(t, err)
+	assert.Equal(t, "redis:6380", options.Addr)
+	assert.Equal(t, 0, options.DB)
+
+	_, err = Options(domain.DatabaseConfig{Host: "redis", Name: "passwordexchange"})
+	assert.ErrorIs(t, err, domain.ErrDatabaseConnection)
+}
+
+func TestRedisAdapter_InsertMessage_IndexesExpiresAt(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	expiresAt := time.Now().Add(2 * time.Hour)
+
+	err := adapter.InsertMessage(&domain.Message{
+		Content:             "encrypted-content",
+		UniqueID:            "test-uuid",
+		Passphrase:          "hashed-passphrase",
+		RecipientEmail:      "recipient@example.com",
+		MaxViewCount:        3,
+		ExpiresAt:           &expiresAt,
+		ClientEncrypted:     true,
+		RevocationTokenHash: "token-hash",
+		SenderEmail:         "sender@example.com",
+		PublicKeyEncrypted:  true,
+		PassphraseKeySalt:   "c2FsdC1zYWx0LXNhbHQ",
+		WebhookURL:          "https://hooks.example.com/events",
+		WebhookSecret:       "0123456789abcdef",
+	})
+	require.NoError(t, err)
+
+	// The keys outlive the expiry so the cleanup job can still report the message
+	ttl := server.TTL(messageKey("test-uuid"))
+	assert.InDelta(t, (2*time.Hour + expiryGracePeriod).Seconds(), ttl.Seconds(), 5)
+	score, err := server.ZScore(expiresIndexKey, "test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, float64(expiresAt.Unix()), score)
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "encrypted-content", message.Content)
+	assert.Equal(t, "hashed-passphrase", message.Passphrase)
+	assert.Equal(t, "recipient@example.com", message.RecipientEmail)
+	assert.Equal(t, 0, message.ViewCount)
+	assert.Equal(t, 3, message.MaxViewCount)
+	assert.True(t, message.ClientEncrypted)
+	assert.Equal(t, "token-hash", message.RevocationTokenHash)
+	assert.Equal(t, "sender@example.com", message.SenderEmail)
+	assert.True(t, message.PublicKeyEncrypted)
+	assert.Equal(t, "c2FsdC1zYWx0LXNhbHQ", message.PassphraseKeySalt)
+	assert.Equal(t, "https://hooks.example.com/events", message.WebhookURL)
+	assert.Equal(t, "0123456789abcdef", message.WebhookSecret)
+	assert.Nil(t, message.Attachment)
+	require.NotNil(t, message.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*message.ExpiresAt))
+
+	// Should the cleanup job never run, the keys still expire eventually
+	server.FastForward(2*time.Hour + expiryGracePeriod + time.Second)
+	_, err = adapter.GetMessage("test-uuid")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestRedisAdapter_GetMessage_NotFound(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	_, err := adapter.GetMessage("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestRedisAdapter_IncrementViewCountAndGet_DeletesAtLimit(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:      "encrypted-content",
+		UniqueID:     "test-uuid",
+		MaxViewCount: 2,
+		Attachment: &domain.Attachment{
+			Filename:  "enc-name",
+			SizeBytes: 3,
+			Chunks:    [][]byte{[]byte("abc")},
+		},
+	}))
+
+	message, err := adapter.IncrementViewCountAndGet("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 1, message.ViewCount)
+	assert.Equal(t, "encrypted-content", message.Content)
+
+	message, err = adapter.IncrementViewCountAndGet("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 2, message.ViewCount)
+
+	_, err = adapter.IncrementViewCountAndGet("test-uuid")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	assert.False(t, server.Exists(messageKey("test-uuid")))
+	assert.False(t, server.Exists(attachmentKey("test-uuid")))
+	assert.False(t, server.Exists(messageIDKey(1)))
+	assert.False(t, server.Exists(createdIndexKey))
+	assert.False(t, server.Exists(expiresIndexKey))
+}
+
+func TestRedisAdapter_IncrementViewCountAndGet_NotFound(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+
+	_, err := adapter.IncrementViewCountAndGet("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestRedisAdapter_GetAttachment(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:      "encrypted-content",
+		UniqueID:     "test-uuid",
+		MaxViewCount: 5,
+		Attachment: &domain.Attachment{
+			Filename:    "enc-name",
+			ContentType: "enc-type",
+			SizeBytes:   6,
+			Chunks:      [][]byte{[]byte("abc"), []byte("def")},
+		},
+	}))
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	require.NotNil(t, message.Attachment)
+	assert.Equal(t, int64(6), message.Attachment.SizeBytes)
+	assert.Nil(t, message.Attachment.Chunks)
+
+	attachment, err := adapter.GetAttachment("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "enc-name", attachment.Filename)
+	assert.Equal(t, "enc-type", attachment.ContentType)
+	assert.Equal(t, [][]byte{[]byte("abc"), []byte("def")}, attachment.Chunks)
+}
+
+func TestRedisAdapter_GetAttachment_NotFound(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "no-file", MaxViewCount: 5}))
+
+	_, err := adapter.GetAttachment("no-file")
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	_, err = adapter.GetAttachment("missing")
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+}
+
+func TestRedisAdapter_DeleteMessage(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:      "c",
+		UniqueID:     "test-uuid",
+		MaxViewCount: 5,
+		Attachment:   &domain.Attachment{Filename: "enc-name", SizeBytes: 3, Chunks: [][]byte{[]byte("abc")}},
+	}))
+
+	require.NoError(t, adapter.DeleteMessage("test-uuid"))
+
+	assert.False(t, server.Exists(messageKey("test-uuid")))
+	assert.False(t, server.Exists(attachmentKey("test-uuid")))
+	assert.False(t, server.Exists(messageIDKey(1)))
+	assert.False(t, server.Exists(createdIndexKey))
+	assert.False(t, server.Exists(expiresIndexKey))
+	assert.ErrorIs(t, adapter.DeleteMessage("test-uuid"), domain.ErrMessageNotFound)
+}
+
+func TestRedisAdapter_ReservePassphraseAttempt(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "hash", MaxViewCount: 5}))
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Zero(t, message.FailedPassphraseAttempts)
+
+	for want := 1; want <= 2; want++ {
+		failedAttempts, err := adapter.ReservePassphraseAttempt("test-uuid")
+		require.NoError(t, err)
+		assert.Equal(t, want, failedAttempts)
+	}
+
+	message, err = adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 2, message.FailedPassphraseAttempts)
+
+	_, err = adapter.ReservePassphraseAttempt("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	assert.False(t, server.Exists(messageKey("missing")))
+}
+
+func TestRedisAdapter_ReleasePassphraseAttempt(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "hash", MaxViewCount: 5}))
+
+	_, err := adapter.ReservePassphraseAttempt("test-uuid")
+	require.NoError(t, err)
+
+	// Releasing more than was reserved leaves the count at zero
+	for i := 0; i < 2; i++ {
+		require.NoError(t, adapter.ReleasePassphraseAttempt("test-uuid"))
+	}
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Zero(t, message.FailedPassphraseAttempts)
+
+	require.NoError(t, adapter.ReleasePassphraseAttempt("missing"))
+	assert.False(t, server.Exists(messageKey("missing")))
+}
+
+func TestRedisAdapter_UpdatePassphraseHash(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "old-hash", MaxViewCount: 5}))
+
+	require.NoError(t, adapter.UpdatePassphraseHash("test-uuid", "new-hash"))
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", message.Passphrase)
+
+	assert.ErrorIs(t, adapter.UpdatePassphraseHash("missing", "new-hash"), domain.ErrMessageNotFound)
+	assert.False(t, server.Exists(messageKey("missing")))
+}
+
+func TestRedisAdapter_MessageContents(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	for _, uniqueID := range []string{"first", "second", "third", "fourth"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: uniqueID + "-content", UniqueID: uniqueID, MaxViewCount: 5}))
+	}
+	require.NoError(t, adapter.DeleteMessage("second"))
+
+	page, err := adapter.SelectMessageContents(0, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "first", page[0].UniqueID)
+	assert.Equal(t, "third-content", page[1].Content)
+
+	page, err = adapter.SelectMessageContents(page[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "fourth", page[0].UniqueID)
+
+	require.NoError(t, adapter.UpdateMessageContent("first", "first-content", "rewrapped"))
+	message, err := adapter.GetMessage("first")
+	require.NoError(t, err)
+	assert.Equal(t, "rewrapped", message.Content)
+	assert.True(t, server.TTL(messageKey("first")) > 0, "the message must keep its expiry")
+
+	assert.ErrorIs(t, adapter.UpdateMessageContent("first", "first-content", "stale"), domain.ErrMessageNotFound)
+	assert.ErrorIs(t, adapter.UpdateMessageContent("missing", "c", "rewrapped"), domain.ErrMessageNotFound)
+	assert.False(t, server.Exists(messageKey("missing")))
+}
+
+func TestRedisAdapter_ExpiredMessages(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	past := time.Now().Add(-time.Minute)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:       "c",
+		UniqueID:      "expired",
+		MaxViewCount:  5,
+		ExpiresAt:     &past,
+		WebhookURL:    "https://hooks.example.com/events",
+		WebhookSecret: "0123456789abcdef",
+		Attachment:    &domain.Attachment{Filename: "enc-name", SizeBytes: 3, Chunks: [][]byte{[]byte("abc")}},
+	}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "live", MaxViewCount: 5, ExpiresAt: &later}))
+
+	// Past its expiry the message is no longer served, though it is kept for the cleanup job
+	_, err := adapter.GetMessage("expired")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	_, err = adapter.IncrementViewCountAndGet("expired")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	assert.Equal(t, "0", server.HGet(messageKey("expired"), fieldViewCount))
+
+	expired, err := adapter.SelectExpiredMessages(100)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "expired", expired[0].UniqueID)
+	assert.Equal(t, "https://hooks.example.com/events", expired[0].WebhookURL)
+
+	purged, err := adapter.DeleteExpiredMessages(100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.False(t, server.Exists(messageKey("expired")))
+	assert.False(t, server.Exists(attachmentKey("expired")))
+	assert.False(t, server.Exists(messageIDKey(1)))
+
+	for _, index := range []string{createdIndexKey, expiresIndexKey} {
+		members, err := server.ZMembers(index)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"live"}, members, index)
+	}
+
+	expired, err = adapter.SelectExpiredMessages(100)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+}
+
+func TestRedisAdapter_ExpiredMessages_Limit(t *testing.T) {
+	adapter, _ := newTestAdapter(t)
+	past := time.Now().Add(-time.Minute)
+	for _, uniqueID := range []string{"first", "second", "third"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: uniqueID, MaxViewCount: 5, ExpiresAt: &past}))
+	}
+
+	expired, err := adapter.SelectExpiredMessages(2)
+	require.NoError(t, err)
+	assert.Len(t, expired, 2)
+
+	purged, err := adapter.DeleteExpiredMessages(2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	purged, err = adapter.DeleteExpiredMessages(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+}
+
+func TestRedisAdapter_ExpiredMessages_KeysAlreadyExpired(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "gone", MaxViewCount: 5, ExpiresAt: &past}))
+
+	// The grace period ran out before the cleanup job did
+	server.FastForward(expiryGracePeriod)
+	require.False(t, server.Exists(messageKey("gone")))
+
+	expired, err := adapter.SelectExpiredMessages(100)
+	require.NoError(t, err)
+	assert.Empty(t, expired)
+
+	purged, err := adapter.DeleteExpiredMessages(100)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	assert.False(t, server.Exists(createdIndexKey))
+	assert.False(t, server.Exists(expiresIndexKey))
+}
+
+func TestRedisAdapter_Reminders(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:        "c",
+		UniqueID:       "old",
+		RecipientEmail: "recipient@example.com",
+		MaxViewCount:   5,
+	}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "no-email", MaxViewCount: 5}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:        "c",
+		UniqueID:       "recent",
+		RecipientEmail: "recipient@example.com",
+		MaxViewCount:   5,
+	}))
+
+	// Backdate the first two messages by three days
+	created := time.Now().Add(-72 * time.Hour).UTC()
+	for _, uniqueID := range []string{"old", "no-email"} {
+		server.HSet(messageKey(uniqueID), fieldCreated, created.Format(time.RFC3339Nano))
+		_, err := server.ZAdd(createdIndexKey, float64(created.Unix()), uniqueID)
+		require.NoError(t, err)
+	}
+	// An index entry whose keys expired without the cleanup job
+	_, err := server.ZAdd(createdIndexKey, float64(created.Unix()), "gone")
+	require.NoError(t, err)
+
+	messages, err := adapter.GetUnviewedMessagesForReminders(24, 3, 24)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "old", messages[0].UniqueID)
+	assert.Equal(t, "recipient@example.com", messages[0].RecipientEmail)
+	assert.Equal(t, 3, messages[0].DaysOld)
+
+	members, err := server.ZMembers(createdIndexKey)
+	require.NoError(t, err)
+	assert.NotContains(t, members, "gone")
+
+	messageID := messages[0].MessageID
+	require.NoError(t, adapter.LogReminderSent(messageID, "recipient@example.com"))
+	require.NoError(t, adapter.LogReminderSent(messageID, "recipient@example.com"))
+
+	history, err := adapter.GetReminderHistory(messageID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 2, history[0].ReminderCount)
+	assert.Equal(t, "recipient@example.com", history[0].EmailAddress)
+	assert.WithinDuration(t, time.Now(), history[0].LastReminderSent, time.Minute)
+
+	// The reminder log expires with the message
+	assert.Greater(t, server.TTL(reminderKey(messageID)), time.Duration(0))
+
+	// A reminder was just sent, so the interval excludes the message
+	messages, err = adapter.GetUnviewedMessagesForReminders(24, 3, 24)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestRedisAdapter_SecretRequests(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	expiresAt := time.Now().Add(2 * time.Hour)
+	require.NoError(t, adapter.InsertSecretRequest(&domain.SecretRequest{
+		UniqueID:       "request-uuid",
+		RequesterName:  "Alice",
+		RequesterEmail: "alice@example.com",
+		Description:    "VPN password",
+		ExpiresAt:      &expiresAt,
+	}))
+
+	ttl := server.TTL(secretRequestKey("request-uuid"))
+	assert.InDelta(t, (2 * time.Hour).Seconds(), ttl.Seconds(), 5)
+
+	request, err := adapter.GetSecretRequest("request-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", request.RequesterName)
+	assert.Equal(t, "alice@example.com", request.RequesterEmail)
+	assert.Equal(t, "VPN password", request.Description)
+	require.NotNil(t, request.ExpiresAt)
+	assert.WithinDuration(t, expiresAt, *request.ExpiresAt, time.Second)
+	assert.Nil(t, request.FulfilledAt)
+
+	require.NoError(t, adapter.FulfillSecretRequest("request-uuid", "message-uuid"))
+	assert.ErrorIs(t, adapter.FulfillSecretRequest("request-uuid", "other-message"), domain.ErrSecretRequestUnavailable)
+
+	request, err = adapter.GetSecretRequest("request-uuid")
+	require.NoError(t, err)
+	assert.NotNil(t, request.FulfilledAt)
+	assert.Equal(t, "message-uuid", request.MessageUniqueID)
+
+	_, err = adapter.GetSecretRequest("missing")
+	assert.ErrorIs(t, err, domain.ErrSecretRequestNotFound)
+	assert.ErrorIs(t, adapter.FulfillSecretRequest("missing", "message-uuid"), domain.ErrSecretRequestUnavailable)
+
+	// Requests expire with their key
+	server.FastForward(3 * time.Hour)
+	_, err = adapter.GetSecretRequest("request-uuid")
+	assert.ErrorIs(t, err, domain.ErrSecretRequestNotFound)
+}
+
+func TestRedisAdapter_ConnectFailure(t *testing.T) {
+	server := miniredis.RunT(t)
+	addr := server.Addr()
+	server.Close()
+
+	adapter := NewRedisAdapter(domain.DatabaseConfig{Host: addr})
+	err := adapter.(*RedisAdapter).Connect()
+	assert.ErrorIs(t, err, domain.ErrDatabaseConnection)
+}
@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// auditLogKey is a list of JSON audit entries; the entry with sequence n is at index n-1.
+// Unlike messages it has no TTL.
+const auditLogKey = keyPrefix + "audit_events"
+
+// appendAuditScript appends an audit entry only if it takes the next sequence, returning 0 when
+// another writer got there first.
+//
+// KEYS[1] audit list; ARGV[1] sequence, ARGV[2] JSON entry
+var appendAuditScript = goredis.NewScript(`
+if redis.call('LLEN', KEYS[1]) + 1 ~= tonumber(ARGV[1]) then
+  return 0
+end
+redis.call('RPUSH', KEYS[1], ARGV[2])
+return 1
+`)
+
+// storedAuditEvent is the JSON form of an audit entry
+type storedAuditEvent struct {
+	Sequence      int64     `json:"sequence"`
+	EventType     string    `json:"event_type"`
+	MessageID     string    `json:"message_uniqueid"`
+	ClientIPHash  string    `json:"client_ip_hash"`
+	UserAgentHash string    `json:"user_agent_hash"`
+	OccurredAt    time.Time `json:"occurred_at"`
+	PrevHash      string    `json:"prev_hash"`
+	Hash          string    `json:"hash"`
+}
+
+// LastAuditEvent returns the audit entry with the highest sequence, or nil when there is none
+func (r *RedisAdapter) LastAuditEvent() (*domain.AuditEvent, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	encoded, err := r.client.LIndex(context.Background(), auditLogKey, -1).Result()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to read last audit event")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return decodeAuditEvent(encoded)
+}
+
+// AppendAuditEvent appends an audit entry if its sequence is the next one
+func (r *RedisAdapter) AppendAuditEvent(event *domain.AuditEvent) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := json.Marshal(storedAuditEvent{
+		Sequence:      event.Sequence,
+		EventType:     string(event.EventType),
+		MessageID:     event.MessageID,
+		ClientIPHash:  event.ClientIPHash,
+		UserAgentHash: event.UserAgentHash,
+		OccurredAt:    event.OccurredAt,
+		PrevHash:      event.PrevHash,
+		Hash:          event.Hash,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	appended, err := appendAuditScript.Run(context.Background(), r.client, []string{auditLogKey}, event.Sequence, encoded).Int()
+	if err != nil {
+		logging.Error().Err(err).Int64("sequence", event.Sequence).Msg("Failed to append audit event")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if appended == 0 {
+		return fmt.Errorf("%w: %d", domain.ErrAuditConflict, event.Sequence)
+	}
+	return nil
+}
+
+// SelectAuditEvents returns up to limit audit entries after afterSequence, in sequence order
+func (r *RedisAdapter) SelectAuditEvents(afterSequence int64, limit int) ([]*domain.AuditEvent, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	encoded, err := r.client.LRange(context.Background(), auditLogKey, afterSequence, afterSequence+int64(limit)-1).Result()
+	if err != nil {
+		logging.Error().Err(err).Int64("afterSequence", afterSequence).Msg("Failed to select audit events")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	events := make([]*domain.AuditEvent, 0, len(encoded))
+	for _, entry := range encoded {
+		event, err := decodeAuditEvent(entry)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// decodeAuditEvent parses an entry stored by AppendAuditEvent
+func decodeAuditEvent(encoded string) (*domain.AuditEvent, error) {
+	var stored storedAuditEvent
+	if err := json.Unmarshal([]byte(encoded), &stored); err != nil {
+		logging.Error().Err(err).Msg("Failed to decode audit event")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return &domain.AuditEvent{
+		Sequence:      stored.Sequence,
+		EventType:     domain.AuditEventType(stored.EventType),
+		MessageID:     stored.MessageID,
+		ClientIPHash:  stored.ClientIPHash,
+		UserAgentHash: stored.UserAgentHash,
+		OccurredAt:    stored.OccurredAt,
+		PrevHash:      stored.PrevHash,
+		Hash:          stored.Hash,
+	}, nil
+}
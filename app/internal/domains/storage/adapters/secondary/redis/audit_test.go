@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func TestRedisAdapter_AuditLog(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	auditLog := domain.NewAuditLog(adapter, []byte("client-key"))
+	ctx := domain.WithAuditClient(context.Background(), domain.AuditClient{IP: "203.0.113.7"})
+
+	last, err := adapter.LastAuditEvent()
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	for _, eventType := range []domain.AuditEventType{domain.AuditEventCreated, domain.AuditEventPassphraseFailed, domain.AuditEventDeleted} {
+		require.NoError(t, auditLog.Record(ctx, eventType, "message-1"))
+	}
+
+	verification, err := auditLog.VerifyAuditLog(context.Background(), domain.AuditAnchor{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), verification.Events)
+
+	events, err := adapter.SelectAuditEvents(1, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(2), events[0].Sequence)
+	assert.Equal(t, domain.AuditEventPassphraseFailed, events[0].EventType)
+
+	// A second writer chaining onto the same entry loses
+	stale := *events[1]
+	assert.ErrorIs(t, adapter.AppendAuditEvent(&stale), domain.ErrAuditConflict)
+
+	// The log does not expire with the messages it describes
+	assert.Zero(t, server.TTL(auditLogKey))
+}
@@ -0,0 +1,322 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Outbox keys. Each entry is a hash that expires with its message; the pending index is a
+// sorted set of undelivered entry IDs scored by their next attempt in Unix milliseconds.
+const (
+	outboxSequenceKey = keyPrefix + "outbox:next_id"
+	outboxPendingKey  = keyPrefix + "outbox:pending"
+)
+
+// Hash fields of an outbox entry, alongside fieldCreated and fieldMessageUniqueID
+const (
+	fieldPayload      = "payload"
+	fieldAttempts     = "attempts"
+	fieldLastError    = "last_error"
+	fieldDeliveredAt  = "delivered_at"
+	fieldDeadLettered = "dead_lettered_at"
+)
+
+// outboxEntryKey holds an outbox entry hash
+func outboxEntryKey(id int64) string { return keyPrefix + "outbox:" + strconv.FormatInt(id, 10) }
+
+// claimOutboxScript moves the next attempt of up to ARGV[2] due entries to ARGV[3] and returns
+// them as flat id, unique ID, payload, attempts, created groups. Entries whose message is gone,
+// because it was viewed, revoked or expired, are dropped instead, as the SQL foreign key would.
+//
+// KEYS[1] pending index
+// ARGV[1] now, ARGV[2] limit, ARGV[3] lease end, ARGV[4] key prefix
+var claimOutboxScript = goredis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[2]))
+local claimed = {}
+for _, id in ipairs(ids) do
+  local entryKey = ARGV[4] .. 'outbox:' .. id
+  local fields = redis.call('HMGET', entryKey, 'message_unique_id', 'payload', 'attempts', 'created')
+  if fields[1] and redis.call('EXISTS', ARGV[4] .. 'message:' .. fields[1]) == 1 then
+    redis.call('ZADD', KEYS[1], ARGV[3], id)
+    table.insert(claimed, id)
+    table.insert(claimed, fields[1])
+    table.insert(claimed, fields[2] or '')
+    table.insert(claimed, fields[3] or '0')
+    table.insert(claimed, fields[4] or '')
+  else
+    redis.call('ZREM', KEYS[1], id)
+    redis.call('DEL', entryKey)
+  end
+end
+return claimed
+`)
+
+// markOutboxDeliveredScript removes an entry from the pending index and clears its payload,
+// without recreating an entry that already expired.
+//
+// KEYS[1] entry hash, KEYS[2] pending index
+// ARGV[1] entry ID, ARGV[2] delivery time
+var markOutboxDeliveredScript = goredis.NewScript(`
+redis.call('ZREM', KEYS[2], ARGV[1])
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  redis.call('HDEL', KEYS[1], 'payload')
+  redis.call('HSET', KEYS[1], 'delivered_at', ARGV[2])
+end
+return 1
+`)
+
+// markOutboxFailedScript counts a failed publish and reschedules the entry, unless it is gone.
+//
+// KEYS[1] entry hash, KEYS[2] pending index
+// ARGV[1] entry ID, ARGV[2] next attempt, ARGV[3] error
+var markOutboxFailedScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+  redis.call('ZREM', KEYS[2], ARGV[1])
+  return 0
+end
+redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+redis.call('HSET', KEYS[1], 'last_error', ARGV[3])
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return 1
+`)
+
+// deadLetterOutboxScript removes an entry from the pending index and records why, keeping its
+// payload, without recreating an entry that already expired.
+//
+// KEYS[1] entry hash, KEYS[2] pending index
+// ARGV[1] entry ID, ARGV[2] dead-letter time, ARGV[3] error
+var deadLetterOutboxScript = goredis.NewScript(`
+redis.call('ZREM', KEYS[2], ARGV[1])
+if redis.call('EXISTS', KEYS[1]) == 1 then
+  redis.call('HSET', KEYS[1], 'dead_lettered_at', ARGV[2], 'last_error', ARGV[3])
+end
+return 1
+`)
+
+// updateOutboxPayloadScript replaces the payload of a pending entry if it is unchanged, returning
+// 0 when the entry is gone, delivered, dead-lettered or its payload differs.
+//
+// KEYS[1] entry hash, KEYS[2] pending index
+// ARGV[1] entry ID, ARGV[2] current payload, ARGV[3] new payload
+var updateOutboxPayloadScript = goredis.NewScript(`
+if not redis.call('ZSCORE', KEYS[2], ARGV[1]) or redis.call('HGET', KEYS[1], 'payload') ~= ARGV[2] then
+  return 0
+end
+redis.call('HSET', KEYS[1], 'payload', ARGV[3])
+return 1
+`)
+
+// queueOutboxEntry adds a message's notification to the transaction that stores the message
+func queueOutboxEntry(ctx context.Context, pipe goredis.Pipeliner, id int64, uniqueID string, payload []byte, now, expiresAt time.Time) {
+	pipe.HSet(ctx, outboxEntryKey(id), map[string]interface{}{
+		fieldMessageUniqueID: uniqueID,
+		fieldPayload:         payload,
+		fieldAttempts:        0,
+		fieldCreated:         now.Format(time.RFC3339Nano),
+	})
+	pipe.ExpireAt(ctx, outboxEntryKey(id), expiresAt)
+	pipe.ZAdd(ctx, outboxPendingKey, goredis.Z{Score: float64(now.UnixMilli()), Member: id})
+}
+
+// ClaimOutboxEntries moves the next attempt of up to limit due entries lease into the future
+// and returns them
+func (r *RedisAdapter) ClaimOutboxEntries(limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	result, err := claimOutboxScript.Run(
+		context.Background(),
+		r.client,
+		[]string{outboxPendingKey},
+		now.UnixMilli(),
+		limit,
+		now.Add(lease).UnixMilli(),
+		keyPrefix,
+	).StringSlice()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to claim outbox entries")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var entries []*domain.OutboxEntry
+	for i := 0; i+4 < len(result); i += 5 {
+		id, err := strconv.ParseInt(result[i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: outbox entry id %q: %v", domain.ErrDatabaseOperation, result[i], err)
+		}
+		attempts, _ := strconv.Atoi(result[i+3])
+		created, _ := time.Parse(time.RFC3339Nano, result[i+4])
+		entries = append(entries, &domain.OutboxEntry{
+			ID:        id,
+			MessageID: result[i+1],
+			Payload:   []byte(result[i+2]),
+			Attempts:  attempts,
+			CreatedAt: created,
+		})
+	}
+	return entries, nil
+}
+
+// MarkOutboxEntryDelivered removes the entry from the pending index and clears its payload, which
+// holds the message link. The delivered entry expires with its message.
+func (r *RedisAdapter) MarkOutboxEntryDelivered(id int64) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+	err := markOutboxDeliveredScript.Run(
+		context.Background(),
+		r.client,
+		[]string{outboxEntryKey(id), outboxPendingKey},
+		id,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	).Err()
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to mark outbox entry delivered")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// MarkOutboxEntryFailed counts a failed publish and schedules the next attempt
+func (r *RedisAdapter) MarkOutboxEntryFailed(id int64, retryAfter time.Duration, lastError string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	err := markOutboxFailedScript.Run(
+		context.Background(),
+		r.client,
+		[]string{outboxEntryKey(id), outboxPendingKey},
+		id,
+		time.Now().Add(retryAfter).UnixMilli(),
+		lastError,
+	).Err()
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to mark outbox entry failed")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// DeadLetterOutboxEntry removes the entry from the pending index, keeping its payload and
+// lastError for inspection until it expires with its message
+func (r *RedisAdapter) DeadLetterOutboxEntry(id int64, lastError string) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	err := deadLetterOutboxScript.Run(
+		context.Background(),
+		r.client,
+		[]string{outboxEntryKey(id), outboxPendingKey},
+		id,
+		time.Now().UTC().Format(time.RFC3339Nano),
+		lastError,
+	).Err()
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to dead-letter outbox entry")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// SelectOutboxPayloads returns up to limit pending entries with an ID above afterID, in ID order,
+// without claiming them. Only the ID, message ID and payload are filled in.
+func (r *RedisAdapter) SelectOutboxPayloads(afterID int64, limit int) ([]*domain.OutboxEntry, error) {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return nil, err
+		}
+	}
+	ctx := context.Background()
+
+	lastID, err := r.client.Get(ctx, outboxSequenceKey).Int64()
+	if err != nil && !errors.Is(err, goredis.Nil) {
+		logging.Error().Err(err).Msg("Failed to read outbox entry sequence")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var entries []*domain.OutboxEntry
+	for start := afterID + 1; start <= lastID && len(entries) < limit; start += int64(limit) {
+		end := min(start+int64(limit)-1, lastID)
+
+		pendingCmds := make([]*goredis.FloatCmd, 0, end-start+1)
+		fieldCmds := make([]*goredis.SliceCmd, 0, end-start+1)
+		_, err := r.client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			for id := start; id <= end; id++ {
+				pendingCmds = append(pendingCmds, pipe.ZScore(ctx, outboxPendingKey, strconv.FormatInt(id, 10)))
+				fieldCmds = append(fieldCmds, pipe.HMGet(ctx, outboxEntryKey(id), fieldMessageUniqueID, fieldPayload))
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to read outbox entries")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+
+		for i, cmd := range fieldCmds {
+			// Delivered and dead-lettered entries are no longer pending
+			if errors.Is(pendingCmds[i].Err(), goredis.Nil) {
+				continue
+			}
+			fields := cmd.Val()
+			uniqueID, _ := fields[0].(string)
+			payload, _ := fields[1].(string)
+			if uniqueID == "" || payload == "" {
+				continue
+			}
+
+			entries = append(entries, &domain.OutboxEntry{ID: start + int64(i), MessageID: uniqueID, Payload: []byte(payload)})
+			if len(entries) == limit {
+				break
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// UpdateOutboxPayload replaces a pending entry's payload if it still equals currentPayload,
+// returning ErrOutboxEntryNotFound when the entry is gone, no longer pending or changed
+func (r *RedisAdapter) UpdateOutboxPayload(id int64, currentPayload, newPayload []byte) error {
+	if r.client == nil {
+		if err := r.Connect(); err != nil {
+			return err
+		}
+	}
+
+	updated, err := updateOutboxPayloadScript.Run(
+		context.Background(),
+		r.client,
+		[]string{outboxEntryKey(id), outboxPendingKey},
+		id,
+		currentPayload,
+		newPayload,
+	).Int()
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to update outbox payload")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if updated == 0 {
+		logging.Debug().Int64("entryID", id).Msg("Outbox entry not found or changed for payload update")
+		return domain.ErrOutboxEntryNotFound
+	}
+	return nil
+}
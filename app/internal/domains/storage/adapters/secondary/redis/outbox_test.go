@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func TestRedisAdapter_Outbox(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-1", ExpiresAt: &expiresAt, Notification: []byte("notify-1")}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-2", ExpiresAt: &expiresAt, Notification: []byte("notify-2")}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-3", ExpiresAt: &expiresAt}))
+
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "message-1", entries[0].MessageID)
+	assert.Equal(t, []byte("notify-1"), entries[0].Payload)
+	assert.False(t, entries[0].CreatedAt.IsZero())
+	assert.Positive(t, server.TTL(outboxEntryKey(entries[0].ID)), "entries expire with their message")
+
+	// Claimed entries are leased to this relay
+	leased, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	require.NoError(t, adapter.MarkOutboxEntryDelivered(entries[0].ID))
+	require.NoError(t, adapter.MarkOutboxEntryFailed(entries[1].ID, 0, "connection refused"))
+	assert.Empty(t, server.HGet(outboxEntryKey(entries[0].ID), fieldPayload), "a delivered entry no longer holds the message link")
+
+	retried, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, "message-2", retried[0].MessageID)
+	assert.Equal(t, 1, retried[0].Attempts)
+
+	// An entry whose message is gone is dropped rather than sent
+	require.NoError(t, adapter.MarkOutboxEntryFailed(retried[0].ID, 0, "connection refused"))
+	require.NoError(t, adapter.DeleteMessage("message-2"))
+	retried, err = adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, retried)
+	assert.False(t, server.Exists(outboxEntryKey(entries[1].ID)))
+}
+
+func TestRedisAdapter_OutboxPayloads(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	expiresAt := time.Now().Add(time.Hour)
+	for _, uniqueID := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: uniqueID, ExpiresAt: &expiresAt, Notification: []byte("notify " + uniqueID)}))
+	}
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.NoError(t, adapter.MarkOutboxEntryDelivered(entries[0].ID))
+
+	// Leased entries are still read, but delivered ones no longer hold anything to re-wrap
+	pending, err := adapter.SelectOutboxPayloads(0, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "message-2", pending[0].MessageID)
+	assert.Equal(t, []byte("notify message-2"), pending[0].Payload)
+
+	pending, err = adapter.SelectOutboxPayloads(pending[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "message-3", pending[0].MessageID)
+
+	// The payload is only replaced while it is unchanged and undelivered
+	require.NoError(t, adapter.UpdateOutboxPayload(entries[1].ID, []byte("notify message-2"), []byte("rewrapped")))
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[1].ID, []byte("notify message-2"), []byte("again")), domain.ErrOutboxEntryNotFound)
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[0].ID, nil, []byte("rewrapped")), domain.ErrOutboxEntryNotFound)
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(99, nil, []byte("rewrapped")), domain.ErrOutboxEntryNotFound)
+	assert.Equal(t, "rewrapped", server.HGet(outboxEntryKey(entries[1].ID), fieldPayload))
+	assert.False(t, server.Exists(outboxEntryKey(99)))
+}
+
+func TestRedisAdapter_DeadLetterOutboxEntry(t *testing.T) {
+	adapter, server := newTestAdapter(t)
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-1", ExpiresAt: &expiresAt, Notification: []byte("notify")}))
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, adapter.DeadLetterOutboxEntry(entries[0].ID, "unknown master key"))
+
+	// A dead-lettered entry is neither claimed again nor re-wrapped, but kept for inspection
+	assert.False(t, server.Exists(outboxPendingKey))
+	pending, err := adapter.SelectOutboxPayloads(0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[0].ID, []byte("notify"), []byte("rewrapped")), domain.ErrOutboxEntryNotFound)
+
+	entryKey := outboxEntryKey(entries[0].ID)
+	assert.Equal(t, "notify", server.HGet(entryKey, fieldPayload))
+	assert.Equal(t, "unknown master key", server.HGet(entryKey, fieldLastError))
+	assert.NotEmpty(t, server.HGet(entryKey, fieldDeadLettered))
+}
@@ -0,0 +1,59 @@
+// Package repository selects the MessageRepository implementation named by DatabaseConfig.Driver
+// and the KeyWrapper named by AtRestConfig.
+package repository
+
+import (
+	"fmt"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/keyring"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/mysql"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/postgres"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/redis"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/sqlite"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/adapters/secondary/transit"
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+// Connector is implemented by repositories that can open their connection eagerly,
+// letting callers fail fast at startup instead of on the first query.
+type Connector interface {
+	Connect() error
+}
+
+// NewMessageRepository creates the storage adapter for the configured driver.
+// An empty driver selects MySQL for compatibility with existing deployments.
+func NewMessageRepository(config domain.DatabaseConfig) (domain.MessageRepository, error) {
+	switch config.Driver {
+	case "", domain.DriverMySQL:
+		return mysql.NewMySQLAdapter(config), nil
+	case domain.DriverPostgres:
+		return postgres.NewPostgresAdapter(config), nil
+	case domain.DriverSQLite:
+		return sqlite.NewSQLiteAdapter(config), nil
+	case domain.DriverRedis:
+		return redis.NewRedisAdapter(config), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedDriver, config.Driver)
+	}
+}
+
+// NewKeyWrapper creates the master key source for at-rest encryption: a local keyring file or a
+// Vault-Transit-compatible endpoint. Configuring both is an error, since it is ambiguous which
+// one holds the current key.
+func NewKeyWrapper(config domain.AtRestConfig) (domain.KeyWrapper, error) {
+	switch {
+	case config.KeyringPath != "" && config.TransitAddress != "":
+		return nil, fmt.Errorf("%w: configure either a keyring file or a transit endpoint, not both", domain.ErrInvalidParameter)
+	case config.KeyringPath != "":
+		return keyring.Load(config.KeyringPath)
+	case config.TransitAddress != "":
+		return transit.NewKeyWrapper(transit.Config{
+			Address: config.TransitAddress,
+			Token:   config.TransitToken,
+			Mount:   config.TransitMount,
+			Key:     config.TransitKey,
+		})
+	default:
+		return nil, fmt.Errorf("%w: no master key configured for at-rest encryption", domain.ErrInvalidParameter)
+	}
+}
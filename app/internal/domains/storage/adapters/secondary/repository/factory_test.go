@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func TestNewMessageRepository(t *testing.T) {
+	tests := []struct {
+		name     string
+		driver   string
+		wantType string
+		wantErr  error
+	}{
+		{"empty driver defaults to mysql", "", "*mysql.MySQLAdapter", nil},
+		{"mysql", domain.DriverMySQL, "*mysql.MySQLAdapter", nil},
+		{"postgres", domain.DriverPostgres, "*postgres.PostgresAdapter", nil},
+		{"sqlite", domain.DriverSQLite, "*sqlite.SQLiteAdapter", nil},
+		{"redis", domain.DriverRedis, "*redis.RedisAdapter", nil},
+		{"unknown driver", "oracle", "", domain.ErrUnsupportedDriver},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := NewMessageRepository(domain.DatabaseConfig{Driver: tt.driver})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewMessageRepository() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewMessageRepository() error = %v", err)
+			}
+			if got := fmt.Sprintf("%T", repo); got != tt.wantType {
+				t.Errorf("NewMessageRepository() = %s, want %s", got, tt.wantType)
+			}
+			if _, ok := repo.(Connector); !ok {
+				t.Errorf("%T does not implement Connector", repo)
+			}
+		})
+	}
+}
+
+func TestNewKeyWrapper(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   domain.AtRestConfig
+		wantType string
+		wantErr  error
+	}{
+		{"transit", domain.AtRestConfig{TransitAddress: "https://vault:8200", TransitKey: "pwx"}, "*transit.KeyWrapper", nil},
+		{"both sources", domain.AtRestConfig{KeyringPath: "keyring.json", TransitAddress: "https://vault:8200"}, "", domain.ErrInvalidParameter},
+		{"no source", domain.AtRestConfig{}, "", domain.ErrInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapper, err := NewKeyWrapper(tt.config)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("NewKeyWrapper() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewKeyWrapper() error = %v", err)
+			}
+			if got := fmt.Sprintf("%T", wrapper); got != tt.wantType {
+				t.Errorf("NewKeyWrapper() = %s, want %s", got, tt.wantType)
+			}
+		})
+	}
+}
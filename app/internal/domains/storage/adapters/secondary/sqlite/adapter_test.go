@@ -0,0 +1,361 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migrationsDir is the repository's SQLite migration set, relative to this package
+const migrationsDir = "../../../../../../migrations/sqlite"
+
+// newTestAdapter returns a connected adapter backed by a migrated database in a temp directory
+func newTestAdapter(t *testing.T) *SQLiteAdapter {
+	t.Helper()
+	adapter := NewSQLiteAdapter(domain.DatabaseConfig{
+		Driver: domain.DriverSQLite,
+		Path:   filepath.Join(t.TempDir(), "passwordexchange.db"),
+	}).(*SQLiteAdapter)
+	require.NoError(t, adapter.Migrate(migrationsDir))
+	require.NoError(t, adapter.Connect())
+	t.Cleanup(func() { adapter.Close() })
+	return adapter
+}
+
+func TestSQLiteAdapter_InsertAndGetMessage(t *testing.T) {
+	adapter := newTestAdapter(t)
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	err := adapter.InsertMessage(&domain.Message{
+		Content:             "encrypted-content",
+		UniqueID:            "test-uuid",
+		Passphrase:          "hashed-passphrase",
+		RecipientEmail:      "recipient@example.com",
+		MaxViewCount:        3,
+		ExpiresAt:           &expiresAt,
+		ClientEncrypted:     true,
+		RevocationTokenHash: "token-hash",
+		SenderEmail:         "sender@example.com",
+		PublicKeyEncrypted:  true,
+		PassphraseKeySalt:   "c2FsdC1zYWx0LXNhbHQ",
+	})
+	require.NoError(t, err)
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.NotZero(t, message.ID)
+	assert.Equal(t, "encrypted-content", message.Content)
+	assert.Equal(t, "hashed-passphrase", message.Passphrase)
+	assert.Equal(t, "recipient@example.com", message.RecipientEmail)
+	assert.Equal(t, 0, message.ViewCount)
+	assert.Equal(t, 3, message.MaxViewCount)
+	assert.True(t, message.ClientEncrypted)
+	assert.Equal(t, "token-hash", message.RevocationTokenHash)
+	assert.Equal(t, "sender@example.com", message.SenderEmail)
+	assert.True(t, message.PublicKeyEncrypted)
+	assert.Equal(t, "c2FsdC1zYWx0LXNhbHQ", message.PassphraseKeySalt)
+	assert.Nil(t, message.Attachment)
+	require.NotNil(t, message.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*message.ExpiresAt), "expires_at = %v, want %v", *message.ExpiresAt, expiresAt)
+}
+
+func TestSQLiteAdapter_GetMessage_NotFound(t *testing.T) {
+	adapter := newTestAdapter(t)
+
+	_, err := adapter.GetMessage("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_IncrementViewCountAndGet_DeletesAtLimit(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:      "encrypted-content",
+		UniqueID:     "test-uuid",
+		MaxViewCount: 2,
+	}))
+
+	message, err := adapter.IncrementViewCountAndGet("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 1, message.ViewCount)
+
+	message, err = adapter.IncrementViewCountAndGet("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 2, message.ViewCount)
+
+	_, err = adapter.GetMessage("test-uuid")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_IncrementViewCountAndGet_NotFound(t *testing.T) {
+	adapter := newTestAdapter(t)
+
+	_, err := adapter.IncrementViewCountAndGet("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_Attachment(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:      "encrypted-content",
+		UniqueID:     "test-uuid",
+		MaxViewCount: 5,
+		Attachment: &domain.Attachment{
+			Filename:    "enc-name",
+			ContentType: "enc-type",
+			SizeBytes:   6,
+			Chunks:      [][]byte{[]byte("abc"), []byte("def")},
+		},
+	}))
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	require.NotNil(t, message.Attachment)
+	assert.Equal(t, int64(6), message.Attachment.SizeBytes)
+	assert.Nil(t, message.Attachment.Chunks)
+
+	attachment, err := adapter.GetAttachment("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "enc-name", attachment.Filename)
+	assert.Equal(t, "enc-type", attachment.ContentType)
+	assert.Equal(t, [][]byte{[]byte("abc"), []byte("def")}, attachment.Chunks)
+
+	// Deleting the message cascades to the attachment
+	_, err = adapter.db.Exec("DELETE FROM messages WHERE uniqueid = ?", "test-uuid")
+	require.NoError(t, err)
+	_, err = adapter.GetAttachment("test-uuid")
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	var chunkCount int
+	require.NoError(t, adapter.db.QueryRow("SELECT COUNT(*) FROM message_attachment_chunks").Scan(&chunkCount))
+	assert.Equal(t, 0, chunkCount)
+}
+
+func TestSQLiteAdapter_DeleteMessage(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", MaxViewCount: 5}))
+
+	require.NoError(t, adapter.DeleteMessage("test-uuid"))
+
+	_, err := adapter.GetMessage("test-uuid")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	assert.ErrorIs(t, adapter.DeleteMessage("test-uuid"), domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_ReservePassphraseAttempt(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "hash", MaxViewCount: 5}))
+
+	for want := 1; want <= 2; want++ {
+		failedAttempts, err := adapter.ReservePassphraseAttempt("test-uuid")
+		require.NoError(t, err)
+		assert.Equal(t, want, failedAttempts)
+	}
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, 2, message.FailedPassphraseAttempts)
+
+	_, err = adapter.ReservePassphraseAttempt("missing")
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_ReleasePassphraseAttempt(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "hash", MaxViewCount: 5}))
+
+	_, err := adapter.ReservePassphraseAttempt("test-uuid")
+	require.NoError(t, err)
+
+	// Releasing more than was reserved leaves the count at zero
+	for i := 0; i < 2; i++ {
+		require.NoError(t, adapter.ReleasePassphraseAttempt("test-uuid"))
+	}
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Zero(t, message.FailedPassphraseAttempts)
+}
+
+func TestSQLiteAdapter_UpdatePassphraseHash(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "test-uuid", Passphrase: "old-hash", MaxViewCount: 5}))
+
+	require.NoError(t, adapter.UpdatePassphraseHash("test-uuid", "new-hash"))
+
+	message, err := adapter.GetMessage("test-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "new-hash", message.Passphrase)
+
+	assert.ErrorIs(t, adapter.UpdatePassphraseHash("missing", "new-hash"), domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_MessageContents(t *testing.T) {
+	adapter := newTestAdapter(t)
+	for _, uniqueID := range []string{"first", "second", "third"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: uniqueID + "-content", UniqueID: uniqueID, MaxViewCount: 5}))
+	}
+
+	page, err := adapter.SelectMessageContents(0, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "first", page[0].UniqueID)
+	assert.Equal(t, "second-content", page[1].Content)
+
+	page, err = adapter.SelectMessageContents(page[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "third", page[0].UniqueID)
+
+	require.NoError(t, adapter.UpdateMessageContent("first", "first-content", "rewrapped"))
+	message, err := adapter.GetMessage("first")
+	require.NoError(t, err)
+	assert.Equal(t, "rewrapped", message.Content)
+
+	// The content must still match what the caller read
+	assert.ErrorIs(t, adapter.UpdateMessageContent("first", "first-content", "stale"), domain.ErrMessageNotFound)
+	assert.ErrorIs(t, adapter.UpdateMessageContent("missing", "c", "rewrapped"), domain.ErrMessageNotFound)
+}
+
+func TestSQLiteAdapter_DeleteExpiredMessages(t *testing.T) {
+	adapter := newTestAdapter(t)
+	expired := time.Now().Add(-time.Hour)
+	live := time.Now().Add(time.Hour)
+	for _, uniqueID := range []string{"expired-1", "expired-2", "expired-3"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: uniqueID, MaxViewCount: 5, ExpiresAt: &expired}))
+	}
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "live", MaxViewCount: 5, ExpiresAt: &live}))
+
+	// Deletes at most limit rows per call
+	deleted, err := adapter.DeleteExpiredMessages(2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	deleted, err = adapter.DeleteExpiredMessages(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	for _, uniqueID := range []string{"expired-1", "expired-2", "expired-3"} {
+		_, err = adapter.GetMessage(uniqueID)
+		assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	}
+	_, err = adapter.GetMessage("live")
+	assert.NoError(t, err)
+}
+
+func TestSQLiteAdapter_SelectExpiredMessages(t *testing.T) {
+	adapter := newTestAdapter(t)
+	expired := time.Now().Add(-time.Hour)
+	live := time.Now().Add(time.Hour)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:       "c",
+		UniqueID:      "expired",
+		MaxViewCount:  5,
+		ExpiresAt:     &expired,
+		WebhookURL:    "https://hooks.example.com/events",
+		WebhookSecret: "0123456789abcdef",
+	}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "live", MaxViewCount: 5, ExpiresAt: &live}))
+
+	messages, err := adapter.SelectExpiredMessages(10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "expired", messages[0].UniqueID)
+	assert.Equal(t, 5, messages[0].MaxViewCount)
+	assert.Equal(t, "https://hooks.example.com/events", messages[0].WebhookURL)
+	assert.Equal(t, "0123456789abcdef", messages[0].WebhookSecret)
+
+	stored, err := adapter.GetMessage("expired")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/events", stored.WebhookURL)
+	assert.Equal(t, "0123456789abcdef", stored.WebhookSecret)
+}
+
+func TestSQLiteAdapter_Reminders(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{
+		Content:        "c",
+		UniqueID:       "old",
+		RecipientEmail: "recipient@example.com",
+		MaxViewCount:   5,
+	}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "c", UniqueID: "no-email", MaxViewCount: 5}))
+	_, err := adapter.db.Exec("UPDATE messages SET created = datetime('now', '-3 days')")
+	require.NoError(t, err)
+
+	messages, err := adapter.GetUnviewedMessagesForReminders(24, 3, 24)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "old", messages[0].UniqueID)
+	assert.Equal(t, "recipient@example.com", messages[0].RecipientEmail)
+	assert.Equal(t, 3, messages[0].DaysOld)
+
+	messageID := messages[0].MessageID
+	require.NoError(t, adapter.LogReminderSent(messageID, "recipient@example.com"))
+	require.NoError(t, adapter.LogReminderSent(messageID, "recipient@example.com"))
+
+	history, err := adapter.GetReminderHistory(messageID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, 2, history[0].ReminderCount)
+
+	// A reminder was just sent, so the interval excludes the message
+	messages, err = adapter.GetUnviewedMessagesForReminders(24, 3, 24)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
+
+func TestSQLiteAdapter_SecretRequests(t *testing.T) {
+	adapter := newTestAdapter(t)
+	expiresAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	require.NoError(t, adapter.InsertSecretRequest(&domain.SecretRequest{
+		UniqueID:       "request-uuid",
+		RequesterName:  "Alice",
+		RequesterEmail: "alice@example.com",
+		Description:    "VPN password",
+		ExpiresAt:      &expiresAt,
+	}))
+
+	request, err := adapter.GetSecretRequest("request-uuid")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", request.RequesterName)
+	assert.Equal(t, "alice@example.com", request.RequesterEmail)
+	assert.Equal(t, "VPN password", request.Description)
+	assert.False(t, request.CreatedAt.IsZero())
+	require.NotNil(t, request.ExpiresAt)
+	assert.True(t, expiresAt.Equal(*request.ExpiresAt), "expires_at = %v, want %v", *request.ExpiresAt, expiresAt)
+	assert.Nil(t, request.FulfilledAt)
+	assert.Empty(t, request.MessageUniqueID)
+
+	require.NoError(t, adapter.FulfillSecretRequest("request-uuid", "message-uuid"))
+	assert.ErrorIs(t, adapter.FulfillSecretRequest("request-uuid", "other-message"), domain.ErrSecretRequestUnavailable)
+
+	request, err = adapter.GetSecretRequest("request-uuid")
+	require.NoError(t, err)
+	assert.NotNil(t, request.FulfilledAt)
+	assert.Equal(t, "message-uuid", request.MessageUniqueID)
+
+	_, err = adapter.GetSecretRequest("missing")
+	assert.ErrorIs(t, err, domain.ErrSecretRequestNotFound)
+	assert.ErrorIs(t, adapter.FulfillSecretRequest("missing", "message-uuid"), domain.ErrSecretRequestUnavailable)
+}
+
+func TestSQLiteAdapter_SecretRequests_Expired(t *testing.T) {
+	adapter := newTestAdapter(t)
+	expired := time.Now().Add(-time.Hour)
+	live := time.Now().Add(time.Hour)
+	require.NoError(t, adapter.InsertSecretRequest(&domain.SecretRequest{UniqueID: "expired", RequesterEmail: "a@example.com", ExpiresAt: &expired}))
+	require.NoError(t, adapter.InsertSecretRequest(&domain.SecretRequest{UniqueID: "live", RequesterEmail: "a@example.com", ExpiresAt: &live}))
+
+	assert.ErrorIs(t, adapter.FulfillSecretRequest("expired", "message-uuid"), domain.ErrSecretRequestUnavailable)
+
+	deleted, err := adapter.DeleteExpiredSecretRequests()
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	_, err = adapter.GetSecretRequest("expired")
+	assert.ErrorIs(t, err, domain.ErrSecretRequestNotFound)
+	_, err = adapter.GetSecretRequest("live")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+	"github.com/mattn/go-sqlite3"
+)
+
+// selectAuditEventColumns lists the audit_events columns in AuditEvent field order
+const selectAuditEventColumns = "SELECT sequence, event_type, message_uniqueid, client_ip_hash, user_agent_hash, occurred_at, prev_hash, hash FROM audit_events"
+
+// LastAuditEvent returns the audit entry with the highest sequence, or nil when there is none
+func (s *SQLiteAdapter) LastAuditEvent() (*domain.AuditEvent, error) {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	event, err := scanAuditEvent(s.db.QueryRow(selectAuditEventColumns + " ORDER BY sequence DESC LIMIT 1"))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to read last audit event")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return event, nil
+}
+
+// AppendAuditEvent inserts an audit entry. The sequence is the primary key, so two writers
+// chaining onto the same entry cannot both succeed.
+func (s *SQLiteAdapter) AppendAuditEvent(event *domain.AuditEvent) error {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.db.Exec(
+		"INSERT INTO audit_events (sequence, event_type, message_uniqueid, client_ip_hash, user_agent_hash, occurred_at, prev_hash, hash) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		event.Sequence, string(event.EventType), event.MessageID, event.ClientIPHash, event.UserAgentHash, event.OccurredAt, event.PrevHash, event.Hash,
+	)
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+		return fmt.Errorf("%w: %d", domain.ErrAuditConflict, event.Sequence)
+	}
+	if err != nil {
+		logging.Error().Err(err).Int64("sequence", event.Sequence).Msg("Failed to append audit event")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// SelectAuditEvents returns up to limit audit entries after afterSequence, in sequence order
+func (s *SQLiteAdapter) SelectAuditEvents(afterSequence int64, limit int) ([]*domain.AuditEvent, error) {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.db.Query(selectAuditEventColumns+" WHERE sequence > ? ORDER BY sequence LIMIT ?", afterSequence, limit)
+	if err != nil {
+		logging.Error().Err(err).Int64("afterSequence", afterSequence).Msg("Failed to select audit events")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var events []*domain.AuditEvent
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to scan audit event")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return events, nil
+}
+
+// scanAuditEvent reads a row selected with selectAuditEventColumns
+func scanAuditEvent(row interface{ Scan(...interface{}) error }) (*domain.AuditEvent, error) {
+	var event domain.AuditEvent
+	var eventType string
+	if err := row.Scan(&event.Sequence, &eventType, &event.MessageID, &event.ClientIPHash, &event.UserAgentHash, &event.OccurredAt, &event.PrevHash, &event.Hash); err != nil {
+		return nil, err
+	}
+	event.EventType = domain.AuditEventType(eventType)
+	return &event, nil
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func TestSQLiteAdapter_AuditLog(t *testing.T) {
+	adapter := newTestAdapter(t)
+	auditLog := domain.NewAuditLog(adapter, []byte("client-key"))
+	ctx := domain.WithAuditClient(context.Background(), domain.AuditClient{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+
+	last, err := adapter.LastAuditEvent()
+	require.NoError(t, err)
+	assert.Nil(t, last)
+
+	require.NoError(t, auditLog.Record(ctx, domain.AuditEventCreated, "message-1"))
+	require.NoError(t, auditLog.Record(ctx, domain.AuditEventViewed, "message-1"))
+
+	// Times round-trip exactly, so the stored entries still hash the same
+	verification, err := auditLog.VerifyAuditLog(context.Background(), domain.AuditAnchor{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), verification.Events)
+
+	last, err = adapter.LastAuditEvent()
+	require.NoError(t, err)
+	assert.Equal(t, domain.AuditEventViewed, last.EventType)
+	assert.Equal(t, verification.Head.Hash, last.Hash)
+
+	// A second writer chaining onto the same entry loses
+	stale := *last
+	err = adapter.AppendAuditEvent(&stale)
+	assert.ErrorIs(t, err, domain.ErrAuditConflict)
+}
+
+func TestSQLiteAdapter_AuditLogIsAppendOnly(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, domain.NewAuditLog(adapter, nil).Record(context.Background(), domain.AuditEventCreated, "message-1"))
+
+	_, err := adapter.db.Exec("UPDATE audit_events SET event_type = 'message.viewed'")
+	assert.ErrorContains(t, err, "append-only")
+	_, err = adapter.db.Exec("DELETE FROM audit_events")
+	assert.ErrorContains(t, err, "append-only")
+}
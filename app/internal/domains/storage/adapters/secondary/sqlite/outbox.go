@@ -0,0 +1,196 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// insertOutboxEntry queues a message's notification inside the transaction that stores the message
+func insertOutboxEntry(tx *sql.Tx, messageID int64, payload []byte) error {
+	_, err := tx.Exec("INSERT INTO notification_outbox (message_id, payload) VALUES (?, ?)", messageID, payload)
+	return err
+}
+
+// ClaimOutboxEntries moves the lease of up to limit due entries into the future and returns
+// them. SQLite serializes writers, so the transaction alone keeps two relays apart.
+func (s *SQLiteAdapter) ClaimOutboxEntries(limit int, lease time.Duration) ([]*domain.OutboxEntry, error) {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to begin outbox claim")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer tx.Rollback() // Will be ignored if transaction is committed
+
+	rows, err := tx.Query(`
+		SELECT o.id, m.uniqueid, o.payload, o.attempts, o.created_at
+		FROM notification_outbox o
+		JOIN messages m ON m.messageid = o.message_id
+		WHERE o.delivered_at IS NULL AND o.dead_lettered_at IS NULL AND datetime(o.next_attempt_at) <= datetime('now')
+		ORDER BY o.id
+		LIMIT ?`, limit)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to select outbox entries")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	var entries []*domain.OutboxEntry
+	for rows.Next() {
+		entry := &domain.OutboxEntry{}
+		if err := rows.Scan(&entry.ID, &entry.MessageID, &entry.Payload, &entry.Attempts, &entry.CreatedAt); err != nil {
+			rows.Close()
+			logging.Error().Err(err).Msg("Failed to scan outbox entry")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		entries = append(entries, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		logging.Error().Err(err).Msg("Error iterating outbox entries")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	args := []interface{}{int(lease / time.Second)}
+	for _, entry := range entries {
+		args = append(args, entry.ID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(entries)), ", ")
+	if _, err := tx.Exec("UPDATE notification_outbox SET next_attempt_at = datetime('now', printf('+%d seconds', ?)) WHERE id IN ("+placeholders+")", args...); err != nil {
+		logging.Error().Err(err).Msg("Failed to lease outbox entries")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if err := tx.Commit(); err != nil {
+		logging.Error().Err(err).Msg("Failed to commit outbox claim")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return entries, nil
+}
+
+// MarkOutboxEntryDelivered records the delivery and clears the payload, which holds the message link
+func (s *SQLiteAdapter) MarkOutboxEntryDelivered(id int64) error {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.db.Exec("UPDATE notification_outbox SET delivered_at = CURRENT_TIMESTAMP, payload = NULL WHERE id = ?", id); err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to mark outbox entry delivered")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// MarkOutboxEntryFailed counts a failed publish and schedules the next attempt
+func (s *SQLiteAdapter) MarkOutboxEntryFailed(id int64, retryAfter time.Duration, lastError string) error {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.db.Exec(
+		"UPDATE notification_outbox SET attempts = attempts + 1, next_attempt_at = datetime('now', printf('+%d seconds', ?)), last_error = ? WHERE id = ?",
+		int(retryAfter/time.Second), lastError, id,
+	)
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to mark outbox entry failed")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// DeadLetterOutboxEntry stops claiming the entry, keeping its payload and lastError for inspection
+func (s *SQLiteAdapter) DeadLetterOutboxEntry(id int64, lastError string) error {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.db.Exec("UPDATE notification_outbox SET dead_lettered_at = CURRENT_TIMESTAMP, last_error = ? WHERE id = ?", lastError, id); err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to dead-letter outbox entry")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// SelectOutboxPayloads returns up to limit undelivered entries with an ID above afterID, in ID
+// order, without claiming them. Only the ID, message ID and payload are filled in.
+func (s *SQLiteAdapter) SelectOutboxPayloads(afterID int64, limit int) ([]*domain.OutboxEntry, error) {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := s.db.Query(`
+		SELECT o.id, m.uniqueid, o.payload
+		FROM notification_outbox o
+		JOIN messages m ON m.messageid = o.message_id
+		WHERE o.id > ? AND o.delivered_at IS NULL AND o.dead_lettered_at IS NULL
+		ORDER BY o.id
+		LIMIT ?`, afterID, limit)
+	if err != nil {
+		logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to select outbox payloads")
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	defer rows.Close()
+
+	var entries []*domain.OutboxEntry
+	for rows.Next() {
+		entry := &domain.OutboxEntry{}
+		if err := rows.Scan(&entry.ID, &entry.MessageID, &entry.Payload); err != nil {
+			logging.Error().Err(err).Msg("Failed to scan outbox payload")
+			return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	return entries, nil
+}
+
+// UpdateOutboxPayload replaces an undelivered entry's payload if it still equals currentPayload,
+// returning ErrOutboxEntryNotFound when the entry is gone, delivered, dead-lettered or changed
+func (s *SQLiteAdapter) UpdateOutboxPayload(id int64, currentPayload, newPayload []byte) error {
+	if s.db == nil {
+		if err := s.Connect(); err != nil {
+			return err
+		}
+	}
+
+	result, err := s.db.Exec(
+		"UPDATE notification_outbox SET payload = ? WHERE id = ? AND payload = ? AND delivered_at IS NULL AND dead_lettered_at IS NULL",
+		newPayload, id, currentPayload,
+	)
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to update outbox payload")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		logging.Error().Err(err).Int64("entryID", id).Msg("Failed to get rows affected")
+		return fmt.Errorf("%w: %v", domain.ErrDatabaseOperation, err)
+	}
+	if rowsAffected == 0 {
+		logging.Debug().Int64("entryID", id).Msg("Outbox entry not found or changed for payload update")
+		return domain.ErrOutboxEntryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+func TestSQLiteAdapter_Outbox(t *testing.T) {
+	adapter := newTestAdapter(t)
+
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-1", Notification: []byte("notify-1")}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-2", Notification: []byte("notify-2")}))
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-3"}))
+
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "message-1", entries[0].MessageID)
+	assert.Equal(t, []byte("notify-1"), entries[0].Payload)
+	assert.Zero(t, entries[0].Attempts)
+	assert.False(t, entries[0].CreatedAt.IsZero())
+
+	// Claimed entries are leased to this relay
+	leased, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, leased)
+
+	require.NoError(t, adapter.MarkOutboxEntryDelivered(entries[0].ID))
+	require.NoError(t, adapter.MarkOutboxEntryFailed(entries[1].ID, 0, "connection refused"))
+
+	var payload []byte
+	require.NoError(t, adapter.db.QueryRow("SELECT payload FROM notification_outbox WHERE id = ?", entries[0].ID).Scan(&payload))
+	assert.Nil(t, payload, "a delivered entry no longer holds the message link")
+
+	retried, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, "message-2", retried[0].MessageID)
+	assert.Equal(t, 1, retried[0].Attempts)
+
+	// The entry goes with its message
+	require.NoError(t, adapter.DeleteMessage("message-2"))
+	var count int
+	require.NoError(t, adapter.db.QueryRow("SELECT COUNT(*) FROM notification_outbox WHERE id = ?", retried[0].ID).Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestSQLiteAdapter_OutboxPayloads(t *testing.T) {
+	adapter := newTestAdapter(t)
+	for _, uniqueID := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: uniqueID, Notification: []byte("notify " + uniqueID)}))
+	}
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.NoError(t, adapter.MarkOutboxEntryDelivered(entries[0].ID))
+
+	// Leased entries are still read, but delivered ones no longer hold anything to re-wrap
+	pending, err := adapter.SelectOutboxPayloads(0, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "message-2", pending[0].MessageID)
+	assert.Equal(t, []byte("notify message-2"), pending[0].Payload)
+
+	pending, err = adapter.SelectOutboxPayloads(pending[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "message-3", pending[0].MessageID)
+
+	// The payload is only replaced while it is unchanged and undelivered
+	require.NoError(t, adapter.UpdateOutboxPayload(entries[1].ID, []byte("notify message-2"), []byte("rewrapped")))
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[1].ID, []byte("notify message-2"), []byte("again")), domain.ErrOutboxEntryNotFound)
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[0].ID, nil, []byte("rewrapped")), domain.ErrOutboxEntryNotFound)
+
+	var payload []byte
+	require.NoError(t, adapter.db.QueryRow("SELECT payload FROM notification_outbox WHERE id = ?", entries[1].ID).Scan(&payload))
+	assert.Equal(t, []byte("rewrapped"), payload)
+}
+
+func TestSQLiteAdapter_DeadLetterOutboxEntry(t *testing.T) {
+	adapter := newTestAdapter(t)
+	require.NoError(t, adapter.InsertMessage(&domain.Message{Content: "content", UniqueID: "message-1", Notification: []byte("notify")}))
+	entries, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	require.NoError(t, adapter.DeadLetterOutboxEntry(entries[0].ID, "unknown master key"))
+	require.NoError(t, adapter.MarkOutboxEntryFailed(entries[0].ID, 0, "unknown master key"))
+
+	// A dead-lettered entry is neither claimed again nor re-wrapped, but kept for inspection
+	retried, err := adapter.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, retried)
+	pending, err := adapter.SelectOutboxPayloads(0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+	assert.ErrorIs(t, adapter.UpdateOutboxPayload(entries[0].ID, []byte("notify"), []byte("rewrapped")), domain.ErrOutboxEntryNotFound)
+
+	var payload []byte
+	var lastError string
+	require.NoError(t, adapter.db.QueryRow("SELECT payload, last_error FROM notification_outbox WHERE id = ? AND dead_lettered_at IS NOT NULL", entries[0].ID).Scan(&payload, &lastError))
+	assert.Equal(t, []byte("notify"), payload)
+	assert.Equal(t, "unknown master key", lastError)
+}
@@ -0,0 +1,169 @@
+// Package transit wraps data-encryption keys with a Vault-Transit-compatible HTTP endpoint, so
+// the master keys never leave the key management service.
+package transit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+// RequestTimeout bounds each call to the transit endpoint
+const RequestTimeout = 10 * time.Second
+
+// DefaultMount is the path the transit secrets engine is mounted at unless configured otherwise
+const DefaultMount = "transit"
+
+// maxResponseBytes caps how much of a response is read; transit replies are a few hundred bytes
+const maxResponseBytes = 64 * 1024
+
+// ErrInvalidConfig is returned when the transit address or key name is missing or malformed
+var ErrInvalidConfig = errors.New("invalid transit configuration")
+
+// Config locates the transit endpoint and the key new data keys are wrapped under
+type Config struct {
+	Address string // Base URL, e.g. https://vault.internal:8200
+	Token   string // Sent as X-Vault-Token
+	Mount   string // Empty means DefaultMount
+	Key     string
+}
+
+// KeyWrapper implements the domain.KeyWrapper port with the transit encrypt and decrypt
+// endpoints. Key IDs are transit key names, so rotating to a new key name keeps the old one
+// usable for unwrapping until every message has been re-wrapped.
+type KeyWrapper struct {
+	baseURL *url.URL
+	token   string
+	mount   string
+	key     string
+	client  *http.Client
+}
+
+// NewKeyWrapper creates a transit key wrapper
+func NewKeyWrapper(config Config) (*KeyWrapper, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(config.Address, "/"))
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("%w: address %q must be an absolute URL", ErrInvalidConfig, config.Address)
+	}
+	if config.Key == "" {
+		return nil, fmt.Errorf("%w: key name is required", ErrInvalidConfig)
+	}
+	mount := strings.Trim(config.Mount, "/")
+	if mount == "" {
+		mount = DefaultMount
+	}
+
+	return &KeyWrapper{
+		baseURL: baseURL,
+		token:   config.Token,
+		mount:   mount,
+		key:     config.Key,
+		client:  &http.Client{Timeout: RequestTimeout},
+	}, nil
+}
+
+// CurrentKeyID names the transit key that WrapKey uses
+func (k *KeyWrapper) CurrentKeyID() string {
+	return k.key
+}
+
+// WrapKey encrypts a data key with the current transit key. The wrapped key is the transit
+// ciphertext, e.g. "vault:v1:...", which records the transit key version itself.
+func (k *KeyWrapper) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	request := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}
+	if err := k.post(ctx, "encrypt", k.key, request, &response); err != nil {
+		return nil, err
+	}
+	if response.Data.Ciphertext == "" {
+		return nil, fmt.Errorf("%w: transit returned no ciphertext", domain.ErrKeyWrapping)
+	}
+	return []byte(response.Data.Ciphertext), nil
+}
+
+// UnwrapKey decrypts a data key with the named transit key
+func (k *KeyWrapper) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	var response struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	request := map[string]string{"ciphertext": string(wrappedKey)}
+	if err := k.post(ctx, "decrypt", keyID, request, &response); err != nil {
+		return nil, err
+	}
+
+	dataKey, err := base64.StdEncoding.DecodeString(response.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: transit plaintext: %v", domain.ErrKeyWrapping, err)
+	}
+	return dataKey, nil
+}
+
+// post calls /v1/<mount>/<operation>/<key> and decodes the JSON response into out
+func (k *KeyWrapper) post(ctx context.Context, operation, key string, body interface{}, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrKeyWrapping, err)
+	}
+
+	endpoint := k.baseURL.JoinPath("v1", k.mount, operation, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrKeyWrapping, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if k.token != "" {
+		req.Header.Set("X-Vault-Token", k.token)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: transit %s request failed: %v", domain.ErrKeyWrapping, operation, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("%w: reading transit %s response: %v", domain.ErrKeyWrapping, operation, err)
+	}
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+	// Vault answers 400 "encryption key not found" for a key that does not exist
+	case operation == "decrypt" && (resp.StatusCode == http.StatusNotFound || strings.Contains(string(data), "key not found")):
+		return fmt.Errorf("%w: %q", domain.ErrUnknownMasterKey, key)
+	default:
+		return fmt.Errorf("%w: transit %s returned status %d: %s", domain.ErrKeyWrapping, operation, resp.StatusCode, transitErrors(data))
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: decoding transit %s response: %v", domain.ErrKeyWrapping, operation, err)
+	}
+	return nil
+}
+
+// transitErrors extracts the "errors" list of a Vault error response, falling back to the raw body
+func transitErrors(body []byte) string {
+	var response struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Errors) > 0 {
+		return strings.Join(response.Errors, "; ")
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,119 @@
+package transit
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
+)
+
+// newTransitStub serves the encrypt and decrypt endpoints of a transit engine mounted at mount.
+// Its "ciphertext" is the key name and plaintext joined, which is enough to check the round trip.
+func newTransitStub(t *testing.T, mount, token string, keys ...string) *httptest.Server {
+	t.Helper()
+	known := map[string]bool{}
+	for _, key := range keys {
+		known[key] = true
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/"+mount+"/"), "/")
+		if r.Method != http.MethodPost || len(parts) != 2 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		operation, key := parts[0], parts[1]
+		if !known[key] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["encryption key not found"]}`))
+			return
+		}
+
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch operation {
+		case "encrypt":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]string{"ciphertext": "vault:v1:" + key + ":" + request["plaintext"]},
+			})
+		case "decrypt":
+			plaintext, ok := strings.CutPrefix(request["ciphertext"], "vault:v1:"+key+":")
+			if !ok {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors":["cipher: message authentication failed"]}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]string{"plaintext": plaintext},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestKeyWrapper_WrapAndUnwrap(t *testing.T) {
+	ctx := context.Background()
+	server := newTransitStub(t, "secrets/transit", "s.token", "pwx-2026-01", "pwx-2026-10")
+	defer server.Close()
+
+	before, err := NewKeyWrapper(Config{Address: server.URL, Token: "s.token", Mount: "/secrets/transit/", Key: "pwx-2026-01"})
+	require.NoError(t, err)
+	dataKey := bytes.Repeat([]byte{7}, 32)
+	wrapped, err := before.WrapKey(ctx, dataKey)
+	require.NoError(t, err)
+	assert.Equal(t, "vault:v1:pwx-2026-01:"+base64.StdEncoding.EncodeToString(dataKey), string(wrapped))
+
+	// Moving to a new transit key keeps the old one usable for unwrapping
+	after, err := NewKeyWrapper(Config{Address: server.URL, Token: "s.token", Mount: "secrets/transit", Key: "pwx-2026-10"})
+	require.NoError(t, err)
+	assert.Equal(t, "pwx-2026-10", after.CurrentKeyID())
+
+	unwrapped, err := after.UnwrapKey(ctx, "pwx-2026-01", wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, dataKey, unwrapped)
+
+	_, err = after.UnwrapKey(ctx, "pwx-2026-10", wrapped)
+	assert.ErrorIs(t, err, domain.ErrKeyWrapping)
+	assert.Contains(t, err.Error(), "message authentication failed")
+
+	_, err = after.UnwrapKey(ctx, "retired", wrapped)
+	assert.ErrorIs(t, err, domain.ErrUnknownMasterKey)
+}
+
+func TestKeyWrapper_RejectedToken(t *testing.T) {
+	server := newTransitStub(t, DefaultMount, "s.token", "pwx")
+	defer server.Close()
+
+	wrapper, err := NewKeyWrapper(Config{Address: server.URL, Token: "wrong", Key: "pwx"})
+	require.NoError(t, err)
+
+	_, err = wrapper.WrapKey(context.Background(), []byte("data key"))
+	assert.ErrorIs(t, err, domain.ErrKeyWrapping)
+	assert.Contains(t, err.Error(), "status 403: permission denied")
+}
+
+func TestNewKeyWrapper_InvalidConfig(t *testing.T) {
+	_, err := NewKeyWrapper(Config{Address: "vault:8200", Key: "pwx"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewKeyWrapper(Config{Address: "https://vault:8200"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,321 @@
+package domain
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// Sealed content layout, base64url-encoded after AtRestPrefix:
+//
+//	version (1) | key ID length (1) | key ID | wrapped key length (2) | wrapped key | nonce | ciphertext and tag
+//
+// Each message gets its own data key, sealed with AES-256-GCM and wrapped under the master key
+// named by the key ID. The content is bound to the message's unique ID, so a sealed value cannot
+// be copied to another row. Re-wrapping only replaces the wrapped key; the ciphertext stays as is.
+// Content stored before at-rest encryption was enabled never contains '.', so the prefix tells
+// the two apart.
+const (
+	AtRestPrefix   = "pxr."
+	AtRestVersion1 = 1
+	dataKeyLength  = 32
+)
+
+// AtRestCipher seals message content with per-message data keys wrapped by a KeyWrapper
+type AtRestCipher struct {
+	wrapper KeyWrapper
+}
+
+// NewAtRestCipher creates a cipher that wraps data keys with the given master key source
+func NewAtRestCipher(wrapper KeyWrapper) *AtRestCipher {
+	return &AtRestCipher{
+		wrapper: wrapper,
+	}
+}
+
+// sealedContent is a decoded at-rest value
+type sealedContent struct {
+	keyID      string
+	wrappedKey []byte
+	sealed     []byte // nonce || ciphertext
+}
+
+// IsSealedAtRest reports whether stored content was sealed by an AtRestCipher
+func IsSealedAtRest(content string) bool {
+	return strings.HasPrefix(content, AtRestPrefix)
+}
+
+// Seal encrypts content for the message with a fresh data key wrapped under the current master key
+func (c *AtRestCipher) Seal(ctx context.Context, uniqueID, content string) (string, error) {
+	dataKey := make([]byte, dataKeyLength)
+	if _, err := rand.Read(dataKey); err != nil {
+		logging.Error().Err(err).Msg("Failed to generate data key")
+		return "", fmt.Errorf("%w: %v", ErrKeyWrapping, err)
+	}
+
+	keyID := c.wrapper.CurrentKeyID()
+	wrappedKey, err := c.wrapper.WrapKey(ctx, dataKey)
+	if err != nil {
+		logging.Error().Err(err).Str("keyID", keyID).Msg("Failed to wrap data key")
+		return "", err
+	}
+
+	gcm, err := newDataKeyAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		logging.Error().Err(err).Msg("Failed to generate nonce")
+		return "", fmt.Errorf("%w: %v", ErrKeyWrapping, err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(content), contentAssociatedData(uniqueID))
+	return encodeSealedContent(sealedContent{keyID: keyID, wrappedKey: wrappedKey, sealed: sealed})
+}
+
+// Open decrypts content sealed by Seal. Content stored before at-rest encryption was enabled is
+// returned unchanged.
+func (c *AtRestCipher) Open(ctx context.Context, uniqueID, content string) (string, error) {
+	if !IsSealedAtRest(content) {
+		return content, nil
+	}
+
+	decoded, err := decodeSealedContent(content)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.wrapper.UnwrapKey(ctx, decoded.keyID, decoded.wrappedKey)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Str("keyID", decoded.keyID).Msg("Failed to unwrap data key")
+		return "", err
+	}
+
+	gcm, err := newDataKeyAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+	if len(decoded.sealed) < gcm.NonceSize()+gcm.Overhead() {
+		return "", fmt.Errorf("%w: ciphertext truncated", ErrAtRestDecryption)
+	}
+	nonce, ciphertext := decoded.sealed[:gcm.NonceSize()], decoded.sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, contentAssociatedData(uniqueID))
+	if err != nil {
+		logging.Error().Str("uniqueID", uniqueID).Msg("Failed to open content sealed at rest")
+		return "", fmt.Errorf("%w: %v", ErrAtRestDecryption, err)
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRewrap reports whether stored content is unsealed or wrapped under an older master key
+func (c *AtRestCipher) NeedsRewrap(content string) bool {
+	if !IsSealedAtRest(content) {
+		return true
+	}
+	decoded, err := decodeSealedContent(content)
+	return err != nil || decoded.keyID != c.wrapper.CurrentKeyID()
+}
+
+// Rewrap wraps the content's data key under the current master key, sealing content that was
+// stored before at-rest encryption was enabled
+func (c *AtRestCipher) Rewrap(ctx context.Context, uniqueID, content string) (string, error) {
+	if !IsSealedAtRest(content) {
+		return c.Seal(ctx, uniqueID, content)
+	}
+
+	decoded, err := decodeSealedContent(content)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := c.wrapper.UnwrapKey(ctx, decoded.keyID, decoded.wrappedKey)
+	if err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Str("keyID", decoded.keyID).Msg("Failed to unwrap data key")
+		return "", err
+	}
+
+	decoded.keyID = c.wrapper.CurrentKeyID()
+	if decoded.wrappedKey, err = c.wrapper.WrapKey(ctx, dataKey); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Str("keyID", decoded.keyID).Msg("Failed to re-wrap data key")
+		return "", err
+	}
+	return encodeSealedContent(decoded)
+}
+
+// contentAssociatedData binds sealed content to its format version and message
+func contentAssociatedData(uniqueID string) []byte {
+	return append([]byte{AtRestVersion1}, uniqueID...)
+}
+
+// outboxAssociatedID binds a sealed outbox payload to its message without letting it be swapped
+// with the message's content
+func outboxAssociatedID(uniqueID string) string {
+	return uniqueID + "#outbox"
+}
+
+// newDataKeyAEAD creates the AES-256-GCM AEAD for a data key
+func newDataKeyAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeyLength {
+		return nil, fmt.Errorf("%w: data key is %d bytes", ErrAtRestDecryption, len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyWrapping, err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyWrapping, err)
+	}
+	return gcm, nil
+}
+
+// encodeSealedContent serializes a sealed value in the layout described above
+func encodeSealedContent(content sealedContent) (string, error) {
+	if content.keyID == "" || len(content.keyID) > 255 {
+		return "", fmt.Errorf("%w: master key ID must be 1-255 bytes", ErrKeyWrapping)
+	}
+	if len(content.wrappedKey) > 0xffff {
+		return "", fmt.Errorf("%w: wrapped key too long", ErrKeyWrapping)
+	}
+
+	raw := make([]byte, 0, 4+len(content.keyID)+len(content.wrappedKey)+len(content.sealed))
+	raw = append(raw, AtRestVersion1, byte(len(content.keyID)))
+	raw = append(raw, content.keyID...)
+	raw = binary.BigEndian.AppendUint16(raw, uint16(len(content.wrappedKey)))
+	raw = append(raw, content.wrappedKey...)
+	raw = append(raw, content.sealed...)
+	return AtRestPrefix + base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// decodeSealedContent parses a value produced by encodeSealedContent
+func decodeSealedContent(encoded string) (sealedContent, error) {
+	raw, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(encoded, AtRestPrefix))
+	if err != nil {
+		return sealedContent{}, fmt.Errorf("%w: %v", ErrAtRestDecryption, err)
+	}
+	if len(raw) < 2 {
+		return sealedContent{}, fmt.Errorf("%w: header truncated", ErrAtRestDecryption)
+	}
+	if raw[0] != AtRestVersion1 {
+		return sealedContent{}, fmt.Errorf("%w: unsupported version %d", ErrAtRestDecryption, raw[0])
+	}
+
+	keyIDEnd := 2 + int(raw[1])
+	if len(raw) < keyIDEnd+2 {
+		return sealedContent{}, fmt.Errorf("%w: key ID truncated", ErrAtRestDecryption)
+	}
+	wrappedEnd := keyIDEnd + 2 + int(binary.BigEndian.Uint16(raw[keyIDEnd:]))
+	if len(raw) < wrappedEnd {
+		return sealedContent{}, fmt.Errorf("%w: wrapped key truncated", ErrAtRestDecryption)
+	}
+
+	return sealedContent{
+		keyID:      string(raw[2:keyIDEnd]),
+		wrappedKey: raw[keyIDEnd+2 : wrappedEnd],
+		sealed:     raw[wrappedEnd:],
+	}, nil
+}
+
+// EncryptedRepository decorates a MessageRepository so message content is sealed on insert and
+// opened on read. Every other operation goes straight to the wrapped repository.
+type EncryptedRepository struct {
+	MessageRepository
+	cipher *AtRestCipher
+}
+
+// NewEncryptedRepository wraps repository with at-rest encryption of message content
+func NewEncryptedRepository(repository MessageRepository, cipher *AtRestCipher) *EncryptedRepository {
+	return &EncryptedRepository{
+		MessageRepository: repository,
+		cipher:            cipher,
+	}
+}
+
+// InsertMessage seals the content, and the outbox notification that carries the message's link,
+// before storing the message. The caller's message is not modified.
+func (r *EncryptedRepository) InsertMessage(message *Message) error {
+	ctx := context.Background()
+	content, err := r.cipher.Seal(ctx, message.UniqueID, message.Content)
+	if err != nil {
+		return err
+	}
+
+	sealed := *message
+	sealed.Content = content
+	if len(message.Notification) > 0 {
+		notification, err := r.cipher.Seal(ctx, outboxAssociatedID(message.UniqueID), string(message.Notification))
+		if err != nil {
+			return err
+		}
+		sealed.Notification = []byte(notification)
+	}
+	return r.MessageRepository.InsertMessage(&sealed)
+}
+
+// ClaimOutboxEntries claims entries and opens their payloads. An entry that cannot be opened is
+// left out: if its master key was retired or the payload is corrupt it never will be, so it is
+// dead-lettered; otherwise, e.g. while Transit is unreachable, it is retried with backoff.
+func (r *EncryptedRepository) ClaimOutboxEntries(limit int, lease time.Duration) ([]*OutboxEntry, error) {
+	entries, err := r.MessageRepository.ClaimOutboxEntries(limit, lease)
+	if err != nil {
+		return nil, err
+	}
+
+	opened := entries[:0]
+	for _, entry := range entries {
+		payload, err := r.cipher.Open(context.Background(), outboxAssociatedID(entry.MessageID), string(entry.Payload))
+		if errors.Is(err, ErrUnknownMasterKey) || errors.Is(err, ErrAtRestDecryption) {
+			logging.Error().Err(err).Int64("entryID", entry.ID).Str("uniqueID", entry.MessageID).Msg("Outbox entry cannot be opened, dead-lettering it")
+			if markErr := r.MessageRepository.DeadLetterOutboxEntry(entry.ID, err.Error()); markErr != nil {
+				logging.Error().Err(markErr).Int64("entryID", entry.ID).Msg("Failed to dead-letter outbox entry")
+			}
+			continue
+		}
+		if err != nil {
+			if markErr := r.MessageRepository.MarkOutboxEntryFailed(entry.ID, outboxRetryDelay(entry.Attempts), err.Error()); markErr != nil {
+				logging.Error().Err(markErr).Int64("entryID", entry.ID).Msg("Failed to record outbox publish failure")
+			}
+			continue
+		}
+		entry.Payload = []byte(payload)
+		opened = append(opened, entry)
+	}
+	return opened, nil
+}
+
+// SelectMessageByUniqueID retrieves a message and opens its content
+func (r *EncryptedRepository) SelectMessageByUniqueID(uniqueID string) (*Message, error) {
+	return r.open(r.MessageRepository.SelectMessageByUniqueID(uniqueID))
+}
+
+// GetMessage retrieves a message without incrementing its view count and opens its content
+func (r *EncryptedRepository) GetMessage(uniqueID string) (*Message, error) {
+	return r.open(r.MessageRepository.GetMessage(uniqueID))
+}
+
+// IncrementViewCountAndGet counts a view and opens the returned message's content
+func (r *EncryptedRepository) IncrementViewCountAndGet(uniqueID string) (*Message, error) {
+	return r.open(r.MessageRepository.IncrementViewCountAndGet(uniqueID))
+}
+
+// open replaces a retrieved message's sealed content with the plaintext
+func (r *EncryptedRepository) open(message *Message, err error) (*Message, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := r.cipher.Open(context.Background(), message.UniqueID, message.Content)
+	if err != nil {
+		return nil, err
+	}
+	message.Content = content
+	return message, nil
+}
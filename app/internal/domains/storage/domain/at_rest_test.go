@@ -0,0 +1,258 @@
+package domain
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKeyWrapper is a KeyWrapper holding AES master keys in memory
+type memoryKeyWrapper struct {
+	current string
+	keys    map[string][]byte
+}
+
+func newMemoryKeyWrapper(keyIDs ...string) *memoryKeyWrapper {
+	wrapper := &memoryKeyWrapper{current: keyIDs[0], keys: map[string][]byte{}}
+	for _, keyID := range keyIDs {
+		key := make([]byte, 32)
+		_, _ = rand.Read(key)
+		wrapper.keys[keyID] = key
+	}
+	return wrapper
+}
+
+func (w *memoryKeyWrapper) CurrentKeyID() string { return w.current }
+
+func (w *memoryKeyWrapper) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	gcm := w.aead(w.current)
+	nonce := make([]byte, gcm.NonceSize())
+	_, _ = rand.Read(nonce)
+	return gcm.Seal(nonce, nonce, dataKey, []byte(w.current)), nil
+}
+
+func (w *memoryKeyWrapper) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	if _, ok := w.keys[keyID]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMasterKey, keyID)
+	}
+	gcm := w.aead(keyID)
+	dataKey, err := gcm.Open(nil, wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyWrapping, err)
+	}
+	return dataKey, nil
+}
+
+func (w *memoryKeyWrapper) aead(keyID string) cipher.AEAD {
+	block, _ := aes.NewCipher(w.keys[keyID])
+	gcm, _ := cipher.NewGCM(block)
+	return gcm
+}
+
+// memoryRepository stores messages in a map; only the methods at-rest encryption touches are implemented
+type memoryRepository struct {
+	MessageRepository
+	messages map[string]*Message
+	nextID   int64
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{messages: map[string]*Message{}}
+}
+
+func (r *memoryRepository) InsertMessage(message *Message) error {
+	r.nextID++
+	stored := *message
+	stored.ID = r.nextID
+	r.messages[message.UniqueID] = &stored
+	return nil
+}
+
+func (r *memoryRepository) GetMessage(uniqueID string) (*Message, error) {
+	message, ok := r.messages[uniqueID]
+	if !ok {
+		return nil, ErrMessageNotFound
+	}
+	copied := *message
+	return &copied, nil
+}
+
+func (r *memoryRepository) SelectMessageContents(afterID int64, limit int) ([]*Message, error) {
+	var messages []*Message
+	for _, message := range r.messages {
+		if message.ID > afterID {
+			messages = append(messages, &Message{ID: message.ID, UniqueID: message.UniqueID, Content: message.Content})
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (r *memoryRepository) UpdateMessageContent(uniqueID, currentContent, newContent string) error {
+	message, ok := r.messages[uniqueID]
+	if !ok || message.Content != currentContent {
+		return ErrMessageNotFound
+	}
+	message.Content = newContent
+	return nil
+}
+
+func TestAtRestCipher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	atRest := NewAtRestCipher(newMemoryKeyWrapper("2026-10"))
+
+	sealed, err := atRest.Seal(ctx, "message-1", "pxe.client-ciphertext")
+	require.NoError(t, err)
+	assert.True(t, IsSealedAtRest(sealed))
+	assert.NotContains(t, sealed, "client-ciphertext")
+	assert.False(t, atRest.NeedsRewrap(sealed))
+
+	opened, err := atRest.Open(ctx, "message-1", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "pxe.client-ciphertext", opened)
+
+	// A sealed value copied to another row does not open
+	_, err = atRest.Open(ctx, "message-2", sealed)
+	assert.ErrorIs(t, err, ErrAtRestDecryption)
+
+	// Content stored before at-rest encryption passes through
+	opened, err = atRest.Open(ctx, "message-1", "bGVnYWN5")
+	require.NoError(t, err)
+	assert.Equal(t, "bGVnYWN5", opened)
+	assert.True(t, atRest.NeedsRewrap("bGVnYWN5"))
+}
+
+func TestAtRestCipher_RejectsTamperedContent(t *testing.T) {
+	ctx := context.Background()
+	atRest := NewAtRestCipher(newMemoryKeyWrapper("2026-10"))
+
+	sealed, err := atRest.Seal(ctx, "message-1", "secret")
+	require.NoError(t, err)
+	raw, err := base64.URLEncoding.DecodeString(strings.TrimPrefix(sealed, AtRestPrefix))
+	require.NoError(t, err)
+	reencode := func(mutate func([]byte) []byte) string {
+		copied := append([]byte(nil), raw...)
+		return AtRestPrefix + base64.URLEncoding.EncodeToString(mutate(copied))
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{"ciphertext flipped", reencode(func(b []byte) []byte { b[len(b)-1] ^= 1; return b }), ErrAtRestDecryption},
+		{"wrapped key flipped", reencode(func(b []byte) []byte { b[2+len("2026-10")+2] ^= 1; return b }), ErrKeyWrapping},
+		{"unknown master key", reencode(func(b []byte) []byte { b[2] = 'X'; return b }), ErrUnknownMasterKey},
+		{"unknown version", reencode(func(b []byte) []byte { b[0] = 9; return b }), ErrAtRestDecryption},
+		{"truncated", reencode(func(b []byte) []byte { return b[:5] }), ErrAtRestDecryption},
+		{"not base64", AtRestPrefix + "!!!", ErrAtRestDecryption},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := atRest.Open(ctx, "message-1", tt.content)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+// SelectOutboxPayloads reports an empty outbox; memoryOutboxRepository keeps entries
+func (r *memoryRepository) SelectOutboxPayloads(afterID int64, limit int) ([]*OutboxEntry, error) {
+	return nil, nil
+}
+
+func TestEncryptedRepository_SealsContent(t *testing.T) {
+	inner := newMemoryRepository()
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(newMemoryKeyWrapper("2026-10")))
+
+	message := &Message{UniqueID: "message-1", Content: "ciphertext", MaxViewCount: 1}
+	require.NoError(t, repo.InsertMessage(message))
+	assert.Equal(t, "ciphertext", message.Content, "caller's message must not be modified")
+	assert.True(t, IsSealedAtRest(inner.messages["message-1"].Content))
+
+	retrieved, err := repo.GetMessage("message-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ciphertext", retrieved.Content)
+
+	_, err = repo.GetMessage("missing")
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+}
+
+func TestKeyRotator_RewrapsUnderNewMasterKey(t *testing.T) {
+	ctx := context.Background()
+	wrapper := newMemoryKeyWrapper("2026-01", "2026-10")
+	inner := newMemoryRepository()
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(wrapper))
+
+	// One message from before at-rest encryption, three under the old master key
+	require.NoError(t, inner.InsertMessage(&Message{UniqueID: "legacy", Content: "legacy-content"}))
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, repo.InsertMessage(&Message{UniqueID: fmt.Sprintf("message-%d", i), Content: fmt.Sprintf("content-%d", i)}))
+	}
+	oldSealed := inner.messages["message-1"].Content
+
+	wrapper.current = "2026-10"
+	rotator := NewKeyRotator(inner, NewAtRestCipher(wrapper))
+	rotator.batchSize = 2
+
+	result, err := rotator.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RotationResult{Scanned: 4, Rewrapped: 3, Sealed: 1}, result)
+
+	// Only the wrapped key changed, so the ciphertext at the end is untouched
+	newSealed := inner.messages["message-1"].Content
+	assert.NotEqual(t, oldSealed, newSealed)
+	oldRaw, _ := decodeSealedContent(oldSealed)
+	newRaw, _ := decodeSealedContent(newSealed)
+	assert.Equal(t, "2026-10", newRaw.keyID)
+	assert.Equal(t, oldRaw.sealed, newRaw.sealed)
+
+	// The old master key can now be retired
+	delete(wrapper.keys, "2026-01")
+	for _, uniqueID := range []string{"legacy", "message-1", "message-2", "message-3"} {
+		message, err := repo.GetMessage(uniqueID)
+		require.NoError(t, err, uniqueID)
+		assert.NotEmpty(t, message.Content)
+	}
+
+	// A second run has nothing left to do
+	result, err = rotator.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RotationResult{Scanned: 4}, result)
+}
+
+func TestKeyRotator_SkipsMessagesChangedDuringRotation(t *testing.T) {
+	wrapper := newMemoryKeyWrapper("2026-01", "2026-10")
+	inner := &racingRepository{memoryRepository: newMemoryRepository()}
+	require.NoError(t, inner.InsertMessage(&Message{UniqueID: "message-1", Content: "content"}))
+
+	wrapper.current = "2026-10"
+	result, err := NewKeyRotator(inner, NewAtRestCipher(wrapper)).Rotate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, RotationResult{Scanned: 1, Skipped: 1}, result)
+}
+
+// racingRepository deletes each message right after the rotator reads it, as a last view would
+type racingRepository struct {
+	*memoryRepository
+}
+
+func (r *racingRepository) SelectMessageContents(afterID int64, limit int) ([]*Message, error) {
+	messages, err := r.memoryRepository.SelectMessageContents(afterID, limit)
+	for _, message := range messages {
+		delete(r.messages, message.UniqueID)
+	}
+	return messages, err
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// AuditEventType names what happened to a message
+type AuditEventType string
+
+// Audit event types, one per step of a message's life
+const (
+	AuditEventCreated          AuditEventType = "message.created"
+	AuditEventViewed           AuditEventType = "message.viewed"
+	AuditEventPassphraseFailed AuditEventType = "message.passphrase_failed"
+	AuditEventExpired          AuditEventType = "message.expired"
+	AuditEventDeleted          AuditEventType = "message.deleted"
+)
+
+// auditHashVersion is mixed into every entry hash so the format can change without ambiguity
+const auditHashVersion = "v1"
+
+// maxAuditAppendAttempts bounds the retries when other writers keep taking the next sequence
+const maxAuditAppendAttempts = 5
+
+// auditVerifyBatchSize is how many entries VerifyAuditLog reads per query
+const auditVerifyBatchSize = 1000
+
+// AuditClient identifies who made a request, as seen by the message service
+type AuditClient struct {
+	IP        string
+	UserAgent string
+}
+
+type auditClientContextKey struct{}
+
+// WithAuditClient returns a context carrying the client that audit events recorded under it are attributed to
+func WithAuditClient(ctx context.Context, client AuditClient) context.Context {
+	return context.WithValue(ctx, auditClientContextKey{}, client)
+}
+
+// AuditClientFromContext returns the client stored by WithAuditClient, or the zero value
+func AuditClientFromContext(ctx context.Context) AuditClient {
+	client, _ := ctx.Value(auditClientContextKey{}).(AuditClient)
+	return client
+}
+
+// AuditAnchor is an entry hash recorded outside the database, e.g. from an earlier verification.
+// The hash chain alone cannot show that entries were cut off the end; an anchor can.
+type AuditAnchor struct {
+	Sequence int64
+	Hash     string
+}
+
+// AuditVerification summarizes a successful VerifyAuditLog run
+type AuditVerification struct {
+	Events int64
+	Head   AuditAnchor // The last entry; record it to anchor the next verification
+}
+
+// AuditLog appends message events to a hash chain and verifies it. Client IPs and user agents
+// are stored only as HMAC-SHA256 under clientKey, so they can be matched against a known value
+// without being readable.
+type AuditLog struct {
+	repository AuditRepository
+	clientKey  []byte
+	now        func() time.Time
+	mu         sync.Mutex
+}
+
+// NewAuditLog creates an audit log on the given repository
+func NewAuditLog(repository AuditRepository, clientKey []byte) *AuditLog {
+	return &AuditLog{
+		repository: repository,
+		clientKey:  clientKey,
+		now:        time.Now,
+	}
+}
+
+// Record appends an event for the message, attributed to the client in ctx
+func (a *AuditLog) Record(ctx context.Context, eventType AuditEventType, messageID string) error {
+	client := AuditClientFromContext(ctx)
+	event := &AuditEvent{
+		EventType:     eventType,
+		MessageID:     messageID,
+		ClientIPHash:  a.hashClientValue(client.IP),
+		UserAgentHash: a.hashClientValue(client.UserAgent),
+		// Stored with microsecond precision, which every backend keeps
+		OccurredAt: a.now().UTC().Truncate(time.Microsecond),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Another replica may take the next sequence first; chain onto its entry instead
+	for attempt := 1; ; attempt++ {
+		last, err := a.repository.LastAuditEvent()
+		if err != nil {
+			return err
+		}
+		event.Sequence, event.PrevHash = 1, ""
+		if last != nil {
+			event.Sequence, event.PrevHash = last.Sequence+1, last.Hash
+		}
+		event.Hash = auditEventHash(event)
+
+		err = a.repository.AppendAuditEvent(event)
+		if errors.Is(err, ErrAuditConflict) && attempt < maxAuditAppendAttempts {
+			continue
+		}
+		return err
+	}
+}
+
+// VerifyAuditLog recomputes every entry's hash and checks that each links to the one before it.
+// With a non-zero anchor, the entry at the anchor's sequence must also have the anchor's hash.
+func (a *AuditLog) VerifyAuditLog(ctx context.Context, anchor AuditAnchor) (AuditVerification, error) {
+	var verification AuditVerification
+	anchorSeen := anchor.Sequence == 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return verification, err
+		}
+
+		events, err := a.repository.SelectAuditEvents(verification.Head.Sequence, auditVerifyBatchSize)
+		if err != nil {
+			return verification, err
+		}
+		if len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			switch {
+			case event.Sequence != verification.Head.Sequence+1:
+				return verification, fmt.Errorf("%w: expected sequence %d, found %d", ErrAuditChainBroken, verification.Head.Sequence+1, event.Sequence)
+			case event.PrevHash != verification.Head.Hash:
+				return verification, fmt.Errorf("%w: sequence %d does not link to the entry before it", ErrAuditChainBroken, event.Sequence)
+			case event.Hash != auditEventHash(event):
+				return verification, fmt.Errorf("%w: sequence %d does not match its hash", ErrAuditChainBroken, event.Sequence)
+			}
+			if event.Sequence == anchor.Sequence {
+				if event.Hash != anchor.Hash {
+					return verification, fmt.Errorf("%w: sequence %d does not match the anchor", ErrAuditChainBroken, event.Sequence)
+				}
+				anchorSeen = true
+			}
+
+			verification.Events++
+			verification.Head = AuditAnchor{Sequence: event.Sequence, Hash: event.Hash}
+		}
+	}
+
+	if !anchorSeen {
+		return verification, fmt.Errorf("%w: log ends at sequence %d, before anchor %d", ErrAuditChainBroken, verification.Head.Sequence, anchor.Sequence)
+	}
+
+	logging.Info().Int64("events", verification.Events).Str("head", verification.Head.Hash).Msg("Audit log verified")
+	return verification, nil
+}
+
+// hashClientValue returns the hex HMAC-SHA256 of a client value, or "" when it is unknown
+func (a *AuditLog) hashClientValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.clientKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// auditEventHash is the hex SHA-256 of an entry's fields, each length-prefixed so no two
+// different entries encode the same
+func auditEventHash(event *AuditEvent) string {
+	h := sha256.New()
+	for _, field := range []string{
+		auditHashVersion,
+		strconv.FormatInt(event.Sequence, 10),
+		string(event.EventType),
+		event.MessageID,
+		event.ClientIPHash,
+		event.UserAgentHash,
+		strconv.FormatInt(event.OccurredAt.UnixMicro(), 10),
+		event.PrevHash,
+	} {
+		writeAuditField(h, field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// writeAuditField writes a length-prefixed field to the hash
+func writeAuditField(h hash.Hash, field string) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(field)))
+	h.Write(length[:])
+	h.Write([]byte(field))
+}
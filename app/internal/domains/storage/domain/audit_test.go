@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditRepository keeps audit entries in a slice indexed by sequence - 1
+type memoryAuditRepository struct {
+	events []*AuditEvent
+	// conflicts makes the next appends fail as if another writer took the sequence
+	conflicts int
+}
+
+func (r *memoryAuditRepository) LastAuditEvent() (*AuditEvent, error) {
+	if len(r.events) == 0 {
+		return nil, nil
+	}
+	copied := *r.events[len(r.events)-1]
+	return &copied, nil
+}
+
+func (r *memoryAuditRepository) AppendAuditEvent(event *AuditEvent) error {
+	if r.conflicts > 0 {
+		r.conflicts--
+		return ErrAuditConflict
+	}
+	if event.Sequence != int64(len(r.events))+1 {
+		return ErrAuditConflict
+	}
+	copied := *event
+	r.events = append(r.events, &copied)
+	return nil
+}
+
+func (r *memoryAuditRepository) SelectAuditEvents(afterSequence int64, limit int) ([]*AuditEvent, error) {
+	var events []*AuditEvent
+	for _, event := range r.events {
+		if event.Sequence > afterSequence && len(events) < limit {
+			copied := *event
+			events = append(events, &copied)
+		}
+	}
+	return events, nil
+}
+
+func TestAuditLog_RecordChainsEvents(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	auditLog := NewAuditLog(repo, []byte("client-key"))
+	ctx := WithAuditClient(context.Background(), AuditClient{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+
+	require.NoError(t, auditLog.Record(ctx, AuditEventCreated, "message-1"))
+	require.NoError(t, auditLog.Record(ctx, AuditEventViewed, "message-1"))
+	require.NoError(t, auditLog.Record(context.Background(), AuditEventExpired, "message-1"))
+
+	require.Len(t, repo.events, 3)
+	first, second, third := repo.events[0], repo.events[1], repo.events[2]
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Equal(t, second.Hash, third.PrevHash)
+
+	// Client values are keyed hashes, never the values themselves
+	assert.Len(t, first.ClientIPHash, 64)
+	assert.NotContains(t, first.ClientIPHash, "203.0.113.7")
+	assert.Equal(t, first.ClientIPHash, second.ClientIPHash)
+	assert.NotEqual(t, first.ClientIPHash, NewAuditLog(repo, []byte("other-key")).hashClientValue("203.0.113.7"))
+	assert.Empty(t, third.ClientIPHash, "expiry has no client")
+
+	verification, err := auditLog.VerifyAuditLog(context.Background(), AuditAnchor{})
+	require.NoError(t, err)
+	assert.Equal(t, AuditVerification{Events: 3, Head: AuditAnchor{Sequence: 3, Hash: third.Hash}}, verification)
+}
+
+func TestAuditLog_RecordRetriesOnConflict(t *testing.T) {
+	repo := &memoryAuditRepository{conflicts: 2}
+	require.NoError(t, NewAuditLog(repo, nil).Record(context.Background(), AuditEventCreated, "message-1"))
+	assert.Len(t, repo.events, 1)
+
+	repo.conflicts = maxAuditAppendAttempts
+	err := NewAuditLog(repo, nil).Record(context.Background(), AuditEventViewed, "message-1")
+	assert.ErrorIs(t, err, ErrAuditConflict)
+}
+
+func TestAuditLog_VerifyDetectsTampering(t *testing.T) {
+	newLog := func(t *testing.T) (*memoryAuditRepository, *AuditLog) {
+		repo := &memoryAuditRepository{}
+		auditLog := NewAuditLog(repo, []byte("client-key"))
+		for _, eventType := range []AuditEventType{AuditEventCreated, AuditEventPassphraseFailed, AuditEventViewed, AuditEventDeleted} {
+			require.NoError(t, auditLog.Record(context.Background(), eventType, "message-1"))
+		}
+		return repo, auditLog
+	}
+
+	tests := []struct {
+		name   string
+		tamper func(repo *memoryAuditRepository)
+	}{
+		{"event type changed", func(repo *memoryAuditRepository) { repo.events[1].EventType = AuditEventViewed }},
+		{"time changed", func(repo *memoryAuditRepository) {
+			repo.events[2].OccurredAt = repo.events[2].OccurredAt.Add(time.Hour)
+		}},
+		{"entry removed", func(repo *memoryAuditRepository) {
+			repo.events = append(repo.events[:1], repo.events[2:]...)
+		}},
+		{"entry rewritten with a fresh hash", func(repo *memoryAuditRepository) {
+			repo.events[1].MessageID = "message-2"
+			repo.events[1].Hash = auditEventHash(repo.events[1])
+		}},
+		{"entries reordered", func(repo *memoryAuditRepository) {
+			repo.events[1], repo.events[2] = repo.events[2], repo.events[1]
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, auditLog := newLog(t)
+			tt.tamper(repo)
+			_, err := auditLog.VerifyAuditLog(context.Background(), AuditAnchor{})
+			assert.ErrorIs(t, err, ErrAuditChainBroken)
+		})
+	}
+}
+
+func TestAuditLog_VerifyChecksAnchor(t *testing.T) {
+	repo := &memoryAuditRepository{}
+	auditLog := NewAuditLog(repo, nil)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, auditLog.Record(context.Background(), AuditEventViewed, "message-1"))
+	}
+	anchor := AuditAnchor{Sequence: 3, Hash: repo.events[2].Hash}
+
+	_, err := auditLog.VerifyAuditLog(context.Background(), anchor)
+	require.NoError(t, err)
+
+	// Cutting entries off the end leaves a valid chain, but not one that reaches the anchor
+	repo.events = repo.events[:2]
+	_, err = auditLog.VerifyAuditLog(context.Background(), anchor)
+	assert.ErrorIs(t, err, ErrAuditChainBroken)
+
+	_, err = auditLog.VerifyAuditLog(context.Background(), AuditAnchor{Sequence: 2, Hash: "not-the-hash"})
+	assert.ErrorIs(t, err, ErrAuditChainBroken)
+}
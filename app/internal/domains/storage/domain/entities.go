@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 )
 
@@ -15,6 +16,24 @@ type Message struct {
 	MaxViewCount   int        `json:"max_view_count"` // Maximum number of views allowed
 	CreatedAt      time.Time  `json:"created_at"`
 	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	ClientEncrypted bool      `json:"client_encrypted"` // Content was encrypted in the browser; server holds no key
+	Attachment     *Attachment `json:"attachment,omitempty"` // Optional encrypted file stored with the message
+	RevocationTokenHash string `json:"-"` // SHA-256 of the sender's revocation token; empty for legacy messages
+	SenderEmail    string     `json:"sender_email,omitempty"` // Receives read receipts; empty unless the sender opted in
+	WebhookURL     string     `json:"webhook_url,omitempty"` // Per-message lifecycle event endpoint; empty uses the global webhook
+	WebhookSecret  string     `json:"-"` // HMAC key for WebhookURL
+	PublicKeyEncrypted bool   `json:"public_key_encrypted"` // Content is an armored age or OpenPGP message sealed to the recipient's key
+	PassphraseKeySalt string  `json:"-"` // Argon2id salt mixing the passphrase into the content key; empty when the passphrase is only a gate
+	FailedPassphraseAttempts int `json:"failed_passphrase_attempts"` // Wrong passphrases submitted so far
+	Notification   []byte     `json:"-"` // Queue message written to the outbox with the message; nil when there is nothing to send
+}
+
+// Attachment represents an encrypted file stored alongside a message
+type Attachment struct {
+	Filename    string   `json:"filename"`     // Encrypted original file name
+	ContentType string   `json:"content_type"` // Encrypted MIME type
+	SizeBytes   int64    `json:"size_bytes"`   // Plaintext size in bytes
+	Chunks      [][]byte `json:"-"`            // AES-GCM sealed chunks in order; only loaded by GetAttachment
 }
 
 // UnviewedMessage represents a message eligible for reminder emails
@@ -34,23 +53,157 @@ type ReminderLogEntry struct {
 	LastReminderSent  time.Time `json:"last_reminder_sent"`
 }
 
+// OutboxEntry is a notification written in the same transaction as its message, so it is
+// published even if the queue was unreachable when the message was stored. Entries are removed
+// with their message.
+type OutboxEntry struct {
+	ID        int64
+	MessageID string // The message's unique ID
+	Payload   []byte // The queue message, published as is
+	Attempts  int    // Failed publishes so far
+	CreatedAt time.Time
+}
+
+// OutboxRelayResult counts the outbox entries handled by one RelayOutbox run
+type OutboxRelayResult struct {
+	Delivered int `json:"delivered"`
+	Failed    int `json:"failed"`
+}
+
+// CleanupResult counts the rows removed by one CleanupExpiredMessages run
+type CleanupResult struct {
+	MessagesPurged       int `json:"messages_purged"`
+	SecretRequestsPurged int `json:"secret_requests_purged"`
+}
+
+// SecretRequest is a request for someone to send the requester a secret. Fulfilling it
+// stores an ordinary message addressed to the requester.
+type SecretRequest struct {
+	ID              int64      `json:"id"`
+	UniqueID        string     `json:"unique_id"`       // UUID used in the request link
+	RequesterName   string     `json:"requester_name"`
+	RequesterEmail  string     `json:"requester_email"` // Receives the link to the fulfilled secret
+	Description     string     `json:"description"`     // What the requester is asking for
+	CreatedAt       time.Time  `json:"created_at"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	FulfilledAt     *time.Time `json:"fulfilled_at,omitempty"`
+	MessageUniqueID string     `json:"message_unique_id,omitempty"` // Message stored when the request was fulfilled
+}
+
 // MessageRepository defines the contract for message storage operations
 type MessageRepository interface {
 	InsertMessage(message *Message) error
 	SelectMessageByUniqueID(uniqueID string) (*Message, error)
 	IncrementViewCountAndGet(uniqueID string) (*Message, error)
-	DeleteExpiredMessages() error
+	DeleteExpiredMessages(limit int) (int, error)
+	SelectExpiredMessages(limit int) ([]*Message, error)
 	GetMessage(uniqueID string) (*Message, error)
 	GetUnviewedMessagesForReminders(olderThanHours, maxReminders, reminderIntervalHours int) ([]*UnviewedMessage, error)
 	LogReminderSent(messageID int, emailAddress string) error
 	GetReminderHistory(messageID int) ([]*ReminderLogEntry, error)
+	GetAttachment(uniqueID string) (*Attachment, error)
+	DeleteMessage(uniqueID string) error
+	ReservePassphraseAttempt(uniqueID string) (int, error)
+	ReleasePassphraseAttempt(uniqueID string) error
+	UpdatePassphraseHash(uniqueID, hashedPassphrase string) error
+	SelectMessageContents(afterID int64, limit int) ([]*Message, error)
+	UpdateMessageContent(uniqueID, currentContent, newContent string) error
+	InsertSecretRequest(request *SecretRequest) error
+	GetSecretRequest(uniqueID string) (*SecretRequest, error)
+	FulfillSecretRequest(uniqueID, messageUniqueID string) error
+	DeleteExpiredSecretRequests() (int, error)
+	// ClaimOutboxEntries returns up to limit undelivered entries that are due, oldest first, and
+	// hides them from other relays for lease
+	ClaimOutboxEntries(limit int, lease time.Duration) ([]*OutboxEntry, error)
+	// MarkOutboxEntryDelivered records that an entry was published and drops its payload
+	MarkOutboxEntryDelivered(id int64) error
+	// MarkOutboxEntryFailed counts a failed publish and makes the entry due again after retryAfter
+	MarkOutboxEntryFailed(id int64, retryAfter time.Duration, lastError string) error
+	// DeadLetterOutboxEntry stops retrying an entry that can never be published, keeping it and
+	// lastError for inspection
+	DeadLetterOutboxEntry(id int64, lastError string) error
+	// SelectOutboxPayloads returns up to limit undelivered entries with an ID above afterID, in
+	// ID order, leaving their leases alone. Only the ID, message ID and payload are filled in.
+	SelectOutboxPayloads(afterID int64, limit int) ([]*OutboxEntry, error)
+	// UpdateOutboxPayload replaces an undelivered entry's payload if it still equals
+	// currentPayload, returning ErrOutboxEntryNotFound otherwise
+	UpdateOutboxPayload(id int64, currentPayload, newPayload []byte) error
 	Close() error
 }
 
+// AuditEvent is one entry of the hash-chained audit log. Hash covers every other field,
+// PrevHash included, so altering, removing or reordering an entry breaks the chain after it.
+type AuditEvent struct {
+	Sequence      int64 // 1 for the first entry, then consecutive
+	EventType     AuditEventType
+	MessageID     string // The message's unique ID
+	ClientIPHash  string // Keyed hash of the client IP; empty when there was no client, e.g. expiry
+	UserAgentHash string // Keyed hash of the client user agent
+	OccurredAt    time.Time
+	PrevHash      string // Hash of the previous entry; empty for the first
+	Hash          string
+}
+
+// AuditRepository stores the audit log. It is append-only: entries are never updated or deleted.
+type AuditRepository interface {
+	// LastAuditEvent returns the entry with the highest sequence, or nil when the log is empty
+	LastAuditEvent() (*AuditEvent, error)
+	// AppendAuditEvent stores an entry, returning ErrAuditConflict if its sequence is already taken
+	AppendAuditEvent(event *AuditEvent) error
+	// SelectAuditEvents returns up to limit entries after the given sequence, in order
+	SelectAuditEvents(afterSequence int64, limit int) ([]*AuditEvent, error)
+}
+
+// ExpiryNotifier is told about each message removed by CleanupExpiredMessages so that
+// "message.expired" webhooks can be sent
+type ExpiryNotifier interface {
+	NotifyExpired(ctx context.Context, message *Message) error
+}
+
+// OutboxPublisher publishes outbox payloads to the notification queue. It returns nil only once
+// the queue has accepted the message.
+type OutboxPublisher interface {
+	PublishOutboxEntry(ctx context.Context, payload []byte) error
+}
+
+// KeyWrapper encrypts data-encryption keys under a master key-encryption key. It can unwrap
+// keys made under any master key it still knows, so the master key can be rotated.
+type KeyWrapper interface {
+	// CurrentKeyID names the master key that WrapKey uses
+	CurrentKeyID() string
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// Supported values for DatabaseConfig.Driver
+const (
+	DriverMySQL    = "mysql"
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverRedis    = "redis"
+)
+
 // DatabaseConfig contains database connection configuration
 type DatabaseConfig struct {
+	Driver   string // DriverMySQL, DriverPostgres, DriverSQLite or DriverRedis; empty means DriverMySQL
 	Host     string
 	User     string
 	Password string
 	Name     string
+	Path     string // Database file for DriverSQLite; the network settings above are ignored
+}
+
+// AtRestConfig selects the master key that wraps stored message content. Set either KeyringPath
+// or TransitAddress; with neither, content is stored exactly as the message service sent it.
+type AtRestConfig struct {
+	KeyringPath    string // JSON keyring file holding the master keys
+	TransitAddress string // Base URL of a Vault-Transit-compatible endpoint, e.g. https://vault:8200
+	TransitToken   string
+	TransitMount   string // Transit mount path; empty means "transit"
+	TransitKey     string // Name of the transit key new data keys are wrapped under
+}
+
+// Enabled reports whether a master key source is configured
+func (c AtRestConfig) Enabled() bool {
+	return c.KeyringPath != "" || c.TransitAddress != ""
 }
@@ -21,9 +21,47 @@ var (
 	// ErrMessageNotFound is returned when a message is not found in storage
 	ErrMessageNotFound = errors.New("message not found")
 	
+	// ErrAttachmentNotFound is returned when a message has no stored attachment
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	
+	// ErrEmptyAttachment is returned when an attachment has no encrypted chunks
+	ErrEmptyAttachment = errors.New("attachment must contain at least one chunk")
+	
+	// ErrSecretRequestNotFound is returned when a secret request is not found in storage
+	ErrSecretRequestNotFound = errors.New("secret request not found")
+	
+	// ErrSecretRequestUnavailable is returned when fulfilling a secret request that was
+	// already fulfilled or has expired
+	ErrSecretRequestUnavailable = errors.New("secret request already fulfilled or expired")
+	
 	// ErrDatabaseConnection is returned when database connection fails
 	ErrDatabaseConnection = errors.New("database connection failed")
 	
 	// ErrDatabaseOperation is returned when database operation fails
 	ErrDatabaseOperation = errors.New("database operation failed")
+	
+	// ErrUnknownMasterKey is returned when stored content was wrapped under a master key that
+	// is not (or no longer) configured
+	ErrUnknownMasterKey = errors.New("unknown master key")
+	
+	// ErrKeyWrapping is returned when a data-encryption key cannot be wrapped or unwrapped
+	ErrKeyWrapping = errors.New("key wrapping failed")
+	
+	// ErrAtRestDecryption is returned when content sealed at rest cannot be opened
+	ErrAtRestDecryption = errors.New("at-rest decryption failed")
+	
+	// ErrAuditConflict is returned when another writer appended an audit entry with the same sequence
+	ErrAuditConflict = errors.New("audit log sequence already taken")
+	
+	// ErrAuditChainBroken is returned when the audit log does not verify, i.e. it was tampered with
+	ErrAuditChainBroken = errors.New("audit log hash chain broken")
+	
+	// ErrOutboxEntryNotFound is returned when an outbox entry is gone, delivered, dead-lettered or changed
+	ErrOutboxEntryNotFound = errors.New("outbox entry not found")
+
+	// ErrNoOutboxPublisher is returned when relaying the outbox without a queue to publish to
+	ErrNoOutboxPublisher = errors.New("no outbox publisher configured")
+	
+	// ErrUnsupportedDriver is returned when DatabaseConfig names an unknown database driver
+	ErrUnsupportedDriver = errors.New("unsupported database driver")
 )
\ No newline at end of file
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// DefaultRotationBatchSize is how many messages or outbox entries KeyRotator reads per query
+const DefaultRotationBatchSize = 500
+
+// RotationResult summarizes a KeyRotator run
+type RotationResult struct {
+	Scanned   int // Messages read
+	Rewrapped int // Messages whose data key was re-wrapped under the current master key
+	Sealed    int // Messages stored before at-rest encryption that are now sealed
+	Skipped   int // Messages deleted or changed while the rotation ran
+
+	Notifications int // Undelivered outbox payloads re-wrapped or sealed
+}
+
+// KeyRotator re-wraps the data key of every stored message, and of every notification still
+// waiting in the outbox, under the current master key. The repository must be the undecorated
+// one, so the rotator sees content as it is stored.
+type KeyRotator struct {
+	repository MessageRepository
+	cipher     *AtRestCipher
+	batchSize  int
+}
+
+// NewKeyRotator creates a rotator that reads messages in batches of DefaultRotationBatchSize
+func NewKeyRotator(repository MessageRepository, cipher *AtRestCipher) *KeyRotator {
+	return &KeyRotator{
+		repository: repository,
+		cipher:     cipher,
+		batchSize:  DefaultRotationBatchSize,
+	}
+}
+
+// Rotate re-wraps all messages and undelivered notifications not yet under the current master
+// key. Only the wrapped data key changes, so it is safe to run while the service is live, and
+// safe to run again after a failure.
+func (r *KeyRotator) Rotate(ctx context.Context) (RotationResult, error) {
+	var result RotationResult
+	var afterID int64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		messages, err := r.repository.SelectMessageContents(afterID, r.batchSize)
+		if err != nil {
+			logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to read messages for key rotation")
+			return result, err
+		}
+		if len(messages) == 0 {
+			break
+		}
+
+		for _, message := range messages {
+			afterID = message.ID
+			result.Scanned++
+			if !r.cipher.NeedsRewrap(message.Content) {
+				continue
+			}
+
+			content, err := r.cipher.Rewrap(ctx, message.UniqueID, message.Content)
+			if err != nil {
+				return result, err
+			}
+
+			// The row may have been viewed to its limit or revoked since it was read
+			err = r.repository.UpdateMessageContent(message.UniqueID, message.Content, content)
+			if errors.Is(err, ErrMessageNotFound) {
+				result.Skipped++
+				continue
+			}
+			if err != nil {
+				logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to store re-wrapped message")
+				return result, err
+			}
+
+			if IsSealedAtRest(message.Content) {
+				result.Rewrapped++
+			} else {
+				result.Sealed++
+			}
+		}
+	}
+
+	notifications, err := r.rotateOutbox(ctx)
+	result.Notifications = notifications
+	if err != nil {
+		return result, err
+	}
+
+	logging.Info().
+		Str("keyID", r.cipher.wrapper.CurrentKeyID()).
+		Int("scanned", result.Scanned).
+		Int("rewrapped", result.Rewrapped).
+		Int("sealed", result.Sealed).
+		Int("skipped", result.Skipped).
+		Int("notifications", result.Notifications).
+		Msg("Master key rotation finished")
+	return result, nil
+}
+
+// rotateOutbox re-wraps the payloads of undelivered outbox entries, which the relay opens with
+// the same master keys as message content. It returns how many it re-wrapped.
+func (r *KeyRotator) rotateOutbox(ctx context.Context) (int, error) {
+	rewrapped := 0
+	var afterID int64
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return rewrapped, err
+		}
+
+		entries, err := r.repository.SelectOutboxPayloads(afterID, r.batchSize)
+		if err != nil {
+			logging.Error().Err(err).Int64("afterID", afterID).Msg("Failed to read outbox entries for key rotation")
+			return rewrapped, err
+		}
+		if len(entries) == 0 {
+			return rewrapped, nil
+		}
+
+		for _, entry := range entries {
+			afterID = entry.ID
+			if !r.cipher.NeedsRewrap(string(entry.Payload)) {
+				continue
+			}
+
+			payload, err := r.cipher.Rewrap(ctx, outboxAssociatedID(entry.MessageID), string(entry.Payload))
+			if err != nil {
+				return rewrapped, err
+			}
+
+			// The entry may have been delivered, or removed with its message, since it was read
+			err = r.repository.UpdateOutboxPayload(entry.ID, entry.Payload, []byte(payload))
+			if errors.Is(err, ErrOutboxEntryNotFound) {
+				continue
+			}
+			if err != nil {
+				logging.Error().Err(err).Int64("entryID", entry.ID).Msg("Failed to store re-wrapped outbox entry")
+				return rewrapped, err
+			}
+			rewrapped++
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
+)
+
+// DefaultOutboxBatchSize is how many outbox entries RelayOutbox claims per query
+const DefaultOutboxBatchSize = 100
+
+// Outbox retry timing. A claimed entry is hidden from other relays for outboxLease; an entry
+// that failed to publish is retried after outboxMinRetry, doubling up to outboxMaxRetry.
+const (
+	outboxLease    = time.Minute
+	outboxMinRetry = 5 * time.Second
+	outboxMaxRetry = 15 * time.Minute
+)
+
+// RelayOutbox publishes the notifications waiting in the outbox and marks them delivered.
+// Delivery is at least once: an entry published just before the relay stops is sent again.
+// After the first failed publish the run stops, since the queue is most likely unreachable;
+// the entries claimed but not tried become due again once their lease runs out.
+func (s *StorageService) RelayOutbox(ctx context.Context, batchSize int) (OutboxRelayResult, error) {
+	var result OutboxRelayResult
+	if s.outboxPublisher == nil {
+		return result, ErrNoOutboxPublisher
+	}
+	if batchSize < 1 {
+		batchSize = DefaultOutboxBatchSize
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		entries, err := s.repository.ClaimOutboxEntries(batchSize, outboxLease)
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to claim outbox entries")
+			return result, err
+		}
+
+		for _, entry := range entries {
+			if err := s.outboxPublisher.PublishOutboxEntry(ctx, entry.Payload); err != nil {
+				result.Failed++
+				retryAfter := outboxRetryDelay(entry.Attempts)
+				logging.Warn().
+					Err(err).
+					Int64("entryID", entry.ID).
+					Str("uniqueID", entry.MessageID).
+					Int("attempts", entry.Attempts+1).
+					Dur("retryAfter", retryAfter).
+					Msg("Failed to publish outbox entry")
+				if markErr := s.repository.MarkOutboxEntryFailed(entry.ID, retryAfter, err.Error()); markErr != nil {
+					logging.Error().Err(markErr).Int64("entryID", entry.ID).Msg("Failed to record outbox publish failure")
+				}
+				return result, nil
+			}
+
+			// The entry is already on the queue, so failing to mark it only means it is sent twice
+			if err := s.repository.MarkOutboxEntryDelivered(entry.ID); err != nil {
+				logging.Error().Err(err).Int64("entryID", entry.ID).Str("uniqueID", entry.MessageID).Msg("Failed to mark outbox entry delivered")
+				return result, err
+			}
+			result.Delivered++
+		}
+
+		if len(entries) < batchSize {
+			break
+		}
+	}
+
+	if result.Delivered > 0 {
+		logging.Info().Int("delivered", result.Delivered).Msg("Relayed outbox notifications")
+	}
+	return result, nil
+}
+
+// outboxRetryDelay is the backoff after an entry's attempts+1'th failed publish
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxMinRetry
+	for i := 0; i < attempts && delay < outboxMaxRetry; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxRetry {
+		delay = outboxMaxRetry
+	}
+	return delay
+}
@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOutboxRepository keeps outbox entries in insertion order; claimed entries are hidden until released
+type memoryOutboxRepository struct {
+	*memoryRepository
+	entries   []*OutboxEntry
+	claimed      map[int64]bool
+	delivered    map[int64]bool
+	failures     map[int64]time.Duration
+	deadLettered map[int64]string
+}
+
+func newMemoryOutboxRepository() *memoryOutboxRepository {
+	return &memoryOutboxRepository{
+		memoryRepository: newMemoryRepository(),
+		claimed:          map[int64]bool{},
+		delivered:        map[int64]bool{},
+		failures:         map[int64]time.Duration{},
+		deadLettered:     map[int64]string{},
+	}
+}
+
+func (r *memoryOutboxRepository) InsertMessage(message *Message) error {
+	if len(message.Notification) > 0 {
+		r.entries = append(r.entries, &OutboxEntry{
+			ID:        int64(len(r.entries) + 1),
+			MessageID: message.UniqueID,
+			Payload:   append([]byte(nil), message.Notification...),
+		})
+	}
+	return r.memoryRepository.InsertMessage(message)
+}
+
+func (r *memoryOutboxRepository) ClaimOutboxEntries(limit int, lease time.Duration) ([]*OutboxEntry, error) {
+	var entries []*OutboxEntry
+	for _, entry := range r.entries {
+		if r.claimed[entry.ID] || !r.pending(entry.ID) || len(entries) == limit {
+			continue
+		}
+		r.claimed[entry.ID] = true
+		copied := *entry
+		entries = append(entries, &copied)
+	}
+	return entries, nil
+}
+
+func (r *memoryOutboxRepository) MarkOutboxEntryDelivered(id int64) error {
+	r.delivered[id] = true
+	return nil
+}
+
+func (r *memoryOutboxRepository) MarkOutboxEntryFailed(id int64, retryAfter time.Duration, lastError string) error {
+	r.failures[id] = retryAfter
+	r.entries[id-1].Attempts++
+	return nil
+}
+
+func (r *memoryOutboxRepository) DeadLetterOutboxEntry(id int64, lastError string) error {
+	r.deadLettered[id] = lastError
+	return nil
+}
+
+func (r *memoryOutboxRepository) SelectOutboxPayloads(afterID int64, limit int) ([]*OutboxEntry, error) {
+	var entries []*OutboxEntry
+	for _, entry := range r.entries {
+		if entry.ID > afterID && r.pending(entry.ID) && len(entries) < limit {
+			entries = append(entries, &OutboxEntry{ID: entry.ID, MessageID: entry.MessageID, Payload: entry.Payload})
+		}
+	}
+	return entries, nil
+}
+
+func (r *memoryOutboxRepository) UpdateOutboxPayload(id int64, currentPayload, newPayload []byte) error {
+	if id < 1 || int(id) > len(r.entries) || !r.pending(id) || string(r.entries[id-1].Payload) != string(currentPayload) {
+		return ErrOutboxEntryNotFound
+	}
+	r.entries[id-1].Payload = newPayload
+	return nil
+}
+
+// pending reports whether an entry is neither delivered nor dead-lettered
+func (r *memoryOutboxRepository) pending(id int64) bool {
+	_, deadLettered := r.deadLettered[id]
+	return !r.delivered[id] && !deadLettered
+}
+
+// recordingPublisher records published payloads and fails once failAfter payloads were published
+type recordingPublisher struct {
+	published [][]byte
+	failAfter int
+}
+
+func (p *recordingPublisher) PublishOutboxEntry(ctx context.Context, payload []byte) error {
+	if p.failAfter >= 0 && len(p.published) >= p.failAfter {
+		return errors.New("connection refused")
+	}
+	p.published = append(p.published, payload)
+	return nil
+}
+
+func TestRelayOutbox_PublishesInBatches(t *testing.T) {
+	repo := newMemoryOutboxRepository()
+	for _, uniqueID := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, repo.InsertMessage(&Message{UniqueID: uniqueID, Notification: []byte("notify " + uniqueID)}))
+	}
+	require.NoError(t, repo.InsertMessage(&Message{UniqueID: "no-notification"}))
+	publisher := &recordingPublisher{failAfter: -1}
+	service := NewStorageService(repo).WithOutboxPublisher(publisher)
+
+	result, err := service.RelayOutbox(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxRelayResult{Delivered: 3}, result)
+	assert.Equal(t, [][]byte{[]byte("notify message-1"), []byte("notify message-2"), []byte("notify message-3")}, publisher.published)
+	assert.Len(t, repo.delivered, 3)
+
+	// Nothing is published twice
+	result, err = service.RelayOutbox(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxRelayResult{}, result)
+}
+
+func TestRelayOutbox_StopsAtFirstFailure(t *testing.T) {
+	repo := newMemoryOutboxRepository()
+	for _, uniqueID := range []string{"message-1", "message-2", "message-3"} {
+		require.NoError(t, repo.InsertMessage(&Message{UniqueID: uniqueID, Notification: []byte(uniqueID)}))
+	}
+	repo.entries[1].Attempts = 2
+	publisher := &recordingPublisher{failAfter: 1}
+
+	result, err := NewStorageService(repo).WithOutboxPublisher(publisher).RelayOutbox(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, OutboxRelayResult{Delivered: 1, Failed: 1}, result)
+	assert.True(t, repo.delivered[1])
+	assert.Equal(t, map[int64]time.Duration{2: 20 * time.Second}, repo.failures, "third failure waits 5s doubled twice")
+	assert.False(t, repo.delivered[3], "entries after the failure wait for their lease to run out")
+}
+
+func TestRelayOutbox_RequiresPublisher(t *testing.T) {
+	_, err := NewStorageService(newMemoryOutboxRepository()).RelayOutbox(context.Background(), 10)
+	assert.ErrorIs(t, err, ErrNoOutboxPublisher)
+}
+
+func TestOutboxRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, outboxRetryDelay(0))
+	assert.Equal(t, 10*time.Second, outboxRetryDelay(1))
+	assert.Equal(t, 80*time.Second, outboxRetryDelay(4))
+	assert.Equal(t, outboxMaxRetry, outboxRetryDelay(20))
+	assert.Equal(t, outboxMaxRetry, outboxRetryDelay(1000))
+}
+
+func TestEncryptedRepository_SealsOutboxPayload(t *testing.T) {
+	wrapper := newMemoryKeyWrapper("2026-10")
+	inner := newMemoryOutboxRepository()
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(wrapper))
+
+	require.NoError(t, repo.InsertMessage(&Message{UniqueID: "message-1", Content: "ciphertext", Notification: []byte("https://example.com/decrypt/message-1/key")}))
+	require.NoError(t, repo.InsertMessage(&Message{UniqueID: "message-2", Content: "ciphertext", Notification: []byte("second")}))
+	require.Len(t, inner.entries, 2)
+	assert.True(t, IsSealedAtRest(string(inner.entries[0].Payload)))
+	assert.NotContains(t, string(inner.entries[0].Payload), "decrypt")
+
+	// A payload moved to another message's entry never opens, so it is dead-lettered
+	inner.entries[1].Payload = inner.entries[0].Payload
+
+	entries, err := repo.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "https://example.com/decrypt/message-1/key", string(entries[0].Payload))
+	assert.Contains(t, inner.deadLettered, int64(2))
+	assert.Empty(t, inner.failures)
+}
+
+func TestEncryptedRepository_DeadLettersPayloadUnderRetiredKey(t *testing.T) {
+	wrapper := newMemoryKeyWrapper("2026-01", "2026-10")
+	inner := newMemoryOutboxRepository()
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(wrapper))
+	require.NoError(t, repo.InsertMessage(&Message{UniqueID: "message-1", Content: "ciphertext", Notification: []byte("notify")}))
+
+	delete(wrapper.keys, "2026-01")
+	entries, err := repo.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Contains(t, inner.deadLettered[1], ErrUnknownMasterKey.Error())
+	assert.Empty(t, inner.failures)
+}
+
+// unreachableKeyWrapper fails every unwrap, as Transit does while it is down
+type unreachableKeyWrapper struct {
+	*memoryKeyWrapper
+}
+
+func (w *unreachableKeyWrapper) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	return nil, fmt.Errorf("%w: connection refused", ErrKeyWrapping)
+}
+
+func TestEncryptedRepository_RetriesPayloadWhenKeyUnreachable(t *testing.T) {
+	wrapper := newMemoryKeyWrapper("2026-10")
+	inner := newMemoryOutboxRepository()
+	require.NoError(t, NewEncryptedRepository(inner, NewAtRestCipher(wrapper)).InsertMessage(&Message{UniqueID: "message-1", Content: "ciphertext", Notification: []byte("notify")}))
+	inner.entries[0].Attempts = 1
+
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(&unreachableKeyWrapper{wrapper}))
+	entries, err := repo.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, map[int64]time.Duration{1: 10 * time.Second}, inner.failures, "second failure backs off like a failed publish")
+	assert.Empty(t, inner.deadLettered)
+}
+
+func TestKeyRotator_RewrapsOutboxPayloads(t *testing.T) {
+	ctx := context.Background()
+	wrapper := newMemoryKeyWrapper("2026-01", "2026-10")
+	inner := newMemoryOutboxRepository()
+	repo := NewEncryptedRepository(inner, NewAtRestCipher(wrapper))
+
+	for i := 1; i <= 3; i++ {
+		uniqueID := fmt.Sprintf("message-%d", i)
+		require.NoError(t, repo.InsertMessage(&Message{UniqueID: uniqueID, Content: "ciphertext", Notification: []byte("notify " + uniqueID)}))
+	}
+	// Delivered entries are left alone
+	require.NoError(t, inner.MarkOutboxEntryDelivered(1))
+	delivered := inner.entries[0].Payload
+
+	wrapper.current = "2026-10"
+	rotator := NewKeyRotator(inner, NewAtRestCipher(wrapper))
+	rotator.batchSize = 1
+
+	result, err := rotator.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RotationResult{Scanned: 3, Rewrapped: 3, Notifications: 2}, result)
+	assert.Equal(t, delivered, inner.entries[0].Payload)
+
+	// The old master key can be retired without stranding the waiting notifications
+	delete(wrapper.keys, "2026-01")
+	entries, err := repo.ClaimOutboxEntries(10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "notify message-2", string(entries[0].Payload))
+	assert.Equal(t, "notify message-3", string(entries[1].Payload))
+	assert.Empty(t, inner.deadLettered)
+
+	// A second run has nothing left to do
+	result, err = rotator.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, RotationResult{Scanned: 3}, result)
+}
+
+func TestKeyRotator_SkipsOutboxEntriesDeliveredDuringRotation(t *testing.T) {
+	wrapper := newMemoryKeyWrapper("2026-01", "2026-10")
+	inner := &deliveringRepository{memoryOutboxRepository: newMemoryOutboxRepository()}
+	require.NoError(t, NewEncryptedRepository(inner, NewAtRestCipher(wrapper)).InsertMessage(&Message{UniqueID: "message-1", Content: "ciphertext", Notification: []byte("notify")}))
+
+	wrapper.current = "2026-10"
+	result, err := NewKeyRotator(inner, NewAtRestCipher(wrapper)).Rotate(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Notifications)
+}
+
+// deliveringRepository delivers each outbox entry right after the rotator reads it, as the relay would
+type deliveringRepository struct {
+	*memoryOutboxRepository
+}
+
+func (r *deliveringRepository) SelectOutboxPayloads(afterID int64, limit int) ([]*OutboxEntry, error) {
+	entries, err := r.memoryOutboxRepository.SelectOutboxPayloads(afterID, limit)
+	for _, entry := range entries {
+		r.delivered[entry.ID] = true
+	}
+	return entries, err
+}
@@ -2,14 +2,22 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Anthony-Bible/password-exchange/app/internal/shared/logging"
 	"github.com/Anthony-Bible/password-exchange/app/pkg/validation"
 )
 
+// DefaultCleanupBatchSize is how many expired messages CleanupExpiredMessages deletes per query
+const DefaultCleanupBatchSize = 1000
+
 // StorageService implements the primary port and provides business logic for storage operations
 type StorageService struct {
-	repository MessageRepository
+	repository      MessageRepository
+	expiryNotifier  ExpiryNotifier
+	auditLog        *AuditLog
+	outboxPublisher OutboxPublisher
 }
 
 // NewStorageService creates a new storage service with the given repository
@@ -19,6 +27,28 @@ func NewStorageService(repository MessageRepository) *StorageService {
 	}
 }
 
+// NewStorageServiceWithExpiryNotifier creates a storage service that reports each message
+// removed by CleanupExpiredMessages to the given notifier
+func NewStorageServiceWithExpiryNotifier(repository MessageRepository, expiryNotifier ExpiryNotifier) *StorageService {
+	return &StorageService{
+		repository:     repository,
+		expiryNotifier: expiryNotifier,
+	}
+}
+
+// WithAuditLog records each message's creation, views, failed passphrases, expiry and deletion
+// in the given audit log
+func (s *StorageService) WithAuditLog(auditLog *AuditLog) *StorageService {
+	s.auditLog = auditLog
+	return s
+}
+
+// WithOutboxPublisher lets RelayOutbox publish the notifications stored with messages
+func (s *StorageService) WithOutboxPublisher(publisher OutboxPublisher) *StorageService {
+	s.outboxPublisher = publisher
+	return s
+}
+
 // StoreMessage stores a new encrypted message with validation
 func (s *StorageService) StoreMessage(ctx context.Context, message *Message) error {
 	// Business rule validation
@@ -34,9 +64,18 @@ func (s *StorageService) StoreMessage(ctx context.Context, message *Message) err
 		logging.Warn().Int("maxViewCount", message.MaxViewCount).Msg("Attempted to store message with invalid max view count")
 		return ErrInvalidMaxViewCount
 	}
+	if message.Attachment != nil && len(message.Attachment.Chunks) == 0 {
+		logging.Warn().Str("uniqueID", message.UniqueID).Msg("Attempted to store attachment without chunks")
+		return ErrEmptyAttachment
+	}
 
 	// Delegate to repository
-	return s.repository.InsertMessage(message)
+	if err := s.repository.InsertMessage(message); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, AuditEventCreated, message.UniqueID)
+	return nil
 }
 
 // RetrieveMessage retrieves a message by its unique ID with validation and increments view count
@@ -55,6 +94,7 @@ func (s *StorageService) RetrieveMessage(ctx context.Context, uniqueID string) (
 	}
 
 	logging.Info().Str("uniqueID", uniqueID).Int("viewCount", message.ViewCount).Msg("Message retrieved and view count incremented")
+	s.recordAudit(ctx, AuditEventViewed, uniqueID)
 	return message, nil
 }
 
@@ -77,10 +117,274 @@ func (s *StorageService) GetMessage(ctx context.Context, uniqueID string) (*Mess
 	return message, nil
 }
 
-// CleanupExpiredMessages removes expired messages from storage
-func (s *StorageService) CleanupExpiredMessages(ctx context.Context) error {
-	logging.Info().Msg("Starting cleanup of expired messages")
-	return s.repository.DeleteExpiredMessages()
+// GetAttachment retrieves the encrypted attachment of a message without incrementing view count
+func (s *StorageService) GetAttachment(ctx context.Context, uniqueID string) (*Attachment, error) {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to get attachment with empty unique ID")
+		return nil, ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	attachment, err := s.repository.GetAttachment(uniqueID)
+	if err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to retrieve attachment")
+		return nil, err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("chunkCount", len(attachment.Chunks)).Msg("Attachment retrieved successfully")
+	return attachment, nil
+}
+
+// DeleteMessage permanently removes a message and its attachment
+func (s *StorageService) DeleteMessage(ctx context.Context, uniqueID string) error {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to delete message with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	if err := s.repository.DeleteMessage(uniqueID); err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to delete message")
+		return err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Message deleted successfully")
+	s.recordAudit(ctx, AuditEventDeleted, uniqueID)
+	return nil
+}
+
+// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+func (s *StorageService) ReservePassphraseAttempt(ctx context.Context, uniqueID string) (int, error) {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to reserve passphrase attempt with empty unique ID")
+		return 0, ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	failedAttempts, err := s.repository.ReservePassphraseAttempt(uniqueID)
+	if err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to reserve passphrase attempt")
+		return 0, err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Int("failedAttempts", failedAttempts).Msg("Passphrase attempt reserved")
+	return failedAttempts, nil
+}
+
+// RecordPassphraseFailure audits a wrong passphrase. Its attempt was already reserved, and stays
+// counted; only the caller knows the passphrase turned out wrong.
+func (s *StorageService) RecordPassphraseFailure(ctx context.Context, uniqueID string) error {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to record passphrase failure with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Passphrase failure recorded")
+	s.recordAudit(ctx, AuditEventPassphraseFailed, uniqueID)
+	return nil
+}
+
+// ReleasePassphraseAttempt takes back a reserved attempt once the passphrase turned out right
+func (s *StorageService) ReleasePassphraseAttempt(ctx context.Context, uniqueID string) error {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to release passphrase attempt with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	if err := s.repository.ReleasePassphraseAttempt(uniqueID); err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to release passphrase attempt")
+		return err
+	}
+
+	logging.Debug().Str("uniqueID", uniqueID).Msg("Passphrase attempt released")
+	return nil
+}
+
+// UpdatePassphraseHash replaces a message's stored passphrase hash, e.g. to upgrade its algorithm
+func (s *StorageService) UpdatePassphraseHash(ctx context.Context, uniqueID, hashedPassphrase string) error {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to update passphrase hash with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+	// Clearing the hash would remove the passphrase requirement altogether
+	if hashedPassphrase == "" {
+		logging.Warn().Str("uniqueID", uniqueID).Msg("Attempted to clear passphrase hash")
+		return fmt.Errorf("%w: passphrase hash cannot be empty", ErrInvalidParameter)
+	}
+
+	// Delegate to repository
+	if err := s.repository.UpdatePassphraseHash(uniqueID, hashedPassphrase); err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to update passphrase hash")
+		return err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Passphrase hash updated")
+	return nil
+}
+
+// StoreSecretRequest stores a new request for someone to send the requester a secret
+func (s *StorageService) StoreSecretRequest(ctx context.Context, request *SecretRequest) error {
+	// Business rule validation
+	if request.UniqueID == "" {
+		logging.Warn().Msg("Attempted to store secret request with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+	if request.RequesterEmail == "" {
+		logging.Warn().Str("uniqueID", request.UniqueID).Msg("Attempted to store secret request without requester email")
+		return ErrEmptyEmailAddress
+	}
+
+	// Delegate to repository
+	return s.repository.InsertSecretRequest(request)
+}
+
+// GetSecretRequest retrieves a secret request by its unique ID
+func (s *StorageService) GetSecretRequest(ctx context.Context, uniqueID string) (*SecretRequest, error) {
+	// Business rule validation
+	if uniqueID == "" {
+		logging.Warn().Msg("Attempted to get secret request with empty unique ID")
+		return nil, ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	request, err := s.repository.GetSecretRequest(uniqueID)
+	if err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to retrieve secret request")
+		return nil, err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Msg("Secret request retrieved successfully")
+	return request, nil
+}
+
+// FulfillSecretRequest marks an open secret request as fulfilled by the given message. Only one
+// caller can fulfill a request; the rest get ErrSecretRequestUnavailable.
+func (s *StorageService) FulfillSecretRequest(ctx context.Context, uniqueID, messageUniqueID string) error {
+	// Business rule validation
+	if uniqueID == "" || messageUniqueID == "" {
+		logging.Warn().Msg("Attempted to fulfill secret request with empty unique ID")
+		return ErrEmptyUniqueID
+	}
+
+	// Delegate to repository
+	if err := s.repository.FulfillSecretRequest(uniqueID, messageUniqueID); err != nil {
+		logging.Warn().Err(err).Str("uniqueID", uniqueID).Msg("Failed to fulfill secret request")
+		return err
+	}
+
+	logging.Info().Str("uniqueID", uniqueID).Str("messageUniqueID", messageUniqueID).Msg("Secret request fulfilled successfully")
+	return nil
+}
+
+// CleanupExpiredMessages removes expired messages and secret requests from storage. Messages are
+// deleted batchSize at a time so a large backlog never holds long locks; zero or less uses
+// DefaultCleanupBatchSize.
+func (s *StorageService) CleanupExpiredMessages(ctx context.Context, batchSize int) (CleanupResult, error) {
+	if batchSize < 1 {
+		batchSize = DefaultCleanupBatchSize
+	}
+	var result CleanupResult
+
+	logging.Info().Int("batchSize", batchSize).Msg("Starting cleanup of expired messages")
+	purged, err := s.repository.DeleteExpiredSecretRequests()
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to delete expired secret requests")
+		return result, err
+	}
+	result.SecretRequestsPurged = purged
+
+	// Reported or audited messages are deleted one by one, so only those actually deleted are recorded
+	if s.expiryNotifier != nil || s.auditLog != nil {
+		for {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			purged, selected, err := s.purgeAndReportExpired(ctx, batchSize)
+			result.MessagesPurged += purged
+			if err != nil {
+				return result, err
+			}
+			if selected < batchSize {
+				break
+			}
+		}
+	}
+
+	// Whatever is left, which without a notifier is everything, is deleted in bulk
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		purged, err := s.repository.DeleteExpiredMessages(batchSize)
+		result.MessagesPurged += purged
+		if err != nil {
+			logging.Error().Err(err).Msg("Failed to delete expired messages")
+			return result, err
+		}
+		if purged < batchSize {
+			break
+		}
+	}
+
+	logging.Info().
+		Int("messagesPurged", result.MessagesPurged).
+		Int("secretRequestsPurged", result.SecretRequestsPurged).
+		Msg("Expired messages cleaned up")
+	return result, nil
+}
+
+// purgeAndReportExpired deletes up to batchSize expired messages, reports each to the expiry
+// notifier and records it in the audit log. They are read first; once deleted there is nothing
+// left to report. It returns how many were deleted and how many were read.
+func (s *StorageService) purgeAndReportExpired(ctx context.Context, batchSize int) (int, int, error) {
+	expired, err := s.repository.SelectExpiredMessages(batchSize)
+	if err != nil {
+		logging.Error().Err(err).Msg("Failed to select expired messages")
+		return 0, 0, err
+	}
+
+	purged := 0
+	for _, message := range expired {
+		// A message viewed to its limit or revoked since it was read is not reported as expired
+		err := s.repository.DeleteMessage(message.UniqueID)
+		if errors.Is(err, ErrMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to delete expired message")
+			return purged, len(expired), err
+		}
+		purged++
+
+		s.recordAudit(ctx, AuditEventExpired, message.UniqueID)
+		if s.expiryNotifier == nil {
+			continue
+		}
+		if err := s.expiryNotifier.NotifyExpired(ctx, message); err != nil {
+			logging.Error().Err(err).Str("uniqueID", message.UniqueID).Msg("Failed to report expired message")
+		}
+	}
+
+	logging.Info().Int("count", purged).Msg("Reported expired messages")
+	return purged, len(expired), nil
+}
+
+// recordAudit appends an event to the audit log, if there is one. A failure is logged rather than
+// returned: the operation it describes has already happened.
+func (s *StorageService) recordAudit(ctx context.Context, eventType AuditEventType, uniqueID string) {
+	if s.auditLog == nil {
+		return
+	}
+	if err := s.auditLog.Record(ctx, eventType, uniqueID); err != nil {
+		logging.Error().Err(err).Str("uniqueID", uniqueID).Str("eventType", string(eventType)).Msg("Failed to record audit event")
+	}
 }
 
 // GetUnviewedMessagesForReminders retrieves messages eligible for reminder emails
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expiringRepository extends memoryRepository with the expiry queries cleanup uses
+type expiringRepository struct {
+	*memoryRepository
+	now                   time.Time
+	expiredSecretRequests int
+	deleteQueries         int
+}
+
+func newExpiringRepository() *expiringRepository {
+	return &expiringRepository{memoryRepository: newMemoryRepository(), now: time.Now()}
+}
+
+func (r *expiringRepository) insertExpiring(uniqueID string, expiresIn time.Duration) {
+	expiresAt := r.now.Add(expiresIn)
+	_ = r.InsertMessage(&Message{UniqueID: uniqueID, Content: "c", MaxViewCount: 1, ExpiresAt: &expiresAt})
+}
+
+func (r *expiringRepository) expired(limit int) []*Message {
+	var expired []*Message
+	for _, message := range r.messages {
+		if message.ExpiresAt != nil && message.ExpiresAt.Before(r.now) {
+			expired = append(expired, message)
+		}
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].ID < expired[j].ID })
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+	return expired
+}
+
+func (r *expiringRepository) DeleteExpiredSecretRequests() (int, error) {
+	purged := r.expiredSecretRequests
+	r.expiredSecretRequests = 0
+	return purged, nil
+}
+
+func (r *expiringRepository) SelectExpiredMessages(limit int) ([]*Message, error) {
+	return r.expired(limit), nil
+}
+
+func (r *expiringRepository) DeleteExpiredMessages(limit int) (int, error) {
+	r.deleteQueries++
+	expired := r.expired(limit)
+	for _, message := range expired {
+		delete(r.messages, message.UniqueID)
+	}
+	return len(expired), nil
+}
+
+func (r *expiringRepository) DeleteMessage(uniqueID string) error {
+	if _, ok := r.messages[uniqueID]; !ok {
+		return ErrMessageNotFound
+	}
+	delete(r.messages, uniqueID)
+	return nil
+}
+
+func (r *expiringRepository) ReservePassphraseAttempt(uniqueID string) (int, error) {
+	message, ok := r.messages[uniqueID]
+	if !ok {
+		return 0, ErrMessageNotFound
+	}
+	message.FailedPassphraseAttempts++
+	return message.FailedPassphraseAttempts, nil
+}
+
+// recordingNotifier records the messages reported as expired
+type recordingNotifier struct {
+	reported []string
+}
+
+func (n *recordingNotifier) NotifyExpired(ctx context.Context, message *Message) error {
+	n.reported = append(n.reported, message.UniqueID)
+	return errors.New("queue unavailable")
+}
+
+func TestCleanupExpiredMessages_DeletesInBatches(t *testing.T) {
+	repo := newExpiringRepository()
+	repo.expiredSecretRequests = 2
+	for _, uniqueID := range []string{"a", "b", "c", "d", "e"} {
+		repo.insertExpiring(uniqueID, -time.Minute)
+	}
+	repo.insertExpiring("live", time.Hour)
+
+	result, err := NewStorageService(repo).CleanupExpiredMessages(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, CleanupResult{MessagesPurged: 5, SecretRequestsPurged: 2}, result)
+	assert.Equal(t, 3, repo.deleteQueries)
+	assert.Contains(t, repo.messages, "live")
+	assert.Len(t, repo.messages, 1)
+}
+
+func TestCleanupExpiredMessages_ReportsEachDeletedMessage(t *testing.T) {
+	repo := newExpiringRepository()
+	for _, uniqueID := range []string{"a", "b", "c"} {
+		repo.insertExpiring(uniqueID, -time.Minute)
+	}
+	repo.insertExpiring("live", time.Hour)
+	notifier := &recordingNotifier{}
+
+	// A notifier failure does not stop the cleanup
+	result, err := NewStorageServiceWithExpiryNotifier(repo, notifier).CleanupExpiredMessages(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, CleanupResult{MessagesPurged: 3}, result)
+	assert.Equal(t, []string{"a", "b", "c"}, notifier.reported)
+	assert.Len(t, repo.messages, 1)
+}
+
+func TestCleanupExpiredMessages_StopsWhenCancelled(t *testing.T) {
+	repo := newExpiringRepository()
+	repo.insertExpiring("a", -time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewStorageService(repo).CleanupExpiredMessages(ctx, 0)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, repo.messages, "a")
+}
+
+func TestStorageService_RecordPassphraseFailureRejectsEmptyID(t *testing.T) {
+	service := NewStorageService(newExpiringRepository())
+	assert.ErrorIs(t, service.RecordPassphraseFailure(context.Background(), ""), ErrEmptyUniqueID)
+}
+
+func TestStorageService_RecordsAuditEvents(t *testing.T) {
+	repo := newExpiringRepository()
+	auditRepo := &memoryAuditRepository{}
+	service := NewStorageService(repo).WithAuditLog(NewAuditLog(auditRepo, []byte("client-key")))
+	ctx := WithAuditClient(context.Background(), AuditClient{IP: "203.0.113.7", UserAgent: "curl/8.0"})
+
+	require.NoError(t, service.StoreMessage(ctx, &Message{UniqueID: "message-1", Content: "c", MaxViewCount: 1}))
+	// Reserving an attempt says nothing about the passphrase; only a failure is recorded
+	_, err := service.ReservePassphraseAttempt(ctx, "message-1")
+	require.NoError(t, err)
+	require.NoError(t, service.RecordPassphraseFailure(ctx, "message-1"))
+	require.NoError(t, service.DeleteMessage(ctx, "message-1"))
+	assert.Error(t, service.DeleteMessage(ctx, "message-1"), "a failed operation is not recorded")
+	repo.insertExpiring("message-2", -time.Minute)
+	_, err = service.CleanupExpiredMessages(context.Background(), 0)
+	require.NoError(t, err)
+
+	var recorded []AuditEventType
+	for _, event := range auditRepo.events {
+		recorded = append(recorded, event.EventType)
+	}
+	assert.Equal(t, []AuditEventType{AuditEventCreated, AuditEventPassphraseFailed, AuditEventDeleted, AuditEventExpired}, recorded)
+	assert.Equal(t, "message-2", auditRepo.events[3].MessageID)
+	assert.NotEmpty(t, auditRepo.events[0].ClientIPHash)
+}
@@ -18,8 +18,38 @@ type StorageServicePort interface {
 	// GetMessage retrieves a message by its unique ID without incrementing view count
 	GetMessage(ctx context.Context, uniqueID string) (*domain.Message, error)
 
-	// CleanupExpiredMessages removes expired messages from storage
-	CleanupExpiredMessages(ctx context.Context) error
+	// GetAttachment retrieves a message's encrypted attachment without incrementing view count
+	GetAttachment(ctx context.Context, uniqueID string) (*domain.Attachment, error)
+
+	// DeleteMessage permanently removes a message and its attachment
+	DeleteMessage(ctx context.Context, uniqueID string) error
+
+	// ReservePassphraseAttempt reserves a passphrase attempt against a message and returns the new total
+	ReservePassphraseAttempt(ctx context.Context, uniqueID string) (int, error)
+
+	// ReleasePassphraseAttempt takes back a reserved attempt once the passphrase turned out right
+	ReleasePassphraseAttempt(ctx context.Context, uniqueID string) error
+
+	// RecordPassphraseFailure audits a wrong passphrase whose attempt was already reserved
+	RecordPassphraseFailure(ctx context.Context, uniqueID string) error
+
+	// UpdatePassphraseHash replaces a message's stored passphrase hash, e.g. to upgrade its algorithm
+	UpdatePassphraseHash(ctx context.Context, uniqueID, hashedPassphrase string) error
+
+	// StoreSecretRequest stores a new request for someone to send the requester a secret
+	StoreSecretRequest(ctx context.Context, request *domain.SecretRequest) error
+
+	// GetSecretRequest retrieves a secret request by its unique ID
+	GetSecretRequest(ctx context.Context, uniqueID string) (*domain.SecretRequest, error)
+
+	// FulfillSecretRequest marks an open secret request as fulfilled by the given message
+	FulfillSecretRequest(ctx context.Context, uniqueID, messageUniqueID string) error
+
+	// CleanupExpiredMessages removes expired messages, batchSize per query, and expired secret requests from storage
+	CleanupExpiredMessages(ctx context.Context, batchSize int) (domain.CleanupResult, error)
+
+	// RelayOutbox publishes the notifications stored with messages, batchSize entries per query
+	RelayOutbox(ctx context.Context, batchSize int) (domain.OutboxRelayResult, error)
 
 	// GetUnviewedMessagesForReminders retrieves messages eligible for reminder emails
 	GetUnviewedMessagesForReminders(ctx context.Context, olderThanHours, maxReminders, reminderIntervalHours int) ([]*domain.UnviewedMessage, error)
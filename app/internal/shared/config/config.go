@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/Anthony-Bible/password-exchange/app/internal/domains/storage/domain"
 )
 
@@ -13,18 +15,30 @@ type EmailConfig struct {
 	Body      EmailBody      `mapstructure:"body"`
 	Sender    EmailSender    `mapstructure:"sender"`
 	URL       string         `mapstructure:"url"`
+	// ListUnsubscribe is the List-Unsubscribe target of outgoing emails; empty uses a mailto:
+	// link to the sender address
+	ListUnsubscribe string `mapstructure:"listunsubscribe"`
 }
 
 // EmailTemplates defines paths or inline content for email templates.
 type EmailTemplates struct {
-	Initial  string `mapstructure:"initial"`
-	Reminder string `mapstructure:"reminder"`
+	Initial          string `mapstructure:"initial"`
+	Reminder         string `mapstructure:"reminder"`
+	ReadReceipt      string `mapstructure:"readreceipt"`
+	RequestFulfilled string `mapstructure:"requestfulfilled"`
+	PassphraseAlert  string `mapstructure:"passphrasealert"`
+	// InitialText and ReminderText are the plain-text alternatives of Initial and Reminder
+	InitialText  string `mapstructure:"initialtext"`
+	ReminderText string `mapstructure:"remindertext"`
 }
 
 // EmailSubjects defines the subject lines for different emails.
 type EmailSubjects struct {
-	Initial  string `mapstructure:"initial"`
-	Reminder string `mapstructure:"reminder"`
+	Initial          string `mapstructure:"initial"`
+	Reminder         string `mapstructure:"reminder"`
+	ReadReceipt      string `mapstructure:"readreceipt"`
+	RequestFulfilled string `mapstructure:"requestfulfilled"`
+	PassphraseAlert  string `mapstructure:"passphrasealert"`
 }
 
 // EmailBody defines the body content for different emails.
@@ -99,10 +113,12 @@ type PassConfig struct {
 	RabUser               string `mapstructure:"rabuser"`
 	RabPass               string `mapstructure:"rabpass"`
 	RabQName              string `mapstructure:"rabqname"`
+	DbDriver              string `mapstructure:"dbdriver"`
 	DbHost                string `mapstructure:"dbhost"`
 	DbUser                string `mapstructure:"dbuser"`
 	DbPass                string `mapstructure:"dbpass"`
 	DbName                string `mapstructure:"dbname"`
+	DbPath                string `mapstructure:"dbpath"`
 	ProdHost              string `mapstructure:"prodhost"`
 	DevHost               string `mapstructure:"devhost"`
 	EncryptionProdService string `mapstructure:"encryptionprodservice"`
@@ -117,4 +133,73 @@ type PassConfig struct {
 	DbPort                int    `mapstructure:"dbport"`
 	EmailPort             int    `mapstructure:"emailport"`
 	RabPort               int    `mapstructure:"rabport"`
+	WebhookURL            string `mapstructure:"webhookurl"`
+	WebhookSecret         string `mapstructure:"webhooksecret"`
+	// WebhookTrustedHosts is a comma-separated list of hosts that per-message webhooks may
+	// reach on private networks. The global WebhookURL is always trusted.
+	WebhookTrustedHosts string `mapstructure:"webhooktrustedhosts"`
+	// PassphraseMaxAttempts is how many wrong passphrases a message tolerates; zero uses the default of 5
+	PassphraseMaxAttempts int `mapstructure:"passphrasemaxattempts"`
+	// PassphraseLockoutAction is "destroy" (the default) or "lock", applied once the attempts are used up
+	PassphraseLockoutAction string `mapstructure:"passphraselockoutaction"`
+	// PassphraseHashMemoryKiB, PassphraseHashIterations and PassphraseHashParallelism set the
+	// Argon2id cost of new passphrase hashes; zero keeps each default (19456 KiB, 2, 1).
+	// Changing them upgrades older hashes the next time their passphrase is entered.
+	PassphraseHashMemoryKiB   int `mapstructure:"passphrasehashmemorykib"`
+	PassphraseHashIterations  int `mapstructure:"passphrasehashiterations"`
+	PassphraseHashParallelism int `mapstructure:"passphrasehashparallelism"`
+	// CipherAlgorithm seals new messages: "aes-256-gcm" (the default) or "xchacha20-poly1305".
+	// Existing messages keep decrypting with the algorithm recorded in their ciphertext.
+	CipherAlgorithm string `mapstructure:"cipheralgorithm"`
+	// AtRestKeyring is a JSON keyring file whose master keys wrap the storage service's per-message
+	// data keys. Alternatively the AtRestTransit settings point at a Vault-Transit-compatible
+	// endpoint (mount defaults to "transit"). With neither set, content is stored as received.
+	AtRestKeyring      string `mapstructure:"atrestkeyring"`
+	AtRestTransitAddr  string `mapstructure:"atresttransitaddr"`
+	AtRestTransitToken string `mapstructure:"atresttransittoken"`
+	AtRestTransitMount string `mapstructure:"atresttransitmount"`
+	AtRestTransitKey   string `mapstructure:"atresttransitkey"`
+	// CleanupInterval is how often the database service deletes messages past their expires_at,
+	// e.g. "15m"; zero uses the default of 1h. CleanupBatchSize caps the rows deleted per query;
+	// zero uses the default of 1000.
+	CleanupInterval  time.Duration `mapstructure:"cleanupinterval"`
+	CleanupBatchSize int           `mapstructure:"cleanupbatchsize"`
+	// OutboxInterval is how often the database service publishes notifications waiting in the
+	// outbox, e.g. "10s"; zero uses the default of 5s. OutboxBatchSize caps the entries claimed
+	// per query; zero uses the default of 100.
+	OutboxInterval  time.Duration `mapstructure:"outboxinterval"`
+	OutboxBatchSize int           `mapstructure:"outboxbatchsize"`
+	// EmailMaxAttempts is how many times the email consumer tries a notification before moving it
+	// to the dead-letter queue; zero uses the default of 5. EmailRetryDelay is the wait between
+	// attempts, e.g. "1m"; zero uses the default of 30s.
+	EmailMaxAttempts int           `mapstructure:"emailmaxattempts"`
+	EmailRetryDelay  time.Duration `mapstructure:"emailretrydelay"`
+	// QueueBackend carries notifications to the email consumer: "rabbitmq" (the default) or "nats"
+	// for NATS JetStream at NatsURL.
+	// NatsStream names the JetStream stream; empty uses "NOTIFICATIONS".
+	QueueBackend string `mapstructure:"queuebackend"`
+	NatsURL      string `mapstructure:"natsurl"`
+	NatsStream   string `mapstructure:"natsstream"`
+	// EmailTLSMode secures the connection to emailhost: "opportunistic" (the default) upgrades with
+	// STARTTLS when the server offers it, "starttls" requires STARTTLS, "implicit" speaks TLS from
+	// the first byte as relays on port 465 expect, and "none" never encrypts. EmailTLSSkipVerify
+	// accepts any server certificate; EmailTLSCAFile adds a PEM bundle of trusted CAs.
+	EmailTLSMode       string `mapstructure:"emailtlsmode"`
+	EmailTLSSkipVerify bool   `mapstructure:"emailtlsskipverify"`
+	EmailTLSCAFile     string `mapstructure:"emailtlscafile"`
+	// EmailDKIMKey signs outgoing email with DKIM: a PEM-encoded RSA or Ed25519 private key, or the
+	// path to one. EmailDKIMSelector names the DNS record holding the public key, and
+	// EmailDKIMDomain the signing domain; empty uses the domain of EmailFrom.
+	EmailDKIMKey      string `mapstructure:"emaildkimkey"`
+	EmailDKIMSelector string `mapstructure:"emaildkimselector"`
+	EmailDKIMDomain   string `mapstructure:"emaildkimdomain"`
+	// EmailListUnsubscribe is the List-Unsubscribe target of notification emails, a mailto: or
+	// https: URI; empty uses "mailto:<EmailFrom>?subject=unsubscribe"
+	EmailListUnsubscribe string `mapstructure:"emaillistunsubscribe"`
+	// MetricsAddress is where the database service serves Prometheus metrics, e.g. ":9090";
+	// empty disables the endpoint
+	MetricsAddress string `mapstructure:"metricsaddress"`
+	// AuditHashKey keys the HMAC that client IPs and user agents are hashed with before they are
+	// written to the audit log. Keep it secret and stable; changing it only affects new entries.
+	AuditHashKey string `mapstructure:"audithashkey"`
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
+	pgx "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
@@ -28,6 +29,8 @@ func NewMigrator(db *sql.DB, migrationsDir string) (*Migrator, error) {
 		driverName = "sqlite3"
 	case "*mysql.MySQLDriver":
 		driverName = "mysql"
+	case "*stdlib.Driver":
+		driverName = "pgx5"
 	default:
 		// Attempt to support the two main drivers even if not perfectly matched
 		// but for now we follow the requirement for automatic detection.
@@ -115,6 +118,8 @@ func (m *Migrator) getMigrateInstance() (*migrate.Migrate, error) {
 		driver, err = mysql.WithInstance(m.db, &mysql.Config{})
 	case "sqlite3":
 		driver, err = sqlite3.WithInstance(m.db, &sqlite3.Config{})
+	case "pgx5":
+		driver, err = pgx.WithInstance(m.db, &pgx.Config{})
 	default:
 		return nil, fmt.Errorf("unsupported database driver name: %s", m.driverName)
 	}
@@ -0,0 +1,7 @@
+-- Add client_encrypted column to messages table
+-- Marks messages encrypted in the browser; the server stores the ciphertext
+-- as-is and never holds the key, so it must not attempt to decrypt them
+
+ALTER TABLE messages
+  ADD COLUMN client_encrypted BOOLEAN NOT NULL DEFAULT FALSE
+  COMMENT 'Content was encrypted client-side; key lives only in the URL fragment';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `message_attachment_chunks`;
+DROP TABLE IF EXISTS `message_attachments`;
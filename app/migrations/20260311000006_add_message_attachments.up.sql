@@ -0,0 +1,22 @@
+-- Migration: Add tables for encrypted message attachments
+-- File name and content type are stored encrypted; file data is stored as
+-- independently sealed AES-GCM chunks in message_attachment_chunks.
+
+CREATE TABLE message_attachments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    message_id INT NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (message_id) REFERENCES messages(messageid) ON DELETE CASCADE,
+    UNIQUE INDEX idx_message_attachments_message_id (message_id)
+);
+
+CREATE TABLE message_attachment_chunks (
+    attachment_id INT NOT NULL,
+    chunk_index INT NOT NULL,
+    data MEDIUMBLOB NOT NULL,
+    PRIMARY KEY (attachment_id, chunk_index),
+    FOREIGN KEY (attachment_id) REFERENCES message_attachments(id) ON DELETE CASCADE
+);
@@ -0,0 +1,10 @@
+-- Add webhook columns to messages table
+-- A sender may ask for lifecycle events (created, viewed, exhausted, expired, revoked)
+-- to be POSTed to their own endpoint, signed with an HMAC secret they supply.
+-- The secret is stored as given because every delivery must be signed with it.
+
+ALTER TABLE messages
+  ADD COLUMN webhook_url VARCHAR(2048) NULL DEFAULT NULL
+  COMMENT 'Per-message webhook endpoint; NULL uses the globally configured webhook',
+  ADD COLUMN webhook_secret VARCHAR(256) NULL DEFAULT NULL
+  COMMENT 'HMAC-SHA256 key used to sign deliveries to webhook_url';
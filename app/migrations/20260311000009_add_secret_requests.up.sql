@@ -0,0 +1,17 @@
+-- Migration: Add secret_requests table for the "request a secret" flow
+-- A requester creates a request link; whoever holds the secret fulfills it once,
+-- which stores an ordinary message addressed to the requester.
+
+CREATE TABLE secret_requests (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    uniqueid VARCHAR(255) NOT NULL,
+    requester_name VARCHAR(100) NOT NULL,
+    requester_email VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    fulfilled_at TIMESTAMP NULL DEFAULT NULL,
+    message_uniqueid VARCHAR(255) DEFAULT NULL,
+    UNIQUE INDEX idx_secret_requests_uniqueid (uniqueid),
+    INDEX idx_secret_requests_expires_at (expires_at)
+);
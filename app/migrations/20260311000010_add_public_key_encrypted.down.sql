@@ -0,0 +1 @@
+ALTER TABLE `messages` DROP COLUMN `public_key_encrypted`;
@@ -0,0 +1,16 @@
+-- Migration: Add audit_events table, a tamper-evident log of what happened to each message
+-- Every entry stores the hash of the entry before it, so altering or removing one breaks
+-- the chain; `database verify-audit` checks it. Entries are only ever inserted: grant the
+-- service account INSERT and SELECT on this table, but not UPDATE or DELETE.
+
+CREATE TABLE audit_events (
+    sequence BIGINT NOT NULL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    message_uniqueid VARCHAR(255) NOT NULL,
+    client_ip_hash VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'HMAC-SHA256 of the client IP',
+    user_agent_hash VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'HMAC-SHA256 of the client user agent',
+    occurred_at DATETIME(6) NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    hash CHAR(64) NOT NULL,
+    INDEX idx_audit_events_message_uniqueid (message_uniqueid)
+);
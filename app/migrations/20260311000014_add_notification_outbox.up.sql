@@ -0,0 +1,17 @@
+-- Migration: Add notification_outbox table, notifications written in the same transaction as
+-- their message so they survive a queue outage. The relay in the database service publishes
+-- due entries, retrying with backoff, and clears the payload once delivered. Entries are
+-- removed with their message.
+
+CREATE TABLE notification_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    message_id INT NOT NULL,
+    payload MEDIUMBLOB COMMENT 'Queue message; NULL once delivered',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP NULL,
+    FOREIGN KEY (message_id) REFERENCES messages(messageid) ON DELETE CASCADE,
+    INDEX idx_notification_outbox_pending (delivered_at, next_attempt_at)
+);
@@ -0,0 +1 @@
+ALTER TABLE `messages` MODIFY COLUMN `other_lastname` varchar(100) DEFAULT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_messages_max_view_count;
+ALTER TABLE messages DROP COLUMN max_view_count;
@@ -0,0 +1,9 @@
+-- Migration to add max_view_count column to messages table
+-- This allows configurable maximum view counts per message
+
+ALTER TABLE messages
+ADD COLUMN max_view_count INTEGER NOT NULL DEFAULT 5;
+
+COMMENT ON COLUMN messages.max_view_count IS 'Maximum number of times this message can be viewed before deletion';
+
+CREATE INDEX idx_messages_max_view_count ON messages(max_view_count);
@@ -0,0 +1,13 @@
+-- Add expires_at column to messages table
+-- Messages expire 7 days after creation by default
+
+ALTER TABLE messages
+  ADD COLUMN expires_at TIMESTAMPTZ NOT NULL
+  DEFAULT (CURRENT_TIMESTAMP + INTERVAL '7 days');
+
+COMMENT ON COLUMN messages.expires_at IS 'Expires 7 days after creation';
+
+-- Existing rows received the migration time as their base; anchor them to creation instead
+UPDATE messages SET expires_at = created + INTERVAL '7 days';
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at);
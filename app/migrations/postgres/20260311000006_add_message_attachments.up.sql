@@ -0,0 +1,20 @@
+-- Migration: Add tables for encrypted message attachments
+-- File name and content type are stored encrypted; file data is stored as
+-- independently sealed AES-GCM chunks in message_attachment_chunks.
+
+CREATE TABLE message_attachments (
+    id SERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(messageid) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT idx_message_attachments_message_id UNIQUE (message_id)
+);
+
+CREATE TABLE message_attachment_chunks (
+    attachment_id INTEGER NOT NULL REFERENCES message_attachments(id) ON DELETE CASCADE,
+    chunk_index INTEGER NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (attachment_id, chunk_index)
+);
@@ -0,0 +1 @@
+ALTER TABLE messages DROP COLUMN webhook_url, DROP COLUMN webhook_secret;
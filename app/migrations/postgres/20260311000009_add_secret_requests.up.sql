@@ -0,0 +1,18 @@
+-- Migration: Add secret_requests table for the "request a secret" flow
+-- A requester creates a request link; whoever holds the secret fulfills it once,
+-- which stores an ordinary message addressed to the requester.
+
+CREATE TABLE secret_requests (
+    id SERIAL PRIMARY KEY,
+    uniqueid VARCHAR(255) NOT NULL,
+    requester_name VARCHAR(100) NOT NULL,
+    requester_email VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ,
+    fulfilled_at TIMESTAMPTZ,
+    message_uniqueid VARCHAR(255) DEFAULT NULL,
+    CONSTRAINT idx_secret_requests_uniqueid UNIQUE (uniqueid)
+);
+
+CREATE INDEX idx_secret_requests_expires_at ON secret_requests(expires_at);
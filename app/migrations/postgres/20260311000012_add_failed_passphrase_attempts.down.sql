@@ -0,0 +1 @@
+ALTER TABLE messages DROP COLUMN failed_passphrase_attempts;
@@ -0,0 +1,8 @@
+-- Add failed_passphrase_attempts column to messages table
+-- Counts wrong passphrases so a message can be locked or destroyed after too many,
+-- independently of the per-IP rate limit
+
+ALTER TABLE messages
+  ADD COLUMN failed_passphrase_attempts INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN messages.failed_passphrase_attempts IS 'Wrong passphrases submitted for this message';
@@ -0,0 +1,28 @@
+-- Migration: Add audit_events table, a tamper-evident log of what happened to each message
+-- Every entry stores the hash of the entry before it, so altering or removing one breaks
+-- the chain; `database verify-audit` checks it. Triggers reject updates and deletes.
+
+CREATE TABLE audit_events (
+    sequence BIGINT NOT NULL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    message_uniqueid VARCHAR(255) NOT NULL,
+    client_ip_hash VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent_hash VARCHAR(64) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMPTZ NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    hash CHAR(64) NOT NULL
+);
+
+CREATE INDEX idx_audit_events_message_uniqueid ON audit_events(message_uniqueid);
+
+COMMENT ON COLUMN audit_events.client_ip_hash IS 'HMAC-SHA256 of the client IP';
+COMMENT ON COLUMN audit_events.user_agent_hash IS 'HMAC-SHA256 of the client user agent';
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,20 @@
+-- Migration: Add notification_outbox table, notifications written in the same transaction as
+-- their message so they survive a queue outage. The relay in the database service publishes
+-- due entries, retrying with backoff, and clears the payload once delivered. Entries are
+-- removed with their message.
+
+CREATE TABLE notification_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    message_id INTEGER NOT NULL REFERENCES messages(messageid) ON DELETE CASCADE,
+    payload BYTEA,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox(next_attempt_at) WHERE delivered_at IS NULL;
+CREATE INDEX idx_notification_outbox_message_id ON notification_outbox(message_id);
+
+COMMENT ON COLUMN notification_outbox.payload IS 'Queue message; NULL once delivered';
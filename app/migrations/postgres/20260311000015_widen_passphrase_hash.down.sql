@@ -0,0 +1 @@
+ALTER TABLE messages ALTER COLUMN other_lastname TYPE VARCHAR(100);
@@ -0,0 +1,6 @@
+-- Widen the passphrase hash column of the messages table
+-- Argon2id PHC strings carry their cost parameters and pass 100 characters with a large
+-- memory setting; 255 holds any combination of parameters
+
+ALTER TABLE messages
+  ALTER COLUMN other_lastname TYPE VARCHAR(255);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS messages (
+  messageid INTEGER PRIMARY KEY AUTOINCREMENT,
+  created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  lastAccessed TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  viewed INTEGER DEFAULT 0,
+  firstname VARCHAR(100) DEFAULT NULL,
+  lastname VARCHAR(100) DEFAULT NULL,
+  other_firstname VARCHAR(100) DEFAULT NULL,
+  other_lastname VARCHAR(100) DEFAULT NULL,
+  message TEXT NOT NULL,
+  email VARCHAR(255) DEFAULT NULL,
+  other_email VARCHAR(255) DEFAULT NULL,
+  uniqueid VARCHAR(255) DEFAULT NULL UNIQUE
+);
@@ -0,0 +1,6 @@
+-- Migration to add view_count column to messages table
+-- This migration adds view counting functionality for automatic deletion after 5 views
+
+ALTER TABLE messages ADD COLUMN view_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_messages_view_count ON messages(view_count);
@@ -0,0 +1,9 @@
+-- Add expires_at column to messages table
+-- SQLite cannot add a column with an expression default, so the column is
+-- nullable here and the adapter always writes it (7 days after creation by default)
+
+ALTER TABLE messages ADD COLUMN expires_at TIMESTAMP;
+
+UPDATE messages SET expires_at = datetime(created, '+7 days');
+
+CREATE INDEX idx_messages_expires_at ON messages(expires_at);
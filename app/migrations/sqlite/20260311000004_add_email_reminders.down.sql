@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_messages_reminder_lookup;
+DROP TABLE IF EXISTS email_reminders;
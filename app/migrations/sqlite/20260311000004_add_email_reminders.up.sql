@@ -0,0 +1,18 @@
+-- Migration: Add email_reminders table for daily reminder email tracking
+-- This table tracks reminder attempts for unviewed messages.
+-- message_id is unique so LogReminderSent can upsert with ON CONFLICT.
+
+CREATE TABLE email_reminders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id INTEGER NOT NULL UNIQUE REFERENCES messages(messageid) ON DELETE CASCADE,
+    email_address VARCHAR(255) NOT NULL,
+    reminder_count INTEGER DEFAULT 0,
+    last_reminder_sent TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_email_address ON email_reminders(email_address);
+CREATE INDEX idx_last_reminder_sent ON email_reminders(last_reminder_sent);
+
+-- Add index for efficient queries of unviewed messages for reminders
+CREATE INDEX idx_messages_reminder_lookup ON messages(view_count, created, other_email);
@@ -0,0 +1,5 @@
+-- Add client_encrypted column to messages table
+-- Marks messages encrypted in the browser; the server stores the ciphertext
+-- as-is and never holds the key, so it must not attempt to decrypt them
+
+ALTER TABLE messages ADD COLUMN client_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,19 @@
+-- Migration: Add tables for encrypted message attachments
+-- File name and content type are stored encrypted; file data is stored as
+-- independently sealed AES-GCM chunks in message_attachment_chunks.
+
+CREATE TABLE message_attachments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id INTEGER NOT NULL UNIQUE REFERENCES messages(messageid) ON DELETE CASCADE,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE message_attachment_chunks (
+    attachment_id INTEGER NOT NULL REFERENCES message_attachments(id) ON DELETE CASCADE,
+    chunk_index INTEGER NOT NULL,
+    data BLOB NOT NULL,
+    PRIMARY KEY (attachment_id, chunk_index)
+);
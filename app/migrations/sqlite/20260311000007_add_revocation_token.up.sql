@@ -0,0 +1,5 @@
+-- Add revocation_token_hash column to messages table
+-- Stores the SHA-256 of the token returned to the sender at submission, which lets
+-- them delete the message early. The token itself is never stored.
+
+ALTER TABLE messages ADD COLUMN revocation_token_hash TEXT NULL DEFAULT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE messages DROP COLUMN webhook_secret;
+ALTER TABLE messages DROP COLUMN webhook_url;
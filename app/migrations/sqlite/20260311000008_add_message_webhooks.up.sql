@@ -0,0 +1,7 @@
+-- Add webhook columns to messages table
+-- A sender may ask for lifecycle events (created, viewed, exhausted, expired, revoked)
+-- to be POSTed to their own endpoint, signed with an HMAC secret they supply.
+-- The secret is stored as given because every delivery must be signed with it.
+
+ALTER TABLE messages ADD COLUMN webhook_url TEXT NULL DEFAULT NULL;
+ALTER TABLE messages ADD COLUMN webhook_secret TEXT NULL DEFAULT NULL;
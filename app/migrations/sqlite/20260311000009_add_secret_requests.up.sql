@@ -0,0 +1,17 @@
+-- Migration: Add secret_requests table for the "request a secret" flow
+-- A requester creates a request link; whoever holds the secret fulfills it once,
+-- which stores an ordinary message addressed to the requester.
+
+CREATE TABLE secret_requests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uniqueid VARCHAR(255) NOT NULL UNIQUE,
+    requester_name VARCHAR(100) NOT NULL,
+    requester_email VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP,
+    fulfilled_at TIMESTAMP,
+    message_uniqueid VARCHAR(255) DEFAULT NULL
+);
+
+CREATE INDEX idx_secret_requests_expires_at ON secret_requests(expires_at);
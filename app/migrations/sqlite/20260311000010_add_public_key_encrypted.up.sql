@@ -0,0 +1,5 @@
+-- Add public_key_encrypted column to messages table
+-- Marks messages sealed to the recipient's age or OpenPGP public key; the content
+-- is an armored ciphertext only the recipient's private key can open
+
+ALTER TABLE messages ADD COLUMN public_key_encrypted BOOLEAN NOT NULL DEFAULT FALSE;
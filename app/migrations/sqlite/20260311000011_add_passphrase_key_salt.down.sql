@@ -0,0 +1 @@
+ALTER TABLE messages DROP COLUMN passphrase_key_salt;
@@ -0,0 +1,6 @@
+-- Add passphrase_key_salt column to messages table
+-- Holds the Argon2id salt used to mix the passphrase into the content key, so the
+-- content cannot be decrypted without the passphrase. NULL for messages where the
+-- passphrase is only checked against its hash
+
+ALTER TABLE messages ADD COLUMN passphrase_key_salt TEXT NULL DEFAULT NULL;
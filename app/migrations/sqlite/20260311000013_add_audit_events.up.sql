@@ -0,0 +1,26 @@
+-- Migration: Add audit_events table, a tamper-evident log of what happened to each message
+-- Every entry stores the hash of the entry before it, so altering or removing one breaks
+-- the chain; `database verify-audit` checks it. Triggers reject updates and deletes.
+
+CREATE TABLE audit_events (
+    sequence INTEGER NOT NULL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    message_uniqueid VARCHAR(255) NOT NULL,
+    client_ip_hash VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent_hash VARCHAR(64) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    hash CHAR(64) NOT NULL
+);
+
+CREATE INDEX idx_audit_events_message_uniqueid ON audit_events(message_uniqueid);
+
+CREATE TRIGGER audit_events_no_update BEFORE UPDATE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
+
+CREATE TRIGGER audit_events_no_delete BEFORE DELETE ON audit_events
+BEGIN
+    SELECT RAISE(ABORT, 'audit_events is append-only');
+END;
@@ -0,0 +1,17 @@
+-- Migration: Add notification_outbox table, notifications written in the same transaction as
+-- their message so they survive a queue outage. The relay publishes due entries, retrying
+-- with backoff, and clears the payload once delivered. Entries are removed with their message.
+
+CREATE TABLE notification_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    message_id INTEGER NOT NULL REFERENCES messages(messageid) ON DELETE CASCADE,
+    payload BLOB,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+CREATE INDEX idx_notification_outbox_pending ON notification_outbox(delivered_at, next_attempt_at);
+CREATE INDEX idx_notification_outbox_message_id ON notification_outbox(message_id);
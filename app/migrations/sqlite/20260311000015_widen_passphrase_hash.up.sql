@@ -0,0 +1,5 @@
+-- Widen the passphrase hash column of the messages table
+-- SQLite does not enforce VARCHAR lengths, so Argon2id PHC strings longer than 100 characters
+-- already fit. This migration keeps the version numbers in step with MySQL and PostgreSQL.
+
+SELECT 1;
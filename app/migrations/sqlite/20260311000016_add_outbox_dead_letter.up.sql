@@ -0,0 +1,5 @@
+-- Add dead_lettered_at column to notification_outbox table
+-- Set when an entry can never be published, e.g. its payload was sealed under a retired
+-- master key; the relay stops retrying it and keeps it, with last_error, for inspection
+
+ALTER TABLE notification_outbox ADD COLUMN dead_lettered_at TIMESTAMP;
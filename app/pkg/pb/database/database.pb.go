@@ -66,21 +66,99 @@ func (x *SelectRequest) GetUuid() string {
 	return ""
 }
 
-type SelectResponse struct {
+type Attachment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Passphrase    string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
-	ViewCount     int32                  `protobuf:"varint,4,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
-	MaxViewCount  int32                  `protobuf:"varint,5,opt,name=max_view_count,json=maxViewCount,proto3" json:"max_view_count,omitempty"`
-	ExpiresAt     string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339 timestamp
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`                          // encrypted original file name
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // encrypted MIME type
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`      // plaintext size
+	Chunks        [][]byte               `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks,omitempty"`                              // AES-GCM sealed chunks; empty in SelectResponse
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_database_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Attachment) GetChunks() [][]byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+type SelectResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Uuid                     string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Content                  string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Passphrase               string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	ViewCount                int32                  `protobuf:"varint,4,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	MaxViewCount             int32                  `protobuf:"varint,5,opt,name=max_view_count,json=maxViewCount,proto3" json:"max_view_count,omitempty"`
+	ExpiresAt                string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                                  // RFC3339 timestamp
+	ClientEncrypted          bool                   `protobuf:"varint,7,opt,name=client_encrypted,json=clientEncrypted,proto3" json:"client_encrypted,omitempty"`                               // content is browser-encrypted ciphertext the server cannot decrypt
+	Attachment               *Attachment            `protobuf:"bytes,8,opt,name=attachment,proto3" json:"attachment,omitempty"`                                                                 // metadata only; chunks are fetched with GetAttachment
+	RevocationTokenHash      string                 `protobuf:"bytes,9,opt,name=revocation_token_hash,json=revocationTokenHash,proto3" json:"revocation_token_hash,omitempty"`                  // SHA-256 of the sender's revocation token; empty for legacy messages
+	SenderEmail              string                 `protobuf:"bytes,10,opt,name=sender_email,json=senderEmail,proto3" json:"sender_email,omitempty"`                                           // receives read receipts; empty unless the sender opted in
+	Id                       int64                  `protobuf:"varint,11,opt,name=id,proto3" json:"id,omitempty"`                                                                               // internal row ID, used to look up reminder history
+	WebhookUrl               string                 `protobuf:"bytes,12,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`                                              // per-message endpoint for lifecycle events; empty when not set
+	WebhookSecret            string                 `protobuf:"bytes,13,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`                                     // HMAC key for webhook_url
+	PublicKeyEncrypted       bool                   `protobuf:"varint,14,opt,name=public_key_encrypted,json=publicKeyEncrypted,proto3" json:"public_key_encrypted,omitempty"`                   // content is an armored age or OpenPGP message sealed to the recipient's key
+	PassphraseKeySalt        string                 `protobuf:"bytes,15,opt,name=passphrase_key_salt,json=passphraseKeySalt,proto3" json:"passphrase_key_salt,omitempty"`                       // Argon2id salt mixing the passphrase into the content key; empty when the passphrase is only a gate
+	FailedPassphraseAttempts int32                  `protobuf:"varint,16,opt,name=failed_passphrase_attempts,json=failedPassphraseAttempts,proto3" json:"failed_passphrase_attempts,omitempty"` // wrong passphrases submitted so far
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
 func (x *SelectResponse) Reset() {
 	*x = SelectResponse{}
-	mi := &file_database_proto_msgTypes[1]
+	mi := &file_database_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -92,7 +170,7 @@ func (x *SelectResponse) String() string {
 func (*SelectResponse) ProtoMessage() {}
 
 func (x *SelectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[1]
+	mi := &file_database_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -105,7 +183,7 @@ func (x *SelectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SelectResponse.ProtoReflect.Descriptor instead.
 func (*SelectResponse) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{1}
+	return file_database_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SelectResponse) GetUuid() string {
@@ -150,21 +228,100 @@ func (x *SelectResponse) GetExpiresAt() string {
 	return ""
 }
 
+func (x *SelectResponse) GetClientEncrypted() bool {
+	if x != nil {
+		return x.ClientEncrypted
+	}
+	return false
+}
+
+func (x *SelectResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *SelectResponse) GetRevocationTokenHash() string {
+	if x != nil {
+		return x.RevocationTokenHash
+	}
+	return ""
+}
+
+func (x *SelectResponse) GetSenderEmail() string {
+	if x != nil {
+		return x.SenderEmail
+	}
+	return ""
+}
+
+func (x *SelectResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SelectResponse) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *SelectResponse) GetWebhookSecret() string {
+	if x != nil {
+		return x.WebhookSecret
+	}
+	return ""
+}
+
+func (x *SelectResponse) GetPublicKeyEncrypted() bool {
+	if x != nil {
+		return x.PublicKeyEncrypted
+	}
+	return false
+}
+
+func (x *SelectResponse) GetPassphraseKeySalt() string {
+	if x != nil {
+		return x.PassphraseKeySalt
+	}
+	return ""
+}
+
+func (x *SelectResponse) GetFailedPassphraseAttempts() int32 {
+	if x != nil {
+		return x.FailedPassphraseAttempts
+	}
+	return 0
+}
+
 type InsertRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Content        string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Passphrase     string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
-	MaxViewCount   int32                  `protobuf:"varint,4,opt,name=max_view_count,json=maxViewCount,proto3" json:"max_view_count,omitempty"`
-	RecipientEmail string                 `protobuf:"bytes,5,opt,name=recipient_email,json=recipientEmail,proto3" json:"recipient_email,omitempty"`
-	ExpiresAt      string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // RFC3339 timestamp; empty means use server default TTL
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Uuid                string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Content             string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Passphrase          string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	MaxViewCount        int32                  `protobuf:"varint,4,opt,name=max_view_count,json=maxViewCount,proto3" json:"max_view_count,omitempty"`
+	RecipientEmail      string                 `protobuf:"bytes,5,opt,name=recipient_email,json=recipientEmail,proto3" json:"recipient_email,omitempty"`
+	ExpiresAt           string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                 // RFC3339 timestamp; empty means use server default TTL
+	ClientEncrypted     bool                   `protobuf:"varint,7,opt,name=client_encrypted,json=clientEncrypted,proto3" json:"client_encrypted,omitempty"`              // content is browser-encrypted ciphertext the server cannot decrypt
+	Attachment          *Attachment            `protobuf:"bytes,8,opt,name=attachment,proto3" json:"attachment,omitempty"`                                                // optional encrypted file stored with the message
+	RevocationTokenHash string                 `protobuf:"bytes,9,opt,name=revocation_token_hash,json=revocationTokenHash,proto3" json:"revocation_token_hash,omitempty"` // SHA-256 of the sender's revocation token
+	SenderEmail         string                 `protobuf:"bytes,10,opt,name=sender_email,json=senderEmail,proto3" json:"sender_email,omitempty"`                          // optional; set when the sender wants read receipts
+	WebhookUrl          string                 `protobuf:"bytes,11,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`                             // optional per-message endpoint for lifecycle events
+	WebhookSecret       string                 `protobuf:"bytes,12,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`                    // HMAC key for webhook_url
+	PublicKeyEncrypted  bool                   `protobuf:"varint,13,opt,name=public_key_encrypted,json=publicKeyEncrypted,proto3" json:"public_key_encrypted,omitempty"`  // content is an armored age or OpenPGP message sealed to the recipient's key
+	PassphraseKeySalt   string                 `protobuf:"bytes,14,opt,name=passphrase_key_salt,json=passphraseKeySalt,proto3" json:"passphrase_key_salt,omitempty"`      // Argon2id salt mixing the passphrase into the content key; empty when the passphrase is only a gate
+	Notification        []byte                 `protobuf:"bytes,15,opt,name=notification,proto3" json:"notification,omitempty"`                                           // optional serialized messagepb.Message, written to the outbox with the message and published by the relay
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *InsertRequest) Reset() {
 	*x = InsertRequest{}
-	mi := &file_database_proto_msgTypes[2]
+	mi := &file_database_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -176,7 +333,7 @@ func (x *InsertRequest) String() string {
 func (*InsertRequest) ProtoMessage() {}
 
 func (x *InsertRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[2]
+	mi := &file_database_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -189,7 +346,7 @@ func (x *InsertRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InsertRequest.ProtoReflect.Descriptor instead.
 func (*InsertRequest) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{2}
+	return file_database_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *InsertRequest) GetUuid() string {
@@ -234,6 +391,69 @@ func (x *InsertRequest) GetExpiresAt() string {
 	return ""
 }
 
+func (x *InsertRequest) GetClientEncrypted() bool {
+	if x != nil {
+		return x.ClientEncrypted
+	}
+	return false
+}
+
+func (x *InsertRequest) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *InsertRequest) GetRevocationTokenHash() string {
+	if x != nil {
+		return x.RevocationTokenHash
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetSenderEmail() string {
+	if x != nil {
+		return x.SenderEmail
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetWebhookSecret() string {
+	if x != nil {
+		return x.WebhookSecret
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetPublicKeyEncrypted() bool {
+	if x != nil {
+		return x.PublicKeyEncrypted
+	}
+	return false
+}
+
+func (x *InsertRequest) GetPassphraseKeySalt() string {
+	if x != nil {
+		return x.PassphraseKeySalt
+	}
+	return ""
+}
+
+func (x *InsertRequest) GetNotification() []byte {
+	if x != nil {
+		return x.Notification
+	}
+	return nil
+}
+
 type GetUnviewedMessagesRequest struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	OlderThanHours        int32                  `protobuf:"varint,1,opt,name=older_than_hours,json=olderThanHours,proto3" json:"older_than_hours,omitempty"`
@@ -245,7 +465,7 @@ type GetUnviewedMessagesRequest struct {
 
 func (x *GetUnviewedMessagesRequest) Reset() {
 	*x = GetUnviewedMessagesRequest{}
-	mi := &file_database_proto_msgTypes[3]
+	mi := &file_database_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -257,7 +477,7 @@ func (x *GetUnviewedMessagesRequest) String() string {
 func (*GetUnviewedMessagesRequest) ProtoMessage() {}
 
 func (x *GetUnviewedMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[3]
+	mi := &file_database_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -270,7 +490,7 @@ func (x *GetUnviewedMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUnviewedMessagesRequest.ProtoReflect.Descriptor instead.
 func (*GetUnviewedMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{3}
+	return file_database_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetUnviewedMessagesRequest) GetOlderThanHours() int32 {
@@ -307,7 +527,7 @@ type UnviewedMessage struct {
 
 func (x *UnviewedMessage) Reset() {
 	*x = UnviewedMessage{}
-	mi := &file_database_proto_msgTypes[4]
+	mi := &file_database_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -319,7 +539,7 @@ func (x *UnviewedMessage) String() string {
 func (*UnviewedMessage) ProtoMessage() {}
 
 func (x *UnviewedMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[4]
+	mi := &file_database_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -332,7 +552,7 @@ func (x *UnviewedMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnviewedMessage.ProtoReflect.Descriptor instead.
 func (*UnviewedMessage) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{4}
+	return file_database_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *UnviewedMessage) GetMessageId() int32 {
@@ -379,7 +599,7 @@ type GetUnviewedMessagesResponse struct {
 
 func (x *GetUnviewedMessagesResponse) Reset() {
 	*x = GetUnviewedMessagesResponse{}
-	mi := &file_database_proto_msgTypes[5]
+	mi := &file_database_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -391,7 +611,7 @@ func (x *GetUnviewedMessagesResponse) String() string {
 func (*GetUnviewedMessagesResponse) ProtoMessage() {}
 
 func (x *GetUnviewedMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[5]
+	mi := &file_database_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +624,7 @@ func (x *GetUnviewedMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUnviewedMessagesResponse.ProtoReflect.Descriptor instead.
 func (*GetUnviewedMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{5}
+	return file_database_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetUnviewedMessagesResponse) GetMessages() []*UnviewedMessage {
@@ -424,7 +644,7 @@ type LogReminderRequest struct {
 
 func (x *LogReminderRequest) Reset() {
 	*x = LogReminderRequest{}
-	mi := &file_database_proto_msgTypes[6]
+	mi := &file_database_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +656,7 @@ func (x *LogReminderRequest) String() string {
 func (*LogReminderRequest) ProtoMessage() {}
 
 func (x *LogReminderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[6]
+	mi := &file_database_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +669,7 @@ func (x *LogReminderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogReminderRequest.ProtoReflect.Descriptor instead.
 func (*LogReminderRequest) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{6}
+	return file_database_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *LogReminderRequest) GetMessageId() int32 {
@@ -475,7 +695,7 @@ type GetReminderHistoryRequest struct {
 
 func (x *GetReminderHistoryRequest) Reset() {
 	*x = GetReminderHistoryRequest{}
-	mi := &file_database_proto_msgTypes[7]
+	mi := &file_database_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -487,7 +707,7 @@ func (x *GetReminderHistoryRequest) String() string {
 func (*GetReminderHistoryRequest) ProtoMessage() {}
 
 func (x *GetReminderHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[7]
+	mi := &file_database_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +720,7 @@ func (x *GetReminderHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReminderHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetReminderHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{7}
+	return file_database_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetReminderHistoryRequest) GetMessageId() int32 {
@@ -522,7 +742,7 @@ type ReminderLogEntry struct {
 
 func (x *ReminderLogEntry) Reset() {
 	*x = ReminderLogEntry{}
-	mi := &file_database_proto_msgTypes[8]
+	mi := &file_database_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -534,7 +754,7 @@ func (x *ReminderLogEntry) String() string {
 func (*ReminderLogEntry) ProtoMessage() {}
 
 func (x *ReminderLogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[8]
+	mi := &file_database_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +767,7 @@ func (x *ReminderLogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReminderLogEntry.ProtoReflect.Descriptor instead.
 func (*ReminderLogEntry) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{8}
+	return file_database_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ReminderLogEntry) GetMessageId() int32 {
@@ -587,7 +807,7 @@ type GetReminderHistoryResponse struct {
 
 func (x *GetReminderHistoryResponse) Reset() {
 	*x = GetReminderHistoryResponse{}
-	mi := &file_database_proto_msgTypes[9]
+	mi := &file_database_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -599,7 +819,7 @@ func (x *GetReminderHistoryResponse) String() string {
 func (*GetReminderHistoryResponse) ProtoMessage() {}
 
 func (x *GetReminderHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_database_proto_msgTypes[9]
+	mi := &file_database_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +832,7 @@ func (x *GetReminderHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReminderHistoryResponse.ProtoReflect.Descriptor instead.
 func (*GetReminderHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_database_proto_rawDescGZIP(), []int{9}
+	return file_database_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetReminderHistoryResponse) GetEntries() []*ReminderLogEntry {
@@ -622,6 +842,350 @@ func (x *GetReminderHistoryResponse) GetEntries() []*ReminderLogEntry {
 	return nil
 }
 
+type SecretRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Uuid           string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	RequesterName  string                 `protobuf:"bytes,2,opt,name=requester_name,json=requesterName,proto3" json:"requester_name,omitempty"`
+	RequesterEmail string                 `protobuf:"bytes,3,opt,name=requester_email,json=requesterEmail,proto3" json:"requester_email,omitempty"` // receives the link once the request is fulfilled
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`                             // what the requester is asking for
+	CreatedAt      string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                // RFC3339 timestamp
+	ExpiresAt      string                 `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                // RFC3339 timestamp; empty on insert means use server default TTL
+	FulfilledAt    string                 `protobuf:"bytes,7,opt,name=fulfilled_at,json=fulfilledAt,proto3" json:"fulfilled_at,omitempty"`          // RFC3339 timestamp; empty while the request is open
+	MessageUuid    string                 `protobuf:"bytes,8,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`          // message stored when the request was fulfilled
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SecretRequest) Reset() {
+	*x = SecretRequest{}
+	mi := &file_database_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRequest) ProtoMessage() {}
+
+func (x *SecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRequest.ProtoReflect.Descriptor instead.
+func (*SecretRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SecretRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetRequesterName() string {
+	if x != nil {
+		return x.RequesterName
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetRequesterEmail() string {
+	if x != nil {
+		return x.RequesterEmail
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetFulfilledAt() string {
+	if x != nil {
+		return x.FulfilledAt
+	}
+	return ""
+}
+
+func (x *SecretRequest) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+type FulfillSecretRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	MessageUuid   string                 `protobuf:"bytes,2,opt,name=message_uuid,json=messageUuid,proto3" json:"message_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FulfillSecretRequestRequest) Reset() {
+	*x = FulfillSecretRequestRequest{}
+	mi := &file_database_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FulfillSecretRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FulfillSecretRequestRequest) ProtoMessage() {}
+
+func (x *FulfillSecretRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FulfillSecretRequestRequest.ProtoReflect.Descriptor instead.
+func (*FulfillSecretRequestRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FulfillSecretRequestRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *FulfillSecretRequestRequest) GetMessageUuid() string {
+	if x != nil {
+		return x.MessageUuid
+	}
+	return ""
+}
+
+type FailedPassphraseAttemptResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FailedAttempts int32                  `protobuf:"varint,1,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"` // attempts reserved so far, including this one
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FailedPassphraseAttemptResponse) Reset() {
+	*x = FailedPassphraseAttemptResponse{}
+	mi := &file_database_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FailedPassphraseAttemptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedPassphraseAttemptResponse) ProtoMessage() {}
+
+func (x *FailedPassphraseAttemptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedPassphraseAttemptResponse.ProtoReflect.Descriptor instead.
+func (*FailedPassphraseAttemptResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FailedPassphraseAttemptResponse) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+type UpdatePassphraseHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"` // replacement hash, e.g. after upgrading the hashing algorithm
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePassphraseHashRequest) Reset() {
+	*x = UpdatePassphraseHashRequest{}
+	mi := &file_database_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePassphraseHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePassphraseHashRequest) ProtoMessage() {}
+
+func (x *UpdatePassphraseHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePassphraseHashRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePassphraseHashRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdatePassphraseHashRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdatePassphraseHashRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+type CleanupExpiredMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchSize     int32                  `protobuf:"varint,1,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // expired messages deleted per query; 0 uses the server default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CleanupExpiredMessagesRequest) Reset() {
+	*x = CleanupExpiredMessagesRequest{}
+	mi := &file_database_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupExpiredMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupExpiredMessagesRequest) ProtoMessage() {}
+
+func (x *CleanupExpiredMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupExpiredMessagesRequest.ProtoReflect.Descriptor instead.
+func (*CleanupExpiredMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CleanupExpiredMessagesRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+type CleanupExpiredMessagesResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	MessagesPurged       int32                  `protobuf:"varint,1,opt,name=messages_purged,json=messagesPurged,proto3" json:"messages_purged,omitempty"`
+	SecretRequestsPurged int32                  `protobuf:"varint,2,opt,name=secret_requests_purged,json=secretRequestsPurged,proto3" json:"secret_requests_purged,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CleanupExpiredMessagesResponse) Reset() {
+	*x = CleanupExpiredMessagesResponse{}
+	mi := &file_database_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CleanupExpiredMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CleanupExpiredMessagesResponse) ProtoMessage() {}
+
+func (x *CleanupExpiredMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_database_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CleanupExpiredMessagesResponse.ProtoReflect.Descriptor instead.
+func (*CleanupExpiredMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_database_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CleanupExpiredMessagesResponse) GetMessagesPurged() int32 {
+	if x != nil {
+		return x.MessagesPurged
+	}
+	return 0
+}
+
+func (x *CleanupExpiredMessagesResponse) GetSecretRequestsPurged() int32 {
+	if x != nil {
+		return x.SecretRequestsPurged
+	}
+	return 0
+}
+
 var File_database_proto protoreflect.FileDescriptor
 
 const file_database_proto_rawDesc = "" +
@@ -629,7 +1193,14 @@ const file_database_proto_rawDesc = "" +
 	"\x0edatabase.proto\x12\n" +
 	"databasepb\x1a\x1bgoogle/protobuf/empty.proto\"#\n" +
 	"\rSelectRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\xc2\x01\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x82\x01\n" +
+	"\n" +
+	"Attachment\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06chunks\x18\x04 \x03(\fR\x06chunks\"\xf4\x04\n" +
 	"\x0eSelectResponse\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1e\n" +
@@ -640,7 +1211,21 @@ const file_database_proto_rawDesc = "" +
 	"view_count\x18\x04 \x01(\x05R\tviewCount\x12$\n" +
 	"\x0emax_view_count\x18\x05 \x01(\x05R\fmaxViewCount\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x06 \x01(\tR\texpiresAt\"\xcb\x01\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12)\n" +
+	"\x10client_encrypted\x18\a \x01(\bR\x0fclientEncrypted\x126\n" +
+	"\n" +
+	"attachment\x18\b \x01(\v2\x16.databasepb.AttachmentR\n" +
+	"attachment\x122\n" +
+	"\x15revocation_token_hash\x18\t \x01(\tR\x13revocationTokenHash\x12!\n" +
+	"\fsender_email\x18\n" +
+	" \x01(\tR\vsenderEmail\x12\x0e\n" +
+	"\x02id\x18\v \x01(\x03R\x02id\x12\x1f\n" +
+	"\vwebhook_url\x18\f \x01(\tR\n" +
+	"webhookUrl\x12%\n" +
+	"\x0ewebhook_secret\x18\r \x01(\tR\rwebhookSecret\x120\n" +
+	"\x14public_key_encrypted\x18\x0e \x01(\bR\x12publicKeyEncrypted\x12.\n" +
+	"\x13passphrase_key_salt\x18\x0f \x01(\tR\x11passphraseKeySalt\x12<\n" +
+	"\x1afailed_passphrase_attempts\x18\x10 \x01(\x05R\x18failedPassphraseAttempts\"\xd3\x04\n" +
 	"\rInsertRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1e\n" +
@@ -650,7 +1235,20 @@ const file_database_proto_rawDesc = "" +
 	"\x0emax_view_count\x18\x04 \x01(\x05R\fmaxViewCount\x12'\n" +
 	"\x0frecipient_email\x18\x05 \x01(\tR\x0erecipientEmail\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\x06 \x01(\tR\texpiresAt\"\xa3\x01\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12)\n" +
+	"\x10client_encrypted\x18\a \x01(\bR\x0fclientEncrypted\x126\n" +
+	"\n" +
+	"attachment\x18\b \x01(\v2\x16.databasepb.AttachmentR\n" +
+	"attachment\x122\n" +
+	"\x15revocation_token_hash\x18\t \x01(\tR\x13revocationTokenHash\x12!\n" +
+	"\fsender_email\x18\n" +
+	" \x01(\tR\vsenderEmail\x12\x1f\n" +
+	"\vwebhook_url\x18\v \x01(\tR\n" +
+	"webhookUrl\x12%\n" +
+	"\x0ewebhook_secret\x18\f \x01(\tR\rwebhookSecret\x120\n" +
+	"\x14public_key_encrypted\x18\r \x01(\bR\x12publicKeyEncrypted\x12.\n" +
+	"\x13passphrase_key_salt\x18\x0e \x01(\tR\x11passphraseKeySalt\x12\"\n" +
+	"\fnotification\x18\x0f \x01(\fR\fnotification\"\xa3\x01\n" +
 	"\x1aGetUnviewedMessagesRequest\x12(\n" +
 	"\x10older_than_hours\x18\x01 \x01(\x05R\x0eolderThanHours\x12#\n" +
 	"\rmax_reminders\x18\x02 \x01(\x05R\fmaxReminders\x126\n" +
@@ -678,7 +1276,35 @@ const file_database_proto_rawDesc = "" +
 	"\x0ereminder_count\x18\x03 \x01(\x05R\rreminderCount\x12,\n" +
 	"\x12last_reminder_sent\x18\x04 \x01(\tR\x10lastReminderSent\"T\n" +
 	"\x1aGetReminderHistoryResponse\x126\n" +
-	"\aentries\x18\x01 \x03(\v2\x1c.databasepb.ReminderLogEntryR\aentries2\xfe\x03\n" +
+	"\aentries\x18\x01 \x03(\v2\x1c.databasepb.ReminderLogEntryR\aentries\"\x99\x02\n" +
+	"\rSecretRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12%\n" +
+	"\x0erequester_name\x18\x02 \x01(\tR\rrequesterName\x12'\n" +
+	"\x0frequester_email\x18\x03 \x01(\tR\x0erequesterEmail\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\tR\texpiresAt\x12!\n" +
+	"\ffulfilled_at\x18\a \x01(\tR\vfulfilledAt\x12!\n" +
+	"\fmessage_uuid\x18\b \x01(\tR\vmessageUuid\"T\n" +
+	"\x1bFulfillSecretRequestRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12!\n" +
+	"\fmessage_uuid\x18\x02 \x01(\tR\vmessageUuid\"J\n" +
+	"\x1fFailedPassphraseAttemptResponse\x12'\n" +
+	"\x0ffailed_attempts\x18\x01 \x01(\x05R\x0efailedAttempts\"Q\n" +
+	"\x1bUpdatePassphraseHashRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x02 \x01(\tR\n" +
+	"passphrase\">\n" +
+	"\x1dCleanupExpiredMessagesRequest\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x01 \x01(\x05R\tbatchSize\"\x7f\n" +
+	"\x1eCleanupExpiredMessagesResponse\x12'\n" +
+	"\x0fmessages_purged\x18\x01 \x01(\x05R\x0emessagesPurged\x124\n" +
+	"\x16secret_requests_purged\x18\x02 \x01(\x05R\x14secretRequestsPurged2\xd2\n" +
+	"\n" +
 	"\tdbService\x12A\n" +
 	"\x06Select\x12\x19.databasepb.SelectRequest\x1a\x1a.databasepb.SelectResponse\"\x00\x12=\n" +
 	"\x06Insert\x12\x19.databasepb.InsertRequest\x1a\x16.google.protobuf.Empty\"\x00\x12E\n" +
@@ -686,7 +1312,17 @@ const file_database_proto_rawDesc = "" +
 	"GetMessage\x12\x19.databasepb.SelectRequest\x1a\x1a.databasepb.SelectResponse\"\x00\x12t\n" +
 	"\x1fGetUnviewedMessagesForReminders\x12&.databasepb.GetUnviewedMessagesRequest\x1a'.databasepb.GetUnviewedMessagesResponse\"\x00\x12K\n" +
 	"\x0fLogReminderSent\x12\x1e.databasepb.LogReminderRequest\x1a\x16.google.protobuf.Empty\"\x00\x12e\n" +
-	"\x12GetReminderHistory\x12%.databasepb.GetReminderHistoryRequest\x1a&.databasepb.GetReminderHistoryResponse\"\x00B;Z9github.com/Anthony-Bible/password-exchange/app/databasepbb\x06proto3"
+	"\x12GetReminderHistory\x12%.databasepb.GetReminderHistoryRequest\x1a&.databasepb.GetReminderHistoryResponse\"\x00\x12D\n" +
+	"\rGetAttachment\x12\x19.databasepb.SelectRequest\x1a\x16.databasepb.Attachment\"\x00\x12D\n" +
+	"\rDeleteMessage\x12\x19.databasepb.SelectRequest\x1a\x16.google.protobuf.Empty\"\x00\x12J\n" +
+	"\x13InsertSecretRequest\x12\x19.databasepb.SecretRequest\x1a\x16.google.protobuf.Empty\"\x00\x12J\n" +
+	"\x10GetSecretRequest\x12\x19.databasepb.SelectRequest\x1a\x19.databasepb.SecretRequest\"\x00\x12Y\n" +
+	"\x14FulfillSecretRequest\x12'.databasepb.FulfillSecretRequestRequest\x1a\x16.google.protobuf.Empty\"\x00\x12d\n" +
+	"\x18ReservePassphraseAttempt\x12\x19.databasepb.SelectRequest\x1a+.databasepb.FailedPassphraseAttemptResponse\"\x00\x12O\n" +
+	"\x18ReleasePassphraseAttempt\x12\x19.databasepb.SelectRequest\x1a\x16.google.protobuf.Empty\"\x00\x12N\n" +
+	"\x17RecordPassphraseFailure\x12\x19.databasepb.SelectRequest\x1a\x16.google.protobuf.Empty\"\x00\x12Y\n" +
+	"\x14UpdatePassphraseHash\x12'.databasepb.UpdatePassphraseHashRequest\x1a\x16.google.protobuf.Empty\"\x00\x12q\n" +
+	"\x16CleanupExpiredMessages\x12).databasepb.CleanupExpiredMessagesRequest\x1a*.databasepb.CleanupExpiredMessagesResponse\"\x00B;Z9github.com/Anthony-Bible/password-exchange/app/databasepbb\x06proto3"
 
 var (
 	file_database_proto_rawDescOnce sync.Once
@@ -700,40 +1336,69 @@ func file_database_proto_rawDescGZIP() []byte {
 	return file_database_proto_rawDescData
 }
 
-var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_database_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_database_proto_goTypes = []any{
-	(*SelectRequest)(nil),               // 0: databasepb.SelectRequest
-	(*SelectResponse)(nil),              // 1: databasepb.SelectResponse
-	(*InsertRequest)(nil),               // 2: databasepb.InsertRequest
-	(*GetUnviewedMessagesRequest)(nil),  // 3: databasepb.GetUnviewedMessagesRequest
-	(*UnviewedMessage)(nil),             // 4: databasepb.UnviewedMessage
-	(*GetUnviewedMessagesResponse)(nil), // 5: databasepb.GetUnviewedMessagesResponse
-	(*LogReminderRequest)(nil),          // 6: databasepb.LogReminderRequest
-	(*GetReminderHistoryRequest)(nil),   // 7: databasepb.GetReminderHistoryRequest
-	(*ReminderLogEntry)(nil),            // 8: databasepb.ReminderLogEntry
-	(*GetReminderHistoryResponse)(nil),  // 9: databasepb.GetReminderHistoryResponse
-	(*emptypb.Empty)(nil),               // 10: google.protobuf.Empty
+	(*SelectRequest)(nil),                   // 0: databasepb.SelectRequest
+	(*Attachment)(nil),                      // 1: databasepb.Attachment
+	(*SelectResponse)(nil),                  // 2: databasepb.SelectResponse
+	(*InsertRequest)(nil),                   // 3: databasepb.InsertRequest
+	(*GetUnviewedMessagesRequest)(nil),      // 4: databasepb.GetUnviewedMessagesRequest
+	(*UnviewedMessage)(nil),                 // 5: databasepb.UnviewedMessage
+	(*GetUnviewedMessagesResponse)(nil),     // 6: databasepb.GetUnviewedMessagesResponse
+	(*LogReminderRequest)(nil),              // 7: databasepb.LogReminderRequest
+	(*GetReminderHistoryRequest)(nil),       // 8: databasepb.GetReminderHistoryRequest
+	(*ReminderLogEntry)(nil),                // 9: databasepb.ReminderLogEntry
+	(*GetReminderHistoryResponse)(nil),      // 10: databasepb.GetReminderHistoryResponse
+	(*SecretRequest)(nil),                   // 11: databasepb.SecretRequest
+	(*FulfillSecretRequestRequest)(nil),     // 12: databasepb.FulfillSecretRequestRequest
+	(*FailedPassphraseAttemptResponse)(nil), // 13: databasepb.FailedPassphraseAttemptResponse
+	(*UpdatePassphraseHashRequest)(nil),     // 14: databasepb.UpdatePassphraseHashRequest
+	(*CleanupExpiredMessagesRequest)(nil),   // 15: databasepb.CleanupExpiredMessagesRequest
+	(*CleanupExpiredMessagesResponse)(nil),  // 16: databasepb.CleanupExpiredMessagesResponse
+	(*emptypb.Empty)(nil),                   // 17: google.protobuf.Empty
 }
 var file_database_proto_depIdxs = []int32{
-	4,  // 0: databasepb.GetUnviewedMessagesResponse.messages:type_name -> databasepb.UnviewedMessage
-	8,  // 1: databasepb.GetReminderHistoryResponse.entries:type_name -> databasepb.ReminderLogEntry
-	0,  // 2: databasepb.dbService.Select:input_type -> databasepb.SelectRequest
-	2,  // 3: databasepb.dbService.Insert:input_type -> databasepb.InsertRequest
-	0,  // 4: databasepb.dbService.GetMessage:input_type -> databasepb.SelectRequest
-	3,  // 5: databasepb.dbService.GetUnviewedMessagesForReminders:input_type -> databasepb.GetUnviewedMessagesRequest
-	6,  // 6: databasepb.dbService.LogReminderSent:input_type -> databasepb.LogReminderRequest
-	7,  // 7: databasepb.dbService.GetReminderHistory:input_type -> databasepb.GetReminderHistoryRequest
-	1,  // 8: databasepb.dbService.Select:output_type -> databasepb.SelectResponse
-	10, // 9: databasepb.dbService.Insert:output_type -> google.protobuf.Empty
-	1,  // 10: databasepb.dbService.GetMessage:output_type -> databasepb.SelectResponse
-	5,  // 11: databasepb.dbService.GetUnviewedMessagesForReminders:output_type -> databasepb.GetUnviewedMessagesResponse
-	10, // 12: databasepb.dbService.LogReminderSent:output_type -> google.protobuf.Empty
-	9,  // 13: databasepb.dbService.GetReminderHistory:output_type -> databasepb.GetReminderHistoryResponse
-	8,  // [8:14] is the sub-list for method output_type
-	2,  // [2:8] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	1,  // 0: databasepb.SelectResponse.attachment:type_name -> databasepb.Attachment
+	1,  // 1: databasepb.InsertRequest.attachment:type_name -> databasepb.Attachment
+	5,  // 2: databasepb.GetUnviewedMessagesResponse.messages:type_name -> databasepb.UnviewedMessage
+	9,  // 3: databasepb.GetReminderHistoryResponse.entries:type_name -> databasepb.ReminderLogEntry
+	0,  // 4: databasepb.dbService.Select:input_type -> databasepb.SelectRequest
+	3,  // 5: databasepb.dbService.Insert:input_type -> databasepb.InsertRequest
+	0,  // 6: databasepb.dbService.GetMessage:input_type -> databasepb.SelectRequest
+	4,  // 7: databasepb.dbService.GetUnviewedMessagesForReminders:input_type -> databasepb.GetUnviewedMessagesRequest
+	7,  // 8: databasepb.dbService.LogReminderSent:input_type -> databasepb.LogReminderRequest
+	8,  // 9: databasepb.dbService.GetReminderHistory:input_type -> databasepb.GetReminderHistoryRequest
+	0,  // 10: databasepb.dbService.GetAttachment:input_type -> databasepb.SelectRequest
+	0,  // 11: databasepb.dbService.DeleteMessage:input_type -> databasepb.SelectRequest
+	11, // 12: databasepb.dbService.InsertSecretRequest:input_type -> databasepb.SecretRequest
+	0,  // 13: databasepb.dbService.GetSecretRequest:input_type -> databasepb.SelectRequest
+	12, // 14: databasepb.dbService.FulfillSecretRequest:input_type -> databasepb.FulfillSecretRequestRequest
+	0,  // 15: databasepb.dbService.ReservePassphraseAttempt:input_type -> databasepb.SelectRequest
+	0,  // 16: databasepb.dbService.ReleasePassphraseAttempt:input_type -> databasepb.SelectRequest
+	0,  // 17: databasepb.dbService.RecordPassphraseFailure:input_type -> databasepb.SelectRequest
+	14, // 18: databasepb.dbService.UpdatePassphraseHash:input_type -> databasepb.UpdatePassphraseHashRequest
+	15, // 19: databasepb.dbService.CleanupExpiredMessages:input_type -> databasepb.CleanupExpiredMessagesRequest
+	2,  // 20: databasepb.dbService.Select:output_type -> databasepb.SelectResponse
+	17, // 21: databasepb.dbService.Insert:output_type -> google.protobuf.Empty
+	2,  // 22: databasepb.dbService.GetMessage:output_type -> databasepb.SelectResponse
+	6,  // 23: databasepb.dbService.GetUnviewedMessagesForReminders:output_type -> databasepb.GetUnviewedMessagesResponse
+	17, // 24: databasepb.dbService.LogReminderSent:output_type -> google.protobuf.Empty
+	10, // 25: databasepb.dbService.GetReminderHistory:output_type -> databasepb.GetReminderHistoryResponse
+	1,  // 26: databasepb.dbService.GetAttachment:output_type -> databasepb.Attachment
+	17, // 27: databasepb.dbService.DeleteMessage:output_type -> google.protobuf.Empty
+	17, // 28: databasepb.dbService.InsertSecretRequest:output_type -> google.protobuf.Empty
+	11, // 29: databasepb.dbService.GetSecretRequest:output_type -> databasepb.SecretRequest
+	17, // 30: databasepb.dbService.FulfillSecretRequest:output_type -> google.protobuf.Empty
+	13, // 31: databasepb.dbService.ReservePassphraseAttempt:output_type -> databasepb.FailedPassphraseAttemptResponse
+	17, // 32: databasepb.dbService.ReleasePassphraseAttempt:output_type -> google.protobuf.Empty
+	17, // 33: databasepb.dbService.RecordPassphraseFailure:output_type -> google.protobuf.Empty
+	17, // 34: databasepb.dbService.UpdatePassphraseHash:output_type -> google.protobuf.Empty
+	16, // 35: databasepb.dbService.CleanupExpiredMessages:output_type -> databasepb.CleanupExpiredMessagesResponse
+	20, // [20:36] is the sub-list for method output_type
+	4,  // [4:20] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_database_proto_init() }
@@ -747,7 +1412,7 @@ func file_database_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_database_proto_rawDesc), len(file_database_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,16 @@ const (
 	DbService_GetUnviewedMessagesForReminders_FullMethodName = "/databasepb.dbService/GetUnviewedMessagesForReminders"
 	DbService_LogReminderSent_FullMethodName                 = "/databasepb.dbService/LogReminderSent"
 	DbService_GetReminderHistory_FullMethodName              = "/databasepb.dbService/GetReminderHistory"
+	DbService_GetAttachment_FullMethodName                   = "/databasepb.dbService/GetAttachment"
+	DbService_DeleteMessage_FullMethodName                   = "/databasepb.dbService/DeleteMessage"
+	DbService_InsertSecretRequest_FullMethodName             = "/databasepb.dbService/InsertSecretRequest"
+	DbService_GetSecretRequest_FullMethodName                = "/databasepb.dbService/GetSecretRequest"
+	DbService_FulfillSecretRequest_FullMethodName            = "/databasepb.dbService/FulfillSecretRequest"
+	DbService_ReservePassphraseAttempt_FullMethodName        = "/databasepb.dbService/ReservePassphraseAttempt"
+	DbService_ReleasePassphraseAttempt_FullMethodName        = "/databasepb.dbService/ReleasePassphraseAttempt"
+	DbService_RecordPassphraseFailure_FullMethodName         = "/databasepb.dbService/RecordPassphraseFailure"
+	DbService_UpdatePassphraseHash_FullMethodName            = "/databasepb.dbService/UpdatePassphraseHash"
+	DbService_CleanupExpiredMessages_FullMethodName          = "/databasepb.dbService/CleanupExpiredMessages"
 )
 
 // DbServiceClient is the client API for DbService service.
@@ -38,6 +48,16 @@ type DbServiceClient interface {
 	GetUnviewedMessagesForReminders(ctx context.Context, in *GetUnviewedMessagesRequest, opts ...grpc.CallOption) (*GetUnviewedMessagesResponse, error)
 	LogReminderSent(ctx context.Context, in *LogReminderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetReminderHistory(ctx context.Context, in *GetReminderHistoryRequest, opts ...grpc.CallOption) (*GetReminderHistoryResponse, error)
+	GetAttachment(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*Attachment, error)
+	DeleteMessage(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	InsertSecretRequest(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetSecretRequest(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*SecretRequest, error)
+	FulfillSecretRequest(ctx context.Context, in *FulfillSecretRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReservePassphraseAttempt(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*FailedPassphraseAttemptResponse, error)
+	ReleasePassphraseAttempt(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RecordPassphraseFailure(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdatePassphraseHash(ctx context.Context, in *UpdatePassphraseHashRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CleanupExpiredMessages(ctx context.Context, in *CleanupExpiredMessagesRequest, opts ...grpc.CallOption) (*CleanupExpiredMessagesResponse, error)
 }
 
 type dbServiceClient struct {
@@ -108,6 +128,106 @@ func (c *dbServiceClient) GetReminderHistory(ctx context.Context, in *GetReminde
 	return out, nil
 }
 
+func (c *dbServiceClient) GetAttachment(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, DbService_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) DeleteMessage(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_DeleteMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) InsertSecretRequest(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_InsertSecretRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) GetSecretRequest(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*SecretRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretRequest)
+	err := c.cc.Invoke(ctx, DbService_GetSecretRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) FulfillSecretRequest(ctx context.Context, in *FulfillSecretRequestRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_FulfillSecretRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ReservePassphraseAttempt(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*FailedPassphraseAttemptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FailedPassphraseAttemptResponse)
+	err := c.cc.Invoke(ctx, DbService_ReservePassphraseAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) ReleasePassphraseAttempt(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_ReleasePassphraseAttempt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) RecordPassphraseFailure(ctx context.Context, in *SelectRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_RecordPassphraseFailure_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) UpdatePassphraseHash(ctx context.Context, in *UpdatePassphraseHashRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DbService_UpdatePassphraseHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dbServiceClient) CleanupExpiredMessages(ctx context.Context, in *CleanupExpiredMessagesRequest, opts ...grpc.CallOption) (*CleanupExpiredMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CleanupExpiredMessagesResponse)
+	err := c.cc.Invoke(ctx, DbService_CleanupExpiredMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DbServiceServer is the server API for DbService service.
 // All implementations must embed UnimplementedDbServiceServer
 // for forward compatibility.
@@ -118,6 +238,16 @@ type DbServiceServer interface {
 	GetUnviewedMessagesForReminders(context.Context, *GetUnviewedMessagesRequest) (*GetUnviewedMessagesResponse, error)
 	LogReminderSent(context.Context, *LogReminderRequest) (*emptypb.Empty, error)
 	GetReminderHistory(context.Context, *GetReminderHistoryRequest) (*GetReminderHistoryResponse, error)
+	GetAttachment(context.Context, *SelectRequest) (*Attachment, error)
+	DeleteMessage(context.Context, *SelectRequest) (*emptypb.Empty, error)
+	InsertSecretRequest(context.Context, *SecretRequest) (*emptypb.Empty, error)
+	GetSecretRequest(context.Context, *SelectRequest) (*SecretRequest, error)
+	FulfillSecretRequest(context.Context, *FulfillSecretRequestRequest) (*emptypb.Empty, error)
+	ReservePassphraseAttempt(context.Context, *SelectRequest) (*FailedPassphraseAttemptResponse, error)
+	ReleasePassphraseAttempt(context.Context, *SelectRequest) (*emptypb.Empty, error)
+	RecordPassphraseFailure(context.Context, *SelectRequest) (*emptypb.Empty, error)
+	UpdatePassphraseHash(context.Context, *UpdatePassphraseHashRequest) (*emptypb.Empty, error)
+	CleanupExpiredMessages(context.Context, *CleanupExpiredMessagesRequest) (*CleanupExpiredMessagesResponse, error)
 	mustEmbedUnimplementedDbServiceServer()
 }
 
@@ -146,6 +276,36 @@ func (UnimplementedDbServiceServer) LogReminderSent(context.Context, *LogReminde
 func (UnimplementedDbServiceServer) GetReminderHistory(context.Context, *GetReminderHistoryRequest) (*GetReminderHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetReminderHistory not implemented")
 }
+func (UnimplementedDbServiceServer) GetAttachment(context.Context, *SelectRequest) (*Attachment, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedDbServiceServer) DeleteMessage(context.Context, *SelectRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteMessage not implemented")
+}
+func (UnimplementedDbServiceServer) InsertSecretRequest(context.Context, *SecretRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method InsertSecretRequest not implemented")
+}
+func (UnimplementedDbServiceServer) GetSecretRequest(context.Context, *SelectRequest) (*SecretRequest, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSecretRequest not implemented")
+}
+func (UnimplementedDbServiceServer) FulfillSecretRequest(context.Context, *FulfillSecretRequestRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method FulfillSecretRequest not implemented")
+}
+func (UnimplementedDbServiceServer) ReservePassphraseAttempt(context.Context, *SelectRequest) (*FailedPassphraseAttemptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReservePassphraseAttempt not implemented")
+}
+func (UnimplementedDbServiceServer) ReleasePassphraseAttempt(context.Context, *SelectRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleasePassphraseAttempt not implemented")
+}
+func (UnimplementedDbServiceServer) RecordPassphraseFailure(context.Context, *SelectRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordPassphraseFailure not implemented")
+}
+func (UnimplementedDbServiceServer) UpdatePassphraseHash(context.Context, *UpdatePassphraseHashRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdatePassphraseHash not implemented")
+}
+func (UnimplementedDbServiceServer) CleanupExpiredMessages(context.Context, *CleanupExpiredMessagesRequest) (*CleanupExpiredMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CleanupExpiredMessages not implemented")
+}
 func (UnimplementedDbServiceServer) mustEmbedUnimplementedDbServiceServer() {}
 func (UnimplementedDbServiceServer) testEmbeddedByValue()                   {}
 
@@ -275,6 +435,186 @@ func _DbService_GetReminderHistory_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DbService_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_GetAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetAttachment(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_DeleteMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).DeleteMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_DeleteMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).DeleteMessage(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_InsertSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).InsertSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_InsertSecretRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).InsertSecretRequest(ctx, req.(*SecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_GetSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).GetSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_GetSecretRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).GetSecretRequest(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_FulfillSecretRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FulfillSecretRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).FulfillSecretRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_FulfillSecretRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).FulfillSecretRequest(ctx, req.(*FulfillSecretRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ReservePassphraseAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ReservePassphraseAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_ReservePassphraseAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ReservePassphraseAttempt(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_ReleasePassphraseAttempt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).ReleasePassphraseAttempt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_ReleasePassphraseAttempt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).ReleasePassphraseAttempt(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_RecordPassphraseFailure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).RecordPassphraseFailure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_RecordPassphraseFailure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).RecordPassphraseFailure(ctx, req.(*SelectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_UpdatePassphraseHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePassphraseHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).UpdatePassphraseHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_UpdatePassphraseHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).UpdatePassphraseHash(ctx, req.(*UpdatePassphraseHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DbService_CleanupExpiredMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupExpiredMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DbServiceServer).CleanupExpiredMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DbService_CleanupExpiredMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DbServiceServer).CleanupExpiredMessages(ctx, req.(*CleanupExpiredMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DbService_ServiceDesc is the grpc.ServiceDesc for DbService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -306,6 +646,46 @@ var DbService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetReminderHistory",
 			Handler:    _DbService_GetReminderHistory_Handler,
 		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _DbService_GetAttachment_Handler,
+		},
+		{
+			MethodName: "DeleteMessage",
+			Handler:    _DbService_DeleteMessage_Handler,
+		},
+		{
+			MethodName: "InsertSecretRequest",
+			Handler:    _DbService_InsertSecretRequest_Handler,
+		},
+		{
+			MethodName: "GetSecretRequest",
+			Handler:    _DbService_GetSecretRequest_Handler,
+		},
+		{
+			MethodName: "FulfillSecretRequest",
+			Handler:    _DbService_FulfillSecretRequest_Handler,
+		},
+		{
+			MethodName: "ReservePassphraseAttempt",
+			Handler:    _DbService_ReservePassphraseAttempt_Handler,
+		},
+		{
+			MethodName: "ReleasePassphraseAttempt",
+			Handler:    _DbService_ReleasePassphraseAttempt_Handler,
+		},
+		{
+			MethodName: "RecordPassphraseFailure",
+			Handler:    _DbService_RecordPassphraseFailure_Handler,
+		},
+		{
+			MethodName: "UpdatePassphraseHash",
+			Handler:    _DbService_UpdatePassphraseHash_Handler,
+		},
+		{
+			MethodName: "CleanupExpiredMessages",
+			Handler:    _DbService_CleanupExpiredMessages_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "database.proto",
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.6
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.21.12
 // source: encryption.proto
 
@@ -22,11 +22,12 @@ const (
 )
 
 type EncryptedMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlainText     []string               `protobuf:"bytes,1,rep,name=plain_text,json=plainText,proto3" json:"plain_text,omitempty"`
-	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlainText      []string               `protobuf:"bytes,1,rep,name=plain_text,json=plainText,proto3" json:"plain_text,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	AssociatedData []byte                 `protobuf:"bytes,3,opt,name=associated_data,json=associatedData,proto3" json:"associated_data,omitempty"` // authenticated with each ciphertext, normally the message ID
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *EncryptedMessageRequest) Reset() {
@@ -73,9 +74,16 @@ func (x *EncryptedMessageRequest) GetKey() []byte {
 	return nil
 }
 
+func (x *EncryptedMessageRequest) GetAssociatedData() []byte {
+	if x != nil {
+		return x.AssociatedData
+	}
+	return nil
+}
+
 type EncryptedMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ciphertext    []string               `protobuf:"bytes,1,rep,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	Ciphertext    []string               `protobuf:"bytes,1,rep,name=ciphertext,proto3" json:"ciphertext,omitempty"` // "pxe." + base64url(version | algorithm | key ID | nonce | ciphertext)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -118,11 +126,12 @@ func (x *EncryptedMessageResponse) GetCiphertext() []string {
 }
 
 type DecryptedMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ciphertext    []string               `protobuf:"bytes,1,rep,name=ciphertext,proto3" json:"ciphertext,omitempty"`
-	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext     []string               `protobuf:"bytes,1,rep,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	AssociatedData []byte                 `protobuf:"bytes,3,opt,name=associated_data,json=associatedData,proto3" json:"associated_data,omitempty"` // must match encryption; ignored for legacy unversioned ciphertexts
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DecryptedMessageRequest) Reset() {
@@ -169,6 +178,13 @@ func (x *DecryptedMessageRequest) GetKey() []byte {
 	return nil
 }
 
+func (x *DecryptedMessageRequest) GetAssociatedData() []byte {
+	if x != nil {
+		return x.AssociatedData
+	}
+	return nil
+}
+
 type DecryptedMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Plaintext     []string               `protobuf:"bytes,1,rep,name=plaintext,proto3" json:"plaintext,omitempty"`
@@ -213,28 +229,29 @@ func (x *DecryptedMessageResponse) GetPlaintext() []string {
 	return nil
 }
 
-type Randomresponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	EncryptionBytes  []byte                 `protobuf:"bytes,1,opt,name=encryption_bytes,json=encryptionBytes,proto3" json:"encryption_bytes,omitempty"`
-	EncryptionString string                 `protobuf:"bytes,2,opt,name=encryption_string,json=encryptionString,proto3" json:"encryption_string,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+type EncryptFileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Plaintext      []byte                 `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	AssociatedData []byte                 `protobuf:"bytes,3,opt,name=associated_data,json=associatedData,proto3" json:"associated_data,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Randomresponse) Reset() {
-	*x = Randomresponse{}
+func (x *EncryptFileRequest) Reset() {
+	*x = EncryptFileRequest{}
 	mi := &file_encryption_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Randomresponse) String() string {
+func (x *EncryptFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Randomresponse) ProtoMessage() {}
+func (*EncryptFileRequest) ProtoMessage() {}
 
-func (x *Randomresponse) ProtoReflect() protoreflect.Message {
+func (x *EncryptFileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_encryption_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -246,46 +263,53 @@ func (x *Randomresponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Randomresponse.ProtoReflect.Descriptor instead.
-func (*Randomresponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use EncryptFileRequest.ProtoReflect.Descriptor instead.
+func (*EncryptFileRequest) Descriptor() ([]byte, []int) {
 	return file_encryption_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Randomresponse) GetEncryptionBytes() []byte {
+func (x *EncryptFileRequest) GetPlaintext() []byte {
 	if x != nil {
-		return x.EncryptionBytes
+		return x.Plaintext
 	}
 	return nil
 }
 
-func (x *Randomresponse) GetEncryptionString() string {
+func (x *EncryptFileRequest) GetKey() []byte {
 	if x != nil {
-		return x.EncryptionString
+		return x.Key
 	}
-	return ""
+	return nil
 }
 
-type Randomrequest struct {
+func (x *EncryptFileRequest) GetAssociatedData() []byte {
+	if x != nil {
+		return x.AssociatedData
+	}
+	return nil
+}
+
+type EncryptFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RandomLength  int32                  `protobuf:"varint,1,opt,name=random_length,json=randomLength,proto3" json:"random_length,omitempty"`
+	Chunks        [][]byte               `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"` // nonce || AES-GCM ciphertext, one per 64 KiB of plaintext
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Randomrequest) Reset() {
-	*x = Randomrequest{}
+func (x *EncryptFileResponse) Reset() {
+	*x = EncryptFileResponse{}
 	mi := &file_encryption_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Randomrequest) String() string {
+func (x *EncryptFileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Randomrequest) ProtoMessage() {}
+func (*EncryptFileResponse) ProtoMessage() {}
 
-func (x *Randomrequest) ProtoReflect() protoreflect.Message {
+func (x *EncryptFileResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_encryption_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,47 +321,819 @@ func (x *Randomrequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Randomrequest.ProtoReflect.Descriptor instead.
-func (*Randomrequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use EncryptFileResponse.ProtoReflect.Descriptor instead.
+func (*EncryptFileResponse) Descriptor() ([]byte, []int) {
 	return file_encryption_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Randomrequest) GetRandomLength() int32 {
+func (x *EncryptFileResponse) GetChunks() [][]byte {
 	if x != nil {
-		return x.RandomLength
+		return x.Chunks
 	}
-	return 0
+	return nil
 }
 
-var File_encryption_proto protoreflect.FileDescriptor
+type DecryptFileRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Chunks         [][]byte               `protobuf:"bytes,1,rep,name=chunks,proto3" json:"chunks,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	AssociatedData []byte                 `protobuf:"bytes,3,opt,name=associated_data,json=associatedData,proto3" json:"associated_data,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_encryption_proto_rawDesc = "" +
-	"\n" +
-	"\x10encryption.proto\x12\fencryptionpb\"J\n" +
-	"\x17EncryptedMessageRequest\x12\x1d\n" +
-	"\n" +
-	"plain_text\x18\x01 \x03(\tR\tplainText\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\fR\x03key\":\n" +
-	"\x18EncryptedMessageResponse\x12\x1e\n" +
-	"\n" +
-	"ciphertext\x18\x01 \x03(\tR\n" +
-	"ciphertext\"K\n" +
-	"\x17DecryptedMessageRequest\x12\x1e\n" +
-	"\n" +
-	"ciphertext\x18\x01 \x03(\tR\n" +
-	"ciphertext\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\fR\x03key\"8\n" +
-	"\x18DecryptedMessageResponse\x12\x1c\n" +
-	"\tplaintext\x18\x01 \x03(\tR\tplaintext\"h\n" +
+func (x *DecryptFileRequest) Reset() {
+	*x = DecryptFileRequest{}
+	mi := &file_encryption_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptFileRequest) ProtoMessage() {}
+
+func (x *DecryptFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptFileRequest.ProtoReflect.Descriptor instead.
+func (*DecryptFileRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DecryptFileRequest) GetChunks() [][]byte {
+	if x != nil {
+		return x.Chunks
+	}
+	return nil
+}
+
+func (x *DecryptFileRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *DecryptFileRequest) GetAssociatedData() []byte {
+	if x != nil {
+		return x.AssociatedData
+	}
+	return nil
+}
+
+type DecryptFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plaintext     []byte                 `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecryptFileResponse) Reset() {
+	*x = DecryptFileResponse{}
+	mi := &file_encryption_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecryptFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecryptFileResponse) ProtoMessage() {}
+
+func (x *DecryptFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecryptFileResponse.ProtoReflect.Descriptor instead.
+func (*DecryptFileResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DecryptFileResponse) GetPlaintext() []byte {
+	if x != nil {
+		return x.Plaintext
+	}
+	return nil
+}
+
+type PublicKeyEncryptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plaintext     []byte                 `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	PublicKey     string                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"` // age X25519 recipient or ASCII-armored OpenPGP public key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicKeyEncryptRequest) Reset() {
+	*x = PublicKeyEncryptRequest{}
+	mi := &file_encryption_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicKeyEncryptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeyEncryptRequest) ProtoMessage() {}
+
+func (x *PublicKeyEncryptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeyEncryptRequest.ProtoReflect.Descriptor instead.
+func (*PublicKeyEncryptRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublicKeyEncryptRequest) GetPlaintext() []byte {
+	if x != nil {
+		return x.Plaintext
+	}
+	return nil
+}
+
+func (x *PublicKeyEncryptRequest) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
+type PublicKeyEncryptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext    string                 `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"` // ASCII-armored age or OpenPGP message
+	KeyType       string                 `protobuf:"bytes,2,opt,name=key_type,json=keyType,proto3" json:"key_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicKeyEncryptResponse) Reset() {
+	*x = PublicKeyEncryptResponse{}
+	mi := &file_encryption_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicKeyEncryptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeyEncryptResponse) ProtoMessage() {}
+
+func (x *PublicKeyEncryptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeyEncryptResponse.ProtoReflect.Descriptor instead.
+func (*PublicKeyEncryptResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PublicKeyEncryptResponse) GetCiphertext() string {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return ""
+}
+
+func (x *PublicKeyEncryptResponse) GetKeyType() string {
+	if x != nil {
+		return x.KeyType
+	}
+	return ""
+}
+
+type PublicKeyDecryptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ciphertext    string                 `protobuf:"bytes,1,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	PrivateKey    string                 `protobuf:"bytes,2,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	Passphrase    string                 `protobuf:"bytes,3,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicKeyDecryptRequest) Reset() {
+	*x = PublicKeyDecryptRequest{}
+	mi := &file_encryption_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicKeyDecryptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeyDecryptRequest) ProtoMessage() {}
+
+func (x *PublicKeyDecryptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeyDecryptRequest.ProtoReflect.Descriptor instead.
+func (*PublicKeyDecryptRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PublicKeyDecryptRequest) GetCiphertext() string {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return ""
+}
+
+func (x *PublicKeyDecryptRequest) GetPrivateKey() string {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return ""
+}
+
+func (x *PublicKeyDecryptRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+type PublicKeyDecryptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Plaintext     []byte                 `protobuf:"bytes,1,opt,name=plaintext,proto3" json:"plaintext,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublicKeyDecryptResponse) Reset() {
+	*x = PublicKeyDecryptResponse{}
+	mi := &file_encryption_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublicKeyDecryptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicKeyDecryptResponse) ProtoMessage() {}
+
+func (x *PublicKeyDecryptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicKeyDecryptResponse.ProtoReflect.Descriptor instead.
+func (*PublicKeyDecryptResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PublicKeyDecryptResponse) GetPlaintext() []byte {
+	if x != nil {
+		return x.Plaintext
+	}
+	return nil
+}
+
+type SplitSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        []byte                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	Shares        int32                  `protobuf:"varint,2,opt,name=shares,proto3" json:"shares,omitempty"`
+	Threshold     int32                  `protobuf:"varint,3,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitSecretRequest) Reset() {
+	*x = SplitSecretRequest{}
+	mi := &file_encryption_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitSecretRequest) ProtoMessage() {}
+
+func (x *SplitSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitSecretRequest.ProtoReflect.Descriptor instead.
+func (*SplitSecretRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SplitSecretRequest) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *SplitSecretRequest) GetShares() int32 {
+	if x != nil {
+		return x.Shares
+	}
+	return 0
+}
+
+func (x *SplitSecretRequest) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+type SplitSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []string               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"` // "pxs1.<split id>.<threshold>.<index>.<value>", ordered by index
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitSecretResponse) Reset() {
+	*x = SplitSecretResponse{}
+	mi := &file_encryption_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitSecretResponse) ProtoMessage() {}
+
+func (x *SplitSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitSecretResponse.ProtoReflect.Descriptor instead.
+func (*SplitSecretResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SplitSecretResponse) GetShares() []string {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type CombineSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []string               `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CombineSharesRequest) Reset() {
+	*x = CombineSharesRequest{}
+	mi := &file_encryption_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CombineSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombineSharesRequest) ProtoMessage() {}
+
+func (x *CombineSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombineSharesRequest.ProtoReflect.Descriptor instead.
+func (*CombineSharesRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CombineSharesRequest) GetShares() []string {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+type CombineSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        []byte                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CombineSharesResponse) Reset() {
+	*x = CombineSharesResponse{}
+	mi := &file_encryption_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CombineSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CombineSharesResponse) ProtoMessage() {}
+
+func (x *CombineSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CombineSharesResponse.ProtoReflect.Descriptor instead.
+func (*CombineSharesResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CombineSharesResponse) GetSecret() []byte {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+type PassphraseKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // 32-byte message key from the decrypt URL
+	Passphrase    string                 `protobuf:"bytes,2,opt,name=passphrase,proto3" json:"passphrase,omitempty"`
+	Salt          []byte                 `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"` // empty to generate a new salt when encrypting
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassphraseKeyRequest) Reset() {
+	*x = PassphraseKeyRequest{}
+	mi := &file_encryption_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassphraseKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassphraseKeyRequest) ProtoMessage() {}
+
+func (x *PassphraseKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassphraseKeyRequest.ProtoReflect.Descriptor instead.
+func (*PassphraseKeyRequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PassphraseKeyRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *PassphraseKeyRequest) GetPassphrase() string {
+	if x != nil {
+		return x.Passphrase
+	}
+	return ""
+}
+
+func (x *PassphraseKeyRequest) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+type PassphraseKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`   // content key derived from both the message key and the passphrase
+	Salt          []byte                 `protobuf:"bytes,2,opt,name=salt,proto3" json:"salt,omitempty"` // Argon2id salt to store with the message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassphraseKeyResponse) Reset() {
+	*x = PassphraseKeyResponse{}
+	mi := &file_encryption_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassphraseKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassphraseKeyResponse) ProtoMessage() {}
+
+func (x *PassphraseKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassphraseKeyResponse.ProtoReflect.Descriptor instead.
+func (*PassphraseKeyResponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PassphraseKeyResponse) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *PassphraseKeyResponse) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+type Randomresponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EncryptionBytes  []byte                 `protobuf:"bytes,1,opt,name=encryption_bytes,json=encryptionBytes,proto3" json:"encryption_bytes,omitempty"`
+	EncryptionString string                 `protobuf:"bytes,2,opt,name=encryption_string,json=encryptionString,proto3" json:"encryption_string,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Randomresponse) Reset() {
+	*x = Randomresponse{}
+	mi := &file_encryption_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Randomresponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Randomresponse) ProtoMessage() {}
+
+func (x *Randomresponse) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Randomresponse.ProtoReflect.Descriptor instead.
+func (*Randomresponse) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Randomresponse) GetEncryptionBytes() []byte {
+	if x != nil {
+		return x.EncryptionBytes
+	}
+	return nil
+}
+
+func (x *Randomresponse) GetEncryptionString() string {
+	if x != nil {
+		return x.EncryptionString
+	}
+	return ""
+}
+
+type Randomrequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RandomLength  int32                  `protobuf:"varint,1,opt,name=random_length,json=randomLength,proto3" json:"random_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Randomrequest) Reset() {
+	*x = Randomrequest{}
+	mi := &file_encryption_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Randomrequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Randomrequest) ProtoMessage() {}
+
+func (x *Randomrequest) ProtoReflect() protoreflect.Message {
+	mi := &file_encryption_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Randomrequest.ProtoReflect.Descriptor instead.
+func (*Randomrequest) Descriptor() ([]byte, []int) {
+	return file_encryption_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Randomrequest) GetRandomLength() int32 {
+	if x != nil {
+		return x.RandomLength
+	}
+	return 0
+}
+
+var File_encryption_proto protoreflect.FileDescriptor
+
+const file_encryption_proto_rawDesc = "" +
+	"\n" +
+	"\x10encryption.proto\x12\fencryptionpb\"s\n" +
+	"\x17EncryptedMessageRequest\x12\x1d\n" +
+	"\n" +
+	"plain_text\x18\x01 \x03(\tR\tplainText\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12'\n" +
+	"\x0fassociated_data\x18\x03 \x01(\fR\x0eassociatedData\":\n" +
+	"\x18EncryptedMessageResponse\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x03(\tR\n" +
+	"ciphertext\"t\n" +
+	"\x17DecryptedMessageRequest\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x03(\tR\n" +
+	"ciphertext\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12'\n" +
+	"\x0fassociated_data\x18\x03 \x01(\fR\x0eassociatedData\"8\n" +
+	"\x18DecryptedMessageResponse\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x03(\tR\tplaintext\"m\n" +
+	"\x12EncryptFileRequest\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x01(\fR\tplaintext\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12'\n" +
+	"\x0fassociated_data\x18\x03 \x01(\fR\x0eassociatedData\"-\n" +
+	"\x13EncryptFileResponse\x12\x16\n" +
+	"\x06chunks\x18\x01 \x03(\fR\x06chunks\"g\n" +
+	"\x12DecryptFileRequest\x12\x16\n" +
+	"\x06chunks\x18\x01 \x03(\fR\x06chunks\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12'\n" +
+	"\x0fassociated_data\x18\x03 \x01(\fR\x0eassociatedData\"3\n" +
+	"\x13DecryptFileResponse\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x01(\fR\tplaintext\"V\n" +
+	"\x17PublicKeyEncryptRequest\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x01(\fR\tplaintext\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\tR\tpublicKey\"U\n" +
+	"\x18PublicKeyEncryptResponse\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x01(\tR\n" +
+	"ciphertext\x12\x19\n" +
+	"\bkey_type\x18\x02 \x01(\tR\akeyType\"z\n" +
+	"\x17PublicKeyDecryptRequest\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x01 \x01(\tR\n" +
+	"ciphertext\x12\x1f\n" +
+	"\vprivate_key\x18\x02 \x01(\tR\n" +
+	"privateKey\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x03 \x01(\tR\n" +
+	"passphrase\"8\n" +
+	"\x18PublicKeyDecryptResponse\x12\x1c\n" +
+	"\tplaintext\x18\x01 \x01(\fR\tplaintext\"b\n" +
+	"\x12SplitSecretRequest\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\fR\x06secret\x12\x16\n" +
+	"\x06shares\x18\x02 \x01(\x05R\x06shares\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x05R\tthreshold\"-\n" +
+	"\x13SplitSecretResponse\x12\x16\n" +
+	"\x06shares\x18\x01 \x03(\tR\x06shares\".\n" +
+	"\x14CombineSharesRequest\x12\x16\n" +
+	"\x06shares\x18\x01 \x03(\tR\x06shares\"/\n" +
+	"\x15CombineSharesResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\fR\x06secret\"\\\n" +
+	"\x14PassphraseKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\x12\x1e\n" +
+	"\n" +
+	"passphrase\x18\x02 \x01(\tR\n" +
+	"passphrase\x12\x12\n" +
+	"\x04salt\x18\x03 \x01(\fR\x04salt\"=\n" +
+	"\x15PassphraseKeyResponse\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\fR\x03key\x12\x12\n" +
+	"\x04salt\x18\x02 \x01(\fR\x04salt\"h\n" +
 	"\x0eRandomresponse\x12)\n" +
 	"\x10encryption_bytes\x18\x01 \x01(\fR\x0fencryptionBytes\x12+\n" +
 	"\x11encryption_string\x18\x02 \x01(\tR\x10encryptionString\"4\n" +
 	"\rRandomrequest\x12#\n" +
-	"\rrandom_length\x18\x01 \x01(\x05R\frandomLength2\xab\x02\n" +
+	"\rrandom_length\x18\x01 \x01(\x05R\frandomLength2\xbc\a\n" +
 	"\x0eMessageService\x12a\n" +
 	"\x0eencryptMessage\x12%.encryptionpb.EncryptedMessageRequest\x1a&.encryptionpb.EncryptedMessageResponse\"\x00\x12a\n" +
 	"\x0eDecryptMessage\x12%.encryptionpb.DecryptedMessageRequest\x1a&.encryptionpb.DecryptedMessageResponse\"\x00\x12S\n" +
-	"\x14GenerateRandomString\x12\x1b.encryptionpb.Randomrequest\x1a\x1c.encryptionpb.Randomresponse\"\x00B=Z;github.com/Anthony-Bible/password-exchange/app/encryptionpbb\x06proto3"
+	"\x14GenerateRandomString\x12\x1b.encryptionpb.Randomrequest\x1a\x1c.encryptionpb.Randomresponse\"\x00\x12T\n" +
+	"\vEncryptFile\x12 .encryptionpb.EncryptFileRequest\x1a!.encryptionpb.EncryptFileResponse\"\x00\x12T\n" +
+	"\vDecryptFile\x12 .encryptionpb.DecryptFileRequest\x1a!.encryptionpb.DecryptFileResponse\"\x00\x12e\n" +
+	"\x12EncryptToPublicKey\x12%.encryptionpb.PublicKeyEncryptRequest\x1a&.encryptionpb.PublicKeyEncryptResponse\"\x00\x12h\n" +
+	"\x15DecryptWithPrivateKey\x12%.encryptionpb.PublicKeyDecryptRequest\x1a&.encryptionpb.PublicKeyDecryptResponse\"\x00\x12T\n" +
+	"\vSplitSecret\x12 .encryptionpb.SplitSecretRequest\x1a!.encryptionpb.SplitSecretResponse\"\x00\x12Z\n" +
+	"\rCombineShares\x12\".encryptionpb.CombineSharesRequest\x1a#.encryptionpb.CombineSharesResponse\"\x00\x12`\n" +
+	"\x13DerivePassphraseKey\x12\".encryptionpb.PassphraseKeyRequest\x1a#.encryptionpb.PassphraseKeyResponse\"\x00B=Z;github.com/Anthony-Bible/password-exchange/app/encryptionpbb\x06proto3"
 
 var (
 	file_encryption_proto_rawDescOnce sync.Once
@@ -351,27 +1147,55 @@ func file_encryption_proto_rawDescGZIP() []byte {
 	return file_encryption_proto_rawDescData
 }
 
-var file_encryption_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_encryption_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_encryption_proto_goTypes = []any{
 	(*EncryptedMessageRequest)(nil),  // 0: encryptionpb.EncryptedMessageRequest
 	(*EncryptedMessageResponse)(nil), // 1: encryptionpb.EncryptedMessageResponse
 	(*DecryptedMessageRequest)(nil),  // 2: encryptionpb.DecryptedMessageRequest
 	(*DecryptedMessageResponse)(nil), // 3: encryptionpb.DecryptedMessageResponse
-	(*Randomresponse)(nil),           // 4: encryptionpb.Randomresponse
-	(*Randomrequest)(nil),            // 5: encryptionpb.Randomrequest
+	(*EncryptFileRequest)(nil),       // 4: encryptionpb.EncryptFileRequest
+	(*EncryptFileResponse)(nil),      // 5: encryptionpb.EncryptFileResponse
+	(*DecryptFileRequest)(nil),       // 6: encryptionpb.DecryptFileRequest
+	(*DecryptFileResponse)(nil),      // 7: encryptionpb.DecryptFileResponse
+	(*PublicKeyEncryptRequest)(nil),  // 8: encryptionpb.PublicKeyEncryptRequest
+	(*PublicKeyEncryptResponse)(nil), // 9: encryptionpb.PublicKeyEncryptResponse
+	(*PublicKeyDecryptRequest)(nil),  // 10: encryptionpb.PublicKeyDecryptRequest
+	(*PublicKeyDecryptResponse)(nil), // 11: encryptionpb.PublicKeyDecryptResponse
+	(*SplitSecretRequest)(nil),       // 12: encryptionpb.SplitSecretRequest
+	(*SplitSecretResponse)(nil),      // 13: encryptionpb.SplitSecretResponse
+	(*CombineSharesRequest)(nil),     // 14: encryptionpb.CombineSharesRequest
+	(*CombineSharesResponse)(nil),    // 15: encryptionpb.CombineSharesResponse
+	(*PassphraseKeyRequest)(nil),     // 16: encryptionpb.PassphraseKeyRequest
+	(*PassphraseKeyResponse)(nil),    // 17: encryptionpb.PassphraseKeyResponse
+	(*Randomresponse)(nil),           // 18: encryptionpb.Randomresponse
+	(*Randomrequest)(nil),            // 19: encryptionpb.Randomrequest
 }
 var file_encryption_proto_depIdxs = []int32{
-	0, // 0: encryptionpb.MessageService.encryptMessage:input_type -> encryptionpb.EncryptedMessageRequest
-	2, // 1: encryptionpb.MessageService.DecryptMessage:input_type -> encryptionpb.DecryptedMessageRequest
-	5, // 2: encryptionpb.MessageService.GenerateRandomString:input_type -> encryptionpb.Randomrequest
-	1, // 3: encryptionpb.MessageService.encryptMessage:output_type -> encryptionpb.EncryptedMessageResponse
-	3, // 4: encryptionpb.MessageService.DecryptMessage:output_type -> encryptionpb.DecryptedMessageResponse
-	4, // 5: encryptionpb.MessageService.GenerateRandomString:output_type -> encryptionpb.Randomresponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: encryptionpb.MessageService.encryptMessage:input_type -> encryptionpb.EncryptedMessageRequest
+	2,  // 1: encryptionpb.MessageService.DecryptMessage:input_type -> encryptionpb.DecryptedMessageRequest
+	19, // 2: encryptionpb.MessageService.GenerateRandomString:input_type -> encryptionpb.Randomrequest
+	4,  // 3: encryptionpb.MessageService.EncryptFile:input_type -> encryptionpb.EncryptFileRequest
+	6,  // 4: encryptionpb.MessageService.DecryptFile:input_type -> encryptionpb.DecryptFileRequest
+	8,  // 5: encryptionpb.MessageService.EncryptToPublicKey:input_type -> encryptionpb.PublicKeyEncryptRequest
+	10, // 6: encryptionpb.MessageService.DecryptWithPrivateKey:input_type -> encryptionpb.PublicKeyDecryptRequest
+	12, // 7: encryptionpb.MessageService.SplitSecret:input_type -> encryptionpb.SplitSecretRequest
+	14, // 8: encryptionpb.MessageService.CombineShares:input_type -> encryptionpb.CombineSharesRequest
+	16, // 9: encryptionpb.MessageService.DerivePassphraseKey:input_type -> encryptionpb.PassphraseKeyRequest
+	1,  // 10: encryptionpb.MessageService.encryptMessage:output_type -> encryptionpb.EncryptedMessageResponse
+	3,  // 11: encryptionpb.MessageService.DecryptMessage:output_type -> encryptionpb.DecryptedMessageResponse
+	18, // 12: encryptionpb.MessageService.GenerateRandomString:output_type -> encryptionpb.Randomresponse
+	5,  // 13: encryptionpb.MessageService.EncryptFile:output_type -> encryptionpb.EncryptFileResponse
+	7,  // 14: encryptionpb.MessageService.DecryptFile:output_type -> encryptionpb.DecryptFileResponse
+	9,  // 15: encryptionpb.MessageService.EncryptToPublicKey:output_type -> encryptionpb.PublicKeyEncryptResponse
+	11, // 16: encryptionpb.MessageService.DecryptWithPrivateKey:output_type -> encryptionpb.PublicKeyDecryptResponse
+	13, // 17: encryptionpb.MessageService.SplitSecret:output_type -> encryptionpb.SplitSecretResponse
+	15, // 18: encryptionpb.MessageService.CombineShares:output_type -> encryptionpb.CombineSharesResponse
+	17, // 19: encryptionpb.MessageService.DerivePassphraseKey:output_type -> encryptionpb.PassphraseKeyResponse
+	10, // [10:20] is the sub-list for method output_type
+	0,  // [0:10] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_encryption_proto_init() }
@@ -385,7 +1209,7 @@ func file_encryption_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_encryption_proto_rawDesc), len(file_encryption_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
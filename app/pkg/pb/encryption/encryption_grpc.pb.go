@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
+// - protoc-gen-go-grpc v1.6.1
 // - protoc             v3.21.12
 // source: encryption.proto
 
@@ -15,8 +15,21 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MessageService_EncryptMessage_FullMethodName        = "/encryptionpb.MessageService/encryptMessage"
+	MessageService_DecryptMessage_FullMethodName        = "/encryptionpb.MessageService/DecryptMessage"
+	MessageService_GenerateRandomString_FullMethodName  = "/encryptionpb.MessageService/GenerateRandomString"
+	MessageService_EncryptFile_FullMethodName           = "/encryptionpb.MessageService/EncryptFile"
+	MessageService_DecryptFile_FullMethodName           = "/encryptionpb.MessageService/DecryptFile"
+	MessageService_EncryptToPublicKey_FullMethodName    = "/encryptionpb.MessageService/EncryptToPublicKey"
+	MessageService_DecryptWithPrivateKey_FullMethodName = "/encryptionpb.MessageService/DecryptWithPrivateKey"
+	MessageService_SplitSecret_FullMethodName           = "/encryptionpb.MessageService/SplitSecret"
+	MessageService_CombineShares_FullMethodName         = "/encryptionpb.MessageService/CombineShares"
+	MessageService_DerivePassphraseKey_FullMethodName   = "/encryptionpb.MessageService/DerivePassphraseKey"
+)
 
 // MessageServiceClient is the client API for MessageService service.
 //
@@ -25,6 +38,13 @@ type MessageServiceClient interface {
 	EncryptMessage(ctx context.Context, in *EncryptedMessageRequest, opts ...grpc.CallOption) (*EncryptedMessageResponse, error)
 	DecryptMessage(ctx context.Context, in *DecryptedMessageRequest, opts ...grpc.CallOption) (*DecryptedMessageResponse, error)
 	GenerateRandomString(ctx context.Context, in *Randomrequest, opts ...grpc.CallOption) (*Randomresponse, error)
+	EncryptFile(ctx context.Context, in *EncryptFileRequest, opts ...grpc.CallOption) (*EncryptFileResponse, error)
+	DecryptFile(ctx context.Context, in *DecryptFileRequest, opts ...grpc.CallOption) (*DecryptFileResponse, error)
+	EncryptToPublicKey(ctx context.Context, in *PublicKeyEncryptRequest, opts ...grpc.CallOption) (*PublicKeyEncryptResponse, error)
+	DecryptWithPrivateKey(ctx context.Context, in *PublicKeyDecryptRequest, opts ...grpc.CallOption) (*PublicKeyDecryptResponse, error)
+	SplitSecret(ctx context.Context, in *SplitSecretRequest, opts ...grpc.CallOption) (*SplitSecretResponse, error)
+	CombineShares(ctx context.Context, in *CombineSharesRequest, opts ...grpc.CallOption) (*CombineSharesResponse, error)
+	DerivePassphraseKey(ctx context.Context, in *PassphraseKeyRequest, opts ...grpc.CallOption) (*PassphraseKeyResponse, error)
 }
 
 type messageServiceClient struct {
@@ -36,8 +56,9 @@ func NewMessageServiceClient(cc grpc.ClientConnInterface) MessageServiceClient {
 }
 
 func (c *messageServiceClient) EncryptMessage(ctx context.Context, in *EncryptedMessageRequest, opts ...grpc.CallOption) (*EncryptedMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(EncryptedMessageResponse)
-	err := c.cc.Invoke(ctx, "/encryptionpb.MessageService/encryptMessage", in, out, opts...)
+	err := c.cc.Invoke(ctx, MessageService_EncryptMessage_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -45,8 +66,9 @@ func (c *messageServiceClient) EncryptMessage(ctx context.Context, in *Encrypted
 }
 
 func (c *messageServiceClient) DecryptMessage(ctx context.Context, in *DecryptedMessageRequest, opts ...grpc.CallOption) (*DecryptedMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DecryptedMessageResponse)
-	err := c.cc.Invoke(ctx, "/encryptionpb.MessageService/DecryptMessage", in, out, opts...)
+	err := c.cc.Invoke(ctx, MessageService_DecryptMessage_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +76,79 @@ func (c *messageServiceClient) DecryptMessage(ctx context.Context, in *Decrypted
 }
 
 func (c *messageServiceClient) GenerateRandomString(ctx context.Context, in *Randomrequest, opts ...grpc.CallOption) (*Randomresponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Randomresponse)
-	err := c.cc.Invoke(ctx, "/encryptionpb.MessageService/GenerateRandomString", in, out, opts...)
+	err := c.cc.Invoke(ctx, MessageService_GenerateRandomString_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) EncryptFile(ctx context.Context, in *EncryptFileRequest, opts ...grpc.CallOption) (*EncryptFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncryptFileResponse)
+	err := c.cc.Invoke(ctx, MessageService_EncryptFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) DecryptFile(ctx context.Context, in *DecryptFileRequest, opts ...grpc.CallOption) (*DecryptFileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecryptFileResponse)
+	err := c.cc.Invoke(ctx, MessageService_DecryptFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) EncryptToPublicKey(ctx context.Context, in *PublicKeyEncryptRequest, opts ...grpc.CallOption) (*PublicKeyEncryptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicKeyEncryptResponse)
+	err := c.cc.Invoke(ctx, MessageService_EncryptToPublicKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) DecryptWithPrivateKey(ctx context.Context, in *PublicKeyDecryptRequest, opts ...grpc.CallOption) (*PublicKeyDecryptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PublicKeyDecryptResponse)
+	err := c.cc.Invoke(ctx, MessageService_DecryptWithPrivateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) SplitSecret(ctx context.Context, in *SplitSecretRequest, opts ...grpc.CallOption) (*SplitSecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitSecretResponse)
+	err := c.cc.Invoke(ctx, MessageService_SplitSecret_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) CombineShares(ctx context.Context, in *CombineSharesRequest, opts ...grpc.CallOption) (*CombineSharesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CombineSharesResponse)
+	err := c.cc.Invoke(ctx, MessageService_CombineShares_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageServiceClient) DerivePassphraseKey(ctx context.Context, in *PassphraseKeyRequest, opts ...grpc.CallOption) (*PassphraseKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PassphraseKeyResponse)
+	err := c.cc.Invoke(ctx, MessageService_DerivePassphraseKey_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,28 +157,60 @@ func (c *messageServiceClient) GenerateRandomString(ctx context.Context, in *Ran
 
 // MessageServiceServer is the server API for MessageService service.
 // All implementations must embed UnimplementedMessageServiceServer
-// for forward compatibility
+// for forward compatibility.
 type MessageServiceServer interface {
 	EncryptMessage(context.Context, *EncryptedMessageRequest) (*EncryptedMessageResponse, error)
 	DecryptMessage(context.Context, *DecryptedMessageRequest) (*DecryptedMessageResponse, error)
 	GenerateRandomString(context.Context, *Randomrequest) (*Randomresponse, error)
+	EncryptFile(context.Context, *EncryptFileRequest) (*EncryptFileResponse, error)
+	DecryptFile(context.Context, *DecryptFileRequest) (*DecryptFileResponse, error)
+	EncryptToPublicKey(context.Context, *PublicKeyEncryptRequest) (*PublicKeyEncryptResponse, error)
+	DecryptWithPrivateKey(context.Context, *PublicKeyDecryptRequest) (*PublicKeyDecryptResponse, error)
+	SplitSecret(context.Context, *SplitSecretRequest) (*SplitSecretResponse, error)
+	CombineShares(context.Context, *CombineSharesRequest) (*CombineSharesResponse, error)
+	DerivePassphraseKey(context.Context, *PassphraseKeyRequest) (*PassphraseKeyResponse, error)
 	mustEmbedUnimplementedMessageServiceServer()
 }
 
-// UnimplementedMessageServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedMessageServiceServer struct {
-}
+// UnimplementedMessageServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMessageServiceServer struct{}
 
 func (UnimplementedMessageServiceServer) EncryptMessage(context.Context, *EncryptedMessageRequest) (*EncryptedMessageResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EncryptMessage not implemented")
+	return nil, status.Error(codes.Unimplemented, "method EncryptMessage not implemented")
 }
 func (UnimplementedMessageServiceServer) DecryptMessage(context.Context, *DecryptedMessageRequest) (*DecryptedMessageResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DecryptMessage not implemented")
+	return nil, status.Error(codes.Unimplemented, "method DecryptMessage not implemented")
 }
 func (UnimplementedMessageServiceServer) GenerateRandomString(context.Context, *Randomrequest) (*Randomresponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GenerateRandomString not implemented")
+	return nil, status.Error(codes.Unimplemented, "method GenerateRandomString not implemented")
+}
+func (UnimplementedMessageServiceServer) EncryptFile(context.Context, *EncryptFileRequest) (*EncryptFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EncryptFile not implemented")
+}
+func (UnimplementedMessageServiceServer) DecryptFile(context.Context, *DecryptFileRequest) (*DecryptFileResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DecryptFile not implemented")
+}
+func (UnimplementedMessageServiceServer) EncryptToPublicKey(context.Context, *PublicKeyEncryptRequest) (*PublicKeyEncryptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EncryptToPublicKey not implemented")
+}
+func (UnimplementedMessageServiceServer) DecryptWithPrivateKey(context.Context, *PublicKeyDecryptRequest) (*PublicKeyDecryptResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DecryptWithPrivateKey not implemented")
+}
+func (UnimplementedMessageServiceServer) SplitSecret(context.Context, *SplitSecretRequest) (*SplitSecretResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SplitSecret not implemented")
+}
+func (UnimplementedMessageServiceServer) CombineShares(context.Context, *CombineSharesRequest) (*CombineSharesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CombineShares not implemented")
+}
+func (UnimplementedMessageServiceServer) DerivePassphraseKey(context.Context, *PassphraseKeyRequest) (*PassphraseKeyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DerivePassphraseKey not implemented")
 }
 func (UnimplementedMessageServiceServer) mustEmbedUnimplementedMessageServiceServer() {}
+func (UnimplementedMessageServiceServer) testEmbeddedByValue()                        {}
 
 // UnsafeMessageServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to MessageServiceServer will
@@ -95,6 +220,13 @@ type UnsafeMessageServiceServer interface {
 }
 
 func RegisterMessageServiceServer(s grpc.ServiceRegistrar, srv MessageServiceServer) {
+	// If the following call panics, it indicates UnimplementedMessageServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
 	s.RegisterService(&MessageService_ServiceDesc, srv)
 }
 
@@ -108,7 +240,7 @@ func _MessageService_EncryptMessage_Handler(srv interface{}, ctx context.Context
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/encryptionpb.MessageService/encryptMessage",
+		FullMethod: MessageService_EncryptMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MessageServiceServer).EncryptMessage(ctx, req.(*EncryptedMessageRequest))
@@ -126,7 +258,7 @@ func _MessageService_DecryptMessage_Handler(srv interface{}, ctx context.Context
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/encryptionpb.MessageService/DecryptMessage",
+		FullMethod: MessageService_DecryptMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MessageServiceServer).DecryptMessage(ctx, req.(*DecryptedMessageRequest))
@@ -144,7 +276,7 @@ func _MessageService_GenerateRandomString_Handler(srv interface{}, ctx context.C
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/encryptionpb.MessageService/GenerateRandomString",
+		FullMethod: MessageService_GenerateRandomString_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(MessageServiceServer).GenerateRandomString(ctx, req.(*Randomrequest))
@@ -152,6 +284,132 @@ func _MessageService_GenerateRandomString_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MessageService_EncryptFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).EncryptFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_EncryptFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).EncryptFile(ctx, req.(*EncryptFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_DecryptFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).DecryptFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_DecryptFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).DecryptFile(ctx, req.(*DecryptFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_EncryptToPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicKeyEncryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).EncryptToPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_EncryptToPublicKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).EncryptToPublicKey(ctx, req.(*PublicKeyEncryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_DecryptWithPrivateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicKeyDecryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).DecryptWithPrivateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_DecryptWithPrivateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).DecryptWithPrivateKey(ctx, req.(*PublicKeyDecryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_SplitSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).SplitSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_SplitSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).SplitSecret(ctx, req.(*SplitSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_CombineShares_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CombineSharesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).CombineShares(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_CombineShares_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).CombineShares(ctx, req.(*CombineSharesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageService_DerivePassphraseKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PassphraseKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageServiceServer).DerivePassphraseKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageService_DerivePassphraseKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageServiceServer).DerivePassphraseKey(ctx, req.(*PassphraseKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MessageService_ServiceDesc is the grpc.ServiceDesc for MessageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +429,34 @@ var MessageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GenerateRandomString",
 			Handler:    _MessageService_GenerateRandomString_Handler,
 		},
+		{
+			MethodName: "EncryptFile",
+			Handler:    _MessageService_EncryptFile_Handler,
+		},
+		{
+			MethodName: "DecryptFile",
+			Handler:    _MessageService_DecryptFile_Handler,
+		},
+		{
+			MethodName: "EncryptToPublicKey",
+			Handler:    _MessageService_EncryptToPublicKey_Handler,
+		},
+		{
+			MethodName: "DecryptWithPrivateKey",
+			Handler:    _MessageService_DecryptWithPrivateKey_Handler,
+		},
+		{
+			MethodName: "SplitSecret",
+			Handler:    _MessageService_SplitSecret_Handler,
+		},
+		{
+			MethodName: "CombineShares",
+			Handler:    _MessageService_CombineShares_Handler,
+		},
+		{
+			MethodName: "DerivePassphraseKey",
+			Handler:    _MessageService_DerivePassphraseKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "encryption.proto",
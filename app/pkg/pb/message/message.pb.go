@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.6
+// 	protoc-gen-go v1.36.11
 // 	protoc        v3.21.12
 // source: message.proto
 
@@ -22,18 +22,27 @@ const (
 )
 
 type Message struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Email          string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	FirstName      string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	OtherFirstName string                 `protobuf:"bytes,3,opt,name=other_first_name,json=otherFirstName,proto3" json:"other_first_name,omitempty"`
-	OtherLastName  string                 `protobuf:"bytes,4,opt,name=other_last_name,json=otherLastName,proto3" json:"other_last_name,omitempty"`
-	OtherEmail     string                 `protobuf:"bytes,5,opt,name=other_email,json=otherEmail,proto3" json:"other_email,omitempty"`
-	UniqueId       string                 `protobuf:"bytes,6,opt,name=unique_id,json=uniqueId,proto3" json:"unique_id,omitempty"`
-	Content        string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
-	Errors         string                 `protobuf:"bytes,8,opt,name=errors,proto3" json:"errors,omitempty"`
-	Url            string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
-	Hidden         string                 `protobuf:"bytes,10,opt,name=hidden,proto3" json:"hidden,omitempty"`
-	Captcha        string                 `protobuf:"bytes,11,opt,name=captcha,proto3" json:"captcha,omitempty"`
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Email            string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	FirstName        string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	OtherFirstName   string                 `protobuf:"bytes,3,opt,name=other_first_name,json=otherFirstName,proto3" json:"other_first_name,omitempty"`
+	OtherLastName    string                 `protobuf:"bytes,4,opt,name=other_last_name,json=otherLastName,proto3" json:"other_last_name,omitempty"`
+	OtherEmail       string                 `protobuf:"bytes,5,opt,name=other_email,json=otherEmail,proto3" json:"other_email,omitempty"`
+	UniqueId         string                 `protobuf:"bytes,6,opt,name=unique_id,json=uniqueId,proto3" json:"unique_id,omitempty"`
+	Content          string                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"`
+	Errors           string                 `protobuf:"bytes,8,opt,name=errors,proto3" json:"errors,omitempty"`
+	Url              string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Hidden           string                 `protobuf:"bytes,10,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	Captcha          string                 `protobuf:"bytes,11,opt,name=captcha,proto3" json:"captcha,omitempty"`
+	NotificationType string                 `protobuf:"bytes,12,opt,name=notification_type,json=notificationType,proto3" json:"notification_type,omitempty"`
+	ViewCount        int32                  `protobuf:"varint,13,opt,name=view_count,json=viewCount,proto3" json:"view_count,omitempty"`
+	MaxViewCount     int32                  `protobuf:"varint,14,opt,name=max_view_count,json=maxViewCount,proto3" json:"max_view_count,omitempty"`
+	// Set when notification_type is "webhook"
+	WebhookEvent   string `protobuf:"bytes,15,opt,name=webhook_event,json=webhookEvent,proto3" json:"webhook_event,omitempty"`        // lifecycle event, e.g. "message.viewed"; also set for "passphrase_alert"
+	WebhookUrl     string `protobuf:"bytes,16,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`              // per-message endpoint; empty means the globally configured one
+	WebhookSecret  string `protobuf:"bytes,17,opt,name=webhook_secret,json=webhookSecret,proto3" json:"webhook_secret,omitempty"`     // HMAC key for webhook_url
+	OccurredAt     string `protobuf:"bytes,18,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`              // RFC3339 timestamp of the event
+	ReminderNumber int32  `protobuf:"varint,19,opt,name=reminder_number,json=reminderNumber,proto3" json:"reminder_number,omitempty"` // set when notification_type is "reminder"
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -145,11 +154,67 @@ func (x *Message) GetCaptcha() string {
 	return ""
 }
 
+func (x *Message) GetNotificationType() string {
+	if x != nil {
+		return x.NotificationType
+	}
+	return ""
+}
+
+func (x *Message) GetViewCount() int32 {
+	if x != nil {
+		return x.ViewCount
+	}
+	return 0
+}
+
+func (x *Message) GetMaxViewCount() int32 {
+	if x != nil {
+		return x.MaxViewCount
+	}
+	return 0
+}
+
+func (x *Message) GetWebhookEvent() string {
+	if x != nil {
+		return x.WebhookEvent
+	}
+	return ""
+}
+
+func (x *Message) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+func (x *Message) GetWebhookSecret() string {
+	if x != nil {
+		return x.WebhookSecret
+	}
+	return ""
+}
+
+func (x *Message) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *Message) GetReminderNumber() int32 {
+	if x != nil {
+		return x.ReminderNumber
+	}
+	return 0
+}
+
 var File_message_proto protoreflect.FileDescriptor
 
 const file_message_proto_rawDesc = "" +
 	"\n" +
-	"\rmessage.proto\x12\tmessagepb\"\xc4\x02\n" +
+	"\rmessage.proto\x12\tmessagepb\"\xed\x04\n" +
 	"\aMessage\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
 	"\n" +
@@ -164,7 +229,18 @@ const file_message_proto_rawDesc = "" +
 	"\x03url\x18\t \x01(\tR\x03url\x12\x16\n" +
 	"\x06hidden\x18\n" +
 	" \x01(\tR\x06hidden\x12\x18\n" +
-	"\acaptcha\x18\v \x01(\tR\acaptchaB:Z8github.com/Anthony-Bible/password-exchange/app/messagepbb\x06proto3"
+	"\acaptcha\x18\v \x01(\tR\acaptcha\x12+\n" +
+	"\x11notification_type\x18\f \x01(\tR\x10notificationType\x12\x1d\n" +
+	"\n" +
+	"view_count\x18\r \x01(\x05R\tviewCount\x12$\n" +
+	"\x0emax_view_count\x18\x0e \x01(\x05R\fmaxViewCount\x12#\n" +
+	"\rwebhook_event\x18\x0f \x01(\tR\fwebhookEvent\x12\x1f\n" +
+	"\vwebhook_url\x18\x10 \x01(\tR\n" +
+	"webhookUrl\x12%\n" +
+	"\x0ewebhook_secret\x18\x11 \x01(\tR\rwebhookSecret\x12\x1f\n" +
+	"\voccurred_at\x18\x12 \x01(\tR\n" +
+	"occurredAt\x12'\n" +
+	"\x0freminder_number\x18\x13 \x01(\x05R\x0ereminderNumberB:Z8github.com/Anthony-Bible/password-exchange/app/messagepbb\x06proto3"
 
 var (
 	file_message_proto_rawDescOnce sync.Once
@@ -0,0 +1,73 @@
+// Client-side (zero-knowledge) encryption helpers.
+// Messages are encrypted with AES-256-GCM via WebCrypto before they leave the browser.
+// The payload sent to the server is base64url(nonce || ciphertext || tag); the key is
+// only ever placed in the URL fragment, which browsers never send to the server.
+
+const CLIENT_CRYPTO_KEY_BYTES = 32;
+const CLIENT_CRYPTO_NONCE_BYTES = 12;
+
+// Encode bytes as padded base64url to match the server's base64.URLEncoding
+function bytesToBase64Url(bytes) {
+    let binary = '';
+    for (let i = 0; i < bytes.length; i++) {
+        binary += String.fromCharCode(bytes[i]);
+    }
+    return btoa(binary).replace(/\+/g, '-').replace(/\//g, '_');
+}
+
+// Decode padded or unpadded base64url into bytes
+function base64UrlToBytes(value) {
+    let base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+    while (base64.length % 4 !== 0) {
+        base64 += '=';
+    }
+    const binary = atob(base64);
+    const bytes = new Uint8Array(binary.length);
+    for (let i = 0; i < binary.length; i++) {
+        bytes[i] = binary.charCodeAt(i);
+    }
+    return bytes;
+}
+
+function isClientCryptoSupported() {
+    return !!(window.crypto && window.crypto.subtle && window.isSecureContext);
+}
+
+// Encrypt plaintext with a fresh random key.
+// Returns { ciphertext, key } where both are base64url strings.
+async function clientEncryptMessage(plaintext) {
+    const rawKey = crypto.getRandomValues(new Uint8Array(CLIENT_CRYPTO_KEY_BYTES));
+    const nonce = crypto.getRandomValues(new Uint8Array(CLIENT_CRYPTO_NONCE_BYTES));
+    const key = await crypto.subtle.importKey('raw', rawKey, { name: 'AES-GCM' }, false, ['encrypt']);
+
+    const encrypted = new Uint8Array(await crypto.subtle.encrypt(
+        { name: 'AES-GCM', iv: nonce },
+        key,
+        new TextEncoder().encode(plaintext)
+    ));
+
+    const payload = new Uint8Array(nonce.length + encrypted.length);
+    payload.set(nonce, 0);
+    payload.set(encrypted, nonce.length);
+
+    return {
+        ciphertext: bytesToBase64Url(payload),
+        key: bytesToBase64Url(rawKey)
+    };
+}
+
+// Decrypt a base64url payload produced by clientEncryptMessage using the fragment key
+async function clientDecryptMessage(ciphertext, encodedKey) {
+    const rawKey = base64UrlToBytes(encodedKey);
+    if (rawKey.length !== CLIENT_CRYPTO_KEY_BYTES) {
+        throw new Error('Invalid decryption key length');
+    }
+
+    const payload = base64UrlToBytes(ciphertext);
+    const nonce = payload.slice(0, CLIENT_CRYPTO_NONCE_BYTES);
+    const encrypted = payload.slice(CLIENT_CRYPTO_NONCE_BYTES);
+    const key = await crypto.subtle.importKey('raw', rawKey, { name: 'AES-GCM' }, false, ['decrypt']);
+
+    const decrypted = await crypto.subtle.decrypt({ name: 'AES-GCM', iv: nonce }, key, encrypted);
+    return new TextDecoder().decode(decrypted);
+}
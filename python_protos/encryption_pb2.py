@@ -24,7 +24,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x10\x65ncryption.proto\x12\x0c\x65ncryptionpb\":\n\x17\x45ncryptedMessageRequest\x12\x12\n\nplain_text\x18\x01 \x03(\t\x12\x0b\n\x03key\x18\x02 \x01(\x0c\".\n\x18\x45ncryptedMessageResponse\x12\x12\n\nciphertext\x18\x01 \x03(\t\":\n\x17\x44\x65\x63ryptedMessageRequest\x12\x12\n\nciphertext\x18\x01 \x03(\t\x12\x0b\n\x03key\x18\x02 \x01(\x0c\"-\n\x18\x44\x65\x63ryptedMessageResponse\x12\x11\n\tplaintext\x18\x01 \x03(\t\"E\n\x0eRandomresponse\x12\x18\n\x10\x65ncryption_bytes\x18\x01 \x01(\x0c\x12\x19\n\x11\x65ncryption_string\x18\x02 \x01(\t\"&\n\rRandomrequest\x12\x15\n\rrandom_length\x18\x01 \x01(\x05\x32\xab\x02\n\x0eMessageService\x12\x61\n\x0e\x65ncryptMessage\x12%.encryptionpb.EncryptedMessageRequest\x1a&.encryptionpb.EncryptedMessageResponse\"\x00\x12\x61\n\x0e\x44\x65\x63ryptMessage\x12%.encryptionpb.DecryptedMessageRequest\x1a&.encryptionpb.DecryptedMessageResponse\"\x00\x12S\n\x14GenerateRandomString\x12\x1b.encryptionpb.Randomrequest\x1a\x1c.encryptionpb.Randomresponse\"\x00\x42=Z;github.com/Anthony-Bible/password-exchange/app/encryptionpbb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x10\x65ncryption.proto\x12\x0c\x65ncryptionpb\"S\n\x17\x45ncryptedMessageRequest\x12\x12\n\nplain_text\x18\x01 \x03(\t\x12\x0b\n\x03key\x18\x02 \x01(\x0c\x12\x17\n\x0f\x61ssociated_data\x18\x03 \x01(\x0c\".\n\x18\x45ncryptedMessageResponse\x12\x12\n\nciphertext\x18\x01 \x03(\t\"S\n\x17\x44\x65\x63ryptedMessageRequest\x12\x12\n\nciphertext\x18\x01 \x03(\t\x12\x0b\n\x03key\x18\x02 \x01(\x0c\x12\x17\n\x0f\x61ssociated_data\x18\x03 \x01(\x0c\"-\n\x18\x44\x65\x63ryptedMessageResponse\x12\x11\n\tplaintext\x18\x01 \x03(\t\"M\n\x12\x45ncryptFileRequest\x12\x11\n\tplaintext\x18\x01 \x01(\x0c\x12\x0b\n\x03key\x18\x02 \x01(\x0c\x12\x17\n\x0f\x61ssociated_data\x18\x03 \x01(\x0c\"%\n\x13\x45ncryptFileResponse\x12\x0e\n\x06\x63hunks\x18\x01 \x03(\x0c\"J\n\x12\x44\x65\x63ryptFileRequest\x12\x0e\n\x06\x63hunks\x18\x01 \x03(\x0c\x12\x0b\n\x03key\x18\x02 \x01(\x0c\x12\x17\n\x0f\x61ssociated_data\x18\x03 \x01(\x0c\"(\n\x13\x44\x65\x63ryptFileResponse\x12\x11\n\tplaintext\x18\x01 \x01(\x0c\"@\n\x17PublicKeyEncryptRequest\x12\x11\n\tplaintext\x18\x01 \x01(\x0c\x12\x12\n\npublic_key\x18\x02 \x01(\t\"@\n\x18PublicKeyEncryptResponse\x12\x12\n\nciphertext\x18\x01 \x01(\t\x12\x10\n\x08key_type\x18\x02 \x01(\t\"V\n\x17PublicKeyDecryptRequest\x12\x12\n\nciphertext\x18\x01 \x01(\t\x12\x13\n\x0bprivate_key\x18\x02 \x01(\t\x12\x12\n\npassphrase\x18\x03 \x01(\t\"-\n\x18PublicKeyDecryptResponse\x12\x11\n\tplaintext\x18\x01 \x01(\x0c\"G\n\x12SplitSecretRequest\x12\x0e\n\x06secret\x18\x01 \x01(\x0c\x12\x0e\n\x06shares\x18\x02 \x01(\x05\x12\x11\n\tthreshold\x18\x03 \x01(\x05\"%\n\x13SplitSecretResponse\x12\x0e\n\x06shares\x18\x01 \x03(\t\"&\n\x14\x43ombineSharesRequest\x12\x0e\n\x06shares\x18\x01 \x03(\t\"\'\n\x15\x43ombineSharesResponse\x12\x0e\n\x06secret\x18\x01 \x01(\x0c\"E\n\x14PassphraseKeyRequest\x12\x0b\n\x03key\x18\x01 \x01(\x0c\x12\x12\n\npassphrase\x18\x02 \x01(\t\x12\x0c\n\x04salt\x18\x03 \x01(\x0c\"2\n\x15PassphraseKeyResponse\x12\x0b\n\x03key\x18\x01 \x01(\x0c\x12\x0c\n\x04salt\x18\x02 \x01(\x0c\"E\n\x0eRandomresponse\x12\x18\n\x10\x65ncryption_bytes\x18\x01 \x01(\x0c\x12\x19\n\x11\x65ncryption_string\x18\x02 \x01(\t\"&\n\rRandomrequest\x12\x15\n\rrandom_length\x18\x01 \x01(\x05\x32\xbc\x07\n\x0eMessageService\x12\x61\n\x0e\x65ncryptMessage\x12%.encryptionpb.EncryptedMessageRequest\x1a&.encryptionpb.EncryptedMessageResponse\"\x00\x12\x61\n\x0e\x44\x65\x63ryptMessage\x12%.encryptionpb.DecryptedMessageRequest\x1a&.encryptionpb.DecryptedMessageResponse\"\x00\x12S\n\x14GenerateRandomString\x12\x1b.encryptionpb.Randomrequest\x1a\x1c.encryptionpb.Randomresponse\"\x00\x12T\n\x0b\x45ncryptFile\x12 .encryptionpb.EncryptFileRequest\x1a!.encryptionpb.EncryptFileResponse\"\x00\x12T\n\x0b\x44\x65\x63ryptFile\x12 .encryptionpb.DecryptFileRequest\x1a!.encryptionpb.DecryptFileResponse\"\x00\x12\x65\n\x12\x45ncryptToPublicKey\x12%.encryptionpb.PublicKeyEncryptRequest\x1a&.encryptionpb.PublicKeyEncryptResponse\"\x00\x12h\n\x15\x44\x65\x63ryptWithPrivateKey\x12%.encryptionpb.PublicKeyDecryptRequest\x1a&.encryptionpb.PublicKeyDecryptResponse\"\x00\x12T\n\x0bSplitSecret\x12 .encryptionpb.SplitSecretRequest\x1a!.encryptionpb.SplitSecretResponse\"\x00\x12Z\n\rCombineShares\x12\".encryptionpb.CombineSharesRequest\x1a#.encryptionpb.CombineSharesResponse\"\x00\x12`\n\x13\x44\x65rivePassphraseKey\x12\".encryptionpb.PassphraseKeyRequest\x1a#.encryptionpb.PassphraseKeyResponse\"\x00\x42=Z;github.com/Anthony-Bible/password-exchange/app/encryptionpbb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -33,17 +33,45 @@ if not _descriptor._USE_C_DESCRIPTORS:
   _globals['DESCRIPTOR']._loaded_options = None
   _globals['DESCRIPTOR']._serialized_options = b'Z;github.com/Anthony-Bible/password-exchange/app/encryptionpb'
   _globals['_ENCRYPTEDMESSAGEREQUEST']._serialized_start=34
-  _globals['_ENCRYPTEDMESSAGEREQUEST']._serialized_end=92
-  _globals['_ENCRYPTEDMESSAGERESPONSE']._serialized_start=94
-  _globals['_ENCRYPTEDMESSAGERESPONSE']._serialized_end=140
-  _globals['_DECRYPTEDMESSAGEREQUEST']._serialized_start=142
-  _globals['_DECRYPTEDMESSAGEREQUEST']._serialized_end=200
-  _globals['_DECRYPTEDMESSAGERESPONSE']._serialized_start=202
-  _globals['_DECRYPTEDMESSAGERESPONSE']._serialized_end=247
-  _globals['_RANDOMRESPONSE']._serialized_start=249
-  _globals['_RANDOMRESPONSE']._serialized_end=318
-  _globals['_RANDOMREQUEST']._serialized_start=320
-  _globals['_RANDOMREQUEST']._serialized_end=358
-  _globals['_MESSAGESERVICE']._serialized_start=361
-  _globals['_MESSAGESERVICE']._serialized_end=660
+  _globals['_ENCRYPTEDMESSAGEREQUEST']._serialized_end=117
+  _globals['_ENCRYPTEDMESSAGERESPONSE']._serialized_start=119
+  _globals['_ENCRYPTEDMESSAGERESPONSE']._serialized_end=165
+  _globals['_DECRYPTEDMESSAGEREQUEST']._serialized_start=167
+  _globals['_DECRYPTEDMESSAGEREQUEST']._serialized_end=250
+  _globals['_DECRYPTEDMESSAGERESPONSE']._serialized_start=252
+  _globals['_DECRYPTEDMESSAGERESPONSE']._serialized_end=297
+  _globals['_ENCRYPTFILEREQUEST']._serialized_start=299
+  _globals['_ENCRYPTFILEREQUEST']._serialized_end=376
+  _globals['_ENCRYPTFILERESPONSE']._serialized_start=378
+  _globals['_ENCRYPTFILERESPONSE']._serialized_end=415
+  _globals['_DECRYPTFILEREQUEST']._serialized_start=417
+  _globals['_DECRYPTFILEREQUEST']._serialized_end=491
+  _globals['_DECRYPTFILERESPONSE']._serialized_start=493
+  _globals['_DECRYPTFILERESPONSE']._serialized_end=533
+  _globals['_PUBLICKEYENCRYPTREQUEST']._serialized_start=535
+  _globals['_PUBLICKEYENCRYPTREQUEST']._serialized_end=599
+  _globals['_PUBLICKEYENCRYPTRESPONSE']._serialized_start=601
+  _globals['_PUBLICKEYENCRYPTRESPONSE']._serialized_end=665
+  _globals['_PUBLICKEYDECRYPTREQUEST']._serialized_start=667
+  _globals['_PUBLICKEYDECRYPTREQUEST']._serialized_end=753
+  _globals['_PUBLICKEYDECRYPTRESPONSE']._serialized_start=755
+  _globals['_PUBLICKEYDECRYPTRESPONSE']._serialized_end=800
+  _globals['_SPLITSECRETREQUEST']._serialized_start=802
+  _globals['_SPLITSECRETREQUEST']._serialized_end=873
+  _globals['_SPLITSECRETRESPONSE']._serialized_start=875
+  _globals['_SPLITSECRETRESPONSE']._serialized_end=912
+  _globals['_COMBINESHARESREQUEST']._serialized_start=914
+  _globals['_COMBINESHARESREQUEST']._serialized_end=952
+  _globals['_COMBINESHARESRESPONSE']._serialized_start=954
+  _globals['_COMBINESHARESRESPONSE']._serialized_end=993
+  _globals['_PASSPHRASEKEYREQUEST']._serialized_start=995
+  _globals['_PASSPHRASEKEYREQUEST']._serialized_end=1064
+  _globals['_PASSPHRASEKEYRESPONSE']._serialized_start=1066
+  _globals['_PASSPHRASEKEYRESPONSE']._serialized_end=1116
+  _globals['_RANDOMRESPONSE']._serialized_start=1118
+  _globals['_RANDOMRESPONSE']._serialized_end=1187
+  _globals['_RANDOMREQUEST']._serialized_start=1189
+  _globals['_RANDOMREQUEST']._serialized_end=1227
+  _globals['_MESSAGESERVICE']._serialized_start=1230
+  _globals['_MESSAGESERVICE']._serialized_end=2186
 # @@protoc_insertion_point(module_scope)
@@ -5,44 +5,158 @@ from typing import ClassVar as _ClassVar, Iterable as _Iterable, Optional as _Op
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class EncryptedMessageRequest(_message.Message):
+    __slots__ = ("plain_text", "key", "associated_data")
+    PLAIN_TEXT_FIELD_NUMBER: _ClassVar[int]
+    KEY_FIELD_NUMBER: _ClassVar[int]
+    ASSOCIATED_DATA_FIELD_NUMBER: _ClassVar[int]
+    plain_text: _containers.RepeatedScalarFieldContainer[str]
+    key: bytes
+    associated_data: bytes
+    def __init__(self, plain_text: _Optional[_Iterable[str]] = ..., key: _Optional[bytes] = ..., associated_data: _Optional[bytes] = ...) -> None: ...
+
+class EncryptedMessageResponse(_message.Message):
+    __slots__ = ("ciphertext",)
+    CIPHERTEXT_FIELD_NUMBER: _ClassVar[int]
+    ciphertext: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, ciphertext: _Optional[_Iterable[str]] = ...) -> None: ...
+
 class DecryptedMessageRequest(_message.Message):
-    __slots__ = ["Ciphertext", "key"]
+    __slots__ = ("ciphertext", "key", "associated_data")
     CIPHERTEXT_FIELD_NUMBER: _ClassVar[int]
-    Ciphertext: _containers.RepeatedScalarFieldContainer[str]
     KEY_FIELD_NUMBER: _ClassVar[int]
+    ASSOCIATED_DATA_FIELD_NUMBER: _ClassVar[int]
+    ciphertext: _containers.RepeatedScalarFieldContainer[str]
     key: bytes
-    def __init__(self, Ciphertext: _Optional[_Iterable[str]] = ..., key: _Optional[bytes] = ...) -> None: ...
+    associated_data: bytes
+    def __init__(self, ciphertext: _Optional[_Iterable[str]] = ..., key: _Optional[bytes] = ..., associated_data: _Optional[bytes] = ...) -> None: ...
 
 class DecryptedMessageResponse(_message.Message):
-    __slots__ = ["plaintext"]
+    __slots__ = ("plaintext",)
     PLAINTEXT_FIELD_NUMBER: _ClassVar[int]
     plaintext: _containers.RepeatedScalarFieldContainer[str]
     def __init__(self, plaintext: _Optional[_Iterable[str]] = ...) -> None: ...
 
-class EncryptedMessageRequest(_message.Message):
-    __slots__ = ["Key", "PlainText"]
+class EncryptFileRequest(_message.Message):
+    __slots__ = ("plaintext", "key", "associated_data")
+    PLAINTEXT_FIELD_NUMBER: _ClassVar[int]
     KEY_FIELD_NUMBER: _ClassVar[int]
-    Key: bytes
+    ASSOCIATED_DATA_FIELD_NUMBER: _ClassVar[int]
+    plaintext: bytes
+    key: bytes
+    associated_data: bytes
+    def __init__(self, plaintext: _Optional[bytes] = ..., key: _Optional[bytes] = ..., associated_data: _Optional[bytes] = ...) -> None: ...
+
+class EncryptFileResponse(_message.Message):
+    __slots__ = ("chunks",)
+    CHUNKS_FIELD_NUMBER: _ClassVar[int]
+    chunks: _containers.RepeatedScalarFieldContainer[bytes]
+    def __init__(self, chunks: _Optional[_Iterable[bytes]] = ...) -> None: ...
+
+class DecryptFileRequest(_message.Message):
+    __slots__ = ("chunks", "key", "associated_data")
+    CHUNKS_FIELD_NUMBER: _ClassVar[int]
+    KEY_FIELD_NUMBER: _ClassVar[int]
+    ASSOCIATED_DATA_FIELD_NUMBER: _ClassVar[int]
+    chunks: _containers.RepeatedScalarFieldContainer[bytes]
+    key: bytes
+    associated_data: bytes
+    def __init__(self, chunks: _Optional[_Iterable[bytes]] = ..., key: _Optional[bytes] = ..., associated_data: _Optional[bytes] = ...) -> None: ...
+
+class DecryptFileResponse(_message.Message):
+    __slots__ = ("plaintext",)
     PLAINTEXT_FIELD_NUMBER: _ClassVar[int]
-    PlainText: _containers.RepeatedScalarFieldContainer[str]
-    def __init__(self, PlainText: _Optional[_Iterable[str]] = ..., Key: _Optional[bytes] = ...) -> None: ...
+    plaintext: bytes
+    def __init__(self, plaintext: _Optional[bytes] = ...) -> None: ...
 
-class EncryptedMessageResponse(_message.Message):
-    __slots__ = ["Ciphertext"]
+class PublicKeyEncryptRequest(_message.Message):
+    __slots__ = ("plaintext", "public_key")
+    PLAINTEXT_FIELD_NUMBER: _ClassVar[int]
+    PUBLIC_KEY_FIELD_NUMBER: _ClassVar[int]
+    plaintext: bytes
+    public_key: str
+    def __init__(self, plaintext: _Optional[bytes] = ..., public_key: _Optional[str] = ...) -> None: ...
+
+class PublicKeyEncryptResponse(_message.Message):
+    __slots__ = ("ciphertext", "key_type")
     CIPHERTEXT_FIELD_NUMBER: _ClassVar[int]
-    Ciphertext: _containers.RepeatedScalarFieldContainer[str]
-    def __init__(self, Ciphertext: _Optional[_Iterable[str]] = ...) -> None: ...
+    KEY_TYPE_FIELD_NUMBER: _ClassVar[int]
+    ciphertext: str
+    key_type: str
+    def __init__(self, ciphertext: _Optional[str] = ..., key_type: _Optional[str] = ...) -> None: ...
 
-class Randomrequest(_message.Message):
-    __slots__ = ["randomLength"]
-    RANDOMLENGTH_FIELD_NUMBER: _ClassVar[int]
-    randomLength: int
-    def __init__(self, randomLength: _Optional[int] = ...) -> None: ...
+class PublicKeyDecryptRequest(_message.Message):
+    __slots__ = ("ciphertext", "private_key", "passphrase")
+    CIPHERTEXT_FIELD_NUMBER: _ClassVar[int]
+    PRIVATE_KEY_FIELD_NUMBER: _ClassVar[int]
+    PASSPHRASE_FIELD_NUMBER: _ClassVar[int]
+    ciphertext: str
+    private_key: str
+    passphrase: str
+    def __init__(self, ciphertext: _Optional[str] = ..., private_key: _Optional[str] = ..., passphrase: _Optional[str] = ...) -> None: ...
+
+class PublicKeyDecryptResponse(_message.Message):
+    __slots__ = ("plaintext",)
+    PLAINTEXT_FIELD_NUMBER: _ClassVar[int]
+    plaintext: bytes
+    def __init__(self, plaintext: _Optional[bytes] = ...) -> None: ...
+
+class SplitSecretRequest(_message.Message):
+    __slots__ = ("secret", "shares", "threshold")
+    SECRET_FIELD_NUMBER: _ClassVar[int]
+    SHARES_FIELD_NUMBER: _ClassVar[int]
+    THRESHOLD_FIELD_NUMBER: _ClassVar[int]
+    secret: bytes
+    shares: int
+    threshold: int
+    def __init__(self, secret: _Optional[bytes] = ..., shares: _Optional[int] = ..., threshold: _Optional[int] = ...) -> None: ...
+
+class SplitSecretResponse(_message.Message):
+    __slots__ = ("shares",)
+    SHARES_FIELD_NUMBER: _ClassVar[int]
+    shares: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, shares: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class CombineSharesRequest(_message.Message):
+    __slots__ = ("shares",)
+    SHARES_FIELD_NUMBER: _ClassVar[int]
+    shares: _containers.RepeatedScalarFieldContainer[str]
+    def __init__(self, shares: _Optional[_Iterable[str]] = ...) -> None: ...
+
+class CombineSharesResponse(_message.Message):
+    __slots__ = ("secret",)
+    SECRET_FIELD_NUMBER: _ClassVar[int]
+    secret: bytes
+    def __init__(self, secret: _Optional[bytes] = ...) -> None: ...
+
+class PassphraseKeyRequest(_message.Message):
+    __slots__ = ("key", "passphrase", "salt")
+    KEY_FIELD_NUMBER: _ClassVar[int]
+    PASSPHRASE_FIELD_NUMBER: _ClassVar[int]
+    SALT_FIELD_NUMBER: _ClassVar[int]
+    key: bytes
+    passphrase: str
+    salt: bytes
+    def __init__(self, key: _Optional[bytes] = ..., passphrase: _Optional[str] = ..., salt: _Optional[bytes] = ...) -> None: ...
+
+class PassphraseKeyResponse(_message.Message):
+    __slots__ = ("key", "salt")
+    KEY_FIELD_NUMBER: _ClassVar[int]
+    SALT_FIELD_NUMBER: _ClassVar[int]
+    key: bytes
+    salt: bytes
+    def __init__(self, key: _Optional[bytes] = ..., salt: _Optional[bytes] = ...) -> None: ...
 
 class Randomresponse(_message.Message):
-    __slots__ = ["encryptionString", "encryptionbytes"]
-    ENCRYPTIONBYTES_FIELD_NUMBER: _ClassVar[int]
-    ENCRYPTIONSTRING_FIELD_NUMBER: _ClassVar[int]
-    encryptionString: str
-    encryptionbytes: bytes
-    def __init__(self, encryptionbytes: _Optional[bytes] = ..., encryptionString: _Optional[str] = ...) -> None: ...
+    __slots__ = ("encryption_bytes", "encryption_string")
+    ENCRYPTION_BYTES_FIELD_NUMBER: _ClassVar[int]
+    ENCRYPTION_STRING_FIELD_NUMBER: _ClassVar[int]
+    encryption_bytes: bytes
+    encryption_string: str
+    def __init__(self, encryption_bytes: _Optional[bytes] = ..., encryption_string: _Optional[str] = ...) -> None: ...
+
+class Randomrequest(_message.Message):
+    __slots__ = ("random_length",)
+    RANDOM_LENGTH_FIELD_NUMBER: _ClassVar[int]
+    random_length: int
+    def __init__(self, random_length: _Optional[int] = ...) -> None: ...
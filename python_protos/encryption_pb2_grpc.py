@@ -49,6 +49,41 @@ class MessageServiceStub(object):
                 request_serializer=encryption__pb2.Randomrequest.SerializeToString,
                 response_deserializer=encryption__pb2.Randomresponse.FromString,
                 _registered_method=True)
+        self.EncryptFile = channel.unary_unary(
+                '/encryptionpb.MessageService/EncryptFile',
+                request_serializer=encryption__pb2.EncryptFileRequest.SerializeToString,
+                response_deserializer=encryption__pb2.EncryptFileResponse.FromString,
+                _registered_method=True)
+        self.DecryptFile = channel.unary_unary(
+                '/encryptionpb.MessageService/DecryptFile',
+                request_serializer=encryption__pb2.DecryptFileRequest.SerializeToString,
+                response_deserializer=encryption__pb2.DecryptFileResponse.FromString,
+                _registered_method=True)
+        self.EncryptToPublicKey = channel.unary_unary(
+                '/encryptionpb.MessageService/EncryptToPublicKey',
+                request_serializer=encryption__pb2.PublicKeyEncryptRequest.SerializeToString,
+                response_deserializer=encryption__pb2.PublicKeyEncryptResponse.FromString,
+                _registered_method=True)
+        self.DecryptWithPrivateKey = channel.unary_unary(
+                '/encryptionpb.MessageService/DecryptWithPrivateKey',
+                request_serializer=encryption__pb2.PublicKeyDecryptRequest.SerializeToString,
+                response_deserializer=encryption__pb2.PublicKeyDecryptResponse.FromString,
+                _registered_method=True)
+        self.SplitSecret = channel.unary_unary(
+                '/encryptionpb.MessageService/SplitSecret',
+                request_serializer=encryption__pb2.SplitSecretRequest.SerializeToString,
+                response_deserializer=encryption__pb2.SplitSecretResponse.FromString,
+                _registered_method=True)
+        self.CombineShares = channel.unary_unary(
+                '/encryptionpb.MessageService/CombineShares',
+                request_serializer=encryption__pb2.CombineSharesRequest.SerializeToString,
+                response_deserializer=encryption__pb2.CombineSharesResponse.FromString,
+                _registered_method=True)
+        self.DerivePassphraseKey = channel.unary_unary(
+                '/encryptionpb.MessageService/DerivePassphraseKey',
+                request_serializer=encryption__pb2.PassphraseKeyRequest.SerializeToString,
+                response_deserializer=encryption__pb2.PassphraseKeyResponse.FromString,
+                _registered_method=True)
 
 
 class MessageServiceServicer(object):
@@ -72,6 +107,48 @@ class MessageServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def EncryptFile(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def DecryptFile(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def EncryptToPublicKey(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def DecryptWithPrivateKey(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def SplitSecret(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def CombineShares(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def DerivePassphraseKey(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_MessageServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -90,6 +167,41 @@ def add_MessageServiceServicer_to_server(servicer, server):
                     request_deserializer=encryption__pb2.Randomrequest.FromString,
                     response_serializer=encryption__pb2.Randomresponse.SerializeToString,
             ),
+            'EncryptFile': grpc.unary_unary_rpc_method_handler(
+                    servicer.EncryptFile,
+                    request_deserializer=encryption__pb2.EncryptFileRequest.FromString,
+                    response_serializer=encryption__pb2.EncryptFileResponse.SerializeToString,
+            ),
+            'DecryptFile': grpc.unary_unary_rpc_method_handler(
+                    servicer.DecryptFile,
+                    request_deserializer=encryption__pb2.DecryptFileRequest.FromString,
+                    response_serializer=encryption__pb2.DecryptFileResponse.SerializeToString,
+            ),
+            'EncryptToPublicKey': grpc.unary_unary_rpc_method_handler(
+                    servicer.EncryptToPublicKey,
+                    request_deserializer=encryption__pb2.PublicKeyEncryptRequest.FromString,
+                    response_serializer=encryption__pb2.PublicKeyEncryptResponse.SerializeToString,
+            ),
+            'DecryptWithPrivateKey': grpc.unary_unary_rpc_method_handler(
+                    servicer.DecryptWithPrivateKey,
+                    request_deserializer=encryption__pb2.PublicKeyDecryptRequest.FromString,
+                    response_serializer=encryption__pb2.PublicKeyDecryptResponse.SerializeToString,
+            ),
+            'SplitSecret': grpc.unary_unary_rpc_method_handler(
+                    servicer.SplitSecret,
+                    request_deserializer=encryption__pb2.SplitSecretRequest.FromString,
+                    response_serializer=encryption__pb2.SplitSecretResponse.SerializeToString,
+            ),
+            'CombineShares': grpc.unary_unary_rpc_method_handler(
+                    servicer.CombineShares,
+                    request_deserializer=encryption__pb2.CombineSharesRequest.FromString,
+                    response_serializer=encryption__pb2.CombineSharesResponse.SerializeToString,
+            ),
+            'DerivePassphraseKey': grpc.unary_unary_rpc_method_handler(
+                    servicer.DerivePassphraseKey,
+                    request_deserializer=encryption__pb2.PassphraseKeyRequest.FromString,
+                    response_serializer=encryption__pb2.PassphraseKeyResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'encryptionpb.MessageService', rpc_method_handlers)
@@ -181,3 +293,192 @@ class MessageService(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def EncryptFile(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/EncryptFile',
+            encryption__pb2.EncryptFileRequest.SerializeToString,
+            encryption__pb2.EncryptFileResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def DecryptFile(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/DecryptFile',
+            encryption__pb2.DecryptFileRequest.SerializeToString,
+            encryption__pb2.DecryptFileResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def EncryptToPublicKey(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/EncryptToPublicKey',
+            encryption__pb2.PublicKeyEncryptRequest.SerializeToString,
+            encryption__pb2.PublicKeyEncryptResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def DecryptWithPrivateKey(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/DecryptWithPrivateKey',
+            encryption__pb2.PublicKeyDecryptRequest.SerializeToString,
+            encryption__pb2.PublicKeyDecryptResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def SplitSecret(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/SplitSecret',
+            encryption__pb2.SplitSecretRequest.SerializeToString,
+            encryption__pb2.SplitSecretResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def CombineShares(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/CombineShares',
+            encryption__pb2.CombineSharesRequest.SerializeToString,
+            encryption__pb2.CombineSharesResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
+
+    @staticmethod
+    def DerivePassphraseKey(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/encryptionpb.MessageService/DerivePassphraseKey',
+            encryption__pb2.PassphraseKeyRequest.SerializeToString,
+            encryption__pb2.PassphraseKeyResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -58,6 +58,8 @@ class EncryptionServiceClient(object):
         try:    
             request.key = self.generate_random_strng(32).encryption_bytes
             guid = uuid.uuid4().hex
+            # Bind the ciphertext to its message ID, as the web service does
+            request.associated_data = guid.encode()
             encrypt_response = self.stub.encryptMessage(request)
             for i in encrypt_response.ciphertext:
                 insert_request = {'uuid': guid, 'content': i}